@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+)
+
+const (
+	verifierName    = "@Verifier"
+	exceededMarker  = "!"
+	latestEstimator = 4
+)
+
+// estimation is the result of script estimation by the tree estimator of the particular version.
+type estimation struct {
+	version int
+	ride.TreeEstimation
+	err error
+}
+
+// complexityReport holds the complexities of the script estimated by all versions of tree estimators
+// and the limits of the chosen library version.
+type complexityReport struct {
+	dApp        bool
+	verifier    bool
+	libVersion  ast.LibraryVersion
+	size        int
+	maxSize     int
+	maxCallable int
+	maxVerifier int
+	functions   []string
+	estimations []estimation
+}
+
+// newComplexityReport estimates the compiled script. The limits of the given library version are used,
+// if the version is zero the limits of the script's own library version are used.
+func newComplexityReport(script []byte, libVersion ast.LibraryVersion, asset bool) (*complexityReport, error) {
+	tree, err := serialization.Parse(script)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse script")
+	}
+	if libVersion == 0 {
+		libVersion = tree.LibVersion
+	}
+	// The reduced verifier complexity limit is applied to account and dApp scripts since BlockV5.
+	maxCallable, maxVerifier, err := ride.MaxScriptComplexity(libVersion, !asset)
+	if err != nil {
+		return nil, err
+	}
+	r := &complexityReport{
+		dApp:        tree.IsDApp(),
+		verifier:    !tree.IsDApp() || tree.HasVerifier(),
+		libVersion:  libVersion,
+		size:        len(script),
+		maxSize:     proto.MaxVerifierScriptSize,
+		maxCallable: maxCallable,
+		maxVerifier: maxVerifier,
+	}
+	if r.dApp {
+		r.maxSize = proto.MaxContractScriptSizeV6
+	}
+	for v := 1; v <= latestEstimator; v++ {
+		te, eErr := ride.EstimateTree(tree, v)
+		r.estimations = append(r.estimations, estimation{version: v, TreeEstimation: te, err: eErr})
+		for name := range te.Functions {
+			if !slices.Contains(r.functions, name) {
+				r.functions = append(r.functions, name)
+			}
+		}
+	}
+	slices.Sort(r.functions)
+	return r, nil
+}
+
+// exceeded reports whether the script size or any complexity estimated by the latest estimator exceeds the limits.
+func (r *complexityReport) exceeded() bool {
+	if r.size > r.maxSize {
+		return true
+	}
+	latest := r.estimations[len(r.estimations)-1]
+	if latest.err != nil {
+		return true
+	}
+	if r.verifier && latest.Verifier > r.maxVerifier {
+		return true
+	}
+	for _, c := range latest.Functions {
+		if c > r.maxCallable {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *complexityReport) write(w io.Writer) error {
+	scriptType := "Expression"
+	if r.dApp {
+		scriptType = "DApp"
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Script type:\t%s\n", scriptType)
+	_, _ = fmt.Fprintf(tw, "Script size:\t%d of %d bytes%s\n", r.size, r.maxSize, r.mark(r.size, r.maxSize))
+	_, _ = fmt.Fprintf(tw, "Limits of library V%d:\tcallable %d, verifier %d\n",
+		r.libVersion, r.maxCallable, r.maxVerifier)
+	_, _ = fmt.Fprintln(tw)
+
+	header := []string{"Function"}
+	for _, e := range r.estimations {
+		header = append(header, fmt.Sprintf("V%d", e.version))
+	}
+	_, _ = fmt.Fprintln(tw, strings.Join(header, "\t"))
+	if r.verifier {
+		r.writeRow(tw, verifierName, r.maxVerifier, func(e estimation) (int, bool) { return e.Verifier, true })
+	}
+	for _, name := range r.functions {
+		r.writeRow(tw, name, r.maxCallable, func(e estimation) (int, bool) {
+			c, ok := e.Functions[name]
+			return c, ok
+		})
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, e := range r.estimations {
+		if e.err != nil {
+			_, _ = fmt.Fprintf(w, "Estimator V%d failed: %v\n", e.version, e.err)
+		}
+	}
+	if r.exceeded() {
+		_, _ = fmt.Fprintf(w, "\nThe limits are exceeded (marked with '%s')\n", exceededMarker)
+	}
+	return nil
+}
+
+func (r *complexityReport) writeRow(w io.Writer, name string, limit int, complexity func(e estimation) (int, bool)) {
+	row := []string{name}
+	for _, e := range r.estimations {
+		c, ok := complexity(e)
+		if e.err != nil || !ok {
+			row = append(row, "-")
+			continue
+		}
+		row = append(row, fmt.Sprintf("%d%s", c, r.mark(c, limit)))
+	}
+	_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
+}
+
+func (r *complexityReport) mark(value, limit int) string {
+	if value > limit {
+		return exceededMarker
+	}
+	return ""
+}
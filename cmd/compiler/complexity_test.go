@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+)
+
+const expression = `
+{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE EXPRESSION #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey)
+`
+
+const dAppWithVerifier = `
+{-# STDLIB_VERSION 5 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+
+@Callable(i)
+func store(v: Int) = [IntegerEntry("value", v)]
+
+@Verifier(tx)
+func verify() = sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey)
+`
+
+// heavyDApp returns the dApp with the callable of complexity above the limit of library V3 and below the limit
+// of its own library version V5.
+func heavyDApp() string {
+	checks := make([]string, 25)
+	for i := range checks {
+		checks[i] = fmt.Sprintf("sigVerify(toBytes(%d), i.callerPublicKey, i.callerPublicKey)", i)
+	}
+	return fmt.Sprintf(`
+{-# STDLIB_VERSION 5 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+
+@Callable(i)
+func heavy() = [BooleanEntry("checked", %s)]
+`, strings.Join(checks, " && "))
+}
+
+func compileScript(t *testing.T, src string) []byte {
+	script, errs := compiler.Compile(src, false, false)
+	require.Empty(t, errs)
+	return script
+}
+
+// brokenScript returns the expression that calls the undefined function, all estimators fail on it.
+func brokenScript(t *testing.T) []byte {
+	tree := ast.NewTree(ast.ContentTypeExpression, ast.LibV6)
+	tree.Verifier = ast.NewFunctionCallNode(ast.UserFunction("undefined"), nil)
+	script, err := serialization.SerializeTree(tree)
+	require.NoError(t, err)
+	return script
+}
+
+func TestNewComplexityReport(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		script      []byte
+		libVersion  ast.LibraryVersion
+		asset       bool
+		dApp        bool
+		verifier    bool
+		expectedLib ast.LibraryVersion
+		maxSize     int
+		maxCallable int
+		maxVerifier int
+		functions   []string
+		failed      bool
+		exceeded    bool
+	}{
+		{"expression", compileScript(t, expression), 0, false, false, true, ast.LibV6,
+			proto.MaxVerifierScriptSize, ride.MaxCallableScriptComplexityV6, ride.MaxVerifierScriptComplexityReduced,
+			nil, false, false},
+		{"asset expression", compileScript(t, expression), 0, true, false, true, ast.LibV6,
+			proto.MaxVerifierScriptSize, ride.MaxCallableScriptComplexityV6, ride.MaxVerifierScriptComplexity,
+			nil, false, false},
+		{"dApp with verifier", compileScript(t, dAppWithVerifier), 0, false, true, true, ast.LibV5,
+			proto.MaxContractScriptSizeV6, ride.MaxCallableScriptComplexityV5, ride.MaxVerifierScriptComplexityReduced,
+			[]string{"store"}, false, false},
+		{"callable within script version limit", compileScript(t, heavyDApp()), 0, false, true, false, ast.LibV5,
+			proto.MaxContractScriptSizeV6, ride.MaxCallableScriptComplexityV5, ride.MaxVerifierScriptComplexityReduced,
+			[]string{"heavy"}, false, false},
+		{"callable over chosen version limit", compileScript(t, heavyDApp()), ast.LibV3, false, true, false, ast.LibV3,
+			proto.MaxContractScriptSizeV6, ride.MaxCallableScriptComplexityV34, ride.MaxVerifierScriptComplexityReduced,
+			[]string{"heavy"}, false, true},
+		{"estimator failure", brokenScript(t), 0, false, false, true, ast.LibV6,
+			proto.MaxVerifierScriptSize, ride.MaxCallableScriptComplexityV6, ride.MaxVerifierScriptComplexityReduced,
+			nil, true, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := newComplexityReport(test.script, test.libVersion, test.asset)
+			require.NoError(t, err)
+			assert.Equal(t, test.dApp, r.dApp)
+			assert.Equal(t, test.verifier, r.verifier)
+			assert.Equal(t, test.expectedLib, r.libVersion)
+			assert.Equal(t, len(test.script), r.size)
+			assert.Equal(t, test.maxSize, r.maxSize)
+			assert.Equal(t, test.maxCallable, r.maxCallable)
+			assert.Equal(t, test.maxVerifier, r.maxVerifier)
+			assert.Equal(t, test.functions, r.functions)
+			require.Len(t, r.estimations, latestEstimator)
+			for i, e := range r.estimations {
+				assert.Equal(t, i+1, e.version)
+				assert.Equal(t, test.failed, e.err != nil)
+			}
+			assert.Equal(t, test.exceeded, r.exceeded())
+		})
+	}
+}
+
+func TestNewComplexityReportErrors(t *testing.T) {
+	_, err := newComplexityReport([]byte{0xff}, 0, false)
+	assert.ErrorContains(t, err, "failed to parse script")
+	_, err = newComplexityReport(compileScript(t, expression), ast.LibraryVersion(100), false)
+	assert.ErrorContains(t, err, "unknown script LibVersion=100")
+}
+
+func TestComplexityReportExceeded(t *testing.T) {
+	report := func(size, verifierComplexity, callableComplexity int, verifier bool, err error) *complexityReport {
+		return &complexityReport{
+			dApp:        true,
+			verifier:    verifier,
+			size:        size,
+			maxSize:     100,
+			maxCallable: 20,
+			maxVerifier: 10,
+			estimations: []estimation{
+				{version: 1, TreeEstimation: ride.TreeEstimation{Verifier: 1000, Functions: map[string]int{"f": 1000}}},
+				{version: 2, TreeEstimation: ride.TreeEstimation{
+					Verifier: verifierComplexity, Functions: map[string]int{"f": callableComplexity},
+				}, err: err},
+			},
+		}
+	}
+	for _, test := range []struct {
+		name     string
+		report   *complexityReport
+		exceeded bool
+	}{
+		{"within limits", report(100, 10, 20, true, nil), false},
+		{"size", report(101, 10, 20, true, nil), true},
+		{"verifier", report(100, 11, 20, true, nil), true},
+		{"no verifier", report(100, 11, 20, false, nil), false},
+		{"callable", report(100, 10, 21, true, nil), true},
+		{"latest estimator failure", report(100, 10, 20, true, assert.AnError), true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.exceeded, test.report.exceeded())
+		})
+	}
+}
+
+func TestComplexityReportWriteRow(t *testing.T) {
+	r := &complexityReport{estimations: []estimation{
+		{version: 1, TreeEstimation: ride.TreeEstimation{Functions: map[string]int{"f": 5}}},
+		{version: 2, TreeEstimation: ride.TreeEstimation{Functions: map[string]int{"f": 11}}},
+		{version: 3, TreeEstimation: ride.TreeEstimation{Functions: map[string]int{}}},
+		{version: 4, err: assert.AnError},
+	}}
+	for _, test := range []struct {
+		name     string
+		limit    int
+		expected string
+	}{
+		{"within limit", 11, "f\t5\t11\t-\t-\n"},
+		{"exceeded", 10, "f\t5\t11!\t-\t-\n"},
+		{"all exceeded", 4, "f\t5!\t11!\t-\t-\n"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var b bytes.Buffer
+			r.writeRow(&b, "f", test.limit, func(e estimation) (int, bool) {
+				c, ok := e.Functions["f"]
+				return c, ok
+			})
+			assert.Equal(t, test.expected, b.String())
+		})
+	}
+}
+
+func TestPrintComplexity(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		script     []byte
+		libVersion uint
+		asset      bool
+		code       int
+		output     []string
+	}{
+		{"expression", compileScript(t, expression), 0, false, 0,
+			[]string{"Expression", "Limits of library V6:  callable 52000, verifier 2000", "@Verifier"}},
+		{"dApp with verifier", compileScript(t, dAppWithVerifier), 0, false, 0,
+			[]string{"DApp", "@Verifier", "store"}},
+		{"over limit callable", compileScript(t, heavyDApp()), 3, false, 1,
+			[]string{"Limits of library V3:  callable 4000, verifier 2000", "The limits are exceeded"}},
+		{"estimator failure", brokenScript(t), 0, false, 1,
+			[]string{"Estimator V4 failed", "The limits are exceeded"}},
+		{"invalid library version", compileScript(t, expression), 256, false, 2,
+			[]string{"Invalid library version: 256"}},
+		{"unsupported library version", compileScript(t, expression), 100, false, 2,
+			[]string{"Invalid library version"}},
+		{"invalid script", []byte{0xff}, 0, false, 2,
+			[]string{"Failed to estimate script"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var b bytes.Buffer
+			code := printComplexity(&b, test.script, test.libVersion, test.asset)
+			assert.Equal(t, test.code, code)
+			for _, s := range test.output {
+				assert.Contains(t, b.String(), s)
+			}
+		})
+	}
+}
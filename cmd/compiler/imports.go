@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+)
+
+// importFlags are the options of library imports resolution.
+type importFlags struct {
+	searchPaths  []string
+	vendorDir    string
+	manifestPath string
+	cacheDir     string
+}
+
+func (f *importFlags) register(fs *flag.FlagSet) {
+	fs.Func("I", "Directory to search the imported libraries in, may be repeated", func(s string) error {
+		f.searchPaths = append(f.searchPaths, s)
+		return nil
+	})
+	fs.StringVar(&f.vendorDir, "vendor", "", "Directory of vendored libraries, searched after the search directories")
+	fs.StringVar(&f.manifestPath, "manifest", "", "Path to the manifest that pins remote libraries to their checksums")
+	fs.StringVar(&f.cacheDir, "cache", "", "Directory of the local cache of remote libraries")
+}
+
+// resolver creates the resolver of imports. The relative paths are resolved against the directory of
+// the importing file, then in the search directories and in the vendored libraries directory.
+// The remote libraries are resolved from the cache if the manifest is given.
+func (f *importFlags) resolver() (compiler.ImportResolver, error) {
+	dirs := slices.Clone(f.searchPaths)
+	if f.vendorDir != "" {
+		dirs = append(dirs, f.vendorDir)
+	}
+	r := compiler.ChainResolver{compiler.NewFileResolver(dirs...)}
+	if f.manifestPath == "" {
+		if f.cacheDir != "" {
+			return nil, errors.New("cache directory is set without manifest")
+		}
+		return r, nil
+	}
+	if f.cacheDir == "" {
+		return nil, errors.New("cache directory of remote libraries is not set")
+	}
+	mf, err := os.Open(filepath.Clean(f.manifestPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open manifest")
+	}
+	defer func() { _ = mf.Close() }()
+	m, err := compiler.LoadManifest(mf)
+	if err != nil {
+		return nil, err
+	}
+	return append(r, compiler.NewCacheResolver(m, f.cacheDir)), nil
+}
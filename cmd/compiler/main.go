@@ -4,30 +4,79 @@ import (
 	"encoding/base64"
 	"flag"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/decompiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/lsp"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+	"github.com/wavesplatform/gowaves/pkg/ride/tester"
 )
 
 var usage = `
 Usage:
   compiler -f <script path> [options]
+  compiler test -script <script path> -suite <test suite path> [-junit <report path>]
+  compiler lsp [import options]
 
 Options:
 	-compaction	Compaction mode
     -remove-unused      Remove unused code
+    -decompile          Decompile the Base64 encoded script from the file
+    -asset              Decompile or estimate the expression as an asset script
+    -complexity         Print the complexity report of the script for all estimator versions
+    -lib-version        Library version to check the complexity limits against, script's version by default
+
+Import options:
+    -I                  Directory to search the imported libraries in, may be repeated
+    -vendor             Directory of vendored libraries, searched after the search directories
+    -manifest           Path to the manifest in JSON format that pins the URLs of remote libraries to SHA-256 checksums
+    -cache              Directory of the local cache of remote libraries, the files are named by their checksums
+
+The relative paths of imported libraries are resolved against the directory of the importing file first.
+
+Test options:
+    -script             Path to the dApp script file
+    -suite              Path to the test suite file in JSON format
+    -junit              Path to the JUnit XML report, the report is printed to stdout if empty
+
+The lsp command runs the language server that communicates with the editor over stdin and stdout.
 `
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "test":
+			os.Exit(runTests(os.Args[2:]))
+		case "lsp":
+			os.Exit(runLanguageServer(os.Args[2:]))
+		}
+	}
 	var (
 		scriptPath   string
 		compaction   bool
 		removeUnused bool
+		decompile    bool
+		asset        bool
+		complexity   bool
+		libVersion   uint
+		imports      importFlags
 	)
 	flag.StringVar(&scriptPath, "script", "", "Path to script file")
 	flag.BoolVar(&compaction, "compaction", false, "Compaction mode")
 	flag.BoolVar(&removeUnused, "remove-unused", false, "Remove unused code")
+	flag.BoolVar(&decompile, "decompile", false, "Decompile the Base64 encoded script from the file")
+	flag.BoolVar(&asset, "asset", false, "Decompile or estimate the expression as an asset script")
+	flag.BoolVar(&complexity, "complexity", false, "Print the complexity report of the script")
+	flag.UintVar(&libVersion, "lib-version", 0, "Library version to check the complexity limits against")
+	imports.register(flag.CommandLine)
 
 	flag.Usage = func() {
 		fmt.Println(usage)
@@ -46,7 +95,22 @@ func main() {
 		os.Exit(0)
 	}
 
-	treeBytes, errors := compiler.Compile(string(b), compaction, removeUnused)
+	if decompile {
+		src, err := decompileScript(string(b), asset)
+		if err != nil {
+			fmt.Printf("Failed to decompile script: %s\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(src)
+		return
+	}
+
+	resolver, err := imports.resolver()
+	if err != nil {
+		fmt.Printf("Failed to configure imports: %s", err)
+		os.Exit(0)
+	}
+	treeBytes, errors := compiler.CompileWithImports(string(b), scriptPath, resolver, compaction, removeUnused)
 	if len(errors) > 0 {
 		fmt.Println("Failed to compile script")
 		for _, err := range errors {
@@ -54,5 +118,165 @@ func main() {
 		}
 		os.Exit(0)
 	}
+	if complexity {
+		os.Exit(printComplexity(os.Stdout, treeBytes, libVersion, asset))
+	}
 	fmt.Println(base64.StdEncoding.EncodeToString(treeBytes))
 }
+
+// printComplexity writes the complexity report of the compiled script.
+// The returned exit code is non-zero if the script exceeds the limits.
+func printComplexity(w io.Writer, script []byte, libVersion uint, asset bool) int {
+	var v ast.LibraryVersion
+	if libVersion != 0 {
+		var err error
+		if libVersion > math.MaxUint8 {
+			_, _ = fmt.Fprintf(w, "Invalid library version: %d\n", libVersion)
+			return 2
+		}
+		if v, err = ast.NewLibraryVersion(byte(libVersion)); err != nil {
+			_, _ = fmt.Fprintf(w, "Invalid library version: %s\n", err)
+			return 2
+		}
+	}
+	report, err := newComplexityReport(script, v, asset)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "Failed to estimate script: %s\n", err)
+		return 2
+	}
+	if err := report.write(w); err != nil {
+		_, _ = fmt.Fprintf(w, "Failed to write report: %s\n", err)
+		return 2
+	}
+	if report.exceeded() {
+		return 1
+	}
+	return 0
+}
+
+func decompileScript(encoded string, asset bool) (string, error) {
+	encoded = strings.TrimPrefix(strings.TrimSpace(encoded), "base64:")
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	tree, err := serialization.Parse(b)
+	if err != nil {
+		return "", err
+	}
+	scriptType := decompiler.AccountScript
+	if asset {
+		scriptType = decompiler.AssetScript
+	}
+	return decompiler.Decompile(tree, scriptType)
+}
+
+// runTests compiles the dApp script, runs the tests of the suite and prints the JUnit XML report.
+// The returned exit code is non-zero if any of the tests has failed.
+func runTests(args []string) int {
+	var (
+		scriptPath string
+		suitePath  string
+		junitPath  string
+		imports    importFlags
+	)
+	fs := flag.NewFlagSet("test", flag.ExitOnError)
+	fs.StringVar(&scriptPath, "script", "", "Path to the dApp script file")
+	fs.StringVar(&suitePath, "suite", "", "Path to the test suite file in JSON format")
+	fs.StringVar(&junitPath, "junit", "", "Path to the JUnit XML report, the report is printed to stdout if empty")
+	imports.register(fs)
+	fs.Usage = func() {
+		fmt.Println(usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if scriptPath == "" || suitePath == "" {
+		fmt.Println("Script or test suite path is not specified")
+		fs.Usage()
+		return 2
+	}
+	src, err := os.ReadFile(filepath.Clean(scriptPath))
+	if err != nil {
+		fmt.Printf("Failed to open file: %s\n", err)
+		return 2
+	}
+	resolver, err := imports.resolver()
+	if err != nil {
+		fmt.Printf("Failed to configure imports: %s\n", err)
+		return 2
+	}
+	script, errs := compiler.CompileWithImports(string(src), scriptPath, resolver, false, false)
+	if len(errs) > 0 {
+		fmt.Println("Failed to compile script")
+		for _, e := range errs {
+			fmt.Printf("\t%v\n", e)
+		}
+		return 2
+	}
+	f, err := os.Open(filepath.Clean(suitePath))
+	if err != nil {
+		fmt.Printf("Failed to open file: %s\n", err)
+		return 2
+	}
+	defer func() { _ = f.Close() }()
+	suite, err := tester.LoadSuite(f)
+	if err != nil {
+		fmt.Printf("Failed to load test suite: %s\n", err)
+		return 2
+	}
+	report, err := tester.Run(script, suite)
+	if err != nil {
+		fmt.Printf("Failed to run tests: %s\n", err)
+		return 2
+	}
+	if err := writeReport(report, junitPath); err != nil {
+		fmt.Printf("Failed to write report: %s\n", err)
+		return 2
+	}
+	if report.Failures() > 0 {
+		return 1
+	}
+	return 0
+}
+
+func writeReport(report *tester.Report, path string) (err error) {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, cErr := os.Create(filepath.Clean(path))
+		if cErr != nil {
+			return cErr
+		}
+		defer func() {
+			if clErr := f.Close(); clErr != nil && err == nil {
+				err = clErr
+			}
+		}()
+		w = f
+	}
+	return report.WriteJUnit(w)
+}
+
+// runLanguageServer serves the language server protocol over stdin and stdout, the logs are written to stderr.
+func runLanguageServer(args []string) int {
+	var imports importFlags
+	fs := flag.NewFlagSet("lsp", flag.ExitOnError)
+	imports.register(fs)
+	fs.Usage = func() {
+		fmt.Println(usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	resolver, err := imports.resolver()
+	if err != nil {
+		logger.Error("Failed to configure imports", logging.Error(err))
+		return 2
+	}
+	if err := lsp.NewServer(os.Stdout, logger, resolver).Serve(os.Stdin); err != nil {
+		logger.Error("Language server failed", logging.Error(err))
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+)
+
+func main() {
+	os.Exit(realMain()) // for more info see https://github.com/golang/go/issues/42078
+}
+
+func realMain() int {
+	c := parseFlags()
+	if err := c.lp.Parse(); err != nil {
+		slog.Error("Failed to parse application parameters", logging.Error(err))
+		return 1
+	}
+	slog.SetDefault(slog.New(logging.DefaultHandler(c.lp)))
+	slog.Info("Gowaves DB migration", "version", versioning.Version)
+
+	ctx, done := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer done()
+
+	if err := c.validateFlags(); err != nil {
+		slog.Error("Invalid parameters", logging.Error(err))
+		return 1
+	}
+	if err := run(ctx, &c); err != nil {
+		slog.Error("Failed to migrate state database", logging.Error(err))
+		return 1
+	}
+	return 0
+}
+
+type cfg struct {
+	lp          logging.Parameters
+	dataDirPath string
+	backend     keyvalue.Backend
+	keepOld     bool
+}
+
+func parseFlags() cfg {
+	c := cfg{}
+	c.lp.Initialize()
+	flag.StringVar(&c.dataDirPath, "data-path", "",
+		"Path to directory with the state of the stopped node.")
+	flag.TextVar(&c.backend, "to", keyvalue.BackendPebble,
+		fmt.Sprintf("Storage backend to migrate the state database to. Supported: %v", keyvalue.BackendStrings()),
+	)
+	flag.BoolVar(&c.keepOld, "keep-old", false,
+		"Keep the old state database next to the new one, the name of its backend is added to the directory name.")
+	flag.Parse()
+	return c
+}
+
+func (c *cfg) validateFlags() error {
+	if c.dataDirPath == "" {
+		return errors.New("option data-path is not specified, please specify it")
+	}
+	return nil
+}
+
+func run(ctx context.Context, c *cfg) error {
+	maxFDs, err := fdlimit.MaxFDs()
+	if err != nil {
+		return fmt.Errorf("initialization failed: %w", err)
+	}
+	if _, err = fdlimit.RaiseMaxFDs(maxFDs); err != nil {
+		return fmt.Errorf("initialization failed: %w", err)
+	}
+	start := time.Now()
+	if mErr := state.MigrateDatabase(ctx, c.dataDirPath, c.backend, c.keepOld); mErr != nil {
+		return mErr
+	}
+	slog.Info("State database migrated", "backend", c.backend, "duration", time.Since(start))
+	return nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/wavesplatform/gowaves/pkg/exporter"
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+)
+
+func main() {
+	os.Exit(realMain()) // for more info see https://github.com/golang/go/issues/42078
+}
+
+func realMain() int {
+	c := parseFlags()
+	if err := c.lp.Parse(); err != nil {
+		slog.Error("Failed to parse application parameters", logging.Error(err))
+		return 1
+	}
+	slog.SetDefault(slog.New(logging.DefaultHandler(c.lp)))
+
+	ctx, done := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer done()
+
+	if err := c.validateFlags(); err != nil {
+		slog.Error(capitalize(err.Error()))
+		return 1
+	}
+
+	if err := runExporter(ctx, &c); err != nil {
+		slog.Error(capitalize(err.Error()))
+		return 1
+	}
+	return 0
+}
+
+type cfg struct {
+	lp             logging.Parameters
+	cfgPath        string
+	blockchainType string
+	blockchainPath string
+	snapshotsPath  string
+	dataDirPath    string
+	toHeight       uint64
+	syncInterval   uint64
+}
+
+func parseFlags() cfg {
+	const defaultSyncInterval = 1000
+	c := cfg{}
+	c.lp.Initialize()
+	flag.StringVar(&c.cfgPath, "cfg-path", "",
+		"Path to blockchain settings JSON file for custom blockchains. Not set by default.")
+	flag.StringVar(&c.blockchainType, "blockchain-type", "mainnet",
+		"Blockchain type. Allowed values: mainnet/testnet/stagenet/custom. Default is 'mainnet'.")
+	flag.StringVar(&c.blockchainPath, "blockchain-path", "",
+		"Path to binary blockchain file. The export is resumed if the file exists.")
+	flag.StringVar(&c.snapshotsPath, "snapshots-path", "",
+		"Path to binary snapshots file. Snapshots are not exported if not set.")
+	flag.StringVar(&c.dataDirPath, "data-path", "",
+		"Path to directory with the state of the stopped node.")
+	flag.Uint64Var(&c.toHeight, "to-height", 0,
+		"Height of the last block to export. The whole blockchain is exported by default.")
+	flag.Uint64Var(&c.syncInterval, "sync-interval", defaultSyncInterval,
+		"Number of blocks after which the exported files are synced to disk.")
+	flag.Parse()
+	return c
+}
+
+func (c *cfg) validateFlags() error {
+	if c.blockchainPath == "" {
+		return errors.New("option blockchain-path is not specified, please specify it")
+	}
+	if c.dataDirPath == "" {
+		return errors.New("option data-path is not specified, please specify it")
+	}
+	if c.syncInterval == 0 {
+		return errors.New("option sync-interval should be positive")
+	}
+	return nil
+}
+
+func (c *cfg) params(maxFDs int) state.StateParams {
+	const clearance = 10
+	params := state.DefaultStateParams()
+	params.DbParams.OpenFilesCacheCapacity = maxFDs - clearance
+	params.ProvideExtendedApi = false // We do not need to provide any APIs during export.
+	return params
+}
+
+func runExporter(ctx context.Context, c *cfg) error {
+	slog.Info("Gowaves Exporter", "version", versioning.Version)
+
+	fds, err := riseFDLimit()
+	if err != nil {
+		return err
+	}
+
+	ss, err := configureBlockchainSettings(c.blockchainType, c.cfgPath)
+	if err != nil {
+		return err
+	}
+
+	st, err := state.NewState(ctx, c.dataDirPath, false, c.params(fds), ss, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to open state: %w", err)
+	}
+	defer func() {
+		if clErr := st.Close(); clErr != nil {
+			slog.Error("Failed to close State", logging.Error(clErr))
+		}
+	}()
+
+	params := exporter.ExportParams{
+		Scheme:         ss.AddressSchemeCharacter,
+		BlockchainPath: c.blockchainPath,
+		SnapshotsPath:  c.snapshotsPath,
+		ToHeight:       c.toHeight,
+		SyncInterval:   c.syncInterval,
+	}
+
+	start := time.Now()
+	height, err := exporter.ExportToFile(ctx, params, st)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			slog.Info("Interrupted by user", "height", height)
+			return nil
+		}
+		return fmt.Errorf("failed to export blocks after height %d: %w", height, err)
+	}
+	slog.Info("Export complete", "height", height, "duration", time.Since(start))
+	return nil
+}
+
+func configureBlockchainSettings(blockchainType, cfgPath string) (*settings.BlockchainSettings, error) {
+	var ss *settings.BlockchainSettings
+	if strings.ToLower(blockchainType) == "custom" && cfgPath != "" {
+		f, err := os.Open(filepath.Clean(cfgPath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open custom blockchain settings: %w", err)
+		}
+		defer func() {
+			if clErr := f.Close(); clErr != nil {
+				slog.Error("Failed to close custom blockchain settings", logging.Error(clErr))
+			}
+		}()
+		ss, err = settings.ReadBlockchainSettings(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read custom blockchain settings: %w", err)
+		}
+		return ss, nil
+	}
+	ss, err := settings.BlockchainSettingsByTypeName(blockchainType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load blockchain settings: %w", err)
+	}
+	return ss, nil
+}
+
+func riseFDLimit() (int, error) {
+	maxFDs, err := fdlimit.MaxFDs()
+	if err != nil {
+		return 0, fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+	_, err = fdlimit.RaiseMaxFDs(maxFDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+	return int(maxFDs), nil
+}
+
+func capitalize(str string) string {
+	runes := []rune(str)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
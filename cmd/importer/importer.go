@@ -76,6 +76,7 @@ type cfg struct {
 	memProfilePath            string
 	disableBloomFilter        bool
 	DBCompressionAlgo         keyvalue.CompressionAlgo
+	DBBackend                 keyvalue.Backend
 }
 
 func parseFlags() cfg {
@@ -115,6 +116,9 @@ func parseFlags() cfg {
 			keyvalue.CompressionAlgoStrings(),
 		),
 	)
+	flag.TextVar(&c.DBBackend, "db-backend", keyvalue.BackendLevelDB,
+		fmt.Sprintf("Set the storage backend of the state database. Supported: %v", keyvalue.BackendStrings()),
+	)
 	flag.Parse()
 	return c
 }
@@ -143,6 +147,7 @@ func (c *cfg) params(maxFDs int) state.StateParams {
 	params.BuildStateHashes = c.buildStateHashes
 	params.ProvideExtendedApi = false // We do not need to provide any APIs during import.
 	params.DbParams.CompressionAlgo = c.DBCompressionAlgo
+	params.DbParams.Backend = c.DBBackend
 	return params
 }
 
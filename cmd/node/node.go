@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
 	stderrs "errors"
 	"flag"
 	"fmt"
@@ -40,6 +42,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/node/network"
 	"github.com/wavesplatform/gowaves/pkg/node/peers"
 	peersPersistentStorage "github.com/wavesplatform/gowaves/pkg/node/peers/storage"
+	"github.com/wavesplatform/gowaves/pkg/node/readiness"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/services"
@@ -48,6 +51,8 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/types"
 	"github.com/wavesplatform/gowaves/pkg/util/common"
 	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/util/ratelimit"
+	"github.com/wavesplatform/gowaves/pkg/util/tlsreloader"
 	"github.com/wavesplatform/gowaves/pkg/versioning"
 	"github.com/wavesplatform/gowaves/pkg/wallet"
 )
@@ -94,6 +99,15 @@ type config struct {
 	rateLimiterOptions            string
 	grpcAddr                      string
 	grpcAPIMaxConnections         int
+	grpcRateLimiterOptions        string
+	grpcLogRequests               bool
+	apiTLSCert                    string
+	apiTLSKey                     string
+	apiTLSClientCA                string
+	grpcTLSCert                   string
+	grpcTLSKey                    string
+	grpcTLSClientCA               string
+	readinessMaxHeightLag         uint64
 	enableMetaMaskAPI             bool
 	enableMetaMaskAPILog          bool
 	enableGrpcAPI                 bool
@@ -107,6 +121,7 @@ type config struct {
 	disableBloomFilter            bool
 	reward                        int64
 	obsolescencePeriod            time.Duration
+	utxOptions                    string
 	walletPath                    string
 	walletPassword                string
 	limitAllConnections           uint
@@ -126,6 +141,13 @@ type config struct {
 	enableBlockchainUpdatesPlugin bool
 	blockchainUpdatesL2Address    string
 	DBCompressionAlgo             keyvalue.CompressionAlgo
+	DBBackend                     keyvalue.Backend
+	checkpointsDir                string
+	checkpointInterval            uint64
+	checkpointsKeep               int
+	restoreCheckpoint             string
+	checkpointStateHash           string
+	pruneKeepBlocks               uint64
 	h                             slog.Handler
 }
 
@@ -148,20 +170,28 @@ func (c *config) StatePath() (string, error) {
 func (c *config) String() string {
 	return fmt.Sprintf("{Logger: %s, log-network: %t, log-fsm: %t, state-path: %s, blockchain-type: %s, "+
 		"peers: %s, declared-address: %s, api-address: %s, api-key: %s, grpc-address: %s, "+
-		"enable-grpc-api: %t, black-list-residence-time: %s, build-extended-api: %t, serve-extended-api: %t, "+
-		"build-state-hashes: %t, bind-address: %s, vote: %s, reward: %d, obsolescence: %s, disable-miner: %t, "+
-		"wallet-path: %s, hashed wallet-password: %s, limit-connections: %d, profiler: %t, "+
+		"enable-grpc-api: %t, grpc-rate-limiter-opts: %s, grpc-log-requests: %t, black-list-residence-time: %s, "+
+		"readiness-max-height-lag: %d, api-tls-cert: %s, api-tls-client-ca: %s, grpc-tls-cert: %s, grpc-tls-client-ca: %s, "+
+		"build-extended-api: %t, serve-extended-api: %t, "+
+		"build-state-hashes: %t, bind-address: %s, vote: %s, reward: %d, obsolescence: %s, utx-opts: %s, "+
+		"disable-miner: %t, wallet-path: %s, hashed wallet-password: %s, limit-connections: %d, profiler: %t, "+
 		"disable-bloom: %t, drop-peers: %t, db-file-descriptors: %d, new-connections-limit: %d, "+
 		"enable-metamask: %t, disable-ntp: %t, microblock-interval: %s, enable-light-mode: %t, generate-in-past: %t, "+
-		"enable-blockchain-updates-plugin: %t, l2-contract-address: %s, db-compression-algo: %s, min-peers-mining: %d}",
+		"enable-blockchain-updates-plugin: %t, l2-contract-address: %s, db-compression-algo: %s, min-peers-mining: %d, "+
+		"checkpoints-dir: %s, checkpoint-interval: %d, checkpoints-keep: %d, restore-checkpoint: %s, "+
+		"prune-keep-blocks: %d, db-backend: %s}",
 		c.lp.String(), c.logNetwork, c.logFSM, c.statePath, c.blockchainType,
 		c.peerAddresses, c.declAddr, c.apiAddr, crypto.MustKeccak256([]byte(c.apiKey)).Hex(), c.grpcAddr,
-		c.enableGrpcAPI, c.blackListResidenceTime, c.buildExtendedAPI, c.serveExtendedAPI,
-		c.buildStateHashes, c.bindAddress, c.minerVoteFeatures, c.reward, c.obsolescencePeriod, c.disableMiner,
-		c.walletPath, crypto.MustKeccak256([]byte(c.walletPassword)).Hex(), c.limitAllConnections, c.profiler,
+		c.enableGrpcAPI, c.grpcRateLimiterOptions, c.grpcLogRequests, c.blackListResidenceTime,
+		c.readinessMaxHeightLag, c.apiTLSCert, c.apiTLSClientCA, c.grpcTLSCert, c.grpcTLSClientCA,
+		c.buildExtendedAPI, c.serveExtendedAPI,
+		c.buildStateHashes, c.bindAddress, c.minerVoteFeatures, c.reward, c.obsolescencePeriod, c.utxOptions,
+		c.disableMiner, c.walletPath, crypto.MustKeccak256([]byte(c.walletPassword)).Hex(), c.limitAllConnections, c.profiler,
 		c.disableBloomFilter, c.dropPeers, c.dbFileDescriptors, c.newConnectionsLimit,
 		c.enableMetaMaskAPI, c.disableNTP, c.microblockInterval, c.enableLightMode, c.generateInPast,
-		c.enableBlockchainUpdatesPlugin, c.blockchainUpdatesL2Address, c.DBCompressionAlgo, c.minPeersMining)
+		c.enableBlockchainUpdatesPlugin, c.blockchainUpdatesL2Address, c.DBCompressionAlgo, c.minPeersMining,
+		c.checkpointsDir, c.checkpointInterval, c.checkpointsKeep, c.restoreCheckpoint,
+		c.pruneKeepBlocks, c.DBBackend)
 }
 
 func (c *config) parse() {
@@ -175,6 +205,7 @@ func (c *config) parse() {
 		defaultConnectionsLimit           = 60
 		defaultNewConnectionLimit         = 10
 		defaultMicroblockInterval         = 5 * time.Second
+		defaultCheckpointsKeep            = 2
 	)
 	c.lp = logging.Parameters{}
 	flag.BoolVar(&c.logNetwork, "log-network", false,
@@ -201,6 +232,25 @@ func (c *config) parse() {
 	flag.StringVar(&c.grpcAddr, "grpc-address", "127.0.0.1:7475", "Address for gRPC API.")
 	flag.IntVar(&c.grpcAPIMaxConnections, "grpc-api-max-connections", server.DefaultMaxConnections,
 		"Max number of simultaneous connections for gRPC API.")
+	flag.StringVar(&c.grpcRateLimiterOptions, "grpc-rate-limiter-opts", "",
+		"Per peer rate limiter options of gRPC API in the same form as 'rate-limiter-opts'. "+
+			"Rate limiting of gRPC API is disabled if empty.")
+	flag.BoolVar(&c.grpcLogRequests, "grpc-log-requests", false, "Enables/disables access log of gRPC API calls.")
+	flag.Uint64Var(&c.readinessMaxHeightLag, "readiness-max-height-lag", readiness.DefaultMaxHeightLag,
+		"Maximum number of blocks the node can lag behind the best peer to be considered ready by "+
+			"REST API readiness probe and gRPC health checking service.")
+	flag.StringVar(&c.apiTLSCert, "api-tls-cert", "",
+		"Path to PEM encoded TLS certificate for REST API. HTTPS is enabled if certificate and key are set. "+
+			"Certificates are reloaded on SIGHUP or change of files.")
+	flag.StringVar(&c.apiTLSKey, "api-tls-key", "", "Path to PEM encoded TLS private key for REST API.")
+	flag.StringVar(&c.apiTLSClientCA, "api-tls-client-ca", "",
+		"Path to PEM encoded CA certificates to verify REST API clients. Enables mutual TLS if set.")
+	flag.StringVar(&c.grpcTLSCert, "grpc-tls-cert", "",
+		"Path to PEM encoded TLS certificate for gRPC API. TLS is enabled if certificate and key are set. "+
+			"Certificates are reloaded on SIGHUP or change of files.")
+	flag.StringVar(&c.grpcTLSKey, "grpc-tls-key", "", "Path to PEM encoded TLS private key for gRPC API.")
+	flag.StringVar(&c.grpcTLSClientCA, "grpc-tls-client-ca", "",
+		"Path to PEM encoded CA certificates to verify gRPC API clients. Enables mutual TLS if set.")
 	flag.BoolVar(&c.enableMetaMaskAPI, "enable-metamask", true, "Enables/disables metamask API.")
 	flag.BoolVar(&c.enableMetaMaskAPILog, "enable-metamask-log", false,
 		"Enables/disables metamask API logging.")
@@ -228,6 +278,11 @@ func (c *config) parse() {
 	flag.Int64Var(&c.reward, "reward", 0, "Miner reward: for example 600000000.")
 	flag.DurationVar(&c.obsolescencePeriod, "obsolescence", defaultObsolescenceDuration,
 		"Blockchain obsolescence period. Disable mining if last block older then given value.")
+	flag.StringVar(&c.utxOptions, "utx-opts", "",
+		"UTX pool options in form of URL query options, e.g. \"sender-count=100&ttl=90m&priority=<address>\", "+
+			"keys 'sender-count' - max number of transactions from one sender, 'sender-bytes' - max size of "+
+			"transactions from one sender in bytes, 'ttl' - transaction time to live in the pool, not limited by default, "+
+			"'priority' - address of sender whose transactions go first, can be repeated")
 	flag.StringVar(&c.walletPath, "wallet-path", "", "Path to wallet, or ~/.waves by default.")
 	flag.StringVar(&c.walletPassword, "wallet-password", "", "Pass password for wallet.")
 	flag.UintVar(&c.limitAllConnections, "limit-connections", defaultConnectionsLimit,
@@ -267,6 +322,23 @@ func (c *config) parse() {
 			keyvalue.CompressionAlgoStrings(),
 		),
 	)
+	flag.TextVar(&c.DBBackend, "db-backend", keyvalue.BackendLevelDB,
+		fmt.Sprintf("Set the storage backend of the state database. Supported: %v", keyvalue.BackendStrings()),
+	)
+	flag.StringVar(&c.checkpointsDir, "checkpoints-dir", "",
+		"Path to directory where the state checkpoints are created. Requires 'build-state-hashes' flag.")
+	flag.Uint64Var(&c.checkpointInterval, "checkpoint-interval", 0,
+		"Number of blocks between the state checkpoints. Checkpoints are not created if zero.")
+	flag.IntVar(&c.checkpointsKeep, "checkpoints-keep", defaultCheckpointsKeep,
+		"Number of the most recent state checkpoints to keep. All checkpoints are kept if zero.")
+	flag.StringVar(&c.restoreCheckpoint, "restore-checkpoint", "",
+		"Path to the state checkpoint to restore the empty state from before the start. "+
+			"Requires 'build-state-hashes' and 'checkpoint-state-hash' flags.")
+	flag.StringVar(&c.checkpointStateHash, "checkpoint-state-hash", "",
+		"Trusted state hash of the restored checkpoint in hex, e.g. taken from another node.")
+	flag.Uint64Var(&c.pruneKeepBlocks, "prune-keep-blocks", 0,
+		"Number of the latest blocks which transactions and snapshots are kept in the state, older ones are removed. "+
+			"Must be at least 2000. All blocks are kept if zero. Once pruned, the state can't be used without pruning.")
 	c.lp.Initialize()
 	flag.Parse()
 }
@@ -398,6 +470,12 @@ func runNode(ctx context.Context, nc *config) (_ io.Closer, retErr error) {
 		return nil, errors.Wrap(err, "failed to create state parameters")
 	}
 
+	if nc.restoreCheckpoint != "" {
+		if rErr := restoreCheckpoint(ctx, nc, path, params, cfg); rErr != nil {
+			return nil, errors.Wrap(rErr, "failed to restore state from checkpoint")
+		}
+	}
+
 	updatesChannel := make(chan proto.BUpdatesInfo, blockchaininfo.UpdatesBufferedChannelSize)
 	var bUpdatesPluginInfo *proto.BlockchainUpdatesPluginInfo
 	if nc.enableBlockchainUpdatesPlugin {
@@ -571,9 +649,48 @@ func stateParams(nc *config, ntpTime types.Time) (state.StateParams, error) {
 	params.Time = ntpTime
 	params.DbParams.DisableBloomFilter = nc.disableBloomFilter
 	params.DbParams.CompressionAlgo = nc.DBCompressionAlgo
+	params.DbParams.Backend = nc.DBBackend
+	params.Checkpoints = state.CheckpointParams{
+		Dir:      nc.checkpointsDir,
+		Interval: nc.checkpointInterval,
+		Keep:     nc.checkpointsKeep,
+	}
+	params.Pruning = state.PruningParams{KeepBlocks: nc.pruneKeepBlocks}
 	return params, nil
 }
 
+// restoreCheckpoint restores the empty state from the checkpoint, the checkpoint is validated before use.
+func restoreCheckpoint(
+	ctx context.Context,
+	nc *config,
+	path string,
+	params state.StateParams,
+	cfg *settings.BlockchainSettings,
+) error {
+	if !params.BuildStateHashes {
+		return errors.New("restoring from checkpoint requires 'build-state-hashes' flag")
+	}
+	if nc.checkpointStateHash == "" {
+		return errors.New("restoring from checkpoint requires 'checkpoint-state-hash' flag")
+	}
+	b, err := hex.DecodeString(nc.checkpointStateHash)
+	if err != nil {
+		return errors.Wrap(err, "invalid checkpoint state hash")
+	}
+	expected, err := crypto.NewDigestFromBytes(b)
+	if err != nil {
+		return errors.Wrap(err, "invalid checkpoint state hash")
+	}
+	slog.Info("Restoring state from checkpoint", "checkpoint", nc.restoreCheckpoint)
+	m, err := state.RestoreCheckpoint(ctx, nc.restoreCheckpoint, path, true, params, cfg, expected)
+	if err != nil {
+		return err
+	}
+	slog.Info("State restored from checkpoint", "height", m.Height, "blockID", m.BlockID.String(),
+		"stateHash", m.StateHash.Hex())
+	return nil
+}
+
 func runProfiler(ctx context.Context) <-chan struct{} {
 	pprofMux := http.NewServeMux()
 	// taken from "net/http/pprof" init()
@@ -645,12 +762,27 @@ func runPrometheusMetricsServer(ctx context.Context, prometheusAddr string) <-ch
 }
 
 func runGRPCServer(ctx context.Context, addr string, nc *config, svs services.Services) error {
-	srv, srvErr := server.NewServer(svs)
+	opts, optsErr := grpcAPIInterceptorOptsFromCLIFlags(nc)
+	if optsErr != nil {
+		return optsErr
+	}
+	runOpts := grpcAPIRunOptsFromCLIFlags(nc)
+	tlsConfig, tlsErr := newTLSConfig(ctx, nc.grpcTLSCert, nc.grpcTLSKey, nc.grpcTLSClientCA, "h2")
+	if tlsErr != nil {
+		return errors.Wrap(tlsErr, "failed to configure TLS for gRPC API")
+	}
+	runOpts.TLSConfig = tlsConfig
+	readinessOpts, rErr := readinessOptsFromCLIFlags(nc)
+	if rErr != nil {
+		return rErr
+	}
+	runOpts.ReadinessOpts = readinessOpts
+	srv, srvErr := server.NewServer(svs, nc.apiKey, opts)
 	if srvErr != nil {
 		return errors.Wrap(srvErr, "failed to create gRPC server")
 	}
 	go func() {
-		if runErr := srv.Run(ctx, addr, grpcAPIRunOptsFromCLIFlags(nc)); runErr != nil {
+		if runErr := srv.Run(ctx, addr, runOpts); runErr != nil {
 			slog.Error("Failed to run gRPC server", logging.Error(runErr))
 		}
 	}()
@@ -808,12 +940,16 @@ func createServices(
 	if err != nil {
 		return services.Services{}, errors.Wrap(err, "failed to initialize UTX")
 	}
+	utxOpts, err := utxpool.NewOptionsFromString(nc.utxOptions, cfg.AddressSchemeCharacter)
+	if err != nil {
+		return services.Services{}, errors.Wrap(err, "failed to parse UTX pool options")
+	}
 	return services.Services{
 		State:           st,
 		Peers:           peerManager,
 		Scheduler:       scheduler,
 		BlocksApplier:   blocks_applier.NewBlocksApplier(),
-		UtxPool:         utxpool.New(utxPoolMaxSizeBytes, utxValidator, cfg),
+		UtxPool:         utxpool.NewWithOptions(utxPoolMaxSizeBytes, utxValidator, cfg, utxOpts),
 		Scheme:          cfg.AddressSchemeCharacter,
 		Time:            ntpTime,
 		Wallet:          wal,
@@ -821,6 +957,7 @@ func createServices(
 		InternalChannel: messages.NewInternalChannel(),
 		MinPeersMining:  nc.minPeersMining,
 		SkipMessageList: parent.SkipMessageList,
+		FSMStatus:       services.NewFSMStatus(),
 	}, nil
 }
 
@@ -837,10 +974,21 @@ func runAPIs(
 		}
 	}
 
+	runOpts := apiRunOptsFromCLIFlags(nc)
+	tlsConfig, tlsErr := newTLSConfig(ctx, nc.apiTLSCert, nc.apiTLSKey, nc.apiTLSClientCA, "h2", "http/1.1")
+	if tlsErr != nil {
+		return errors.Wrap(tlsErr, "failed to configure TLS for REST API")
+	}
+	runOpts.TLSConfig = tlsConfig
+	readinessOpts, rErr := readinessOptsFromCLIFlags(nc)
+	if rErr != nil {
+		return rErr
+	}
+	runOpts.ReadinessOpts = readinessOpts
 	webAPI := api.NewNodeAPI(app, svs.State)
 	go func() {
 		slog.Info("Starting node HTTP API", "address", conf.HttpAddr)
-		if runErr := api.Run(ctx, conf.HttpAddr, webAPI, apiRunOptsFromCLIFlags(nc)); runErr != nil {
+		if runErr := api.Run(ctx, conf.HttpAddr, webAPI, runOpts); runErr != nil {
 			slog.Error("Failed to start API", logging.Error(runErr))
 
 		}
@@ -900,7 +1048,7 @@ func apiRunOptsFromCLIFlags(c *config) *api.RunOptions {
 		}
 	}
 	if c.rateLimiterOptions != "" {
-		rlo, err := api.NewRateLimiterOptionsFromString(c.rateLimiterOptions)
+		rlo, err := ratelimit.NewOptionsFromString(c.rateLimiterOptions)
 		if err == nil {
 			opts.RateLimiterOpts = rlo
 		} else {
@@ -917,6 +1065,48 @@ func grpcAPIRunOptsFromCLIFlags(c *config) *server.RunOptions {
 	return opts
 }
 
+func readinessOptsFromCLIFlags(c *config) (*readiness.Options, error) {
+	path, err := c.StatePath()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get state path")
+	}
+	return &readiness.Options{MaxHeightLag: c.readinessMaxHeightLag, DataDir: path}, nil
+}
+
+// newTLSConfig returns nil if the certificate and the key are not set, otherwise it loads the certificates
+// and starts their reloading until the context is done.
+func newTLSConfig(
+	ctx context.Context,
+	certFile, keyFile, clientCAFile string,
+	nextProtos ...string,
+) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, errors.New("client CA is set without certificate and key")
+		}
+		return nil, nil
+	}
+	r, err := tlsreloader.New(certFile, keyFile, clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	go r.Run(ctx, tlsreloader.DefaultCheckInterval)
+	return r.Config(nextProtos...), nil
+}
+
+func grpcAPIInterceptorOptsFromCLIFlags(c *config) (*server.InterceptorOptions, error) {
+	opts := server.DefaultInterceptorOptions()
+	opts.LogRequests = c.grpcLogRequests
+	if c.grpcRateLimiterOptions != "" {
+		rlo, err := ratelimit.NewOptionsFromString(c.grpcRateLimiterOptions)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid gRPC rate limiter options")
+		}
+		opts.RateLimiterOpts = rlo
+	}
+	return opts, nil
+}
+
 func GetNtp(ctx context.Context, disable bool) (types.Time, error) {
 	if disable {
 		return ntptime.Stub{}, nil
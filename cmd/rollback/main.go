@@ -30,6 +30,7 @@ func run() error {
 	var (
 		lp               = logging.Parameters{}
 		compressionAlgo  keyvalue.CompressionAlgo
+		dbBackend        keyvalue.Backend
 		statePath        = flag.String("state-path", "", "Path to node's state directory")
 		blockchainType   = flag.String("blockchain-type", "mainnet", "Blockchain type: mainnet/testnet/stagenet")
 		height           = flag.Uint64("height", 0, "Height to rollback")
@@ -46,6 +47,9 @@ func run() error {
 			keyvalue.CompressionAlgoStrings(),
 		),
 	)
+	flag.TextVar(&dbBackend, "db-backend", keyvalue.BackendLevelDB,
+		fmt.Sprintf("Set the storage backend of the state database. Supported: %v", keyvalue.BackendStrings()),
+	)
 	lp.Initialize()
 	flag.Parse()
 	if err := lp.Parse(); err != nil {
@@ -77,7 +81,8 @@ func run() error {
 		}
 	}
 
-	s, err := openState(ctx, *statePath, cfg, *buildExtendedAPI, *buildStateHashes, *disableBloomFilter, compressionAlgo)
+	s, err := openState(ctx, *statePath, cfg, *buildExtendedAPI, *buildStateHashes, *disableBloomFilter,
+		compressionAlgo, dbBackend)
 	if err != nil {
 		return fmt.Errorf("failed to open state: %w", err)
 	}
@@ -114,6 +119,7 @@ func openState(
 	cfg *settings.BlockchainSettings,
 	buildExtendedAPI, buildStateHashes, disableBloomFilter bool,
 	compressionAlgo keyvalue.CompressionAlgo,
+	dbBackend keyvalue.Backend,
 ) (state.State, error) {
 	maxFDs, err := fdlimit.MaxFDs()
 	if err != nil {
@@ -135,6 +141,7 @@ func openState(
 	params.BuildStateHashes = buildStateHashes
 	params.StoreExtendedApiData = buildExtendedAPI
 	params.DbParams.CompressionAlgo = compressionAlgo
+	params.DbParams.Backend = dbBackend
 
 	return state.NewState(ctx, statePath, true, params, cfg, false, nil)
 }
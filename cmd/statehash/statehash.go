@@ -52,6 +52,7 @@ func run() error {
 		onlyLegacy         bool
 		disableBloomFilter bool
 		compressionAlgo    keyvalue.CompressionAlgo
+		dbBackend          keyvalue.Backend
 	)
 
 	slog.SetDefault(slog.New(logging.NewHandler(logging.LoggerPrettyNoColor, slog.LevelInfo)))
@@ -72,6 +73,9 @@ func run() error {
 			keyvalue.CompressionAlgoStrings(),
 		),
 	)
+	flag.TextVar(&dbBackend, "db-backend", keyvalue.BackendLevelDB,
+		fmt.Sprintf("Set the storage backend of the state database. Supported: %v", keyvalue.BackendStrings()),
+	)
 	flag.Parse()
 
 	if showHelp {
@@ -117,6 +121,7 @@ func run() error {
 	params.BuildStateHashes = true
 	params.ProvideExtendedApi = false
 	params.DbParams.CompressionAlgo = compressionAlgo
+	params.DbParams.Backend = dbBackend
 
 	ctx, done := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer done()
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+)
+
+const (
+	commandStats   = "stats"
+	commandVerify  = "verify"
+	commandCompact = "compact"
+	commandBloom   = "bloom"
+)
+
+func main() {
+	os.Exit(realMain()) // for more info see https://github.com/golang/go/issues/42078
+}
+
+func realMain() int {
+	c := parseFlags()
+	if err := c.lp.Parse(); err != nil {
+		slog.Error("Failed to parse application parameters", logging.Error(err))
+		return 1
+	}
+	slog.SetDefault(slog.New(logging.DefaultHandler(c.lp)))
+	slog.Info("Gowaves state tool", "version", versioning.Version)
+
+	ctx, done := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer done()
+
+	if err := c.validateFlags(); err != nil {
+		slog.Error("Invalid parameters", logging.Error(err))
+		flag.Usage()
+		return 1
+	}
+	if err := run(ctx, &c); err != nil {
+		slog.Error("Failed to execute command", "command", c.command, logging.Error(err))
+		return 1
+	}
+	return 0
+}
+
+type cfg struct {
+	lp          logging.Parameters
+	dataDirPath string
+	prefix      string
+	probes      int
+	command     string
+}
+
+func parseFlags() cfg {
+	c := cfg{}
+	c.lp.Initialize()
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		_, _ = fmt.Fprintf(out, "Usage: %s [flags] <command>\n\nCommands:\n", os.Args[0])
+		_, _ = fmt.Fprintf(out, "  %s\tprint the number and the size of keys and values by key prefixes\n", commandStats)
+		_, _ = fmt.Fprintf(out, "  %s\tverify that history records decode and reference valid blocks\n", commandVerify)
+		_, _ = fmt.Fprintf(out, "  %s\tcompact the database or the keys of a single prefix\n", commandCompact)
+		_, _ = fmt.Fprintf(out, "  %s\treport the filling and the false positive rate of the bloom filter\n", commandBloom)
+		_, _ = fmt.Fprintf(out, "\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.StringVar(&c.dataDirPath, "data-path", "",
+		"Path to directory with the state of the stopped node.")
+	flag.StringVar(&c.prefix, "prefix", "",
+		"Name of the key prefix to compact, as printed by the stats command. The whole database is compacted by default.")
+	flag.IntVar(&c.probes, "probes", 100000,
+		"Number of random absent keys to look up to measure the false positive rate of the bloom filter.")
+	flag.Parse()
+	c.command = flag.Arg(0)
+	return c
+}
+
+func (c *cfg) validateFlags() error {
+	if c.dataDirPath == "" {
+		return errors.New("option data-path is not specified, please specify it")
+	}
+	if flag.NArg() != 1 {
+		return errors.New("exactly one command has to be specified")
+	}
+	switch c.command {
+	case commandStats, commandVerify, commandCompact, commandBloom:
+	default:
+		return fmt.Errorf("unknown command '%s'", c.command)
+	}
+	if c.probes < 0 {
+		return fmt.Errorf("invalid number of probes %d", c.probes)
+	}
+	return nil
+}
+
+//nolint:nonamedreturns // needs in defer
+func run(ctx context.Context, c *cfg) (retErr error) {
+	maxFDs, err := fdlimit.MaxFDs()
+	if err != nil {
+		return fmt.Errorf("initialization failed: %w", err)
+	}
+	if _, err = fdlimit.RaiseMaxFDs(maxFDs); err != nil {
+		return fmt.Errorf("initialization failed: %w", err)
+	}
+	inspector, err := state.NewInspector(c.dataDirPath, state.DefaultStorageParams().DbParams)
+	if err != nil {
+		return err
+	}
+	defer func() { // the database has to be closed properly to save the bloom filter
+		if clErr := inspector.Close(); clErr != nil {
+			retErr = errors.Join(retErr, fmt.Errorf("failed to close state database: %w", clErr))
+		}
+	}()
+	start := time.Now()
+	switch c.command {
+	case commandStats:
+		err = printStats(ctx, inspector)
+	case commandVerify:
+		err = verify(ctx, inspector)
+	case commandCompact:
+		slog.Info("Compacting state database", "prefix", c.prefix)
+		err = inspector.Compact(c.prefix)
+	case commandBloom:
+		err = printBloomStats(inspector, c.probes)
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info("Command completed", "command", c.command, "duration", time.Since(start))
+	return nil
+}
+
+func printStats(ctx context.Context, inspector *state.Inspector) error {
+	stats, err := inspector.KeyStats(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(w, "Prefix\tName\tHistory\tKeys\tKeys size\tValues size\t")
+	var keys, keysSize, valuesSize uint64
+	for _, s := range stats {
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%t\t%d\t%d\t%d\t\n", s.Prefix, s.Name, s.History, s.Keys, s.KeysSize, s.ValuesSize)
+		keys += s.Keys
+		keysSize += s.KeysSize
+		valuesSize += s.ValuesSize
+	}
+	_, _ = fmt.Fprintf(w, "\tTotal\t\t%d\t%d\t%d\t\n", keys, keysSize, valuesSize)
+	return w.Flush()
+}
+
+func verify(ctx context.Context, inspector *state.Inspector) error {
+	report, err := inspector.VerifyHistory(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range report.Problems {
+		_, _ = fmt.Printf("%s %s: %s\n", p.Entity, hex.EncodeToString(p.Key), p.Problem)
+	}
+	_, _ = fmt.Printf("Records: %d, entries: %d, stale entries: %d, problems: %d\n",
+		report.Records, report.Entries, report.StaleEntries, report.ProblemsCount)
+	if report.ProblemsCount != 0 {
+		return fmt.Errorf("found %d problems in history records", report.ProblemsCount)
+	}
+	return nil
+}
+
+func printBloomStats(inspector *state.Inspector, probes int) error {
+	stats := inspector.BloomFilterStats()
+	if !stats.Enabled {
+		_, _ = fmt.Println("Bloom filter is disabled")
+		return nil
+	}
+	falsePositives, err := inspector.ProbeBloomFilter(probes)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Capacity:\t%d\n", stats.Capacity)
+	_, _ = fmt.Fprintf(w, "Additions:\t%d\n", stats.Additions)
+	_, _ = fmt.Fprintf(w, "Filled ratio:\t%.4f\n", stats.FilledRatio)
+	_, _ = fmt.Fprintf(w, "Configured false positive probability:\t%.6f\n", stats.ConfiguredFalsePositiveProbability)
+	_, _ = fmt.Fprintf(w, "Estimated false positive probability:\t%.6f\n", stats.EstimatedFalsePositiveProbability)
+	rate := 0.0
+	if probes > 0 {
+		rate = float64(falsePositives) / float64(probes)
+	}
+	_, _ = fmt.Fprintf(w, "Measured false positive rate:\t%.6f (%d of %d probes)\n", rate, falsePositives, probes)
+	return w.Flush()
+}
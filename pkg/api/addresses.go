@@ -1,9 +1,15 @@
 package api
 
 import (
+	"regexp"
+	"slices"
+	"strings"
+
 	"github.com/pkg/errors"
 
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
 )
 
 func (a *App) Addresses() ([]string, error) {
@@ -37,3 +43,104 @@ func (a *App) WavesRegularBalanceByAddress(addr proto.WavesAddress) (WavesRegula
 		Confirmations: 0, // nickeskov: always 0 confirmations because this method returns the latest balance
 	}, nil
 }
+
+// WavesRegularBalanceByAddressAtHeight returns regular balance of the address at the given height.
+// The height must be within the rollback window.
+func (a *App) WavesRegularBalanceByAddressAtHeight(
+	addr proto.WavesAddress,
+	height proto.Height,
+) (WavesRegularBalance, error) {
+	regularBalance, err := a.state.WavesBalanceAtHeight(proto.NewRecipientFromAddress(addr), height)
+	if err != nil {
+		if stateerr.IsInvalidInput(err) {
+			return WavesRegularBalance{}, apiErrs.NewCustomValidationError(err.Error())
+		}
+		return WavesRegularBalance{}, err
+	}
+	currentHeight, err := a.state.Height()
+	if err != nil {
+		return WavesRegularBalance{}, err
+	}
+	return WavesRegularBalance{
+		Address:       addr,
+		Balance:       regularBalance,
+		Confirmations: currentHeight - height,
+	}, nil
+}
+
+// AddressesDataEntries returns all data entries of the given account sorted by key.
+// If matches is not nil, only entries with keys fully matching the regular expression are returned.
+func (a *App) AddressesDataEntries(addr proto.WavesAddress, matches *regexp.Regexp) ([]proto.DataEntry, error) {
+	entries, err := a.state.RetrieveEntries(proto.NewRecipientFromAddress(addr))
+	if err != nil {
+		if stateerr.IsNotFound(err) {
+			return []proto.DataEntry{}, nil // ensure that empty array will be returned instead of nil
+		}
+		return nil, errors.Wrapf(err, "failed to retrieve data entries for address %q", addr.String())
+	}
+	res := make([]proto.DataEntry, 0, len(entries))
+	for _, e := range entries {
+		if matches != nil && !matches.MatchString(e.GetKey()) {
+			continue
+		}
+		res = append(res, e)
+	}
+	slices.SortFunc(res, func(x, y proto.DataEntry) int { return strings.Compare(x.GetKey(), y.GetKey()) })
+	return res, nil
+}
+
+// AddressesDataEntriesByKeys returns data entries of the given account for the given keys.
+// Keys without values are skipped, the order of the found entries follows the order of keys.
+func (a *App) AddressesDataEntriesByKeys(addr proto.WavesAddress, keys []string) ([]proto.DataEntry, error) {
+	if limit := a.settings.DataKeysRequestLimit; len(keys) > limit {
+		return nil, apiErrs.NewTooBigArrayAllocationError(limit)
+	}
+	rcp := proto.NewRecipientFromAddress(addr)
+	res := make([]proto.DataEntry, 0, len(keys))
+	for _, key := range keys {
+		entry, err := a.state.RetrieveEntry(rcp, key)
+		if err != nil {
+			if stateerr.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to retrieve data entry %q for address %q", key, addr.String())
+		}
+		res = append(res, entry)
+	}
+	return res, nil
+}
+
+// AddressesDataEntry returns data entry of the given account by key.
+func (a *App) AddressesDataEntry(addr proto.WavesAddress, key string) (proto.DataEntry, error) {
+	entry, err := a.state.RetrieveEntry(proto.NewRecipientFromAddress(addr), key)
+	if err != nil {
+		if stateerr.IsNotFound(err) {
+			return nil, apiErrs.DataKeyDoesNotExist
+		}
+		return nil, errors.Wrapf(err, "failed to retrieve data entry %q for address %q", key, addr.String())
+	}
+	return entry, nil
+}
+
+// AddressesDataEntryAtHeight returns data entry of the given account by key as it was at the given height.
+// The height must be within the rollback window.
+func (a *App) AddressesDataEntryAtHeight(
+	addr proto.WavesAddress,
+	key string,
+	height proto.Height,
+) (proto.DataEntry, error) {
+	entry, err := a.state.RetrieveEntryAtHeight(proto.NewRecipientFromAddress(addr), key, height)
+	if err != nil {
+		switch {
+		case stateerr.IsInvalidInput(err):
+			return nil, apiErrs.NewCustomValidationError(err.Error())
+		case stateerr.IsNotFound(err):
+			return nil, apiErrs.DataKeyDoesNotExist
+		default:
+			return nil, errors.Wrapf(err, "failed to retrieve data entry %q for address %q at height %d",
+				key, addr.String(), height,
+			)
+		}
+	}
+	return entry, nil
+}
@@ -28,19 +28,25 @@ type SchedulerEmits interface {
 
 // default app settings
 const (
-	defaultBlockRequestLimit = 100
-	defaultAssetDetailsLimit = 100
+	defaultBlockRequestLimit          = 100
+	defaultAssetDetailsLimit          = 100
+	defaultDataKeysRequestLimit       = 1000
+	defaultTransactionsByAddressLimit = 1000
 )
 
 type appSettings struct {
-	BlockRequestLimit uint64
-	AssetDetailsLimit int
+	BlockRequestLimit          uint64
+	AssetDetailsLimit          int
+	DataKeysRequestLimit       int
+	TransactionsByAddressLimit int
 }
 
 func defaultAppSettings() *appSettings {
 	return &appSettings{
-		BlockRequestLimit: defaultBlockRequestLimit,
-		AssetDetailsLimit: defaultAssetDetailsLimit,
+		BlockRequestLimit:          defaultBlockRequestLimit,
+		AssetDetailsLimit:          defaultAssetDetailsLimit,
+		DataKeysRequestLimit:       defaultDataKeysRequestLimit,
+		TransactionsByAddressLimit: defaultTransactionsByAddressLimit,
 	}
 }
 
@@ -82,20 +88,9 @@ func newApp(apiKey string, scheduler SchedulerEmits, services services.Services,
 }
 
 func (a *App) TransactionsBroadcast(ctx context.Context, b []byte) (proto.Transaction, error) {
-	tt := proto.TransactionTypeVersion{}
-	err := json.Unmarshal(b, &tt)
-	if err != nil {
-		return nil, wrapToBadRequestError(err)
-	}
-
-	realType, err := proto.GuessTransactionType(&tt)
-	if err != nil {
-		return nil, wrapToBadRequestError(err)
-	}
-
-	err = proto.UnmarshalTransactionFromJSON(b, a.services.Scheme, realType)
+	realType, err := a.transactionFromJSON(b)
 	if err != nil {
-		return nil, wrapToBadRequestError(err)
+		return nil, err
 	}
 
 	respCh := make(chan error, 1)
@@ -131,6 +126,25 @@ func (a *App) TransactionsBroadcast(ctx context.Context, b []byte) (proto.Transa
 	}
 }
 
+func (a *App) transactionFromJSON(b []byte) (proto.Transaction, error) {
+	tt := proto.TransactionTypeVersion{}
+	err := json.Unmarshal(b, &tt)
+	if err != nil {
+		return nil, wrapToBadRequestError(err)
+	}
+
+	realType, err := proto.GuessTransactionType(&tt)
+	if err != nil {
+		return nil, wrapToBadRequestError(err)
+	}
+
+	err = proto.UnmarshalTransactionFromJSON(b, a.services.Scheme, realType)
+	if err != nil {
+		return nil, wrapToBadRequestError(err)
+	}
+	return realType, nil
+}
+
 func (a *App) LoadKeys(apiKey string, password []byte) error {
 	err := a.checkAuth(apiKey)
 	if err != nil {
@@ -1,5 +1,132 @@
 package api
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+// TransactionValidation is the result of the transaction dry-run against the current state.
+type TransactionValidation struct {
+	Valid          bool                `json:"valid"`
+	ValidationTime int64               `json:"validationTime"`
+	Height         proto.Height        `json:"height"`
+	Error          string              `json:"error,omitempty"`
+	Complexity     uint64              `json:"complexity"`
+	Fee            uint64              `json:"fee"`
+	FeeAssetID     proto.OptionalAsset `json:"feeAssetId"`
+	Transaction    proto.Transaction   `json:"transaction"`
+	Snapshot       proto.TxSnapshot    `json:"snapshot,omitempty"`
+}
+
+// InvokeTrace is the trace of re-executed invoke script transaction.
+type InvokeTrace struct {
+	ID    crypto.Digest       `json:"id"`
+	Trace []InvokeScriptTrace `json:"trace"`
+}
+
+// InvokeScriptTrace is the log of evaluation of the callable function in the format of the Scala node trace.
+type InvokeScriptTrace struct {
+	ID         proto.WavesAddress  `json:"id"`
+	Type       string              `json:"type"`
+	Function   string              `json:"function"`
+	Args       proto.Arguments     `json:"args"`
+	Complexity int                 `json:"complexity"`
+	Result     *ScriptStateChanges `json:"result,omitempty"`
+	Error      string              `json:"error,omitempty"`
+	Vars       []ride.TraceEntry   `json:"vars"`
+}
+
 func (a *App) DebugSyncEnabled(enabled bool) {
 	a.sync.SetEnabled(enabled)
 }
+
+// DebugValidate validates the transaction against the current state without putting it into the UTX pool.
+// Signature of the transaction is checked only if the transaction has proofs or signature.
+func (a *App) DebugValidate(b []byte) (*TransactionValidation, error) {
+	tx, err := a.transactionFromJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := isSignedTransactionJSON(b)
+	if err != nil {
+		return nil, wrapToBadRequestError(err)
+	}
+	height, err := a.state.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get height")
+	}
+	start := time.Now()
+	snapshot, complexity, vErr := a.state.DryRunTx(tx, proto.NewTimestampFromTime(start), signed)
+	res := &TransactionValidation{
+		Valid:          vErr == nil,
+		ValidationTime: time.Since(start).Milliseconds(),
+		Height:         height,
+		Complexity:     complexity,
+		Fee:            tx.GetFee(),
+		FeeAssetID:     tx.GetFeeAsset(),
+		Transaction:    tx,
+		Snapshot:       snapshot,
+	}
+	if vErr != nil {
+		res.Error = vErr.Error()
+	}
+	return res, nil
+}
+
+// isSignedTransactionJSON reports whether the JSON representation of the transaction has proofs or signature.
+func isSignedTransactionJSON(b []byte) (bool, error) {
+	var tx struct {
+		Proofs    []string `json:"proofs"`
+		Signature string   `json:"signature"`
+	}
+	if err := json.Unmarshal(b, &tx); err != nil {
+		return false, err
+	}
+	return len(tx.Proofs) != 0 || tx.Signature != "", nil
+}
+
+// DebugTrace re-executes the confirmed invoke script or Ethereum invoke transaction against the state right
+// before it and returns the log of evaluation, including the evaluation of nested invocations. Transactions
+// whose previous block is out of the rollback window are rejected with the validation error.
+// The failure of the script is a part of the trace, not an error.
+func (a *App) DebugTrace(id crypto.Digest) (*InvokeTrace, error) {
+	t, err := a.state.TraceInvoke(id)
+	if err != nil {
+		switch {
+		case stateerr.IsInvalidInput(err):
+			return nil, apiErrs.NewCustomValidationError(err.Error())
+		case stateerr.IsNotFound(err):
+			return nil, apiErrs.TransactionDoesNotExist
+		default:
+			return nil, errors.Wrap(err, "failed to trace transaction")
+		}
+	}
+	st := InvokeScriptTrace{
+		ID:       t.DApp,
+		Type:     "dApp",
+		Function: t.Call.Name(),
+		Args:     t.Call.Arguments(),
+		Vars:     t.Trace.Entries(),
+	}
+	if t.Err != nil {
+		st.Complexity = ride.EvaluationErrorSpentComplexity(t.Err)
+		st.Error = t.Err.Error()
+	} else {
+		st.Complexity = t.Result.Complexity()
+		if st.Result, err = newScriptStateChanges(t.Result.ScriptActions()); err != nil {
+			return nil, errors.Wrap(err, "failed to build state changes")
+		}
+	}
+	if st.Vars == nil {
+		st.Vars = []ride.TraceEntry{}
+	}
+	return &InvokeTrace{ID: id, Trace: []InvokeScriptTrace{st}}, nil
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+func TestNodeApi_DebugValidate(t *testing.T) {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("test"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	waves := proto.NewOptionalAssetWaves()
+	newTransfer := func(t *testing.T, sign bool) []byte {
+		tx := proto.NewUnsignedTransferWithProofs(3, pk, waves, waves, 1000, 100, 100000,
+			proto.NewRecipientFromAddress(addr), nil,
+		)
+		if sign {
+			require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+		}
+		js, jsErr := json.Marshal(tx)
+		require.NoError(t, jsErr)
+		return js
+	}
+	doRequest := func(t *testing.T, st state.State, body []byte) map[string]any {
+		a, aErr := NewApp("", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+		require.NoError(t, aErr)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/debug/validate", strings.NewReader(string(body)))
+		require.NoError(t, NewNodeAPI(a, nil).DebugValidate(resp, req))
+		var res map[string]any
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		return res
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		snapshot := []proto.AtomicSnapshot{
+			&proto.TransactionStatusSnapshot{Status: proto.TransactionSucceeded},
+			&proto.WavesBalanceSnapshot{Address: addr, Balance: 900},
+		}
+		st := state.NewMockState(t)
+		st.EXPECT().Height().Return(proto.Height(10), nil).Once()
+		st.EXPECT().DryRunTx(mock.Anything, mock.Anything, true).Return(snapshot, 0, nil).Once()
+		res := doRequest(t, st, newTransfer(t, true))
+		assert.Equal(t, true, res["valid"])
+		assert.EqualValues(t, 10, res["height"])
+		assert.EqualValues(t, 100000, res["fee"])
+		assert.Nil(t, res["feeAssetId"])
+		assert.NotContains(t, res, "error")
+		assert.Contains(t, res["snapshot"], "balances")
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().Height().Return(proto.Height(10), nil).Once()
+		st.EXPECT().DryRunTx(mock.Anything, mock.Anything, false).Return(nil, 0, nil).Once()
+		res := doRequest(t, st, newTransfer(t, false))
+		assert.Equal(t, true, res["valid"])
+	})
+	t.Run("invalid", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().Height().Return(proto.Height(10), nil).Once()
+		st.EXPECT().DryRunTx(mock.Anything, mock.Anything, true).
+			Return(nil, 0, errors.New("negative waves balance")).Once()
+		res := doRequest(t, st, newTransfer(t, true))
+		assert.Equal(t, false, res["valid"])
+		assert.Equal(t, "negative waves balance", res["error"])
+		assert.NotContains(t, res, "snapshot")
+	})
+}
+
+func TestNodeApi_DebugTrace(t *testing.T) {
+	txID := crypto.MustDigestFromBase58("8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS")
+	dApp, err := proto.NewAddressFromString("3Myqjf1D44wR8Vko4Tr5CwSzRNo2Vg9S7u7")
+	require.NoError(t, err)
+	fc := proto.NewFunctionCall("f", proto.Arguments{&proto.IntegerArgument{Value: 1}})
+	doRequest := func(t *testing.T, st state.State, id string) (*httptest.ResponseRecorder, error) {
+		a, aErr := NewApp("", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+		require.NoError(t, aErr)
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", id)
+		req := httptest.NewRequest(http.MethodGet, "/debug/trace/"+id, nil)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+		resp := httptest.NewRecorder()
+		return resp, NewNodeAPI(a, nil).DebugTrace(resp, req)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().TraceInvoke(txID).Return(&state.InvokeTrace{
+			DApp: dApp, Call: fc, Result: ride.DAppResult{}, Trace: ride.NewTrace(),
+		}, nil).Once()
+		resp, rErr := doRequest(t, st, txID.String())
+		require.NoError(t, rErr)
+		assert.JSONEq(t, `{"id":"8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS","trace":[{`+
+			`"id":"3Myqjf1D44wR8Vko4Tr5CwSzRNo2Vg9S7u7","type":"dApp","function":"f",`+
+			`"args":[{"type":"integer","value":1}],"complexity":0,"result":{"data":[],"transfers":[],`+
+			`"issues":[],"reissues":[],"burns":[],"sponsorFees":[],"leases":[],"leaseCancels":[]},"vars":[]}]}`,
+			resp.Body.String(),
+		)
+	})
+	t.Run("failed", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().TraceInvoke(txID).Return(&state.InvokeTrace{
+			DApp: dApp, Call: fc, Err: ride.EvaluationErrorSetComplexity(ride.UserError.New("boom"), 12),
+			Trace: ride.NewTrace(),
+		}, nil).Once()
+		resp, rErr := doRequest(t, st, txID.String())
+		require.NoError(t, rErr)
+		var res InvokeTrace
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		require.Len(t, res.Trace, 1)
+		assert.Equal(t, 12, res.Trace[0].Complexity)
+		assert.Equal(t, "boom", res.Trace[0].Error)
+		assert.Nil(t, res.Trace[0].Result)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, rErr := doRequest(t, state.NewMockState(t), "invalid")
+		var idErr *apiErrs.InvalidTransactionIdError
+		assert.ErrorAs(t, rErr, &idErr)
+
+		st := state.NewMockState(t)
+		st.EXPECT().TraceInvoke(txID).
+			Return(nil, stateerr.NewStateError(stateerr.NotFoundError, errors.New("not found"))).Once()
+		_, rErr = doRequest(t, st, txID.String())
+		assert.ErrorIs(t, rErr, apiErrs.TransactionDoesNotExist)
+
+		st = state.NewMockState(t)
+		st.EXPECT().TraceInvoke(txID).
+			Return(nil, stateerr.NewStateError(stateerr.InvalidInputError, errors.New("not an invoke"))).Once()
+		_, rErr = doRequest(t, st, txID.String())
+		var target *apiErrs.CustomValidationError
+		assert.ErrorAs(t, rErr, &target)
+	})
+}
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -61,6 +64,97 @@ func (a *NodeApi) TransactionsBroadcast(w http.ResponseWriter, r *http.Request)
 	return nil
 }
 
+func (a *NodeApi) TransactionsCalculateFee(w http.ResponseWriter, r *http.Request) error {
+	b, err := io.ReadAll(io.LimitReader(r.Body, postMessageSizeLimit))
+	if err != nil {
+		return errors.Wrap(err, "TransactionsCalculateFee: failed to read request body")
+	}
+	res, err := a.app.TransactionsCalculateFee(b)
+	if err != nil {
+		return errors.Wrap(err, "TransactionsCalculateFee")
+	}
+	if jsErr := trySendJSON(w, res); jsErr != nil {
+		return errors.Wrap(jsErr, "TransactionsCalculateFee")
+	}
+	return nil
+}
+
+// TransactionsByAddress returns confirmed transactions of the address in Scala compatible format,
+// which is an array with the single array of transactions inside.
+func (a *NodeApi) TransactionsByAddress(w http.ResponseWriter, r *http.Request) error {
+	addr, err := a.addressFromURLParam(r)
+	if err != nil {
+		return err
+	}
+	limitStr := chi.URLParam(r, "limit")
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		return apiErrs.NewCustomValidationError(fmt.Sprintf("invalid limit %q", limitStr))
+	}
+	query := r.URL.Query()
+	var after *crypto.Digest
+	if s := query.Get("after"); s != "" {
+		id, idErr := crypto.NewDigestFromBase58(s)
+		if idErr != nil {
+			if invalidRune, isInvalid := findFirstInvalidRuneInBase58String(s); isInvalid {
+				return transactionIDAtInvalidCharErr(invalidRune, s)
+			}
+			return transactionIDAtInvalidLenErr(s)
+		}
+		after = &id
+	}
+	types := make(map[proto.TransactionType]struct{}, len(query["type"]))
+	for _, s := range query["type"] {
+		t, pErr := strconv.ParseUint(s, 10, 8)
+		if _, ok := proto.ProtobufTransactionsVersions[proto.TransactionType(t)]; pErr != nil || !ok {
+			return apiErrs.NewCustomValidationError(fmt.Sprintf("invalid transaction type %q", s))
+		}
+		types[proto.TransactionType(t)] = struct{}{}
+	}
+	txs, err := a.app.TransactionsByAddress(addr, limit, after, types)
+	if err != nil {
+		return errors.Wrap(err, "TransactionsByAddress")
+	}
+	if jsErr := trySendJSON(w, [][]TransactionWithStatus{txs}); jsErr != nil {
+		return errors.Wrap(jsErr, "TransactionsByAddress")
+	}
+	return nil
+}
+
+func (a *NodeApi) DebugValidate(w http.ResponseWriter, r *http.Request) error {
+	b, err := io.ReadAll(io.LimitReader(r.Body, postMessageSizeLimit))
+	if err != nil {
+		return errors.Wrap(err, "DebugValidate: failed to read request body")
+	}
+	res, err := a.app.DebugValidate(b)
+	if err != nil {
+		return errors.Wrap(err, "DebugValidate")
+	}
+	if jsErr := trySendJSON(w, res); jsErr != nil {
+		return errors.Wrap(jsErr, "DebugValidate")
+	}
+	return nil
+}
+
+func (a *NodeApi) DebugTrace(w http.ResponseWriter, r *http.Request) error {
+	s := chi.URLParam(r, "id")
+	id, err := crypto.NewDigestFromBase58(s)
+	if err != nil {
+		if invalidRune, isInvalid := findFirstInvalidRuneInBase58String(s); isInvalid {
+			return transactionIDAtInvalidCharErr(invalidRune, s)
+		}
+		return transactionIDAtInvalidLenErr(s)
+	}
+	res, err := a.app.DebugTrace(id)
+	if err != nil {
+		return errors.Wrap(err, "DebugTrace")
+	}
+	if jsErr := trySendJSON(w, res); jsErr != nil {
+		return errors.Wrap(jsErr, "DebugTrace")
+	}
+	return nil
+}
+
 func transactionIDAtInvalidLenErr(key string) *apiErrs.InvalidTransactionIdError {
 	return apiErrs.NewInvalidTransactionIDError(
 		fmt.Sprintf("%s has invalid length %d. Length can either be %d or %d",
@@ -432,22 +526,26 @@ func Run(ctx context.Context, address string, n *NodeApi, opts *RunOptions) erro
 		}
 	}()
 
-	if opts.MaxConnections > 0 {
-		if address == "" {
-			address = ":http"
+	if address == "" {
+		address = ":http"
+		if opts.TLSConfig != nil {
+			address = ":https"
 		}
-
-		ln, lErr := net.Listen("tcp", address)
-		if lErr != nil {
-			return lErr
-		}
-
+	}
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	if opts.MaxConnections > 0 {
 		ln = limit_listener.LimitListener(ln, opts.MaxConnections)
 		slog.Debug("Set limit for number of simultaneous connections for REST API", "limit", opts.MaxConnections)
-
-		err = apiServer.Serve(ln)
+	}
+	if opts.TLSConfig != nil {
+		apiServer.TLSConfig = opts.TLSConfig
+		slog.Debug("TLS is enabled for REST API")
+		err = apiServer.ServeTLS(ln, "", "")
 	} else {
-		err = apiServer.ListenAndServe()
+		err = apiServer.Serve(ln)
 	}
 
 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -789,22 +887,145 @@ func (a *NodeApi) Addresses(w http.ResponseWriter, _ *http.Request) error {
 }
 
 func (a *NodeApi) WavesRegularBalanceByAddress(w http.ResponseWriter, r *http.Request) error {
+	addr, err := a.addressFromURLParam(r)
+	if err != nil {
+		return err
+	}
+
+	height, ok, err := heightFromQuery(r)
+	if err != nil {
+		return err
+	}
+	var balance WavesRegularBalance
+	if ok {
+		balance, err = a.app.WavesRegularBalanceByAddressAtHeight(addr, height)
+	} else {
+		balance, err = a.app.WavesRegularBalanceByAddress(addr)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get Waves regular balance by address")
+	}
+
+	if jsErr := trySendJSON(w, balance); jsErr != nil {
+		return errors.Wrap(jsErr, "WavesRegularBalanceByAddress")
+	}
+	return nil
+}
+
+func (a *NodeApi) addressFromURLParam(r *http.Request) (proto.WavesAddress, error) {
 	addrStr := chi.URLParam(r, "address")
 	addr, err := proto.NewAddressFromString(addrStr)
 	if err != nil {
-		return stderrs.Join(apiErrs.InvalidAddress, err)
+		return proto.WavesAddress{}, stderrs.Join(apiErrs.InvalidAddress, err)
 	}
 	if valid, vErr := addr.Valid(a.app.scheme()); !valid || vErr != nil {
-		return stderrs.Join(apiErrs.InvalidAddress, vErr)
+		return proto.WavesAddress{}, stderrs.Join(apiErrs.InvalidAddress, vErr)
+	}
+	return addr, nil
+}
+
+// heightFromQuery parses optional 'height' query parameter.
+func heightFromQuery(r *http.Request) (proto.Height, bool, error) {
+	s := r.URL.Query().Get("height")
+	if s == "" {
+		return 0, false, nil
 	}
+	height, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || height < 1 {
+		return 0, false, apiErrs.NewCustomValidationError(fmt.Sprintf("invalid height %q", s))
+	}
+	return height, true, nil
+}
 
-	balance, err := a.app.WavesRegularBalanceByAddress(addr)
+func (a *NodeApi) AddressesData(w http.ResponseWriter, r *http.Request) error {
+	addr, err := a.addressFromURLParam(r)
 	if err != nil {
-		return errors.Wrap(err, "failed to get Waves regular balance by address")
+		return err
+	}
+	query := r.URL.Query()
+	keys := query["key"]
+	matches := query.Get("matches")
+	if len(keys) != 0 && matches != "" {
+		return apiErrs.NewCustomValidationError("only one of 'key' or 'matches' parameters can be specified")
+	}
+	var entries []proto.DataEntry
+	if len(keys) != 0 {
+		entries, err = a.app.AddressesDataEntriesByKeys(addr, keys)
+	} else {
+		var re *regexp.Regexp
+		if matches != "" {
+			// scala node matches the whole key, not a substring
+			re, err = regexp.Compile("^(?:" + matches + ")$")
+			if err != nil {
+				return apiErrs.NewCustomValidationError(fmt.Sprintf("Cannot compile regex: %s", err.Error()))
+			}
+		}
+		entries, err = a.app.AddressesDataEntries(addr, re)
 	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get data entries by address")
+	}
+	if jsErr := trySendJSON(w, entries); jsErr != nil {
+		return errors.Wrap(jsErr, "AddressesData")
+	}
+	return nil
+}
 
-	if jsErr := trySendJSON(w, balance); jsErr != nil {
-		return errors.Wrap(jsErr, "WavesRegularBalanceByAddress")
+func (a *NodeApi) AddressesDataPost(w http.ResponseWriter, r *http.Request) error {
+	addr, err := a.addressFromURLParam(r)
+	if err != nil {
+		return err
+	}
+	var keys []string
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "application/x-www-form-urlencoded" {
+		r.Body = http.MaxBytesReader(w, r.Body, postMessageSizeLimit)
+		if pErr := r.ParseForm(); pErr != nil {
+			return wrapToBadRequestError(pErr)
+		}
+		keys = r.PostForm["key"]
+	} else {
+		var req struct {
+			Keys []string `json:"keys"`
+		}
+		if pErr := tryParseJSON(io.LimitReader(r.Body, postMessageSizeLimit), &req); pErr != nil {
+			return apiErrs.NewWrongJsonError(pErr.Error(), nil)
+		}
+		keys = req.Keys
+	}
+	entries, err := a.app.AddressesDataEntriesByKeys(addr, keys)
+	if err != nil {
+		return errors.Wrap(err, "failed to get data entries by keys")
+	}
+	if jsErr := trySendJSON(w, entries); jsErr != nil {
+		return errors.Wrap(jsErr, "AddressesDataPost")
+	}
+	return nil
+}
+
+func (a *NodeApi) AddressesDataKey(w http.ResponseWriter, r *http.Request) error {
+	addr, err := a.addressFromURLParam(r)
+	if err != nil {
+		return err
+	}
+	key, err := url.PathUnescape(chi.URLParam(r, "key"))
+	if err != nil {
+		return apiErrs.NewCustomValidationError(fmt.Sprintf("invalid data key: %s", err.Error()))
+	}
+	height, ok, err := heightFromQuery(r)
+	if err != nil {
+		return err
+	}
+	var entry proto.DataEntry
+	if ok {
+		entry, err = a.app.AddressesDataEntryAtHeight(addr, key, height)
+	} else {
+		entry, err = a.app.AddressesDataEntry(addr, key)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get data entry by key")
+	}
+	if jsErr := trySendJSON(w, entry); jsErr != nil {
+		return errors.Wrap(jsErr, "AddressesDataKey")
 	}
 	return nil
 }
@@ -927,6 +1148,47 @@ func (a *NodeApi) EthereumDAppABI(w http.ResponseWriter, r *http.Request) error
 	return nil
 }
 
+func (a *NodeApi) ScriptEvaluate(w http.ResponseWriter, r *http.Request) error {
+	addr, err := a.addressFromURLParam(r)
+	if err != nil {
+		return err
+	}
+	var req ScriptEvaluationRequest
+	if pErr := tryParseJSON(io.LimitReader(r.Body, postMessageSizeLimit), &req); pErr != nil {
+		return apiErrs.NewWrongJsonError(pErr.Error(), nil)
+	}
+	res, err := a.app.ScriptEvaluate(addr, req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to evaluate script at address=%q", addr.String())
+	}
+	if jsErr := trySendJSON(w, res); jsErr != nil {
+		return errors.Wrap(jsErr, "ScriptEvaluate")
+	}
+	return nil
+}
+
+func (a *NodeApi) ScriptDecompile(w http.ResponseWriter, r *http.Request) error {
+	var asset bool
+	if v := r.URL.Query().Get("asset"); v != "" {
+		var err error
+		if asset, err = strconv.ParseBool(v); err != nil {
+			return apiErrs.NewCustomValidationError(fmt.Sprintf("invalid 'asset' parameter: %v", err))
+		}
+	}
+	b, err := io.ReadAll(io.LimitReader(r.Body, postMessageSizeLimit))
+	if err != nil {
+		return errors.Wrap(err, "ScriptDecompile: failed to read request body")
+	}
+	res, err := a.app.ScriptDecompile(string(b), asset)
+	if err != nil {
+		return errors.Wrap(err, "ScriptDecompile")
+	}
+	if jsErr := trySendJSON(w, res); jsErr != nil {
+		return errors.Wrap(jsErr, "ScriptDecompile")
+	}
+	return nil
+}
+
 func (a *NodeApi) AssetsDetailsByID(w http.ResponseWriter, r *http.Request) error {
 	s := chi.URLParam(r, "id")
 	fullAssetID, err := crypto.NewDigestFromBase58(s)
@@ -3,6 +3,8 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -16,6 +18,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/services"
 	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
 )
 
 const apiKey = "X-API-Key"
@@ -108,6 +111,37 @@ func TestNodeApi_WavesRegularBalanceByAddress(t *testing.T) {
 		assert.JSONEq(t, string(expJS), resp.Body.String())
 	})
 
+	t.Run("at-height", func(t *testing.T) {
+		const addrStr = "3Myqjf1D44wR8Vko4Tr5CwSzRNo2Vg9S7u7"
+		addr, err := proto.NewAddressFromString(addrStr)
+		require.NoError(t, err)
+		rcp := proto.NewRecipientFromAddress(addr)
+
+		st := state.NewMockState(t)
+		st.EXPECT().WavesBalanceAtHeight(rcp, proto.Height(7)).Return(uint64(500), nil).Once()
+		st.EXPECT().Height().Return(proto.Height(10), nil).Once()
+		st.EXPECT().WavesBalanceAtHeight(rcp, proto.Height(1)).
+			Return(0, stateerr.NewStateError(stateerr.InvalidInputError, errors.New("invalid height"))).Once()
+
+		a, err := NewApp("", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+		require.NoError(t, err)
+		api := NewNodeAPI(a, nil)
+
+		resp := httptest.NewRecorder()
+		req := createRequest(addrStr)
+		req.URL.RawQuery = "height=7"
+		require.NoError(t, api.WavesRegularBalanceByAddress(resp, req))
+		assert.JSONEq(t, `{"address":"3Myqjf1D44wR8Vko4Tr5CwSzRNo2Vg9S7u7","balance":500,"confirmations":3}`,
+			resp.Body.String(),
+		)
+
+		var target *apiErrs.CustomValidationError
+		req.URL.RawQuery = "height=1"
+		assert.ErrorAs(t, api.WavesRegularBalanceByAddress(httptest.NewRecorder(), req), &target)
+		req.URL.RawQuery = "height=abc"
+		assert.ErrorAs(t, api.WavesRegularBalanceByAddress(httptest.NewRecorder(), req), &target)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		doTest := func(t *testing.T, addrStr string) {
 			resp := httptest.NewRecorder()
@@ -136,3 +170,103 @@ func TestNodeApi_WavesRegularBalanceByAddress(t *testing.T) {
 		})
 	})
 }
+
+func TestNodeApi_AddressesData(t *testing.T) {
+	const addrStr = "3Myqjf1D44wR8Vko4Tr5CwSzRNo2Vg9S7u7"
+	addr, err := proto.NewAddressFromString(addrStr)
+	require.NoError(t, err)
+	rcp := proto.NewRecipientFromAddress(addr)
+
+	createRequest := func(method, target string, params map[string]string) *http.Request {
+		chiCtx := chi.NewRouteContext()
+		for k, v := range params {
+			chiCtx.URLParams.Add(k, v)
+		}
+		req := httptest.NewRequest(method, target, nil)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+	}
+	createAPI := func(t *testing.T, st state.State) *NodeApi {
+		a, aErr := NewApp("", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+		require.NoError(t, aErr)
+		return NewNodeAPI(a, nil)
+	}
+	entries := []proto.DataEntry{
+		&proto.StringDataEntry{Key: "b", Value: "str"},
+		&proto.IntegerDataEntry{Key: "a", Value: 1},
+		&proto.BooleanDataEntry{Key: "ab", Value: true},
+	}
+
+	t.Run("all", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().RetrieveEntries(rcp).Return(entries, nil).Once()
+		resp := httptest.NewRecorder()
+		req := createRequest(http.MethodGet, "/", map[string]string{"address": addrStr})
+		require.NoError(t, createAPI(t, st).AddressesData(resp, req))
+		assert.JSONEq(t,
+			`[{"key":"a","type":"integer","value":1},{"key":"ab","type":"boolean","value":true},`+
+				`{"key":"b","type":"string","value":"str"}]`,
+			resp.Body.String(),
+		)
+	})
+	t.Run("matches", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().RetrieveEntries(rcp).Return(entries, nil).Once()
+		resp := httptest.NewRecorder()
+		req := createRequest(http.MethodGet, "/?matches=a.*", map[string]string{"address": addrStr})
+		require.NoError(t, createAPI(t, st).AddressesData(resp, req))
+		assert.JSONEq(t,
+			`[{"key":"a","type":"integer","value":1},{"key":"ab","type":"boolean","value":true}]`,
+			resp.Body.String(),
+		)
+	})
+	t.Run("invalid-regex", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := createRequest(http.MethodGet, "/?matches=%5B", map[string]string{"address": addrStr})
+		aErr := createAPI(t, state.NewMockState(t)).AddressesData(resp, req)
+		var target *apiErrs.CustomValidationError
+		assert.ErrorAs(t, aErr, &target)
+	})
+	t.Run("keys", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().RetrieveEntry(rcp, "b").Return(entries[0], nil).Once()
+		st.EXPECT().RetrieveEntry(rcp, "c").
+			Return(nil, stateerr.NewStateError(stateerr.RetrievalError, errors.New("not found"))).Once()
+		resp := httptest.NewRecorder()
+		req := createRequest(http.MethodGet, "/?key=b&key=c", map[string]string{"address": addrStr})
+		require.NoError(t, createAPI(t, st).AddressesData(resp, req))
+		assert.JSONEq(t, `[{"key":"b","type":"string","value":"str"}]`, resp.Body.String())
+	})
+	t.Run("post-keys", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().RetrieveEntry(rcp, "a").Return(entries[1], nil).Once()
+		resp := httptest.NewRecorder()
+		req := createRequest(http.MethodPost, "/", map[string]string{"address": addrStr})
+		req.Body = io.NopCloser(strings.NewReader(`{"keys":["a"]}`))
+		require.NoError(t, createAPI(t, st).AddressesDataPost(resp, req))
+		assert.JSONEq(t, `[{"key":"a","type":"integer","value":1}]`, resp.Body.String())
+	})
+	t.Run("single-key", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().RetrieveEntry(rcp, "a b").Return(entries[1], nil).Once()
+		resp := httptest.NewRecorder()
+		req := createRequest(http.MethodGet, "/", map[string]string{"address": addrStr, "key": "a%20b"})
+		require.NoError(t, createAPI(t, st).AddressesDataKey(resp, req))
+		assert.JSONEq(t, `{"key":"a","type":"integer","value":1}`, resp.Body.String())
+	})
+	t.Run("single-key-at-height", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().RetrieveEntryAtHeight(rcp, "a", proto.Height(5)).Return(entries[1], nil).Once()
+		resp := httptest.NewRecorder()
+		req := createRequest(http.MethodGet, "/?height=5", map[string]string{"address": addrStr, "key": "a"})
+		require.NoError(t, createAPI(t, st).AddressesDataKey(resp, req))
+		assert.JSONEq(t, `{"key":"a","type":"integer","value":1}`, resp.Body.String())
+	})
+	t.Run("single-key-not-found", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().RetrieveEntry(rcp, "x").Return(nil, stateerr.NewStateError(stateerr.NotFoundError, nil)).Once()
+		resp := httptest.NewRecorder()
+		req := createRequest(http.MethodGet, "/", map[string]string{"address": addrStr, "key": "x"})
+		aErr := createAPI(t, st).AddressesDataKey(resp, req)
+		assert.ErrorIs(t, aErr, apiErrs.DataKeyDoesNotExist)
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"github.com/pkg/errors"
 	"github.com/throttled/throttled/v2"
 	"github.com/throttled/throttled/v2/store/memstore"
+
+	"github.com/wavesplatform/gowaves/pkg/util/ratelimit"
 )
 
-func createRateLimiter(opts *RateLimiterOptions) (throttled.HTTPRateLimiterCtx, error) {
+func createRateLimiter(opts *ratelimit.Options) (throttled.HTTPRateLimiterCtx, error) {
 	store, err := memstore.New(opts.MemoryCacheSize)
 	if err != nil {
 		return throttled.HTTPRateLimiterCtx{},
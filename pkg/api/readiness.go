@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/node/readiness"
+)
+
+// Readiness responds with the result of the node readiness check, the status code is 503 if the node
+// is not ready.
+func (a *NodeApi) Readiness(checker *readiness.Checker) HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) error {
+		r, err := checker.Check()
+		if err != nil {
+			return errors.Wrap(err, "Readiness")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if !r.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if jsErr := trySendJSON(w, r); jsErr != nil {
+			return errors.Wrap(jsErr, "Readiness")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/node/fsm"
+	"github.com/wavesplatform/gowaves/pkg/node/peers"
+	"github.com/wavesplatform/gowaves/pkg/node/readiness"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func newReadinessServices(t *testing.T, fsmState string, peerScores ...int64) services.Services {
+	st := state.NewMockState(t)
+	st.EXPECT().Height().Return(proto.Height(10), nil)
+	st.EXPECT().CurrentScore().Return(big.NewInt(1000), nil)
+	st.EXPECT().ScoreAtHeight(proto.Height(9)).Return(big.NewInt(900), nil)
+	pm := peers.NewMockPeerManager(t)
+	pm.EXPECT().EachConnected(mock.Anything).Run(func(f func(peer.Peer, *proto.Score)) {
+		for _, s := range peerScores {
+			f(peer.NewMockPeer(t), big.NewInt(s))
+		}
+	})
+	status := services.NewFSMStatus()
+	if fsmState != "" {
+		status.Set(fsmState)
+	}
+	return services.Services{State: st, Peers: pm, FSMStatus: status, MinPeersMining: 2}
+}
+
+func TestNodeApi_Readiness(t *testing.T) {
+	for _, test := range []struct {
+		fsmState string
+		code     int
+	}{
+		{fsm.NGStateName, http.StatusOK},
+		{fsm.HaltStateName, http.StatusServiceUnavailable},
+	} {
+		t.Run(test.fsmState, func(t *testing.T) {
+			app, err := NewApp("key", nil, newReadinessServices(t, test.fsmState, 1000, 1000))
+			require.NoError(t, err)
+			a := NewNodeAPI(app, nil)
+			w := httptest.NewRecorder()
+			checker := readiness.NewChecker(app.services, &readiness.Options{MaxHeightLag: 2})
+			require.NoError(t, a.Readiness(checker)(w, httptest.NewRequest(http.MethodGet, "/go/node/readyz", nil)))
+			assert.Equal(t, test.code, w.Code)
+			var r readiness.Status
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&r))
+			assert.Equal(t, test.fsmState, r.FSMState)
+			assert.Equal(t, test.code == http.StatusOK, r.Ready)
+		})
+	}
+}
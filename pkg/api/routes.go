@@ -11,6 +11,7 @@ import (
 
 	"github.com/wavesplatform/gowaves/pkg/api/metamask"
 	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/node/readiness"
 )
 
 type HandleErrorFunc func(w http.ResponseWriter, r *http.Request, err error)
@@ -68,6 +69,8 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 				w.WriteHeader(http.StatusInternalServerError)
 			}
 		})
+		checker := readiness.NewChecker(a.app.services, opts.ReadinessOpts)
+		r.Get("/go/node/readyz", wrapper(a.Readiness(checker)))
 	}
 
 	// nickeskov: go node routes
@@ -126,6 +129,9 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 		r.Route("/addresses", func(r chi.Router) {
 			r.Get("/", wrapper(a.Addresses))
 			r.Get("/balance/{address}", wrapper(a.WavesRegularBalanceByAddress))
+			r.Get("/data/{address}", wrapper(a.AddressesData))
+			r.Post("/data/{address}", wrapper(a.AddressesDataPost))
+			r.Get("/data/{address}/{key}", wrapper(a.AddressesDataKey))
 		})
 
 		r.Route("/alias", func(r chi.Router) {
@@ -136,7 +142,9 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 		r.Route("/transactions", func(r chi.Router) {
 			r.Get("/unconfirmed/size", wrapper(a.unconfirmedSize))
 			r.Get("/info/{id}", wrapper(a.TransactionInfo))
+			r.Get("/address/{address}/limit/{limit}", wrapper(a.TransactionsByAddress))
 			r.Post("/broadcast", wrapper(a.TransactionsBroadcast))
+			r.Post("/calculateFee", wrapper(a.TransactionsCalculateFee))
 		})
 
 		r.Route("/peers", func(r chi.Router) {
@@ -157,6 +165,8 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 
 			rAuth := r.With(checkAuthMiddleware)
 
+			rAuth.Post("/validate", wrapper(a.DebugValidate))
+			rAuth.Get("/trace/{id}", wrapper(a.DebugTrace))
 			rAuth.Post("/print", wrapper(a.debugPrint))
 			rAuth.Post("/rollback", wrapper(a.RollbackToHeight))
 			rAuth.Post("/rollback-to/{id}", wrapper(a.RollbackTo))
@@ -185,6 +195,11 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 			}
 		})
 
+		r.Route("/utils", func(r chi.Router) {
+			r.Post("/script/evaluate/{address}", wrapper(a.ScriptEvaluate))
+			r.Post("/script/decompile", wrapper(a.ScriptDecompile))
+		})
+
 		r.Route("/blockchain", func(r chi.Router) {
 			r.Get("/rewards", wrapper(a.blockchainRewards))
 			r.Get("/rewards/{height}", wrapper(a.blockchainRewardsAtHeight))
@@ -1,29 +1,17 @@
 package api
 
 import (
+	"crypto/tls"
 	"net/http"
-	"net/url"
-	"strconv"
-	"strings"
 
-	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/node/readiness"
+	"github.com/wavesplatform/gowaves/pkg/util/ratelimit"
 )
 
-const (
-	DefaultMaxConnections       = 128
-	DefaultRateLimiterCacheSize = 64 * 1024 // 64 KB
-	DefaultRateLimiterRPS       = 1
-	DefaultRateLimiterBurst     = 1
-)
-
-const (
-	cacheSizeKey = "cache"
-	rpsKey       = "rps"
-	burstKey     = "burst"
-)
+const DefaultMaxConnections = 128
 
 type RunOptions struct {
-	RateLimiterOpts      *RateLimiterOptions
+	RateLimiterOpts      *ratelimit.Options
 	LogHttpRequestOpts   bool
 	CollectMetrics       bool
 	UseRealIPMiddleware  bool
@@ -33,17 +21,15 @@ type RunOptions struct {
 	MaxConnections       int
 	EnableMetaMaskAPI    bool
 	EnableMetaMaskAPILog bool
-}
-
-type RateLimiterOptions struct {
-	MemoryCacheSize      int
-	MaxRequestsPerSecond int
-	MaxBurst             int
+	// TLSConfig enables HTTPS if not nil.
+	TLSConfig *tls.Config
+	// ReadinessOpts configure the readiness probe served along with the heartbeat route.
+	ReadinessOpts *readiness.Options
 }
 
 func DefaultRunOptions() *RunOptions {
 	return &RunOptions{
-		RateLimiterOpts:      DefaultRateLimiterOptions(),
+		RateLimiterOpts:      ratelimit.DefaultOptions(),
 		LogHttpRequestOpts:   false,
 		EnableHeartbeatRoute: true,
 		UseRealIPMiddleware:  true,
@@ -55,52 +41,6 @@ func DefaultRunOptions() *RunOptions {
 		MaxConnections:       DefaultMaxConnections,
 		EnableMetaMaskAPI:    false,
 		EnableMetaMaskAPILog: false,
+		ReadinessOpts:        readiness.DefaultOptions(),
 	}
 }
-
-func DefaultRateLimiterOptions() *RateLimiterOptions {
-	return &RateLimiterOptions{
-		MemoryCacheSize:      DefaultRateLimiterCacheSize,
-		MaxRequestsPerSecond: DefaultRateLimiterRPS,
-		MaxBurst:             DefaultRateLimiterBurst,
-	}
-}
-
-func NewRateLimiterOptionsFromString(s string) (*RateLimiterOptions, error) {
-	opt := DefaultRateLimiterOptions()
-	query, err := url.ParseQuery(strings.TrimSpace(s))
-	if err != nil {
-		return nil, errors.Wrap(err, "invalid rate limiter options")
-	}
-	cacheSize, err := extractFirstIntValue(query, cacheSizeKey, DefaultRateLimiterCacheSize)
-	if err != nil {
-		return nil, errors.Wrap(err, "invalid rate limiter options")
-	}
-	opt.MemoryCacheSize = cacheSize
-	rps, err := extractFirstIntValue(query, rpsKey, DefaultRateLimiterRPS)
-	if err != nil {
-		return nil, errors.Wrap(err, "invalid rate limiter options")
-	}
-	opt.MaxRequestsPerSecond = rps
-	burst, err := extractFirstIntValue(query, burstKey, DefaultRateLimiterBurst)
-	if err != nil {
-		return nil, errors.Wrap(err, "invalid rate limiter options")
-	}
-	opt.MaxBurst = burst
-	return opt, nil
-}
-
-func extractFirstIntValue(query url.Values, key string, dft int) (int, error) {
-	values, ok := query[key]
-	if !ok {
-		return dft, nil
-	}
-	if len(values) < 1 {
-		return 0, errors.Errorf("no value for key '%s'", key)
-	}
-	v, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 32)
-	if err != nil {
-		return 0, errors.Wrapf(err, "invalid value for key '%s'", key)
-	}
-	return int(v), nil
-}
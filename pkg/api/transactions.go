@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+// FeeCalculationResult is the minimal fee of a transaction.
+// FeeAssetID is null if the fee is paid in Waves.
+type FeeCalculationResult struct {
+	FeeAssetID proto.OptionalAsset `json:"feeAssetId"`
+	FeeAmount  uint64              `json:"feeAmount"`
+}
+
+// TransactionsCalculateFee returns the minimal fee of the transaction given in JSON.
+// The fee is calculated in the fee asset of the transaction, the sponsored asset fee is converted
+// from Waves using the current sponsorship rate.
+func (a *App) TransactionsCalculateFee(b []byte) (*FeeCalculationResult, error) {
+	tx, err := a.transactionFromJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	asset, fee, err := a.state.MinFee(tx)
+	if err != nil {
+		if stateerr.IsInvalidInput(err) {
+			return nil, apiErrs.NewCustomValidationError(err.Error())
+		}
+		return nil, errors.Wrap(err, "failed to calculate minimal fee")
+	}
+	return &FeeCalculationResult{FeeAssetID: asset, FeeAmount: fee}, nil
+}
+
+// TransactionWithStatus is a confirmed transaction with the height of its block and its application status.
+// It's marshaled to JSON as the transaction object extended with the fields "height" and "applicationStatus".
+type TransactionWithStatus struct {
+	Transaction       proto.Transaction
+	Height            proto.Height
+	ApplicationStatus proto.TransactionStatus
+}
+
+func (t TransactionWithStatus) MarshalJSON() ([]byte, error) {
+	txJSON, err := json.Marshal(t.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	txJSON = bytes.TrimSpace(txJSON)
+	if len(txJSON) < 2 || txJSON[0] != '{' || txJSON[len(txJSON)-1] != '}' {
+		return nil, errors.Errorf("transaction of type %T is not marshaled to JSON object", t.Transaction)
+	}
+	extJSON, err := json.Marshal(struct {
+		Height            proto.Height            `json:"height"`
+		ApplicationStatus proto.TransactionStatus `json:"applicationStatus"`
+	}{t.Height, t.ApplicationStatus})
+	if err != nil {
+		return nil, err
+	}
+	if len(txJSON) == 2 { // empty object
+		return extJSON, nil
+	}
+	res := make([]byte, 0, len(txJSON)+len(extJSON))
+	res = append(res, txJSON[:len(txJSON)-1]...)
+	res = append(res, ',')
+	return append(res, extJSON[1:]...), nil
+}
+
+// TransactionsByAddress returns up to limit confirmed transactions of the address, starting from the most recent one.
+// If after is set, only transactions older than the transaction with the given ID are returned.
+// If types is not empty, only transactions of the given types are returned.
+func (a *App) TransactionsByAddress(
+	addr proto.WavesAddress,
+	limit int,
+	after *crypto.Digest,
+	types map[proto.TransactionType]struct{},
+) ([]TransactionWithStatus, error) {
+	if maxLimit := a.settings.TransactionsByAddressLimit; limit > maxLimit {
+		return nil, apiErrs.NewTooBigArrayAllocationError(maxLimit)
+	}
+	if after != nil {
+		if _, err := a.state.TransactionHeightByID(after.Bytes()); err != nil {
+			if stateerr.IsNotFound(err) {
+				return nil, apiErrs.TransactionDoesNotExist
+			}
+			return nil, errors.Wrapf(err, "failed to get transaction %s", after.String())
+		}
+	}
+	iter, err := a.state.NewAddrTransactionsIterator(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create transactions iterator for address %s", addr.String())
+	}
+	defer iter.Release()
+	scheme := a.services.Scheme
+	skip := after != nil
+	res := make([]TransactionWithStatus, 0, limit)
+	for len(res) < limit && iter.Next() {
+		tx, status, txErr := iter.Transaction()
+		if txErr != nil {
+			return nil, errors.Wrap(txErr, "failed to get transaction from iterator")
+		}
+		id, idErr := tx.GetID(scheme)
+		if idErr != nil {
+			return nil, errors.Wrap(idErr, "failed to get transaction ID")
+		}
+		if skip {
+			skip = !bytes.Equal(id, after.Bytes())
+			continue
+		}
+		if _, ok := types[tx.GetType()]; len(types) != 0 && !ok {
+			continue
+		}
+		height, hErr := a.state.TransactionHeightByID(id)
+		if hErr != nil {
+			return nil, errors.Wrap(hErr, "failed to get transaction height")
+		}
+		res = append(res, TransactionWithStatus{Transaction: tx, Height: height, ApplicationStatus: status})
+	}
+	if iErr := iter.Error(); iErr != nil {
+		return nil, errors.Wrap(iErr, "transactions iterator error")
+	}
+	return res, nil
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+func TestNodeApi_TransactionsCalculateFee(t *testing.T) {
+	_, pk, err := crypto.GenerateKeyPair([]byte("test"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	assetID := crypto.MustDigestFromBase58("8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS")
+	asset := *proto.NewOptionalAssetFromDigest(assetID)
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), asset, 1000, 100, 0,
+		proto.NewRecipientFromAddress(addr), nil,
+	)
+	body, err := json.Marshal(tx)
+	require.NoError(t, err)
+	calculateFee := func(st state.State) (*httptest.ResponseRecorder, error) {
+		a, aErr := NewApp("", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+		require.NoError(t, aErr)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/transactions/calculateFee", strings.NewReader(string(body)))
+		return resp, NewNodeAPI(a, nil).TransactionsCalculateFee(resp, req)
+	}
+
+	t.Run("sponsored", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().MinFee(mock.Anything).Return(asset, 5, nil).Once()
+		resp, cErr := calculateFee(st)
+		require.NoError(t, cErr)
+		assert.JSONEq(t, `{"feeAssetId":"8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS","feeAmount":5}`, resp.Body.String())
+	})
+	t.Run("waves", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().MinFee(mock.Anything).Return(proto.NewOptionalAssetWaves(), 500000, nil).Once()
+		resp, cErr := calculateFee(st)
+		require.NoError(t, cErr)
+		assert.JSONEq(t, `{"feeAssetId":null,"feeAmount":500000}`, resp.Body.String())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().MinFee(mock.Anything).
+			Return(proto.OptionalAsset{}, 0, stateerr.NewStateError(stateerr.InvalidInputError, errors.New("bad"))).Once()
+		_, cErr := calculateFee(st)
+		var target *apiErrs.CustomValidationError
+		assert.ErrorAs(t, cErr, &target)
+	})
+}
+
+type txWithStatus struct {
+	tx     proto.Transaction
+	status proto.TransactionStatus
+}
+
+// sliceTxIterator iterates over the transactions from the slice.
+type sliceTxIterator struct {
+	txs []txWithStatus
+	pos int
+}
+
+func (it *sliceTxIterator) Transaction() (proto.Transaction, proto.TransactionStatus, error) {
+	cur := it.txs[it.pos-1]
+	return cur.tx, cur.status, nil
+}
+
+func (it *sliceTxIterator) Next() bool {
+	if it.pos >= len(it.txs) {
+		return false
+	}
+	it.pos++
+	return true
+}
+
+func (it *sliceTxIterator) Release() {}
+
+func (it *sliceTxIterator) Error() error { return nil }
+
+func TestNodeApi_TransactionsByAddress(t *testing.T) {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("test"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	waves := proto.NewOptionalAssetWaves()
+	txs := make([]txWithStatus, 0, 4)
+	ids := make([]crypto.Digest, 0, 4)
+	for i := range 4 {
+		var tx proto.Transaction
+		if i%2 == 0 {
+			tx = proto.NewUnsignedTransferWithProofs(3, pk, waves, waves, uint64(i), 1000, 100000,
+				proto.NewRecipientFromAddress(addr), nil,
+			)
+		} else {
+			tx = proto.NewUnsignedDataWithProofs(2, pk, 100000, uint64(i))
+		}
+		require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+		id, idErr := tx.GetID(proto.TestNetScheme)
+		require.NoError(t, idErr)
+		d, dErr := crypto.NewDigestFromBytes(id)
+		require.NoError(t, dErr)
+		ids = append(ids, d)
+		txs = append(txs, txWithStatus{tx: tx, status: proto.TransactionSucceeded})
+	}
+	txs[3].status = proto.TransactionFailed
+
+	doRequest := func(t *testing.T, st state.State, limit, query string) ([]map[string]any, error) {
+		a, aErr := NewApp("", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+		require.NoError(t, aErr)
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("address", addr.String())
+		chiCtx.URLParams.Add("limit", limit)
+		req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+		resp := httptest.NewRecorder()
+		if hErr := NewNodeAPI(a, nil).TransactionsByAddress(resp, req); hErr != nil {
+			return nil, hErr
+		}
+		var res [][]map[string]any
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		require.Len(t, res, 1)
+		return res[0], nil
+	}
+	newState := func(t *testing.T) *state.MockState {
+		st := state.NewMockState(t)
+		st.EXPECT().NewAddrTransactionsIterator(addr).Return(&sliceTxIterator{txs: txs}, nil).Once()
+		return st
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		st := newState(t)
+		st.EXPECT().TransactionHeightByID(mock.Anything).Return(7, nil).Times(2)
+		res, rErr := doRequest(t, st, "2", "")
+		require.NoError(t, rErr)
+		require.Len(t, res, 2)
+		assert.Equal(t, ids[0].String(), res[0]["id"])
+		assert.EqualValues(t, 7, res[0]["height"])
+		assert.Equal(t, "succeeded", res[0]["applicationStatus"])
+		assert.Equal(t, ids[1].String(), res[1]["id"])
+	})
+	t.Run("after-and-type", func(t *testing.T) {
+		st := newState(t)
+		st.EXPECT().TransactionHeightByID(ids[1].Bytes()).Return(7, nil).Once()
+		st.EXPECT().TransactionHeightByID(ids[3].Bytes()).Return(5, nil).Once()
+		res, rErr := doRequest(t, st, "10", "after="+ids[1].String()+"&type=12")
+		require.NoError(t, rErr)
+		require.Len(t, res, 1)
+		assert.Equal(t, ids[3].String(), res[0]["id"])
+		assert.EqualValues(t, 5, res[0]["height"])
+		assert.Equal(t, "script_execution_failed", res[0]["applicationStatus"])
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var target *apiErrs.CustomValidationError
+		for _, c := range []struct{ limit, query string }{{"0", ""}, {"x", ""}, {"1", "type=100"}} {
+			_, rErr := doRequest(t, state.NewMockState(t), c.limit, c.query)
+			assert.ErrorAs(t, rErr, &target, c)
+		}
+		var tooBig *apiErrs.TooBigArrayAllocationError
+		_, rErr := doRequest(t, state.NewMockState(t), "1001", "")
+		assert.ErrorAs(t, rErr, &tooBig)
+
+		st := state.NewMockState(t)
+		st.EXPECT().TransactionHeightByID(ids[0].Bytes()).Return(0, proto.ErrNotFound).Once()
+		_, rErr = doRequest(t, st, "1", "after="+ids[0].String())
+		assert.ErrorIs(t, rErr, apiErrs.TransactionDoesNotExist)
+	})
+}
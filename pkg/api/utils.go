@@ -0,0 +1,248 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/decompiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+// ScriptEvaluationRequest is a request of read-only script evaluation.
+// Exactly one of the fields Expr or Call must be set.
+type ScriptEvaluationRequest struct {
+	Expr *string             `json:"expr,omitempty"`
+	Call *proto.FunctionCall `json:"call,omitempty"`
+}
+
+type ScriptEvaluationResult struct {
+	Address      proto.WavesAddress  `json:"address"`
+	Expr         *string             `json:"expr,omitempty"`
+	Call         *proto.FunctionCall `json:"call,omitempty"`
+	Result       ride.EvaluatedValue `json:"result"`
+	Complexity   int                 `json:"complexity"`
+	StateChanges *ScriptStateChanges `json:"stateChanges,omitempty"`
+}
+
+type ScriptStateChanges struct {
+	Data         []proto.DataEntry   `json:"data"`
+	Transfers    []scriptTransfer    `json:"transfers"`
+	Issues       []scriptIssue       `json:"issues"`
+	Reissues     []scriptReissue     `json:"reissues"`
+	Burns        []scriptBurn        `json:"burns"`
+	SponsorFees  []scriptSponsorFee  `json:"sponsorFees"`
+	Leases       []scriptLease       `json:"leases"`
+	LeaseCancels []scriptLeaseCancel `json:"leaseCancels"`
+}
+
+type scriptTransfer struct {
+	Address proto.Recipient     `json:"address"`
+	Asset   proto.OptionalAsset `json:"asset"`
+	Amount  int64               `json:"amount"`
+}
+
+type scriptIssue struct {
+	AssetID      crypto.Digest `json:"assetId"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description"`
+	Quantity     int64         `json:"quantity"`
+	Decimals     int32         `json:"decimals"`
+	IsReissuable bool          `json:"isReissuable"`
+	Nonce        int64         `json:"nonce"`
+}
+
+type scriptReissue struct {
+	AssetID      crypto.Digest `json:"assetId"`
+	IsReissuable bool          `json:"isReissuable"`
+	Quantity     int64         `json:"quantity"`
+}
+
+type scriptBurn struct {
+	AssetID crypto.Digest `json:"assetId"`
+	Amount  int64         `json:"amount"`
+}
+
+type scriptSponsorFee struct {
+	AssetID              crypto.Digest `json:"assetId"`
+	MinSponsoredAssetFee int64         `json:"minSponsoredAssetFee"`
+}
+
+type scriptLease struct {
+	ID        crypto.Digest   `json:"id"`
+	Recipient proto.Recipient `json:"recipient"`
+	Amount    int64           `json:"amount"`
+	Nonce     int64           `json:"nonce"`
+}
+
+type scriptLeaseCancel struct {
+	LeaseID crypto.Digest `json:"leaseId"`
+}
+
+func newScriptStateChanges(actions []proto.ScriptAction) (*ScriptStateChanges, error) {
+	sr, payments, err := proto.NewScriptResult(actions, proto.ScriptErrorMessage{})
+	if err != nil {
+		return nil, err
+	}
+	sc := &ScriptStateChanges{
+		Data:         make([]proto.DataEntry, 0, len(sr.DataEntries)),
+		Transfers:    make([]scriptTransfer, 0, len(payments)+len(sr.Transfers)),
+		Issues:       make([]scriptIssue, 0, len(sr.Issues)),
+		Reissues:     make([]scriptReissue, 0, len(sr.Reissues)),
+		Burns:        make([]scriptBurn, 0, len(sr.Burns)),
+		SponsorFees:  make([]scriptSponsorFee, 0, len(sr.Sponsorships)),
+		Leases:       make([]scriptLease, 0, len(sr.Leases)),
+		LeaseCancels: make([]scriptLeaseCancel, 0, len(sr.LeaseCancels)),
+	}
+	for _, e := range sr.DataEntries {
+		sc.Data = append(sc.Data, e.Entry)
+	}
+	for _, p := range payments {
+		sc.Transfers = append(sc.Transfers, scriptTransfer{Address: p.Recipient, Asset: p.Asset, Amount: p.Amount})
+	}
+	for _, t := range sr.Transfers {
+		sc.Transfers = append(sc.Transfers, scriptTransfer{Address: t.Recipient, Asset: t.Asset, Amount: t.Amount})
+	}
+	for _, i := range sr.Issues {
+		sc.Issues = append(sc.Issues, scriptIssue{
+			AssetID:      i.ID,
+			Name:         i.Name,
+			Description:  i.Description,
+			Quantity:     i.Quantity,
+			Decimals:     i.Decimals,
+			IsReissuable: i.Reissuable,
+			Nonce:        i.Nonce,
+		})
+	}
+	for _, r := range sr.Reissues {
+		sc.Reissues = append(sc.Reissues,
+			scriptReissue{AssetID: r.AssetID, IsReissuable: r.Reissuable, Quantity: r.Quantity},
+		)
+	}
+	for _, b := range sr.Burns {
+		sc.Burns = append(sc.Burns, scriptBurn{AssetID: b.AssetID, Amount: b.Quantity})
+	}
+	for _, s := range sr.Sponsorships {
+		sc.SponsorFees = append(sc.SponsorFees, scriptSponsorFee{AssetID: s.AssetID, MinSponsoredAssetFee: s.MinFee})
+	}
+	for _, l := range sr.Leases {
+		sc.Leases = append(sc.Leases, scriptLease{ID: l.ID, Recipient: l.Recipient, Amount: l.Amount, Nonce: l.Nonce})
+	}
+	for _, lc := range sr.LeaseCancels {
+		sc.LeaseCancels = append(sc.LeaseCancels, scriptLeaseCancel{LeaseID: lc.LeaseID})
+	}
+	return sc, nil
+}
+
+// ScriptEvaluate evaluates the expression or calls the callable function of the dApp in read-only mode.
+// Changes made by the script are never applied to the state.
+func (a *App) ScriptEvaluate(addr proto.WavesAddress, req ScriptEvaluationRequest) (*ScriptEvaluationResult, error) {
+	if (req.Expr == nil) == (req.Call == nil) {
+		return nil, apiErrs.NewCustomValidationError("exactly one of 'expr' or 'call' must be specified")
+	}
+	var (
+		res ride.Result
+		err error
+	)
+	if req.Expr != nil {
+		tree, cErr := a.compileExpression(addr, *req.Expr)
+		if cErr != nil {
+			return nil, cErr
+		}
+		res, err = a.state.EvaluateExpression(addr, tree)
+	} else {
+		res, err = a.state.EvaluateFunctionCall(addr, *req.Call)
+	}
+	if err != nil {
+		switch {
+		case stateerr.IsInvalidInput(err):
+			return nil, apiErrs.NewCustomValidationError(err.Error())
+		case stateerr.IsNotFound(err):
+			return nil, apiErrs.NewCustomValidationError(fmt.Sprintf("no script at address %s", addr.String()))
+		default:
+			return nil, errors.Wrap(err, "failed to evaluate script")
+		}
+	}
+	sc, err := newScriptStateChanges(res.ScriptActions())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build state changes")
+	}
+	return &ScriptEvaluationResult{
+		Address:      addr,
+		Expr:         req.Expr,
+		Call:         req.Call,
+		Result:       ride.ResultValue(res),
+		Complexity:   res.Complexity(),
+		StateChanges: sc,
+	}, nil
+}
+
+// compileExpression compiles the expression with the standard library version of the account script.
+// The latest library version is used for the accounts without script.
+func (a *App) compileExpression(addr proto.WavesAddress, expr string) (*ast.Tree, error) {
+	v := ast.CurrentMaxLibraryVersion()
+	info, err := a.state.ScriptBasicInfoByAccount(proto.NewRecipientFromAddress(addr))
+	switch {
+	case err == nil:
+		v = info.LibraryVersion
+	case !stateerr.IsNotFound(err):
+		return nil, errors.Wrap(err, "failed to get script info")
+	}
+	src := fmt.Sprintf("{-# STDLIB_VERSION %d #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n{-# SCRIPT_TYPE ACCOUNT #-}\n%s",
+		v, expr,
+	)
+	tree, errs := compiler.CompileExpressionToTree(src)
+	if len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, e := range errs {
+			msgs[i] = e.Error()
+		}
+		return nil, apiErrs.NewCustomValidationError(strings.Join(msgs, "; "))
+	}
+	return tree, nil
+}
+
+// ScriptDecompilationResult is the source code of the decompiled script with its directives.
+type ScriptDecompilationResult struct {
+	LibVersion  ast.LibraryVersion `json:"STDLIB_VERSION"`
+	ContentType string             `json:"CONTENT_TYPE"`
+	ScriptType  string             `json:"SCRIPT_TYPE"`
+	Script      string             `json:"script"`
+}
+
+// ScriptDecompile decompiles the Base64 encoded script, the prefix "base64:" is optional.
+// Expression scripts are rendered as account scripts unless asset is set.
+func (a *App) ScriptDecompile(encoded string, asset bool) (*ScriptDecompilationResult, error) {
+	encoded = strings.TrimPrefix(strings.TrimSpace(encoded), "base64:")
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, apiErrs.NewCustomValidationError(fmt.Sprintf("invalid script encoding: %v", err))
+	}
+	tree, err := serialization.Parse(b)
+	if err != nil {
+		return nil, apiErrs.NewCustomValidationError(fmt.Sprintf("invalid script: %v", err))
+	}
+	scriptType := decompiler.AccountScript
+	if asset {
+		scriptType = decompiler.AssetScript
+	}
+	dir, src, err := decompiler.DecompileWithDirectives(tree, scriptType)
+	if err != nil {
+		return nil, apiErrs.NewCustomValidationError(fmt.Sprintf("failed to decompile script: %v", err))
+	}
+	return &ScriptDecompilationResult{
+		LibVersion:  dir.LibVersion,
+		ContentType: dir.ContentType,
+		ScriptType:  dir.ScriptType.String(),
+		Script:      src,
+	}, nil
+}
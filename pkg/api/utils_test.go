@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+func TestNodeApi_ScriptEvaluate(t *testing.T) {
+	const addrStr = "3Myqjf1D44wR8Vko4Tr5CwSzRNo2Vg9S7u7"
+	addr, err := proto.NewAddressFromString(addrStr)
+	require.NoError(t, err)
+	rcp := proto.NewRecipientFromAddress(addr)
+
+	createRequest := func(body string) *http.Request {
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("address", addrStr)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+	}
+	createAPI := func(t *testing.T, st state.State) *NodeApi {
+		a, aErr := NewApp("", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+		require.NoError(t, aErr)
+		return NewNodeAPI(a, nil)
+	}
+
+	t.Run("expr", func(t *testing.T) {
+		st := state.NewMockState(t)
+		st.EXPECT().ScriptBasicInfoByAccount(rcp).Return(&proto.ScriptBasicInfo{LibraryVersion: ast.LibV5}, nil).Once()
+		st.EXPECT().EvaluateExpression(addr, mock.MatchedBy(func(tree *ast.Tree) bool {
+			return tree.LibVersion == ast.LibV5 && !tree.IsDApp()
+		})).Return(ride.ScriptResult{}, nil).Once()
+		resp := httptest.NewRecorder()
+		require.NoError(t, createAPI(t, st).ScriptEvaluate(resp, createRequest(`{"expr":"this.bytes.size()"}`)))
+		assert.JSONEq(t, `{"address":"3Myqjf1D44wR8Vko4Tr5CwSzRNo2Vg9S7u7","expr":"this.bytes.size()",`+
+			`"result":{"type":"Unit","value":{}},"complexity":0,"stateChanges":{"data":[],"transfers":[],`+
+			`"issues":[],"reissues":[],"burns":[],"sponsorFees":[],"leases":[],"leaseCancels":[]}}`,
+			resp.Body.String(),
+		)
+	})
+	t.Run("call", func(t *testing.T) {
+		fc := proto.NewFunctionCall("f", proto.Arguments{&proto.IntegerArgument{Value: 1}})
+		st := state.NewMockState(t)
+		st.EXPECT().EvaluateFunctionCall(addr, fc).Return(ride.DAppResult{}, nil).Once()
+		resp := httptest.NewRecorder()
+		body := `{"call":{"function":"f","args":[{"type":"integer","value":1}]}}`
+		require.NoError(t, createAPI(t, st).ScriptEvaluate(resp, createRequest(body)))
+		var res struct {
+			Call   proto.FunctionCall  `json:"call"`
+			Result ride.EvaluatedValue `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		assert.Equal(t, fc, res.Call)
+		assert.Equal(t, "Unit", res.Result.Type)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var target *apiErrs.CustomValidationError
+		for _, body := range []string{`{}`, `{"expr":"1","call":{"function":"f","args":[]}}`} {
+			aErr := createAPI(t, state.NewMockState(t)).ScriptEvaluate(httptest.NewRecorder(), createRequest(body))
+			assert.ErrorAs(t, aErr, &target, body)
+		}
+
+		st := state.NewMockState(t)
+		st.EXPECT().ScriptBasicInfoByAccount(rcp).Return(nil, proto.ErrNotFound).Once()
+		aErr := createAPI(t, st).ScriptEvaluate(httptest.NewRecorder(), createRequest(`{"expr":"1 +"}`))
+		assert.ErrorAs(t, aErr, &target)
+
+		st = state.NewMockState(t)
+		st.EXPECT().EvaluateFunctionCall(addr, mock.Anything).
+			Return(nil, stateerr.NewStateError(stateerr.InvalidInputError, errors.New("bad call"))).Once()
+		aErr = createAPI(t, st).ScriptEvaluate(httptest.NewRecorder(), createRequest(`{"call":{"function":"g"}}`))
+		assert.ErrorAs(t, aErr, &target)
+	})
+}
+
+func TestNodeApi_ScriptDecompile(t *testing.T) {
+	const src = "{-# STDLIB_VERSION 5 #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n{-# SCRIPT_TYPE ASSET #-}\ntrue"
+	b, errs := compiler.Compile(src, false, false)
+	require.Empty(t, errs)
+	script := base64.StdEncoding.EncodeToString(b)
+
+	a, err := NewApp("", nil, services.Services{Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	api := NewNodeAPI(a, nil)
+	decompile := func(query, body string) (*httptest.ResponseRecorder, error) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/utils/script/decompile"+query, strings.NewReader(body))
+		return resp, api.ScriptDecompile(resp, req)
+	}
+
+	resp, err := decompile("?asset=true", "base64:"+script)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"STDLIB_VERSION":5,"CONTENT_TYPE":"EXPRESSION","SCRIPT_TYPE":"ASSET","script":`+
+		`"{-# STDLIB_VERSION 5 #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n{-# SCRIPT_TYPE ASSET #-}\n\ntrue\n"}`,
+		resp.Body.String(),
+	)
+	resp, err = decompile("", script)
+	require.NoError(t, err)
+	assert.Contains(t, resp.Body.String(), `"SCRIPT_TYPE":"ACCOUNT"`)
+
+	var target *apiErrs.CustomValidationError
+	for _, tc := range []struct{ query, body string }{
+		{"?asset=maybe", script},
+		{"", "not base64"},
+		{"", base64.StdEncoding.EncodeToString([]byte{0xff, 0x01})},
+	} {
+		_, err = decompile(tc.query, tc.body)
+		assert.ErrorAs(t, err, &target, tc)
+	}
+}
+
+func TestNewScriptStateChanges(t *testing.T) {
+	addr, err := proto.NewAddressFromString("3Myqjf1D44wR8Vko4Tr5CwSzRNo2Vg9S7u7")
+	require.NoError(t, err)
+	rcp := proto.NewRecipientFromAddress(addr)
+	assetID := crypto.MustDigestFromBase58("8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS")
+	sc, err := newScriptStateChanges([]proto.ScriptAction{
+		&proto.DataEntryScriptAction{Entry: &proto.IntegerDataEntry{Key: "k", Value: 1}},
+		&proto.TransferScriptAction{Recipient: rcp, Amount: 10, Asset: proto.NewOptionalAssetWaves()},
+		&proto.BurnScriptAction{AssetID: assetID, Quantity: 5},
+		&proto.LeaseCancelScriptAction{LeaseID: assetID},
+	})
+	require.NoError(t, err)
+	js, err := json.Marshal(sc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":[{"key":"k","type":"integer","value":1}],`+
+		`"transfers":[{"address":"3Myqjf1D44wR8Vko4Tr5CwSzRNo2Vg9S7u7","asset":null,"amount":10}],`+
+		`"issues":[],"reissues":[],"burns":[{"assetId":"8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS","amount":5}],`+
+		`"sponsorFees":[],"leases":[],"leaseCancels":[{"leaseId":"8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS"}]}`,
+		string(js),
+	)
+}
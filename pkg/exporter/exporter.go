@@ -0,0 +1,248 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/importer"
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+const (
+	// firstHeight is the height of the first block in the export files, the genesis block is not exported
+	// because it's applied by the state on creation.
+	firstHeight = 2
+
+	maxBlockSnapshotSize = 100 * importer.MiB
+	defaultSyncInterval  = 1000
+)
+
+type State interface {
+	Height() (proto.Height, error)
+	BlockByHeight(height proto.Height) (*proto.Block, error)
+	SnapshotsAtHeight(height proto.Height) (proto.BlockSnapshot, error)
+	IsActiveAtHeight(featureID int16, height proto.Height) (bool, error)
+}
+
+type ExportParams struct {
+	Scheme                        proto.Scheme
+	BlockchainPath, SnapshotsPath string       // the snapshots are not exported if the path is empty
+	ToHeight                      proto.Height // the last height to export, the state height is used if zero
+	SyncInterval                  uint64       // the number of blocks between syncs of the files to the disk
+}
+
+func (p ExportParams) validate() error {
+	if p.Scheme == 0 {
+		return errors.New("scheme/chainID is empty")
+	}
+	if p.BlockchainPath == "" {
+		return errors.New("blockchain path is empty")
+	}
+	if p.ToHeight != 0 && p.ToHeight < firstHeight {
+		return errors.Errorf("invalid last height %d", p.ToHeight)
+	}
+	return nil
+}
+
+// ExportToFile writes the blocks and their snapshots from the state to the files that can be imported
+// by ApplyFromFile of the importer package. If the files already exist, the export is resumed from the last
+// complete block in both files, the last exported block is checked to be the same as the block in the state.
+// ExportToFile returns the height of the last block in the files.
+func ExportToFile(ctx context.Context, params ExportParams, st State) (proto.Height, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := params.validate(); err != nil {
+		return 0, errors.Wrap(err, "invalid export params")
+	}
+	exp, err := newExporter(params, st)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create exporter")
+	}
+	height, err := exp.export(ctx)
+	if clErr := exp.close(); clErr != nil {
+		if err != nil {
+			slog.Error("Failed to close exporter", logging.Error(clErr))
+			return height, err
+		}
+		return height, errors.Wrap(clErr, "failed to close exporter")
+	}
+	return height, err
+}
+
+type exporter struct {
+	scheme       proto.Scheme
+	st           State
+	toHeight     proto.Height
+	syncInterval uint64
+
+	blocks    *recordsFile
+	snapshots *recordsFile // nil if snapshots are not exported
+}
+
+func newExporter(params ExportParams, st State) (*exporter, error) {
+	toHeight := params.ToHeight
+	height, err := st.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get state height")
+	}
+	if toHeight == 0 {
+		toHeight = height
+	}
+	if toHeight > height {
+		return nil, errors.Errorf("last height %d is greater than state height %d", toHeight, height)
+	}
+	syncInterval := params.SyncInterval
+	if syncInterval == 0 {
+		syncInterval = defaultSyncInterval
+	}
+	blocks, err := openRecordsFile(params.BlockchainPath, importer.MaxBlockSize)
+	if err != nil {
+		return nil, err
+	}
+	exp := &exporter{
+		scheme:       params.Scheme,
+		st:           st,
+		toHeight:     toHeight,
+		syncInterval: syncInterval,
+		blocks:       blocks,
+	}
+	if params.SnapshotsPath != "" {
+		snapshots, sErr := openRecordsFile(params.SnapshotsPath, maxBlockSnapshotSize)
+		if sErr != nil {
+			return nil, blocks.closeWithError(sErr)
+		}
+		exp.snapshots = snapshots
+	}
+	return exp, nil
+}
+
+// lastHeight returns the height of the last block exported to all files.
+func (e *exporter) lastHeight() proto.Height {
+	count := e.blocks.count
+	if e.snapshots != nil {
+		count = min(count, e.snapshots.count)
+	}
+	return count + firstHeight - 1
+}
+
+// resume cuts the files to the same number of blocks and checks the last exported block against the state.
+func (e *exporter) resume() (proto.Height, error) {
+	last := e.lastHeight()
+	count := last - firstHeight + 1
+	if err := e.blocks.truncateTo(count); err != nil {
+		return 0, err
+	}
+	if e.snapshots != nil {
+		if err := e.snapshots.truncateTo(count); err != nil {
+			return 0, err
+		}
+	}
+	if count == 0 {
+		return last, nil
+	}
+	exported, err := e.blocks.last()
+	if err != nil {
+		return 0, err
+	}
+	expected, err := e.blockBytes(last)
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(exported, expected) {
+		return 0, errors.Errorf("exported block at height %d differs from the block in the state", last)
+	}
+	return last, nil
+}
+
+func (e *exporter) export(ctx context.Context) (proto.Height, error) {
+	last, err := e.resume()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to resume export")
+	}
+	if last >= e.toHeight {
+		slog.Info("Nothing to export", "exported", last, "height", e.toHeight)
+		return last, nil
+	}
+	slog.Info("Starting export", "from", last+1, "to", e.toHeight)
+	for h := last + 1; h <= e.toHeight; h++ {
+		if ctx.Err() != nil {
+			return last, ctx.Err()
+		}
+		if wErr := e.write(h); wErr != nil {
+			return last, errors.Wrapf(wErr, "failed to export block at height %d", h)
+		}
+		if (h-firstHeight+1)%e.syncInterval == 0 || h == e.toHeight {
+			if sErr := e.sync(); sErr != nil {
+				return last, sErr
+			}
+			slog.Info("Exported blocks", "height", h)
+		}
+		last = h
+	}
+	return last, nil
+}
+
+func (e *exporter) write(height proto.Height) error {
+	b, err := e.blockBytes(height)
+	if err != nil {
+		return err
+	}
+	if e.snapshots != nil {
+		s, sErr := e.st.SnapshotsAtHeight(height)
+		if sErr != nil {
+			return errors.Wrap(sErr, "failed to get snapshots")
+		}
+		sb, mErr := s.MarshalBinaryImport()
+		if mErr != nil {
+			return errors.Wrap(mErr, "failed to marshal snapshots")
+		}
+		if wErr := e.snapshots.write(sb); wErr != nil {
+			return wErr
+		}
+	}
+	return e.blocks.write(b)
+}
+
+// blockBytes returns the block at the given height in the format the state expects it to be imported.
+// The blocks are encoded in protobuf since the activation of BlockV5 feature.
+func (e *exporter) blockBytes(height proto.Height) ([]byte, error) {
+	block, err := e.st.BlockByHeight(height)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block")
+	}
+	protobuf, err := e.st.IsActiveAtHeight(int16(settings.BlockV5), height)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check BlockV5 activation")
+	}
+	if protobuf {
+		return block.MarshalToProtobuf(e.scheme)
+	}
+	return block.MarshalBinary(e.scheme)
+}
+
+func (e *exporter) sync() error {
+	if err := e.blocks.sync(); err != nil {
+		return err
+	}
+	if e.snapshots != nil {
+		return e.snapshots.sync()
+	}
+	return nil
+}
+
+func (e *exporter) close() error {
+	var sErr error
+	if e.snapshots != nil {
+		sErr = e.snapshots.close()
+	}
+	if err := e.blocks.close(); err != nil {
+		return err
+	}
+	return sErr
+}
@@ -0,0 +1,192 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+const (
+	testScheme     = proto.TestNetScheme
+	testV5Height   = 6
+	testHeight     = 10
+	testBaseTarget = 100
+)
+
+type testState struct {
+	blocks    map[proto.Height]*proto.Block
+	snapshots map[proto.Height]proto.BlockSnapshot
+}
+
+func newTestState(t *testing.T) *testState {
+	addr, err := proto.NewAddressFromString("3NA26AC1aLjj6uYnuoTahauhUPPPB3VBPUe")
+	require.NoError(t, err)
+	st := &testState{
+		blocks:    make(map[proto.Height]*proto.Block),
+		snapshots: make(map[proto.Height]proto.BlockSnapshot),
+	}
+	parent := proto.NewBlockIDFromDigest(crypto.Digest{})
+	for h := proto.Height(1); h <= testHeight; h++ {
+		version := proto.NgBlockVersion
+		if h >= testV5Height {
+			version = proto.ProtobufBlockVersion
+		}
+		b := &proto.Block{BlockHeader: proto.BlockHeader{
+			Version:                version,
+			Timestamp:              1_600_000_000_000 + h,
+			Parent:                 parent,
+			ConsensusBlockLength:   40,
+			TransactionBlockLength: 4, // the empty list of transactions
+			NxtConsensus: proto.NxtConsensus{
+				BaseTarget:   testBaseTarget,
+				GenSignature: make([]byte, crypto.DigestSize),
+			},
+			BlockSignature: crypto.Signature{byte(h)},
+		}}
+		if version == proto.ProtobufBlockVersion {
+			b.GenSignature = make([]byte, crypto.SignatureSize)
+		}
+		require.NoError(t, b.GenerateBlockID(testScheme))
+		parent = b.BlockID()
+		st.blocks[h] = b
+		st.snapshots[h] = proto.BlockSnapshot{TxSnapshots: [][]proto.AtomicSnapshot{{
+			&proto.WavesBalanceSnapshot{Address: addr, Balance: h},
+			&proto.TransactionStatusSnapshot{Status: proto.TransactionSucceeded},
+		}}}
+	}
+	return st
+}
+
+func (s *testState) Height() (proto.Height, error) {
+	return testHeight, nil
+}
+
+func (s *testState) BlockByHeight(height proto.Height) (*proto.Block, error) {
+	b, ok := s.blocks[height]
+	if !ok {
+		return nil, errors.Errorf("no block at height %d", height)
+	}
+	return b, nil
+}
+
+func (s *testState) SnapshotsAtHeight(height proto.Height) (proto.BlockSnapshot, error) {
+	bs, ok := s.snapshots[height]
+	if !ok {
+		return proto.BlockSnapshot{}, errors.Errorf("no snapshots at height %d", height)
+	}
+	return bs, nil
+}
+
+func (s *testState) IsActiveAtHeight(featureID int16, height proto.Height) (bool, error) {
+	return featureID == int16(settings.BlockV5) && height >= testV5Height, nil
+}
+
+func readRecords(t *testing.T, path string) [][]byte {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, f.Close()) }()
+	r := bufio.NewReader(f)
+	var records [][]byte
+	for {
+		var buf [uint32Size]byte
+		_, err = io.ReadFull(r, buf[:])
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		require.NoError(t, err)
+		record := make([]byte, binary.BigEndian.Uint32(buf[:]))
+		_, err = io.ReadFull(r, record)
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+}
+
+func checkExported(t *testing.T, st *testState, params ExportParams, last proto.Height) {
+	blocks := readRecords(t, params.BlockchainPath)
+	snapshots := readRecords(t, params.SnapshotsPath)
+	require.Len(t, blocks, int(last-firstHeight+1))
+	require.Len(t, snapshots, len(blocks))
+	for i := range blocks {
+		h := proto.Height(i) + firstHeight
+		var b proto.Block
+		if h >= testV5Height {
+			require.NoError(t, b.UnmarshalFromProtobuf(blocks[i]))
+		} else {
+			require.NoError(t, b.UnmarshalBinary(blocks[i], testScheme))
+		}
+		assert.Equal(t, st.blocks[h].BlockID(), b.BlockID(), "height %d", h)
+		var bs proto.BlockSnapshot
+		require.NoError(t, bs.UnmarshalBinaryImport(snapshots[i], testScheme))
+		assert.Equal(t, st.snapshots[h], bs, "height %d", h)
+	}
+}
+
+func TestExportToFile(t *testing.T) {
+	dir := t.TempDir()
+	st := newTestState(t)
+	params := ExportParams{
+		Scheme:         testScheme,
+		BlockchainPath: filepath.Join(dir, "blocks"),
+		SnapshotsPath:  filepath.Join(dir, "snapshots"),
+		ToHeight:       4,
+		SyncInterval:   2,
+	}
+	last, err := ExportToFile(t.Context(), params, st)
+	require.NoError(t, err)
+	assert.Equal(t, proto.Height(4), last)
+	checkExported(t, st, params, last)
+
+	// Simulate the interrupted export: the snapshot of the next block is written, but the block is incomplete.
+	sf, err := openRecordsFile(params.SnapshotsPath, maxBlockSnapshotSize)
+	require.NoError(t, err)
+	require.NoError(t, sf.write([]byte{}))
+	require.NoError(t, sf.close())
+	bf, err := os.OpenFile(params.BlockchainPath, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = bf.Write([]byte{0, 0, 1, 0, 1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, bf.Close())
+
+	params.ToHeight = 0
+	last, err = ExportToFile(t.Context(), params, st)
+	require.NoError(t, err)
+	assert.Equal(t, proto.Height(testHeight), last)
+	checkExported(t, st, params, last)
+
+	last, err = ExportToFile(t.Context(), params, st)
+	require.NoError(t, err)
+	assert.Equal(t, proto.Height(testHeight), last)
+	checkExported(t, st, params, last)
+}
+
+func TestExportToFileMismatch(t *testing.T) {
+	dir := t.TempDir()
+	params := ExportParams{
+		Scheme:         testScheme,
+		BlockchainPath: filepath.Join(dir, "blocks"),
+		ToHeight:       3,
+	}
+	_, err := ExportToFile(t.Context(), params, newTestState(t))
+	require.NoError(t, err)
+
+	other := newTestState(t)
+	other.blocks[3].Timestamp++
+	params.ToHeight = 0
+	_, err = ExportToFile(t.Context(), params, other)
+	assert.EqualError(t, err, "failed to resume export: exported block at height 3 differs from the block in the state")
+
+	params.ToHeight = testHeight + 1
+	_, err = ExportToFile(t.Context(), params, other)
+	assert.EqualError(t, err, "failed to create exporter: last height 11 is greater than state height 10")
+}
@@ -0,0 +1,161 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	uint32Size          = 4
+	bufioWriterBuffSize = 64 * 1024 // 64 KiB buffer for bufio.Reader and bufio.Writer
+)
+
+// recordsFile is the file of records prefixed with their size as big-endian uint32.
+// It's the format of blocks and snapshots files read by the importer.
+type recordsFile struct {
+	path    string
+	f       *os.File
+	w       *bufio.Writer
+	maxSize uint32
+	count   uint64 // number of complete records in the file
+}
+
+// openRecordsFile opens or creates the file and positions it after the last complete record.
+// The incomplete record at the end of the file, left by the interrupted export, is truncated.
+func openRecordsFile(path string, maxSize uint32) (*recordsFile, error) {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	rf := &recordsFile{path: path, f: f, maxSize: maxSize}
+	count, end, _, err := rf.scan(0)
+	if err != nil {
+		return nil, rf.closeWithError(err)
+	}
+	if tErr := rf.truncate(count, end); tErr != nil {
+		return nil, rf.closeWithError(tErr)
+	}
+	return rf, nil
+}
+
+// scan reads the records from the beginning of the file. It stops after the limit of records if the limit is
+// not zero or at the first incomplete record. It returns the number of complete records, the offset of the end
+// of the last one and its content.
+func (rf *recordsFile) scan(limit uint64) (uint64, int64, []byte, error) {
+	if _, err := rf.f.Seek(0, io.SeekStart); err != nil {
+		return 0, 0, nil, fmt.Errorf("failed to scan file %q: %w", rf.path, err)
+	}
+	r := bufio.NewReaderSize(rf.f, bufioWriterBuffSize)
+	var (
+		count uint64
+		end   int64
+		last  []byte
+		buf   [uint32Size]byte
+	)
+	for limit == 0 || count < limit {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			break // the end of the file or incomplete size
+		}
+		size := binary.BigEndian.Uint32(buf[:])
+		if size > rf.maxSize {
+			return 0, 0, nil, fmt.Errorf("corrupted file %q: invalid record size %d at pos %d", rf.path, size, end)
+		}
+		record := make([]byte, size)
+		if _, err := io.ReadFull(r, record); err != nil {
+			break // incomplete record
+		}
+		count++
+		end += uint32Size + int64(size)
+		last = record
+	}
+	return count, end, last, nil
+}
+
+// last returns the content of the last record or nil if the file is empty.
+// The buffered records must be flushed before the call.
+func (rf *recordsFile) last() ([]byte, error) {
+	if rf.count == 0 {
+		return nil, nil
+	}
+	_, _, last, err := rf.scan(rf.count)
+	if err != nil {
+		return nil, err
+	}
+	if _, sErr := rf.f.Seek(0, io.SeekEnd); sErr != nil {
+		return nil, fmt.Errorf("failed to seek file %q: %w", rf.path, sErr)
+	}
+	return last, nil
+}
+
+// truncate cuts the file after the given number of records that end at the given offset.
+func (rf *recordsFile) truncate(count uint64, end int64) error {
+	if err := rf.f.Truncate(end); err != nil {
+		return fmt.Errorf("failed to truncate file %q: %w", rf.path, err)
+	}
+	if _, err := rf.f.Seek(end, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek file %q: %w", rf.path, err)
+	}
+	rf.count = count
+	rf.w = bufio.NewWriterSize(rf.f, bufioWriterBuffSize)
+	return nil
+}
+
+// truncateTo leaves only the given number of first records in the file.
+func (rf *recordsFile) truncateTo(count uint64) error {
+	if count >= rf.count {
+		return nil
+	}
+	if err := rf.w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush file %q: %w", rf.path, err)
+	}
+	n, end, _, err := rf.scan(count)
+	if err != nil {
+		return err
+	}
+	return rf.truncate(n, end)
+}
+
+func (rf *recordsFile) write(record []byte) error {
+	if uint64(len(record)) > uint64(rf.maxSize) {
+		return fmt.Errorf("record size %d exceeds the limit %d", len(record), rf.maxSize)
+	}
+	var buf [uint32Size]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(len(record)))
+	if _, err := rf.w.Write(buf[:]); err != nil {
+		return fmt.Errorf("failed to write to file %q: %w", rf.path, err)
+	}
+	if _, err := rf.w.Write(record); err != nil {
+		return fmt.Errorf("failed to write to file %q: %w", rf.path, err)
+	}
+	rf.count++
+	return nil
+}
+
+// sync flushes the buffered records and commits the file to the disk.
+func (rf *recordsFile) sync() error {
+	if err := rf.w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush file %q: %w", rf.path, err)
+	}
+	if err := rf.f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync file %q: %w", rf.path, err)
+	}
+	return nil
+}
+
+func (rf *recordsFile) close() error {
+	if err := rf.sync(); err != nil {
+		return rf.closeWithError(err)
+	}
+	return rf.f.Close()
+}
+
+func (rf *recordsFile) closeWithError(err error) error {
+	if clErr := rf.f.Close(); clErr != nil {
+		return fmt.Errorf("%w, failed to close file %q: %v", err, rf.path, clErr)
+	}
+	return err
+}
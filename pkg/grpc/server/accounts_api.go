@@ -2,9 +2,11 @@ package server
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
@@ -15,6 +17,9 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
 )
 
+// heightMetadataKey is the name of the gRPC metadata entry with the height for historical requests.
+const heightMetadataKey = "height"
+
 func (s *Server) GetBalances(req *g.BalancesRequest, srv g.AccountsApi_GetBalancesServer) error {
 	c := proto.ProtobufConverter{FallbackChainID: s.scheme}
 	addr, err := c.Address(s.scheme, req.Address)
@@ -22,12 +27,28 @@ func (s *Server) GetBalances(req *g.BalancesRequest, srv g.AccountsApi_GetBalanc
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 	rcp := proto.NewRecipientFromAddress(addr)
+	height, atHeight, err := heightFromMetadata(srv.Context())
+	if err != nil {
+		return err
+	}
+	if atHeight {
+		return s.sendBalancesAtHeight(rcp, req.Assets, height, srv)
+	}
 	if len(req.Assets) == 0 {
-		// TODO(nickeskov): send waves balance AND all assets balances (portfolio)
-		//  by the given address according to the scala node implementation
+		// Send waves balance and all assets balances (portfolio) like the scala node does.
 		if err := s.sendWavesBalance(rcp, srv); err != nil {
 			return status.Error(codes.Internal, err.Error())
 		}
+		balances, err := s.state.AssetBalances(rcp)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		for _, b := range balances {
+			if err := sendAssetBalance(b.AssetID, b.Balance, srv); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+		return nil
 	}
 	for _, asset := range req.Assets {
 		if len(asset) == 0 {
@@ -44,14 +65,7 @@ func (s *Server) GetBalances(req *g.BalancesRequest, srv g.AccountsApi_GetBalanc
 			if err != nil {
 				return status.Error(codes.NotFound, err.Error())
 			}
-			var res g.BalanceResponse
-			res.Balance = &g.BalanceResponse_Asset{
-				Asset: &pb.Amount{
-					AssetId: fullAssetID.Bytes(),
-					Amount:  int64(balance),
-				},
-			}
-			if err := srv.Send(&res); err != nil {
+			if err := sendAssetBalance(fullAssetID, balance, srv); err != nil {
 				return status.Error(codes.Internal, err.Error())
 			}
 		}
@@ -121,6 +135,31 @@ func (s *Server) GetDataEntries(req *g.DataRequest, srv g.AccountsApi_GetDataEnt
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 	rcp := proto.NewRecipientFromAddress(addr)
+	height, atHeight, err := heightFromMetadata(srv.Context())
+	if err != nil {
+		return err
+	}
+	if atHeight {
+		if req.Key == "" {
+			return status.Error(codes.InvalidArgument, "data key must be specified for the request at height")
+		}
+		entry, err := s.state.RetrieveEntryAtHeight(rcp, req.Key, height)
+		if err != nil {
+			switch {
+			case stateerr.IsInvalidInput(err):
+				return status.Error(codes.InvalidArgument, err.Error())
+			case stateerr.IsNotFound(err):
+				return nil
+			default:
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+		res := &g.DataEntryResponse{Address: req.Address, Entry: entry.ToProtobuf()}
+		if err := srv.Send(res); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return nil
+	}
 	if req.Key != "" {
 		entry, err := s.state.RetrieveEntry(rcp, req.Key)
 		if err != nil {
@@ -177,6 +216,64 @@ func (s *Server) sendWavesBalance(rcp proto.Recipient, srv g.AccountsApi_GetBala
 	return srv.Send(&res)
 }
 
+// sendBalancesAtHeight sends balances of the requested assets at the given height.
+// Only regular Waves balance is provided and the assets list must not be empty.
+func (s *Server) sendBalancesAtHeight(
+	rcp proto.Recipient,
+	assets [][]byte,
+	height proto.Height,
+	srv g.AccountsApi_GetBalancesServer,
+) error {
+	if len(assets) == 0 {
+		return status.Error(codes.InvalidArgument, "assets must be specified for the request at height")
+	}
+	for _, asset := range assets {
+		if len(asset) == 0 {
+			balance, err := s.state.WavesBalanceAtHeight(rcp, height)
+			if err != nil {
+				return stateErrorAtHeight(err)
+			}
+			res := &g.BalanceResponse{
+				Balance: &g.BalanceResponse_Waves{Waves: &g.BalanceResponse_WavesBalances{Regular: int64(balance)}},
+			}
+			if err := srv.Send(res); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			continue
+		}
+		fullAssetID, err := crypto.NewDigestFromBytes(asset)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		balance, err := s.state.AssetBalanceAtHeight(rcp, proto.AssetIDFromDigest(fullAssetID), height)
+		if err != nil {
+			return stateErrorAtHeight(err)
+		}
+		if err := sendAssetBalance(fullAssetID, balance, srv); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+	return nil
+}
+
+func stateErrorAtHeight(err error) error {
+	if stateerr.IsInvalidInput(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func sendAssetBalance(assetID crypto.Digest, balance uint64, srv g.AccountsApi_GetBalancesServer) error {
+	var res g.BalanceResponse
+	res.Balance = &g.BalanceResponse_Asset{
+		Asset: &pb.Amount{
+			AssetId: assetID.Bytes(),
+			Amount:  int64(balance),
+		},
+	}
+	return srv.Send(&res)
+}
+
 type getActiveLeasesHandler struct {
 	srv g.AccountsApi_GetActiveLeasesServer
 	s   *Server
@@ -232,3 +329,21 @@ func (h *getActiveLeasesHandler) handle(tx proto.Transaction, _ proto.Transactio
 	}
 	return nil
 }
+
+// heightFromMetadata parses optional height from the request metadata.
+// Historical requests are served for the heights within the rollback window only.
+func heightFromMetadata(ctx context.Context) (proto.Height, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false, nil
+	}
+	values := md.Get(heightMetadataKey)
+	if len(values) == 0 {
+		return 0, false, nil
+	}
+	height, err := strconv.ParseUint(values[0], 10, 64)
+	if err != nil || height < 1 {
+		return 0, false, status.Errorf(codes.InvalidArgument, "invalid height %q", values[0])
+	}
+	return height, true, nil
+}
@@ -7,6 +7,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
@@ -47,6 +50,33 @@ func TestGetBalances(t *testing.T) {
 	assert.Equal(t, correctBalance, res.Balance)
 	_, err = stream.Recv()
 	assert.Equal(t, io.EOF, err)
+
+	// Empty assets list means the whole portfolio, genesis address holds only waves.
+	stream, err = cl.GetBalances(ctx, &g.BalancesRequest{Address: addrBody})
+	require.NoError(t, err)
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, correctBalance, res.Balance)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	// Historical balance with height in the request metadata.
+	heightCtx := metadata.AppendToOutgoingContext(ctx, heightMetadataKey, "1")
+	stream, err = cl.GetBalances(heightCtx, req)
+	require.NoError(t, err)
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, &g.BalanceResponse_Waves{Waves: &g.BalanceResponse_WavesBalances{Regular: 9999999500000000}},
+		res.Balance,
+	)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	heightCtx = metadata.AppendToOutgoingContext(ctx, heightMetadataKey, "100")
+	stream, err = cl.GetBalances(heightCtx, req)
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
 func TestGetActiveLeases(t *testing.T) {
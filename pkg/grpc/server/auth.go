@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+// apiKeyMetadataKey is the name of the gRPC metadata entry carrying the API key, the same as REST API header.
+const apiKeyMetadataKey = "x-api-key"
+
+const (
+	signFullMethodName      = "/waves.node.grpc.TransactionsApi/Sign"
+	broadcastFullMethodName = "/waves.node.grpc.TransactionsApi/Broadcast"
+)
+
+// apiKeyAuth checks the API key for privileged gRPC methods.
+type apiKeyAuth struct {
+	hashedAPIKey crypto.Digest
+	enabled      bool
+	privileged   map[string]struct{}
+}
+
+func newAPIKeyAuth(apiKey string, privilegedMethods ...string) (*apiKeyAuth, error) {
+	digest, err := crypto.SecureHash([]byte(apiKey))
+	if err != nil {
+		return nil, err
+	}
+	privileged := make(map[string]struct{}, len(privilegedMethods))
+	for _, m := range privilegedMethods {
+		privileged[m] = struct{}{}
+	}
+	return &apiKeyAuth{hashedAPIKey: digest, enabled: len(apiKey) > 0, privileged: privileged}, nil
+}
+
+// isPrivileged reports whether the call requires the API key. Dry-run of Broadcast takes the state lock,
+// so it's privileged even if Broadcast itself is not.
+func (a *apiKeyAuth) isPrivileged(ctx context.Context, fullMethod string) bool {
+	if _, ok := a.privileged[fullMethod]; ok {
+		return true
+	}
+	return fullMethod == broadcastFullMethodName && isDryRun(ctx)
+}
+
+func (a *apiKeyAuth) check(ctx context.Context) error {
+	if !a.enabled {
+		return status.Error(codes.PermissionDenied, "api key disabled")
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "api key not provided")
+	}
+	keys := md.Get(apiKeyMetadataKey)
+	if len(keys) == 0 {
+		return status.Error(codes.Unauthenticated, "api key not provided")
+	}
+	d, err := crypto.SecureHash([]byte(keys[0]))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if subtle.ConstantTimeCompare(d[:], a.hashedAPIKey[:]) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid api key")
+	}
+	return nil
+}
+
+func (a *apiKeyAuth) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if a.isPrivileged(ctx, info.FullMethod) {
+		if err := a.check(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+func (a *apiKeyAuth) streamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if a.isPrivileged(ss.Context(), info.FullMethod) {
+		if err := a.check(ss.Context()); err != nil {
+			return err
+		}
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,737 @@
+package server
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/errs"
+	"github.com/wavesplatform/gowaves/pkg/grpc/generated/waves"
+	"github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+// blockAppendUpdate creates the blockchain update of appending the block at the given height.
+func (s *Server) blockAppendUpdate(st state.StateInfo, height proto.Height) (*events.BlockchainUpdated, error) {
+	block, err := st.BlockByHeight(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block at height %d", height)
+	}
+	vrf, rewards, err := calculateVRFAndRewards(st, s.scheme, &block.BlockHeader, height)
+	if err != nil {
+		return nil, err
+	}
+	pb, err := block.ToProtobufWithHeight(s.scheme, height, vrf, rewards)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert block at height %d", height)
+	}
+	totalWaves, err := st.TotalWavesAmount(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get total Waves amount at height %d", height)
+	}
+	features, err := activatedFeatures(st, height)
+	if err != nil {
+		return nil, err
+	}
+	upd, err := s.transactionsAppend(st, height, block.Transactions, 0)
+	if err != nil {
+		return nil, err
+	}
+	upd.Body = &events.BlockchainUpdated_Append_Block{
+		Block: &events.BlockchainUpdated_Append_BlockAppend{
+			Block:              pb.Block,
+			UpdatedWavesAmount: int64(totalWaves),
+			ActivatedFeatures:  features,
+			Vrf:                pb.Vrf,
+			RewardShares:       pb.RewardShares,
+		},
+	}
+	return &events.BlockchainUpdated{
+		Id:     block.BlockID().Bytes(),
+		Height: int32(height),
+		Update: &events.BlockchainUpdated_Append_{Append: upd},
+	}, nil
+}
+
+// microBlockAppendUpdate creates the blockchain update of appending the transactions of the block at the given
+// height starting from the position from. The key block and previous transactions are expected to be already sent.
+func (s *Server) microBlockAppendUpdate(
+	st state.StateInfo,
+	height proto.Height,
+	reference proto.BlockID,
+	from int,
+) (*events.BlockchainUpdated, error) {
+	block, err := st.BlockByHeight(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block at height %d", height)
+	}
+	txs, err := block.Transactions[from:].ToProtobuf(s.scheme)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert transactions of block at height %d", height)
+	}
+	upd, err := s.transactionsAppend(st, height, block.Transactions, from)
+	if err != nil {
+		return nil, err
+	}
+	upd.Body = &events.BlockchainUpdated_Append_MicroBlock{
+		MicroBlock: &events.BlockchainUpdated_Append_MicroBlockAppend{
+			MicroBlock: &waves.SignedMicroBlock{
+				MicroBlock: &waves.MicroBlock{
+					Version:               int32(block.Version),
+					Reference:             reference.Bytes(),
+					UpdatedBlockSignature: block.BlockSignature.Bytes(),
+					SenderPublicKey:       block.GeneratorPublicKey.Bytes(),
+					Transactions:          txs,
+				},
+				TotalBlockId: block.BlockID().Bytes(),
+			},
+			UpdatedTransactionsRoot: block.TransactionsRoot,
+		},
+	}
+	return &events.BlockchainUpdated{
+		Id:     block.BlockID().Bytes(),
+		Height: int32(height),
+		Update: &events.BlockchainUpdated_Append_{Append: upd},
+	}, nil
+}
+
+// transactionsAppend fills transactions IDs, metadata and state updates of block transactions
+// starting from the position from.
+func (s *Server) transactionsAppend(
+	st state.StateInfo,
+	height proto.Height,
+	txs proto.Transactions,
+	from int,
+) (*events.BlockchainUpdated_Append, error) {
+	snapshot, err := st.SnapshotsAtHeight(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get snapshots at height %d", height)
+	}
+	if len(snapshot.TxSnapshots) != len(txs) {
+		return nil, errors.Errorf("number of snapshots %d doesn't match number of transactions %d at height %d",
+			len(snapshot.TxSnapshots), len(txs), height)
+	}
+	b := newStateUpdateBuilder(st, s.scheme, height)
+	res := &events.BlockchainUpdated_Append{
+		TransactionIds:          make([][]byte, 0, len(txs)-from),
+		TransactionsMetadata:    make([]*events.TransactionMetadata, 0, len(txs)-from),
+		TransactionStateUpdates: make([]*events.StateUpdate, 0, len(txs)-from),
+	}
+	for i, tx := range txs {
+		id, idErr := tx.GetID(s.scheme)
+		if idErr != nil {
+			return nil, errors.Wrap(idErr, "failed to get transaction ID")
+		}
+		upd, uErr := b.build(id, snapshot.TxSnapshots[i])
+		if uErr != nil {
+			return nil, errors.Wrapf(uErr, "failed to build state update of transaction %s", proto.B58Bytes(id))
+		}
+		if i < from { // Only track the changes of already sent transactions.
+			continue
+		}
+		md, mErr := s.transactionMetadata(st, tx)
+		if mErr != nil {
+			return nil, errors.Wrapf(mErr, "failed to build metadata of transaction %s", proto.B58Bytes(id))
+		}
+		res.TransactionIds = append(res.TransactionIds, id)
+		res.TransactionsMetadata = append(res.TransactionsMetadata, md)
+		res.TransactionStateUpdates = append(res.TransactionStateUpdates, upd)
+	}
+	res.StateUpdate = &events.StateUpdate{}
+	return res, nil
+}
+
+func activatedFeatures(st state.StateInfo, height proto.Height) ([]int32, error) {
+	features, err := st.AllFeatures()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get features")
+	}
+	var res []int32
+	for _, f := range features {
+		h, hErr := st.ActivationHeight(f)
+		if hErr != nil {
+			continue // Feature is not activated.
+		}
+		if h == height {
+			res = append(res, int32(f))
+		}
+	}
+	return res, nil
+}
+
+func (s *Server) transactionMetadata(st state.StateInfo, tx proto.Transaction) (*events.TransactionMetadata, error) {
+	sender, err := tx.GetSender(s.scheme)
+	if err != nil {
+		return nil, err
+	}
+	senderAddr, err := sender.ToWavesAddress(s.scheme)
+	if err != nil {
+		return nil, err
+	}
+	res := &events.TransactionMetadata{SenderAddress: senderAddr.Bytes()}
+	switch t := tx.(type) {
+	case *proto.TransferWithSig:
+		res.Metadata, err = s.transferMetadata(st, t.Recipient)
+	case *proto.TransferWithProofs:
+		res.Metadata, err = s.transferMetadata(st, t.Recipient)
+	case *proto.LeaseWithSig:
+		res.Metadata, err = s.leaseMetadata(st, t.Recipient)
+	case *proto.LeaseWithProofs:
+		res.Metadata, err = s.leaseMetadata(st, t.Recipient)
+	case *proto.MassTransferWithProofs:
+		addrs := make([][]byte, len(t.Transfers))
+		for i := range t.Transfers {
+			addr, rErr := recipientAddress(st, t.Transfers[i].Recipient)
+			if rErr != nil {
+				return nil, rErr
+			}
+			addrs[i] = addr.Bytes()
+		}
+		res.Metadata = &events.TransactionMetadata_MassTransfer{
+			MassTransfer: &events.TransactionMetadata_MassTransferMetadata{RecipientsAddresses: addrs},
+		}
+	case proto.Exchange:
+		res.Metadata, err = s.exchangeMetadata(t)
+	case *proto.InvokeScriptWithProofs:
+		res.Metadata, err = s.invokeMetadata(st, t)
+	case *proto.EthereumTransaction:
+		res.Metadata, err = ethereumMetadata(t)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Server) transferMetadata(
+	st state.StateInfo,
+	rcp proto.Recipient,
+) (*events.TransactionMetadata_Transfer, error) {
+	addr, err := recipientAddress(st, rcp)
+	if err != nil {
+		return nil, err
+	}
+	return &events.TransactionMetadata_Transfer{
+		Transfer: &events.TransactionMetadata_TransferMetadata{RecipientAddress: addr.Bytes()},
+	}, nil
+}
+
+func (s *Server) leaseMetadata(st state.StateInfo, rcp proto.Recipient) (*events.TransactionMetadata_Lease, error) {
+	addr, err := recipientAddress(st, rcp)
+	if err != nil {
+		return nil, err
+	}
+	return &events.TransactionMetadata_Lease{
+		Lease: &events.TransactionMetadata_LeaseMetadata{RecipientAddress: addr.Bytes()},
+	}, nil
+}
+
+func (s *Server) exchangeMetadata(tx proto.Exchange) (*events.TransactionMetadata_Exchange, error) {
+	md := &events.TransactionMetadata_ExchangeMetadata{}
+	for _, o := range []proto.Order{tx.GetOrder1(), tx.GetOrder2()} {
+		id, err := o.GetID()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get order ID")
+		}
+		sender, err := o.GetSender(s.scheme)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get order sender")
+		}
+		senderAddr, err := sender.ToWavesAddress(s.scheme)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get order sender address")
+		}
+		md.OrderIds = append(md.OrderIds, id)
+		md.OrderSenderAddresses = append(md.OrderSenderAddresses, senderAddr.Bytes())
+		md.OrderSenderPublicKeys = append(md.OrderSenderPublicKeys, o.GetSenderPKBytes())
+	}
+	return &events.TransactionMetadata_Exchange{Exchange: md}, nil
+}
+
+func (s *Server) invokeMetadata(
+	st state.StateInfo,
+	tx *proto.InvokeScriptWithProofs,
+) (*events.TransactionMetadata_InvokeScript, error) {
+	dApp, err := recipientAddress(st, tx.ScriptRecipient)
+	if err != nil {
+		return nil, err
+	}
+	payments := make([]*waves.Amount, len(tx.Payments))
+	for i, p := range tx.Payments {
+		payments[i] = &waves.Amount{AssetId: p.Asset.ToID(), Amount: int64(p.Amount)}
+	}
+	md := &events.TransactionMetadata_InvokeScriptMetadata{
+		DAppAddress:  dApp.Bytes(),
+		FunctionName: tx.FunctionCall.Name(),
+		Payments:     payments,
+	}
+	if tx.ID != nil {
+		if sr, srErr := st.InvokeResultByID(*tx.ID); srErr == nil { // Results are available only with extended API.
+			if md.Result, err = sr.ToProtobuf(); err != nil {
+				return nil, errors.Wrap(err, "failed to convert invoke result")
+			}
+		}
+	}
+	return &events.TransactionMetadata_InvokeScript{InvokeScript: md}, nil
+}
+
+func ethereumMetadata(tx *proto.EthereumTransaction) (*events.TransactionMetadata_Ethereum, error) {
+	pk, err := tx.FromPK()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sender public key")
+	}
+	return &events.TransactionMetadata_Ethereum{
+		Ethereum: &events.TransactionMetadata_EthereumMetadata{
+			Timestamp:       int64(tx.GetTimestamp()),
+			Fee:             int64(tx.GetFee()),
+			SenderPublicKey: pk.SerializeXYCoordinates(),
+		},
+	}, nil
+}
+
+func recipientAddress(st state.StateInfo, rcp proto.Recipient) (proto.WavesAddress, error) {
+	if addr := rcp.Address(); addr != nil {
+		return *addr, nil
+	}
+	alias := rcp.Alias()
+	if alias == nil {
+		return proto.WavesAddress{}, errors.New("empty recipient")
+	}
+	addr, err := st.AddrByAlias(*alias)
+	if err != nil {
+		return proto.WavesAddress{}, errors.Wrapf(err, "failed to resolve alias '%s'", alias.String())
+	}
+	return addr, nil
+}
+
+type balanceKey struct {
+	addr  proto.WavesAddress
+	asset proto.OptionalAsset
+}
+
+type dataEntryKey struct {
+	addr proto.WavesAddress
+	key  string
+}
+
+type leasingBalance struct {
+	in, out int64
+}
+
+// stateUpdateBuilder converts transaction snapshots into blockchain updates state updates.
+// The values before the change are taken from the changes made by previous transactions of the block.
+// If a value wasn't changed in the block, it is taken from the state at the previous height,
+// which has to be within the rollback window.
+type stateUpdateBuilder struct {
+	st       state.StateInfo
+	scheme   proto.Scheme
+	height   proto.Height
+	balances map[balanceKey]int64
+	leasing  map[proto.WavesAddress]leasingBalance
+	entries  map[dataEntryKey]*waves.DataEntry
+	assets   map[crypto.Digest]*events.StateUpdate_AssetDetails
+	leases   map[crypto.Digest]*events.StateUpdate_LeaseUpdate
+	scripts  map[proto.WavesAddress][]byte
+	// Current transaction.
+	txID        []byte
+	update      *events.StateUpdate
+	assetsIndex map[crypto.Digest]int // Positions of assets updates of the current transaction.
+}
+
+var _ = proto.SnapshotApplier((*stateUpdateBuilder)(nil))
+
+func newStateUpdateBuilder(st state.StateInfo, scheme proto.Scheme, height proto.Height) *stateUpdateBuilder {
+	return &stateUpdateBuilder{
+		st:       st,
+		scheme:   scheme,
+		height:   height,
+		balances: make(map[balanceKey]int64),
+		leasing:  make(map[proto.WavesAddress]leasingBalance),
+		entries:  make(map[dataEntryKey]*waves.DataEntry),
+		assets:   make(map[crypto.Digest]*events.StateUpdate_AssetDetails),
+		leases:   make(map[crypto.Digest]*events.StateUpdate_LeaseUpdate),
+		scripts:  make(map[proto.WavesAddress][]byte),
+	}
+}
+
+// build creates the state update of the transaction with the given ID from its snapshots.
+// Transactions of the block must be passed in order.
+func (b *stateUpdateBuilder) build(txID []byte, snapshots []proto.AtomicSnapshot) (*events.StateUpdate, error) {
+	b.txID = txID
+	b.update = &events.StateUpdate{}
+	b.assetsIndex = make(map[crypto.Digest]int)
+	for _, snapshot := range snapshots {
+		if err := snapshot.Apply(b); err != nil {
+			return nil, err
+		}
+	}
+	return b.update, nil
+}
+
+func (b *stateUpdateBuilder) previousHeight() (proto.Height, bool) {
+	if b.height <= 1 {
+		return 0, false
+	}
+	return b.height - 1, true
+}
+
+func (b *stateUpdateBuilder) balanceBefore(key balanceKey) (int64, error) {
+	if v, ok := b.balances[key]; ok {
+		return v, nil
+	}
+	h, ok := b.previousHeight()
+	if !ok {
+		return 0, nil
+	}
+	var (
+		balance uint64
+		err     error
+	)
+	rcp := proto.NewRecipientFromAddress(key.addr)
+	if key.asset.Present {
+		balance, err = b.st.AssetBalanceAtHeight(rcp, proto.AssetIDFromDigest(key.asset.ID), h)
+	} else {
+		balance, err = b.st.WavesBalanceAtHeight(rcp, h)
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get balance of %s at height %d", key.addr.String(), h)
+	}
+	return int64(balance), nil
+}
+
+func (b *stateUpdateBuilder) applyBalance(key balanceKey, balance uint64) error {
+	before, err := b.balanceBefore(key)
+	if err != nil {
+		return err
+	}
+	b.balances[key] = int64(balance)
+	b.update.Balances = append(b.update.Balances, &events.StateUpdate_BalanceUpdate{
+		Address:      key.addr.Bytes(),
+		AmountAfter:  &waves.Amount{AssetId: key.asset.ToID(), Amount: int64(balance)},
+		AmountBefore: before,
+	})
+	return nil
+}
+
+func (b *stateUpdateBuilder) ApplyWavesBalance(snapshot proto.WavesBalanceSnapshot) error {
+	return b.applyBalance(balanceKey{addr: snapshot.Address, asset: proto.NewOptionalAssetWaves()}, snapshot.Balance)
+}
+
+func (b *stateUpdateBuilder) ApplyAssetBalance(snapshot proto.AssetBalanceSnapshot) error {
+	key := balanceKey{addr: snapshot.Address, asset: *proto.NewOptionalAssetFromDigest(snapshot.AssetID)}
+	return b.applyBalance(key, snapshot.Balance)
+}
+
+func (b *stateUpdateBuilder) leasingBefore(addr proto.WavesAddress) (leasingBalance, error) {
+	if v, ok := b.leasing[addr]; ok {
+		return v, nil
+	}
+	h, ok := b.previousHeight()
+	if !ok {
+		return leasingBalance{}, nil
+	}
+	in, out, err := b.st.LeaseBalanceAtHeight(proto.NewRecipientFromAddress(addr), h)
+	if err != nil {
+		return leasingBalance{}, errors.Wrapf(err, "failed to get leasing of %s at height %d", addr.String(), h)
+	}
+	return leasingBalance{in: in, out: out}, nil
+}
+
+func (b *stateUpdateBuilder) ApplyLeaseBalance(snapshot proto.LeaseBalanceSnapshot) error {
+	before, err := b.leasingBefore(snapshot.Address)
+	if err != nil {
+		return err
+	}
+	after := leasingBalance{in: int64(snapshot.LeaseIn), out: int64(snapshot.LeaseOut)}
+	b.leasing[snapshot.Address] = after
+	b.update.LeasingForAddress = append(b.update.LeasingForAddress, &events.StateUpdate_LeasingUpdate{
+		Address:   snapshot.Address.Bytes(),
+		InAfter:   after.in,
+		OutAfter:  after.out,
+		InBefore:  before.in,
+		OutBefore: before.out,
+	})
+	return nil
+}
+
+func (b *stateUpdateBuilder) ApplyDataEntries(snapshot proto.DataEntriesSnapshot) error {
+	for _, e := range snapshot.DataEntries {
+		key := dataEntryKey{addr: snapshot.Address, key: e.GetKey()}
+		before, _, err := b.entryBefore(key)
+		if err != nil {
+			return err
+		}
+		after := e.ToProtobuf()
+		b.entries[key] = after
+		b.update.DataEntries = append(b.update.DataEntries, &events.StateUpdate_DataEntryUpdate{
+			Address:         snapshot.Address.Bytes(),
+			DataEntry:       after,
+			DataEntryBefore: before,
+		})
+	}
+	return nil
+}
+
+// isNotFoundError reports whether the entity is absent in the state. Unlike stateerr.IsNotFound it doesn't
+// treat retrieval failures as the absence of the entity.
+func isNotFoundError(err error) bool {
+	var stErr stateerr.StateError
+	return errors.As(err, &stErr) && stErr.Type() == stateerr.NotFoundError
+}
+
+// entryBefore returns the data entry before the change and reports whether there was an entry.
+func (b *stateUpdateBuilder) entryBefore(key dataEntryKey) (*waves.DataEntry, bool, error) {
+	if v, ok := b.entries[key]; ok {
+		return v, true, nil
+	}
+	h, ok := b.previousHeight()
+	if !ok {
+		return nil, false, nil
+	}
+	prev, err := b.st.RetrieveEntryAtHeight(proto.NewRecipientFromAddress(key.addr), key.key, h)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrapf(err, "failed to get data entry '%s' of %s at height %d",
+			key.key, key.addr.String(), h)
+	}
+	return prev.ToProtobuf(), true, nil
+}
+
+func (b *stateUpdateBuilder) scriptBefore(addr proto.WavesAddress) ([]byte, error) {
+	if v, ok := b.scripts[addr]; ok {
+		return v, nil
+	}
+	h, ok := b.previousHeight()
+	if !ok {
+		return nil, nil
+	}
+	script, err := b.st.ScriptBytesByAccountAtHeight(proto.NewRecipientFromAddress(addr), h)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get script of %s at height %d", addr.String(), h)
+	}
+	return script, nil
+}
+
+func (b *stateUpdateBuilder) ApplyAccountScript(snapshot proto.AccountScriptSnapshot) error {
+	addr, err := proto.NewAddressFromPublicKey(b.scheme, snapshot.SenderPublicKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to create address from public key")
+	}
+	before, err := b.scriptBefore(addr)
+	if err != nil {
+		return err
+	}
+	b.scripts[addr] = snapshot.Script
+	b.update.Scripts = append(b.update.Scripts, &events.StateUpdate_ScriptUpdate{
+		Address: addr.Bytes(),
+		Before:  before,
+		After:   snapshot.Script,
+	})
+	return nil
+}
+
+func (b *stateUpdateBuilder) ApplyNewLease(snapshot proto.NewLeaseSnapshot) error {
+	sender, err := proto.NewAddressFromPublicKey(b.scheme, snapshot.SenderPK)
+	if err != nil {
+		return errors.Wrap(err, "failed to create address from public key")
+	}
+	upd := &events.StateUpdate_LeaseUpdate{
+		LeaseId:             snapshot.LeaseID.Bytes(),
+		StatusAfter:         events.StateUpdate_LeaseUpdate_ACTIVE,
+		Amount:              int64(snapshot.Amount),
+		Sender:              sender.Bytes(),
+		Recipient:           snapshot.RecipientAddr.Bytes(),
+		OriginTransactionId: b.txID,
+	}
+	b.leases[snapshot.LeaseID] = upd
+	b.update.IndividualLeases = append(b.update.IndividualLeases, upd)
+	return nil
+}
+
+func (b *stateUpdateBuilder) ApplyCancelledLease(snapshot proto.CancelledLeaseSnapshot) error {
+	var upd *events.StateUpdate_LeaseUpdate
+	if l, ok := b.leases[snapshot.LeaseID]; ok {
+		upd = protobuf.CloneOf(l)
+	} else {
+		upd = b.leaseFromTransaction(snapshot.LeaseID)
+	}
+	upd.StatusAfter = events.StateUpdate_LeaseUpdate_INACTIVE
+	b.update.IndividualLeases = append(b.update.IndividualLeases, upd)
+	return nil
+}
+
+// leaseFromTransaction restores the lease details from the Lease transaction that created it.
+// Only the lease ID is set for leases created by scripts.
+func (b *stateUpdateBuilder) leaseFromTransaction(leaseID crypto.Digest) *events.StateUpdate_LeaseUpdate {
+	upd := &events.StateUpdate_LeaseUpdate{LeaseId: leaseID.Bytes()}
+	tx, err := b.st.TransactionByID(leaseID.Bytes())
+	if err != nil {
+		return upd
+	}
+	var lease *proto.Lease
+	switch t := tx.(type) {
+	case *proto.LeaseWithSig:
+		lease = &t.Lease
+	case *proto.LeaseWithProofs:
+		lease = &t.Lease
+	default:
+		return upd
+	}
+	sender, err := proto.NewAddressFromPublicKey(b.scheme, lease.SenderPK)
+	if err != nil {
+		return upd
+	}
+	recipient, err := recipientAddress(b.st, lease.Recipient)
+	if err != nil {
+		return upd
+	}
+	upd.Amount = int64(lease.Amount)
+	upd.Sender = sender.Bytes()
+	upd.Recipient = recipient.Bytes()
+	upd.OriginTransactionId = leaseID.Bytes()
+	return upd
+}
+
+// assetUpdate returns the asset details after the changes of the current transaction.
+// All changes of the asset made by one transaction are collected into one update.
+func (b *stateUpdateBuilder) assetUpdate(assetID crypto.Digest, isNew bool) (*events.StateUpdate_AssetDetails, error) {
+	if i, ok := b.assetsIndex[assetID]; ok {
+		return b.update.Assets[i].After, nil
+	}
+	var (
+		before *events.StateUpdate_AssetDetails
+		found  bool
+	)
+	if !isNew {
+		var err error
+		if before, found, err = b.assetDetails(assetID); err != nil {
+			return nil, err
+		}
+	}
+	after := &events.StateUpdate_AssetDetails{AssetId: assetID.Bytes()}
+	if found {
+		after = protobuf.CloneOf(before)
+	}
+	b.assets[assetID] = after
+	b.assetsIndex[assetID] = len(b.update.Assets)
+	b.update.Assets = append(b.update.Assets, &events.StateUpdate_AssetStateUpdate{Before: before, After: after})
+	return after, nil
+}
+
+// assetDetails returns the asset details before the change and reports whether the asset was issued.
+func (b *stateUpdateBuilder) assetDetails(assetID crypto.Digest) (*events.StateUpdate_AssetDetails, bool, error) {
+	if d, ok := b.assets[assetID]; ok {
+		return d, true, nil
+	}
+	h, ok := b.previousHeight()
+	if !ok {
+		return nil, false, nil
+	}
+	info, err := b.st.EnrichedFullAssetInfoAtHeight(proto.AssetIDFromDigest(assetID), h)
+	if err != nil {
+		if errors.Is(err, errs.UnknownAsset{}) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrapf(err, "failed to get info of asset %s at height %d", assetID.String(), h)
+	}
+	d := &events.StateUpdate_AssetDetails{
+		AssetId:         assetID.Bytes(),
+		Issuer:          info.IssuerPublicKey.Bytes(),
+		Decimals:        int32(info.Decimals),
+		Name:            info.Name,
+		Description:     info.Description,
+		Reissuable:      info.Reissuable,
+		Volume:          int64(info.Quantity),
+		Sponsorship:     int64(info.SponsorshipCost),
+		SequenceInBlock: int32(info.SequenceInBlock),
+		IssueHeight:     int32(info.IssueHeight),
+		SafeVolume:      new(big.Int).SetUint64(info.Quantity).Bytes(),
+	}
+	if info.Scripted {
+		d.ScriptInfo = &events.StateUpdate_AssetDetails_AssetScriptInfo{
+			Script:     info.ScriptInfo.Bytes,
+			Complexity: int64(info.ScriptInfo.Complexity),
+		}
+	}
+	return d, true, nil
+}
+
+func (b *stateUpdateBuilder) ApplyNewAsset(snapshot proto.NewAssetSnapshot) error {
+	d, err := b.assetUpdate(snapshot.AssetID, true)
+	if err != nil {
+		return err
+	}
+	d.Issuer = snapshot.IssuerPublicKey.Bytes()
+	d.Decimals = int32(snapshot.Decimals)
+	d.Nft = snapshot.IsNFT
+	d.IssueHeight = int32(b.height)
+	d.LastUpdated = int32(b.height)
+	return nil
+}
+
+func (b *stateUpdateBuilder) ApplyAssetDescription(snapshot proto.AssetDescriptionSnapshot) error {
+	d, err := b.assetUpdate(snapshot.AssetID, false)
+	if err != nil {
+		return err
+	}
+	d.Name = snapshot.AssetName
+	d.Description = snapshot.AssetDescription
+	d.LastUpdated = int32(b.height)
+	return nil
+}
+
+func (b *stateUpdateBuilder) ApplyAssetVolume(snapshot proto.AssetVolumeSnapshot) error {
+	d, err := b.assetUpdate(snapshot.AssetID, false)
+	if err != nil {
+		return err
+	}
+	d.Reissuable = snapshot.IsReissuable
+	d.Volume = snapshot.TotalQuantity.Int64()
+	d.SafeVolume = snapshot.TotalQuantity.Bytes()
+	return nil
+}
+
+func (b *stateUpdateBuilder) ApplyAssetScript(snapshot proto.AssetScriptSnapshot) error {
+	d, err := b.assetUpdate(snapshot.AssetID, false)
+	if err != nil {
+		return err
+	}
+	if snapshot.Script.IsEmpty() {
+		d.ScriptInfo = nil
+		return nil
+	}
+	// The complexity isn't a part of the snapshot, it is taken from the state at the block height.
+	info, err := b.st.ScriptInfoByAssetAtHeight(proto.AssetIDFromDigest(snapshot.AssetID), b.height)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get script info of asset %s at height %d",
+			snapshot.AssetID.String(), b.height)
+	}
+	d.ScriptInfo = &events.StateUpdate_AssetDetails_AssetScriptInfo{
+		Script:     snapshot.Script,
+		Complexity: int64(info.Complexity),
+	}
+	return nil
+}
+
+func (b *stateUpdateBuilder) ApplySponsorship(snapshot proto.SponsorshipSnapshot) error {
+	d, err := b.assetUpdate(snapshot.AssetID, false)
+	if err != nil {
+		return err
+	}
+	d.Sponsorship = int64(snapshot.MinSponsoredFee)
+	return nil
+}
+
+func (b *stateUpdateBuilder) ApplyAlias(proto.AliasSnapshot) error { return nil }
+
+func (b *stateUpdateBuilder) ApplyFilledVolumeAndFee(proto.FilledVolumeFeeSnapshot) error { return nil }
+
+func (b *stateUpdateBuilder) ApplyTransactionsStatus(proto.TransactionStatusSnapshot) error {
+	return nil
+}
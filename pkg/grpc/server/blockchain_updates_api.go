@@ -0,0 +1,396 @@
+package server
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events"
+	eventsgrpc "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+const (
+	// blockchainUpdatesMaxRange is the maximum number of blocks requested at once with GetBlockUpdatesRange.
+	blockchainUpdatesMaxRange = 1000
+	// blockchainUpdatesBatchSize is the maximum number of blocks processed at once while holding the state lock.
+	blockchainUpdatesBatchSize = 100
+	// blockchainUpdatesHistoryDepth is the number of last sent blocks remembered to detect rollbacks.
+	blockchainUpdatesHistoryDepth = 100
+)
+
+// blockchainUpdatesError converts the error of building blockchain updates into the gRPC status.
+// The values before the changes are read from the state at the previous height, so the updates
+// are available only for blocks within the rollback window.
+func blockchainUpdatesError(err error) error {
+	if stateerr.IsInvalidInput(err) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// checkUpdatesAvailable checks that the updates can be built for the blocks starting from the given height.
+// The values before the changes are read from the state at the previous height, the history of older heights
+// is not stored, so the updates of the blocks below the rollback window are not available.
+func checkUpdatesAvailable(st state.StateInfo, from proto.Height) error {
+	minHeight, err := st.RollbackMinHeight()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if from > 1 && from <= minHeight {
+		return status.Errorf(codes.OutOfRange,
+			"blockchain updates are available only for blocks above height %d, requested height %d", minHeight, from)
+	}
+	return nil
+}
+
+// GetBlockUpdate returns the update of appending the block at the given height.
+// Only blocks within the rollback window are available, codes.OutOfRange is returned for older blocks.
+func (s *Server) GetBlockUpdate(
+	_ context.Context,
+	req *eventsgrpc.GetBlockUpdateRequest,
+) (*eventsgrpc.GetBlockUpdateResponse, error) {
+	if req.Height <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid height %d", req.Height)
+	}
+	upd, err := s.state.MapR(func(st state.StateInfo) (any, error) {
+		height, err := st.Height()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if h := proto.Height(req.Height); h > height {
+			return nil, status.Errorf(codes.NotFound, "block at height %d not found", h)
+		}
+		if err = checkUpdatesAvailable(st, proto.Height(req.Height)); err != nil {
+			return nil, err
+		}
+		u, err := s.blockAppendUpdate(st, proto.Height(req.Height))
+		if err != nil {
+			return nil, blockchainUpdatesError(err)
+		}
+		return u, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &eventsgrpc.GetBlockUpdateResponse{Update: upd.(*events.BlockchainUpdated)}, nil
+}
+
+// GetBlockUpdatesRange returns the updates of appending the blocks in the given range of heights.
+// At most blockchainUpdatesMaxRange blocks can be requested. Only blocks within the rollback window are available,
+// codes.OutOfRange is returned if the range starts below it.
+// The updates are built in batches, the request is aborted if the blockchain is changed between the batches.
+func (s *Server) GetBlockUpdatesRange(
+	_ context.Context,
+	req *eventsgrpc.GetBlockUpdatesRangeRequest,
+) (*eventsgrpc.GetBlockUpdatesRangeResponse, error) {
+	if req.FromHeight <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid from_height %d", req.FromHeight)
+	}
+	if req.ToHeight < req.FromHeight {
+		return nil, status.Errorf(codes.InvalidArgument, "to_height %d is less than from_height %d",
+			req.ToHeight, req.FromHeight)
+	}
+	if n := req.ToHeight - req.FromHeight + 1; n > blockchainUpdatesMaxRange {
+		return nil, status.Errorf(codes.InvalidArgument, "requested %d blocks, at most %d blocks can be requested",
+			n, blockchainUpdatesMaxRange)
+	}
+	from, to := proto.Height(req.FromHeight), proto.Height(req.ToHeight)
+	res := make([]*events.BlockchainUpdated, 0, to-from+1)
+	var last proto.BlockID // The last block of the previous batch.
+	for start := from; start <= to; start += blockchainUpdatesBatchSize {
+		end := min(start+blockchainUpdatesBatchSize-1, to)
+		r, err := s.state.MapR(func(st state.StateInfo) (any, error) {
+			return s.blockUpdatesBatch(st, start, end, to, last)
+		})
+		if err != nil {
+			return nil, err
+		}
+		batch := r.([]*events.BlockchainUpdated)
+		id, err := proto.NewBlockIDFromBytes(batch[len(batch)-1].Id)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		last = id
+		res = append(res, batch...)
+	}
+	return &eventsgrpc.GetBlockUpdatesRangeResponse{Updates: res}, nil
+}
+
+// blockUpdatesBatch returns the updates of appending the blocks from start to end of the range up to the height to.
+// The batch has to continue the previous batch that ends with the block last, unless it is the first one.
+func (s *Server) blockUpdatesBatch(
+	st state.StateInfo,
+	start, end, to proto.Height,
+	last proto.BlockID,
+) ([]*events.BlockchainUpdated, error) {
+	height, err := st.Height()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if last == (proto.BlockID{}) { // The first batch.
+		if to > height {
+			return nil, status.Errorf(codes.InvalidArgument, "to_height %d is greater than blockchain height %d",
+				to, height)
+		}
+		if err = checkUpdatesAvailable(st, start); err != nil {
+			return nil, err
+		}
+	} else {
+		id, idErr := st.HeightToBlockID(start - 1)
+		if idErr != nil && !stateerr.IsNotFound(idErr) {
+			return nil, status.Error(codes.Internal, idErr.Error())
+		}
+		if idErr != nil || id != last || to > height {
+			return nil, status.Errorf(codes.Aborted, "blockchain was changed at height %d while building updates",
+				start-1)
+		}
+	}
+	res := make([]*events.BlockchainUpdated, 0, end-start+1)
+	for h := start; h <= end; h++ {
+		u, uErr := s.blockAppendUpdate(st, h)
+		if uErr != nil {
+			return nil, blockchainUpdatesError(uErr)
+		}
+		res = append(res, u)
+	}
+	return res, nil
+}
+
+// Subscribe streams blockchain updates starting from the block at from_height. The stream is finished after
+// the block at to_height is sent, if to_height is zero the stream continues with appends of new blocks and
+// microblocks and rollbacks as they occur. Only blocks within the rollback window are available,
+// codes.OutOfRange is returned if from_height is below it.
+func (s *Server) Subscribe(
+	req *eventsgrpc.SubscribeRequest,
+	srv eventsgrpc.BlockchainUpdatesApi_SubscribeServer,
+) error {
+	if req.FromHeight <= 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid from_height %d", req.FromHeight)
+	}
+	if req.ToHeight != 0 && req.ToHeight < req.FromHeight {
+		return status.Errorf(codes.InvalidArgument, "to_height %d is less than from_height %d",
+			req.ToHeight, req.FromHeight)
+	}
+	_, err := s.state.MapR(func(st state.StateInfo) (any, error) {
+		return nil, checkUpdatesAvailable(st, proto.Height(req.FromHeight))
+	})
+	if err != nil {
+		return err
+	}
+	sub := newUpdatesSubscription(proto.Height(req.FromHeight), proto.Height(req.ToHeight))
+	for {
+		// Take the notification channel before reading the state to not miss the changes made in between.
+		changed := s.state.BlockchainChanged()
+		r, err := s.state.MapR(func(st state.StateInfo) (any, error) {
+			return s.pollUpdates(st, sub)
+		})
+		if err != nil {
+			return blockchainUpdatesError(err)
+		}
+		updates := r.([]*events.BlockchainUpdated)
+		for _, u := range updates {
+			if sErr := srv.Send(&eventsgrpc.SubscribeEvent{Update: u}); sErr != nil {
+				return sErr
+			}
+		}
+		if sub.done() {
+			return nil
+		}
+		if len(updates) >= blockchainUpdatesBatchSize {
+			continue // Catching up, don't wait.
+		}
+		select {
+		case <-srv.Context().Done():
+			return status.FromContextError(srv.Context().Err()).Err()
+		case <-changed:
+		}
+	}
+}
+
+// sentBlock is the block sent to a subscriber, it's used to detect microblocks and rollbacks.
+type sentBlock struct {
+	id     proto.BlockID
+	parent proto.BlockID
+	txIDs  [][]byte
+}
+
+type updatesSubscription struct {
+	to   proto.Height // Zero means no upper bound.
+	next proto.Height // Height of the next block to send.
+	sent []sentBlock  // Last sent blocks, the last one is at height next-1.
+}
+
+func newUpdatesSubscription(from, to proto.Height) *updatesSubscription {
+	return &updatesSubscription{to: to, next: from}
+}
+
+func (sub *updatesSubscription) done() bool {
+	return sub.to != 0 && sub.next > sub.to
+}
+
+// base returns the height of the first remembered block.
+func (sub *updatesSubscription) base() proto.Height {
+	return sub.next - proto.Height(len(sub.sent))
+}
+
+func (sub *updatesSubscription) push(b sentBlock) {
+	sub.sent = append(sub.sent, b)
+	if len(sub.sent) > blockchainUpdatesHistoryDepth {
+		sub.sent = append(sub.sent[:0], sub.sent[1:]...)
+	}
+	sub.next++
+}
+
+// pollUpdates compares the blocks sent to the subscriber with the blockchain and returns the updates to send.
+func (s *Server) pollUpdates(st state.StateInfo, sub *updatesSubscription) ([]*events.BlockchainUpdated, error) {
+	height, err := st.Height()
+	if err != nil {
+		return nil, err
+	}
+	var res []*events.BlockchainUpdated
+	if len(sub.sent) > 0 {
+		upd, uErr := s.topBlockUpdate(st, sub, height)
+		if uErr != nil {
+			return nil, uErr
+		}
+		if upd != nil {
+			res = append(res, upd)
+		}
+	}
+	for sub.next <= height && !sub.done() && len(res) < blockchainUpdatesBatchSize {
+		upd, uErr := s.blockAppendUpdate(st, sub.next)
+		if uErr != nil {
+			return nil, uErr
+		}
+		id, idErr := proto.NewBlockIDFromBytes(upd.Id)
+		if idErr != nil {
+			return nil, idErr
+		}
+		appended := upd.GetAppend()
+		parent, pErr := proto.NewBlockIDFromBytes(appended.GetBlock().GetBlock().GetHeader().GetReference())
+		if pErr != nil {
+			return nil, pErr
+		}
+		sub.push(sentBlock{id: id, parent: parent, txIDs: appended.TransactionIds})
+		res = append(res, upd)
+	}
+	return res, nil
+}
+
+// topBlockUpdate checks the last sent block. If it was extended with microblocks the append of the new
+// transactions is returned, if some of its microblocks were removed the microblock rollback is returned.
+// If the block was replaced the rollback to the last common block is returned.
+func (s *Server) topBlockUpdate(
+	st state.StateInfo,
+	sub *updatesSubscription,
+	height proto.Height,
+) (*events.BlockchainUpdated, error) {
+	top := &sub.sent[len(sub.sent)-1]
+	topHeight := sub.next - 1
+	if topHeight <= height {
+		id, err := st.HeightToBlockID(topHeight)
+		if err != nil {
+			return nil, err
+		}
+		if id == top.id {
+			return nil, nil
+		}
+		block, err := st.BlockByHeight(topHeight)
+		if err != nil {
+			return nil, err
+		}
+		if block.Parent == top.parent && s.extendsTransactions(block.Transactions, top.txIDs) {
+			upd, uErr := s.microBlockAppendUpdate(st, topHeight, top.id, len(top.txIDs))
+			if uErr != nil {
+				return nil, uErr
+			}
+			top.id = id
+			top.txIDs = append(top.txIDs, upd.GetAppend().TransactionIds...)
+			return upd, nil
+		}
+		if block.Parent == top.parent && s.truncatesTransactions(block.Transactions, top.txIDs) {
+			removed := top.txIDs[len(block.Transactions):]
+			top.id = id
+			top.txIDs = top.txIDs[:len(block.Transactions):len(block.Transactions)]
+			return &events.BlockchainUpdated{
+				Id:     id.Bytes(),
+				Height: int32(topHeight),
+				Update: &events.BlockchainUpdated_Rollback_{
+					Rollback: &events.BlockchainUpdated_Rollback{
+						Type:                  events.BlockchainUpdated_Rollback_MICROBLOCK,
+						RemovedTransactionIds: removed,
+					},
+				},
+			}, nil
+		}
+	}
+	return s.rollbackUpdate(st, sub, height)
+}
+
+// extendsTransactions reports whether the transactions start with the transactions of given IDs and have more.
+func (s *Server) extendsTransactions(txs proto.Transactions, ids [][]byte) bool {
+	return len(txs) > len(ids) && s.equalTransactions(txs[:len(ids)], ids)
+}
+
+// truncatesTransactions reports whether the transactions are the beginning of the transactions of given IDs.
+func (s *Server) truncatesTransactions(txs proto.Transactions, ids [][]byte) bool {
+	return len(txs) < len(ids) && s.equalTransactions(txs, ids[:len(txs)])
+}
+
+func (s *Server) equalTransactions(txs proto.Transactions, ids [][]byte) bool {
+	for i, id := range ids {
+		txID, err := txs[i].GetID(s.scheme)
+		if err != nil || !bytes.Equal(txID, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// rollbackUpdate finds the last sent block that is still in the blockchain and returns the rollback to it.
+func (s *Server) rollbackUpdate(
+	st state.StateInfo,
+	sub *updatesSubscription,
+	height proto.Height,
+) (*events.BlockchainUpdated, error) {
+	base := sub.base()
+	h := min(sub.next-2, height) // Top sent block is already checked.
+	for ; h+1 >= base && h > 0; h-- {
+		id, err := st.HeightToBlockID(h)
+		if err != nil {
+			return nil, err
+		}
+		var sentID proto.BlockID
+		if h >= base {
+			sentID = sub.sent[h-base].id
+		} else {
+			sentID = sub.sent[0].parent // The block before the first remembered one.
+		}
+		if id != sentID {
+			continue
+		}
+		var removed [][]byte
+		for _, b := range sub.sent[h+1-base:] {
+			removed = append(removed, b.txIDs...)
+		}
+		sub.sent = sub.sent[:h+1-base]
+		sub.next = h + 1
+		return &events.BlockchainUpdated{
+			Id:     id.Bytes(),
+			Height: int32(h),
+			Update: &events.BlockchainUpdated_Rollback_{
+				Rollback: &events.BlockchainUpdated_Rollback{
+					Type:                  events.BlockchainUpdated_Rollback_BLOCK,
+					RemovedTransactionIds: removed,
+				},
+			},
+		}, nil
+	}
+	return nil, errors.Errorf("rollback is deeper than %d blocks", len(sub.sent))
+}
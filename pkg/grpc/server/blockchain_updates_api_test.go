@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events"
+	eventsgrpc "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events/grpc"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+func assertBlockAppend(t *testing.T, st state.StateInfo, height proto.Height, upd *events.BlockchainUpdated) {
+	block, err := st.BlockByHeight(height)
+	require.NoError(t, err)
+	assert.Equal(t, block.BlockID().Bytes(), upd.Id)
+	assert.EqualValues(t, height, upd.Height)
+	appended := upd.GetAppend()
+	require.NotNil(t, appended)
+	assert.True(t, protobuf.Equal(blockFromState(t, height, st).Block, appended.GetBlock().GetBlock()))
+	require.Len(t, appended.TransactionIds, len(block.Transactions))
+	require.Len(t, appended.TransactionsMetadata, len(block.Transactions))
+	require.Len(t, appended.TransactionStateUpdates, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		id, idErr := tx.GetID(proto.MainNetScheme)
+		require.NoError(t, idErr)
+		assert.Equal(t, id, appended.TransactionIds[i])
+	}
+}
+
+func TestGetBlockUpdate(t *testing.T) {
+	st := newTestState(t, true, defaultStateParams(), settings.MustMainNetSettings())
+	ctx := withAutoCancel(t, context.Background())
+	require.NoError(t, server.initServer(st, nil, nil))
+	cl := eventsgrpc.NewBlockchainUpdatesApiClient(connectAutoClose(t, grpcTestAddr))
+
+	blocks, err := state.ReadMainnetBlocksToHeight(99)
+	require.NoError(t, err)
+	_, err = st.AddDeserializedBlocks(blocks)
+	require.NoError(t, err)
+
+	res, err := cl.GetBlockUpdate(ctx, &eventsgrpc.GetBlockUpdateRequest{Height: 99})
+	require.NoError(t, err)
+	assertBlockAppend(t, st, 99, res.Update)
+	appended := res.Update.GetAppend()
+	require.NotEmpty(t, appended.TransactionStateUpdates)
+	// Fee and amount of the first transaction change balances of the sender and the recipient.
+	assert.NotEmpty(t, appended.TransactionStateUpdates[0].Balances)
+	assert.NotEmpty(t, appended.TransactionsMetadata[0].SenderAddress)
+
+	_, err = cl.GetBlockUpdate(ctx, &eventsgrpc.GetBlockUpdateRequest{Height: 0})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = cl.GetBlockUpdate(ctx, &eventsgrpc.GetBlockUpdateRequest{Height: 100})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetBlockUpdatesRange(t *testing.T) {
+	st := newTestState(t, true, defaultStateParams(), settings.MustMainNetSettings())
+	ctx := withAutoCancel(t, context.Background())
+	require.NoError(t, server.initServer(st, nil, nil))
+	cl := eventsgrpc.NewBlockchainUpdatesApiClient(connectAutoClose(t, grpcTestAddr))
+
+	blocks, err := state.ReadMainnetBlocksToHeight(150)
+	require.NoError(t, err)
+	_, err = st.AddDeserializedBlocks(blocks)
+	require.NoError(t, err)
+
+	res, err := cl.GetBlockUpdatesRange(ctx, &eventsgrpc.GetBlockUpdatesRangeRequest{FromHeight: 5, ToHeight: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Updates, 6)
+	for i, upd := range res.Updates {
+		assertBlockAppend(t, st, proto.Height(5+i), upd)
+	}
+	// The range is built in several batches.
+	res, err = cl.GetBlockUpdatesRange(ctx, &eventsgrpc.GetBlockUpdatesRangeRequest{FromHeight: 2, ToHeight: 150})
+	require.NoError(t, err)
+	require.Len(t, res.Updates, 149)
+	for i, upd := range res.Updates {
+		assertBlockAppend(t, st, proto.Height(2+i), upd)
+	}
+
+	_, err = cl.GetBlockUpdatesRange(ctx, &eventsgrpc.GetBlockUpdatesRangeRequest{FromHeight: 10, ToHeight: 5})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = cl.GetBlockUpdatesRange(ctx, &eventsgrpc.GetBlockUpdatesRangeRequest{FromHeight: 10, ToHeight: 151})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = cl.GetBlockUpdatesRange(ctx, &eventsgrpc.GetBlockUpdatesRangeRequest{FromHeight: 1, ToHeight: 1001})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSubscribe(t *testing.T) {
+	st := newTestState(t, true, defaultStateParams(), settings.MustMainNetSettings())
+	ctx := withAutoCancel(t, context.Background())
+	require.NoError(t, server.initServer(st, nil, nil))
+	cl := eventsgrpc.NewBlockchainUpdatesApiClient(connectAutoClose(t, grpcTestAddr))
+
+	blocks, err := state.ReadMainnetBlocksToHeight(12)
+	require.NoError(t, err)
+	_, err = st.AddDeserializedBlocks(blocks[:9]) // Blocks from 2 to 10.
+	require.NoError(t, err)
+
+	t.Run("bounded", func(t *testing.T) {
+		stream, sErr := cl.Subscribe(ctx, &eventsgrpc.SubscribeRequest{FromHeight: 2, ToHeight: 5})
+		require.NoError(t, sErr)
+		for h := proto.Height(2); h <= 5; h++ {
+			ev, rErr := stream.Recv()
+			require.NoError(t, rErr)
+			assertBlockAppend(t, st, h, ev.Update)
+		}
+		_, rErr := stream.Recv()
+		assert.True(t, errors.Is(rErr, io.EOF))
+	})
+	t.Run("rollback", func(t *testing.T) {
+		stream, sErr := cl.Subscribe(ctx, &eventsgrpc.SubscribeRequest{FromHeight: 8})
+		require.NoError(t, sErr)
+		for h := proto.Height(8); h <= 10; h++ {
+			ev, rErr := stream.Recv()
+			require.NoError(t, rErr)
+			assertBlockAppend(t, st, h, ev.Update)
+		}
+		removed, rErr := st.BlockByHeight(10)
+		require.NoError(t, rErr)
+		require.NoError(t, st.RollbackToHeight(9))
+		ev, rErr := stream.Recv()
+		require.NoError(t, rErr)
+		rollback := ev.Update.GetRollback()
+		require.NotNil(t, rollback)
+		assert.EqualValues(t, 9, ev.Update.Height)
+		assert.Equal(t, events.BlockchainUpdated_Rollback_BLOCK, rollback.Type)
+		assert.Len(t, rollback.RemovedTransactionIds, len(removed.Transactions))
+
+		_, err = st.AddDeserializedBlocks(blocks[8:])
+		require.NoError(t, err)
+		for h := proto.Height(10); h <= 12; h++ {
+			ev, rErr = stream.Recv()
+			require.NoError(t, rErr)
+			assertBlockAppend(t, st, h, ev.Update)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		stream, sErr := cl.Subscribe(ctx, &eventsgrpc.SubscribeRequest{FromHeight: 0})
+		require.NoError(t, sErr)
+		_, rErr := stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(rErr))
+	})
+}
+
+func TestStateUpdateBuilder(t *testing.T) {
+	addr := proto.MustAddressFromString("3PAWwWa6GbwcJaFzwqXQN5KQm7H96Y7SHTQ")
+	_, pk, err := crypto.GenerateKeyPair([]byte("sender"))
+	require.NoError(t, err)
+	sender, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, pk)
+	require.NoError(t, err)
+	leaseID := crypto.MustDigestFromBase58("8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS")
+	assetID := crypto.MustDigestFromBase58("DG2xFkPdDwKUoBkzGAhQtLpSGzfXLiCYPEzeKH2Ad24p")
+
+	st := state.NewMockState(t)
+	st.EXPECT().WavesBalanceAtHeight(proto.NewRecipientFromAddress(addr), proto.Height(9)).Return(100, nil).Once()
+	st.EXPECT().RetrieveEntryAtHeight(proto.NewRecipientFromAddress(addr), "key", proto.Height(9)).
+		Return(nil, stateerr.NewStateError(stateerr.NotFoundError, keyvalue.ErrNotFound)).Once()
+	st.EXPECT().LeaseBalanceAtHeight(proto.NewRecipientFromAddress(addr), proto.Height(9)).Return(5, 3, nil).Once()
+	st.EXPECT().ScriptBytesByAccountAtHeight(proto.NewRecipientFromAddress(sender), proto.Height(9)).
+		Return(proto.Script{1}, nil).Once()
+	info := &proto.EnrichedFullAssetInfo{FullAssetInfo: proto.FullAssetInfo{
+		AssetInfo: proto.AssetInfo{Quantity: 1000, Reissuable: true, Scripted: true},
+		Name:      "asset",
+		ScriptInfo: proto.ScriptInfo{
+			Bytes:      proto.Script{3},
+			Complexity: 7,
+		},
+	}}
+	st.EXPECT().EnrichedFullAssetInfoAtHeight(proto.AssetIDFromDigest(assetID), proto.Height(9)).
+		Return(info, nil).Once()
+	st.EXPECT().ScriptInfoByAssetAtHeight(proto.AssetIDFromDigest(assetID), proto.Height(10)).
+		Return(&proto.ScriptInfo{Bytes: proto.Script{4}, Complexity: 42}, nil).Once()
+	b := newStateUpdateBuilder(st, proto.MainNetScheme, 10)
+
+	upd1, err := b.build([]byte{1}, []proto.AtomicSnapshot{
+		&proto.WavesBalanceSnapshot{Address: addr, Balance: 50},
+		&proto.DataEntriesSnapshot{Address: addr, DataEntries: proto.DataEntries{
+			&proto.IntegerDataEntry{Key: "key", Value: 1},
+		}},
+		&proto.NewLeaseSnapshot{LeaseID: leaseID, Amount: 10, SenderPK: pk, RecipientAddr: addr},
+		&proto.LeaseBalanceSnapshot{Address: addr, LeaseIn: 15, LeaseOut: 3},
+		&proto.AccountScriptSnapshot{SenderPublicKey: pk, Script: proto.Script{2}},
+		&proto.AssetScriptSnapshot{AssetID: assetID, Script: proto.Script{4}},
+	})
+	require.NoError(t, err)
+	require.Len(t, upd1.Balances, 1)
+	assert.EqualValues(t, 100, upd1.Balances[0].AmountBefore)
+	assert.EqualValues(t, 50, upd1.Balances[0].AmountAfter.Amount)
+	require.Len(t, upd1.DataEntries, 1)
+	assert.Nil(t, upd1.DataEntries[0].DataEntryBefore)
+	require.Len(t, upd1.LeasingForAddress, 1)
+	assert.EqualValues(t, 5, upd1.LeasingForAddress[0].InBefore)
+	assert.EqualValues(t, 3, upd1.LeasingForAddress[0].OutBefore)
+	assert.EqualValues(t, 15, upd1.LeasingForAddress[0].InAfter)
+	require.Len(t, upd1.Scripts, 1)
+	assert.Equal(t, []byte{1}, upd1.Scripts[0].Before)
+	assert.Equal(t, []byte{2}, upd1.Scripts[0].After)
+	require.Len(t, upd1.Assets, 1)
+	asset := upd1.Assets[0]
+	assert.Equal(t, "asset", asset.Before.Name)
+	assert.EqualValues(t, 1000, asset.Before.Volume)
+	assert.Equal(t, []byte{3}, asset.Before.ScriptInfo.Script)
+	assert.EqualValues(t, 7, asset.Before.ScriptInfo.Complexity)
+	assert.Equal(t, "asset", asset.After.Name)
+	assert.Equal(t, []byte{4}, asset.After.ScriptInfo.Script)
+	assert.EqualValues(t, 42, asset.After.ScriptInfo.Complexity)
+	require.Len(t, upd1.IndividualLeases, 1)
+	assert.Equal(t, events.StateUpdate_LeaseUpdate_ACTIVE, upd1.IndividualLeases[0].StatusAfter)
+	assert.Equal(t, []byte{1}, upd1.IndividualLeases[0].OriginTransactionId)
+
+	upd2, err := b.build([]byte{2}, []proto.AtomicSnapshot{
+		&proto.WavesBalanceSnapshot{Address: addr, Balance: 70},
+		&proto.DataEntriesSnapshot{Address: addr, DataEntries: proto.DataEntries{
+			&proto.DeleteDataEntry{Key: "key"},
+		}},
+		&proto.CancelledLeaseSnapshot{LeaseID: leaseID},
+		&proto.LeaseBalanceSnapshot{Address: addr, LeaseIn: 5, LeaseOut: 3},
+		&proto.AccountScriptSnapshot{SenderPublicKey: pk},
+	})
+	require.NoError(t, err)
+	require.Len(t, upd2.Balances, 1)
+	assert.EqualValues(t, 50, upd2.Balances[0].AmountBefore)
+	assert.EqualValues(t, 70, upd2.Balances[0].AmountAfter.Amount)
+	require.Len(t, upd2.DataEntries, 1)
+	assert.EqualValues(t, 1, upd2.DataEntries[0].DataEntryBefore.GetIntValue())
+	assert.Nil(t, upd2.DataEntries[0].DataEntry.Value)
+	require.Len(t, upd2.IndividualLeases, 1)
+	lease := upd2.IndividualLeases[0]
+	assert.Equal(t, events.StateUpdate_LeaseUpdate_INACTIVE, lease.StatusAfter)
+	assert.EqualValues(t, 10, lease.Amount)
+	assert.Equal(t, sender.Bytes(), lease.Sender)
+	assert.Equal(t, addr.Bytes(), lease.Recipient)
+	require.Len(t, upd2.LeasingForAddress, 1)
+	assert.EqualValues(t, 15, upd2.LeasingForAddress[0].InBefore)
+	assert.EqualValues(t, 5, upd2.LeasingForAddress[0].InAfter)
+	require.Len(t, upd2.Scripts, 1)
+	assert.Equal(t, []byte{2}, upd2.Scripts[0].Before)
+	assert.Empty(t, upd2.Scripts[0].After)
+}
+
+func TestStateUpdateBuilderStateError(t *testing.T) {
+	addr := proto.MustAddressFromString("3PAWwWa6GbwcJaFzwqXQN5KQm7H96Y7SHTQ")
+	st := state.NewMockState(t)
+	st.EXPECT().WavesBalanceAtHeight(proto.NewRecipientFromAddress(addr), proto.Height(9)).
+		Return(0, stateerr.NewStateError(stateerr.InvalidInputError, errors.New("invalid height"))).Once()
+	b := newStateUpdateBuilder(st, proto.MainNetScheme, 10)
+	_, err := b.build([]byte{1}, []proto.AtomicSnapshot{
+		&proto.WavesBalanceSnapshot{Address: addr, Balance: 50},
+	})
+	require.Error(t, err)
+	assert.True(t, stateerr.IsInvalidInput(err))
+	assert.Equal(t, codes.OutOfRange, status.Code(blockchainUpdatesError(err)))
+}
+
+func TestCheckUpdatesAvailable(t *testing.T) {
+	st := state.NewMockState(t)
+	st.EXPECT().RollbackMinHeight().Return(100, nil).Times(4)
+	assert.NoError(t, checkUpdatesAvailable(st, 1)) // Genesis block doesn't need the previous state.
+	assert.Equal(t, codes.OutOfRange, status.Code(checkUpdatesAvailable(st, 2)))
+	assert.Equal(t, codes.OutOfRange, status.Code(checkUpdatesAvailable(st, 100)))
+	assert.NoError(t, checkUpdatesAvailable(st, 101))
+}
+
+func TestTopBlockUpdateMicroBlockRollback(t *testing.T) {
+	_, pk, err := crypto.GenerateKeyPair([]byte("sender"))
+	require.NoError(t, err)
+	waves := proto.NewOptionalAssetWaves()
+	rcp := proto.NewRecipientFromAddress(proto.MustAddressFromString("3PAWwWa6GbwcJaFzwqXQN5KQm7H96Y7SHTQ"))
+	txs := make(proto.Transactions, 3)
+	ids := make([][]byte, len(txs))
+	for i := range txs {
+		tx := proto.NewUnsignedTransferWithProofs(3, pk, waves, waves, 100, uint64(i+1), 100000, rcp, nil)
+		require.NoError(t, tx.GenerateID(proto.MainNetScheme))
+		txs[i] = tx
+		ids[i], err = tx.GetID(proto.MainNetScheme)
+		require.NoError(t, err)
+	}
+	parent := proto.NewBlockIDFromDigest(crypto.Digest{1})
+	sentID := proto.NewBlockIDFromDigest(crypto.Digest{2})
+	newID := proto.NewBlockIDFromDigest(crypto.Digest{3})
+	sub := newUpdatesSubscription(10, 0)
+	sub.push(sentBlock{id: sentID, parent: parent, txIDs: ids})
+
+	st := state.NewMockState(t)
+	st.EXPECT().HeightToBlockID(proto.Height(10)).Return(newID, nil).Once()
+	st.EXPECT().BlockByHeight(proto.Height(10)).
+		Return(&proto.Block{BlockHeader: proto.BlockHeader{Parent: parent}, Transactions: txs[:1]}, nil).Once()
+	s := &Server{scheme: proto.MainNetScheme}
+	upd, err := s.topBlockUpdate(st, sub, 10)
+	require.NoError(t, err)
+	assert.Equal(t, newID.Bytes(), upd.Id)
+	assert.EqualValues(t, 10, upd.Height)
+	rollback := upd.GetRollback()
+	require.NotNil(t, rollback)
+	assert.Equal(t, events.BlockchainUpdated_Rollback_MICROBLOCK, rollback.Type)
+	assert.Equal(t, ids[1:], rollback.RemovedTransactionIds)
+	require.Len(t, sub.sent, 1)
+	assert.Equal(t, newID, sub.sent[0].id)
+	assert.Equal(t, ids[:1], sub.sent[0].txIDs)
+	assert.EqualValues(t, 11, sub.next)
+}
@@ -30,8 +30,9 @@ import (
 )
 
 const (
-	sleepTime = 2 * time.Second
-	utxSize   = 1000
+	sleepTime  = 2 * time.Second
+	utxSize    = 1000
+	testAPIKey = "test-api-key"
 )
 
 var (
@@ -95,12 +96,16 @@ func stateWithCustomGenesis(t *testing.T, genesisPath string) state.State {
 }
 
 func createTestNetWallet(t *testing.T) types.EmbeddedWallet {
+	return createWallet(t, proto.TestNetScheme)
+}
+
+func createWallet(t *testing.T, scheme proto.Scheme) types.EmbeddedWallet {
 	w := wallet.NewWallet()
 	decoded, err := base58.Decode(seed)
 	require.NoError(t, err)
 	err = w.AddAccountSeed(decoded)
 	require.NoError(t, err)
-	return wallet.NewEmbeddedWallet(nil, w, proto.TestNetScheme)
+	return wallet.NewEmbeddedWallet(nil, w, scheme)
 }
 
 func connectAutoClose(t *testing.T, addr string) *grpc.ClientConn {
@@ -130,7 +135,7 @@ func newTestState(t *testing.T, amend bool, params state.StateParams, settings *
 
 func TestMain(m *testing.M) {
 	var err error
-	server, err = NewServer(services.Services{Scheme: proto.MainNetScheme})
+	server, err = NewServer(services.Services{Scheme: proto.MainNetScheme}, testAPIKey, nil)
 	if err != nil {
 		log.Fatalf("Failed to create new gRPC server: %v", err)
 	}
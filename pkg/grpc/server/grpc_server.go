@@ -2,17 +2,22 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"log/slog"
 	"net"
 	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
+	eventsgrpc "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events/grpc"
 	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
 	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/node/readiness"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/services"
 	"github.com/wavesplatform/gowaves/pkg/state"
@@ -22,6 +27,8 @@ import (
 
 const (
 	DefaultMaxConnections = 128
+	// healthCheckInterval is the interval of the node readiness checks that update the health status.
+	healthCheckInterval = 5 * time.Second
 )
 
 type Server struct {
@@ -31,10 +38,16 @@ type Server struct {
 	wallet     types.EmbeddedWallet
 	services   services.Services
 	grpcServer *grpc.Server
+	health     *health.Server
 }
 
 type RunOptions struct {
 	MaxConnections int
+	// TLSConfig enables TLS if not nil, the config should support "h2" application protocol.
+	TLSConfig *tls.Config
+	// ReadinessOpts enable the node readiness checks that set the status of health checking service,
+	// if nil the status is always SERVING.
+	ReadinessOpts *readiness.Options
 }
 
 func DefaultRunOptions() *RunOptions {
@@ -43,9 +56,20 @@ func DefaultRunOptions() *RunOptions {
 	}
 }
 
-func NewServer(services services.Services) (*Server, error) {
+// NewServer creates gRPC API server. The apiKey is required to call privileged methods like Sign,
+// such methods are disabled if the apiKey is empty. If opts is nil the default interceptors are used.
+func NewServer(services services.Services, apiKey string, opts *InterceptorOptions) (*Server, error) {
+	if opts == nil {
+		opts = DefaultInterceptorOptions()
+	}
+	serverOpts, err := opts.serverOptions(apiKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gRPC interceptors")
+	}
 	s := &Server{}
-	s.grpcServer = createGRPCServerWithHandlers(s)
+	s.grpcServer = createGRPCServerWithHandlers(s, serverOpts...)
+	s.health = health.NewServer()
+	healthgrpc.RegisterHealthServer(s.grpcServer, s.health)
 	s.services = services
 	if err := s.initServer(services.State, services.UtxPool, services.Wallet); err != nil {
 		return nil, err
@@ -53,18 +77,20 @@ func NewServer(services services.Services) (*Server, error) {
 	return s, nil
 }
 
-func createGRPCServerWithHandlers(handlers GrpcHandlers) *grpc.Server {
-	grpcServer := grpc.NewServer(
+func createGRPCServerWithHandlers(handlers GrpcHandlers, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			MinTime:             10 * time.Second,
 			PermitWithoutStream: true,
 		}),
-	)
+	}, opts...)
+	grpcServer := grpc.NewServer(opts...)
 	g.RegisterAccountsApiServer(grpcServer, handlers)
 	g.RegisterAssetsApiServer(grpcServer, handlers)
 	g.RegisterBlockchainApiServer(grpcServer, handlers)
 	g.RegisterBlocksApiServer(grpcServer, handlers)
 	g.RegisterTransactionsApiServer(grpcServer, handlers)
+	eventsgrpc.RegisterBlockchainUpdatesApiServer(grpcServer, handlers)
 	reflection.Register(grpcServer) // Register reflection service on gRPC server.
 	return grpcServer
 }
@@ -93,6 +119,11 @@ func (s *Server) Run(ctx context.Context, address string, opts *RunOptions) erro
 			"MaxConnections", opts.MaxConnections)
 	}
 
+	if opts.TLSConfig != nil {
+		conn = tls.NewListener(conn, opts.TLSConfig)
+		slog.Debug("TLS is enabled for gRPC API")
+	}
+
 	defer func(conn net.Listener) {
 		clErr := conn.Close()
 		if clErr != nil && !errors.Is(clErr, net.ErrClosed) {
@@ -105,12 +136,45 @@ func (s *Server) Run(ctx context.Context, address string, opts *RunOptions) erro
 		slog.Info("Shutting down gRPC server...")
 		s.Stop()
 	}()
+	if opts.ReadinessOpts != nil {
+		go s.runHealthChecks(ctx, opts.ReadinessOpts)
+	}
 	slog.Info("Starting gRPC server", "address", address)
 	return s.Serve(conn)
 }
 
+// runHealthChecks periodically checks the node readiness and updates the overall health status.
+func (s *Server) runHealthChecks(ctx context.Context, opts *readiness.Options) {
+	checker := readiness.NewChecker(s.services, opts)
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		s.checkHealth(checker)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) checkHealth(checker *readiness.Checker) {
+	servingStatus := healthgrpc.HealthCheckResponse_SERVING
+	r, err := checker.Check()
+	switch {
+	case err != nil:
+		slog.Warn("Failed to check node readiness", logging.Error(err))
+		servingStatus = healthgrpc.HealthCheckResponse_NOT_SERVING
+	case !r.Ready:
+		slog.Debug("Node is not ready", slog.Any("reasons", r.Reasons))
+		servingStatus = healthgrpc.HealthCheckResponse_NOT_SERVING
+	}
+	s.health.SetServingStatus("", servingStatus)
+}
+
 // Stop calls underlying gRPC server stop method.
 func (s *Server) Stop() {
+	s.health.Shutdown()
 	s.grpcServer.Stop()
 }
 
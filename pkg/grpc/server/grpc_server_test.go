@@ -0,0 +1,51 @@
+package server
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/wavesplatform/gowaves/pkg/node/fsm"
+	"github.com/wavesplatform/gowaves/pkg/node/peers"
+	"github.com/wavesplatform/gowaves/pkg/node/readiness"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func TestHealthCheck(t *testing.T) {
+	ctx := withAutoCancel(t, t.Context())
+	cl := healthgrpc.NewHealthClient(connectAutoClose(t, grpcTestAddr))
+	res, err := cl.Check(ctx, &healthgrpc.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthgrpc.HealthCheckResponse_SERVING, res.Status)
+}
+
+func TestCheckHealth(t *testing.T) {
+	st := state.NewMockState(t)
+	st.EXPECT().Height().Return(proto.Height(1), nil)
+	st.EXPECT().CurrentScore().Return(big.NewInt(100), nil)
+	pm := peers.NewMockPeerManager(t)
+	pm.EXPECT().EachConnected(mock.Anything).Return()
+	status := services.NewFSMStatus()
+	svs := services.Services{Scheme: proto.TestNetScheme, State: st, Peers: pm, FSMStatus: status}
+	s, err := NewServer(svs, "", nil)
+	require.NoError(t, err)
+	checker := readiness.NewChecker(svs, &readiness.Options{MaxHeightLag: 1})
+
+	status.Set(fsm.SyncStateName)
+	s.checkHealth(checker)
+	res, err := s.health.Check(t.Context(), &healthgrpc.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthgrpc.HealthCheckResponse_NOT_SERVING, res.Status)
+
+	status.Set(fsm.NGStateName)
+	s.checkHealth(checker)
+	res, err = s.health.Check(t.Context(), &healthgrpc.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthgrpc.HealthCheckResponse_SERVING, res.Status)
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/throttled/throttled/v2"
+	"github.com/throttled/throttled/v2/store/memstore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/util/ratelimit"
+)
+
+// InterceptorOptions configure the chain of interceptors applied to every call of gRPC API.
+type InterceptorOptions struct {
+	// PrivilegedMethods are the full names of methods that require the API key.
+	PrivilegedMethods []string
+	// RateLimiterOpts configure the limit of calls per peer, nil disables rate limiting.
+	RateLimiterOpts *ratelimit.Options
+	// CollectMetrics enables Prometheus metrics of calls duration and errors.
+	CollectMetrics bool
+	// LogRequests enables access log of served calls.
+	LogRequests bool
+}
+
+func DefaultInterceptorOptions() *InterceptorOptions {
+	return &InterceptorOptions{
+		PrivilegedMethods: []string{signFullMethodName}, // Broadcast stays public as on Scala node
+		RateLimiterOpts:   nil,
+		CollectMetrics:    true,
+		LogRequests:       false,
+	}
+}
+
+// serverOptions creates the chains of unary and stream interceptors. Interceptors are applied in the order:
+// access logging, metrics, rate limiting and authentication, so the rejected calls are logged and measured too.
+func (o *InterceptorOptions) serverOptions(apiKey string) ([]grpc.ServerOption, error) {
+	var (
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+	)
+	if o.LogRequests {
+		l := newAccessLogger(slog.Default())
+		unary = append(unary, l.unaryInterceptor)
+		stream = append(stream, l.streamInterceptor)
+	}
+	if o.CollectMetrics {
+		unary = append(unary, metricsUnaryInterceptor)
+		stream = append(stream, metricsStreamInterceptor)
+	}
+	if o.RateLimiterOpts != nil {
+		rl, err := newPeerRateLimiter(o.RateLimiterOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create rate limiter")
+		}
+		unary = append(unary, rl.unaryInterceptor)
+		stream = append(stream, rl.streamInterceptor)
+	}
+	auth, err := newAPIKeyAuth(apiKey, o.PrivilegedMethods...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create API key authenticator")
+	}
+	unary = append(unary, auth.unaryInterceptor)
+	stream = append(stream, auth.streamInterceptor)
+	return []grpc.ServerOption{grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...)}, nil
+}
+
+// peerAddress returns the host of the calling peer or an empty string if it's unknown.
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// peerRateLimiter limits the number of calls from one peer with the same GCRA token bucket as REST API does.
+// A stream counts as a single call.
+type peerRateLimiter struct {
+	limiter throttled.RateLimiterCtx
+}
+
+func newPeerRateLimiter(opts *ratelimit.Options) (*peerRateLimiter, error) {
+	store, err := memstore.NewCtx(opts.MemoryCacheSize)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create memstore with capacity %d", opts.MemoryCacheSize)
+	}
+	quota := throttled.RateQuota{
+		MaxRate:  throttled.PerSec(opts.MaxRequestsPerSecond),
+		MaxBurst: opts.MaxBurst,
+	}
+	limiter, err := throttled.NewGCRARateLimiterCtx(store, quota)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create rate limiter")
+	}
+	return &peerRateLimiter{limiter: limiter}, nil
+}
+
+func (l *peerRateLimiter) check(ctx context.Context) error {
+	limited, res, err := l.limiter.RateLimitCtx(ctx, peerAddress(ctx), 1)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if limited {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %s", res.RetryAfter)
+	}
+	return nil
+}
+
+func (l *peerRateLimiter) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if err := l.check(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (l *peerRateLimiter) streamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := l.check(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func observeCall(method string, begin time.Time, err error) {
+	code := status.Code(err)
+	metricGrpcRequestDuration.WithLabelValues(method, code.String()).Observe(time.Since(begin).Seconds())
+	if code != codes.OK {
+		metricGrpcErrors.WithLabelValues(method, code.String()).Inc()
+	}
+}
+
+func metricsUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	begin := time.Now()
+	resp, err := handler(ctx, req)
+	observeCall(info.FullMethod, begin, err)
+	return resp, err
+}
+
+func metricsStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	begin := time.Now()
+	err := handler(srv, ss)
+	observeCall(info.FullMethod, begin, err)
+	return err
+}
+
+// accessLogger logs every served call with its duration, status code and the address of the peer.
+type accessLogger struct {
+	l *slog.Logger
+}
+
+func newAccessLogger(l *slog.Logger) *accessLogger {
+	return &accessLogger{l: l}
+}
+
+func (a *accessLogger) log(ctx context.Context, method string, begin time.Time, err error) {
+	attrs := []any{
+		slog.String("method", method),
+		slog.Duration("duration", time.Since(begin)),
+		slog.String("code", status.Code(err).String()),
+		slog.String("remote_addr", peerAddress(ctx)),
+	}
+	if err != nil {
+		attrs = append(attrs, logging.Error(err))
+	}
+	a.l.Info("ServedGrpcRequest", attrs...)
+}
+
+func (a *accessLogger) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	begin := time.Now()
+	resp, err := handler(ctx, req)
+	a.log(ctx, info.FullMethod, begin, err)
+	return resp, err
+}
+
+func (a *accessLogger) streamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	begin := time.Now()
+	err := handler(srv, ss)
+	a.log(ss.Context(), info.FullMethod, begin, err)
+	return err
+}
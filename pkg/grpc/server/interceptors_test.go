@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/util/ratelimit"
+)
+
+func serveMockHandlers(t *testing.T, h GrpcHandlers, opts *InterceptorOptions) *grpc.ClientConn {
+	serverOpts, err := opts.serverOptions(testAPIKey)
+	require.NoError(t, err)
+	gRPCServer := createGRPCServerWithHandlers(h, serverOpts...)
+	lis, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	go func() {
+		_ = gRPCServer.Serve(lis)
+	}()
+	t.Cleanup(gRPCServer.Stop)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	return conn
+}
+
+func TestInterceptorsAuth(t *testing.T) {
+	h := NewMockGrpcHandlers(t)
+	h.EXPECT().Sign(mock.Anything, mock.Anything).Return(&pb.SignedTransaction{}, nil).Once()
+	h.EXPECT().Broadcast(mock.Anything, mock.Anything).Return(&pb.SignedTransaction{}, nil).Once()
+	cl := g.NewTransactionsApiClient(serveMockHandlers(t, h, DefaultInterceptorOptions()))
+
+	_, err := cl.Sign(t.Context(), &g.SignRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	authCtx := metadata.AppendToOutgoingContext(t.Context(), apiKeyMetadataKey, testAPIKey)
+	_, err = cl.Sign(authCtx, &g.SignRequest{})
+	assert.NoError(t, err)
+	// Broadcast is public by default, but its dry-run requires the API key.
+	_, err = cl.Broadcast(t.Context(), &pb.SignedTransaction{})
+	assert.NoError(t, err)
+	dryRunCtx := metadata.AppendToOutgoingContext(t.Context(), dryRunMetadataKey, "true")
+	_, err = cl.Broadcast(dryRunCtx, &pb.SignedTransaction{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestInterceptorsRateLimiter(t *testing.T) {
+	h := NewMockGrpcHandlers(t)
+	h.EXPECT().GetUnconfirmed(mock.Anything, mock.Anything).Return(nil).Once()
+	h.EXPECT().GetStatuses(mock.Anything, mock.Anything).Return(nil).Once()
+	opts := DefaultInterceptorOptions()
+	opts.RateLimiterOpts = &ratelimit.Options{MemoryCacheSize: 16, MaxRequestsPerSecond: 1, MaxBurst: 1}
+	cl := g.NewTransactionsApiClient(serveMockHandlers(t, h, opts))
+
+	// Burst of two calls is allowed, the third one is rejected regardless of the method.
+	statuses, err := cl.GetStatuses(t.Context(), &g.TransactionsByIdRequest{})
+	require.NoError(t, err)
+	_, err = statuses.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+	unconfirmed, err := cl.GetUnconfirmed(t.Context(), &g.TransactionsRequest{})
+	require.NoError(t, err)
+	_, err = unconfirmed.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+	statuses, err = cl.GetStatuses(t.Context(), &g.TransactionsByIdRequest{})
+	require.NoError(t, err)
+	_, err = statuses.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestInterceptorsMetricsAndLogs(t *testing.T) {
+	buf := new(bytes.Buffer)
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(buf, nil)))
+	t.Cleanup(func() {
+		slog.SetDefault(defaultLogger)
+	})
+
+	h := NewMockGrpcHandlers(t)
+	opts := DefaultInterceptorOptions()
+	opts.LogRequests = true
+	cl := g.NewTransactionsApiClient(serveMockHandlers(t, h, opts))
+
+	errorsBefore := testutil.ToFloat64(metricGrpcErrors.WithLabelValues(signFullMethodName, "Unauthenticated"))
+	_, err := cl.Sign(t.Context(), &g.SignRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	errorsAfter := testutil.ToFloat64(metricGrpcErrors.WithLabelValues(signFullMethodName, "Unauthenticated"))
+	assert.Equal(t, errorsBefore+1, errorsAfter)
+
+	logs := buf.String()
+	assert.Contains(t, logs, "ServedGrpcRequest")
+	assert.Contains(t, logs, "method="+signFullMethodName)
+	assert.Contains(t, logs, "code=Unauthenticated")
+	assert.Contains(t, logs, "remote_addr=127.0.0.1")
+}
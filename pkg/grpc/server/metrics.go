@@ -0,0 +1,34 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const grpcAPIMetricsNamespace = "grpc_api"
+
+var (
+	metricGrpcRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: grpcAPIMetricsNamespace,
+			Name:      "request_duration",
+			Help:      "Node gRPC API calls duration in seconds by method and status code",
+		},
+		[]string{"method", "code"},
+	)
+
+	metricGrpcErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: grpcAPIMetricsNamespace,
+			Name:      "errors",
+			Help:      "Node gRPC API calls finished with error by method and status code",
+		},
+		[]string{"method", "code"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricGrpcRequestDuration,
+		metricGrpcErrors,
+	)
+}
@@ -9,6 +9,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	"github.com/wavesplatform/gowaves/pkg/grpc/generated/waves"
+	grpc0 "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events/grpc"
 	"github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -484,6 +485,142 @@ func (_c *MockGrpcHandlers_GetBlockRange_Call) RunAndReturn(run func(blockRangeR
 	return _c
 }
 
+// GetBlockUpdate provides a mock function for the type MockGrpcHandlers
+func (_mock *MockGrpcHandlers) GetBlockUpdate(context1 context.Context, getBlockUpdateRequest *grpc0.GetBlockUpdateRequest) (*grpc0.GetBlockUpdateResponse, error) {
+	ret := _mock.Called(context1, getBlockUpdateRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockUpdate")
+	}
+
+	var r0 *grpc0.GetBlockUpdateResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *grpc0.GetBlockUpdateRequest) (*grpc0.GetBlockUpdateResponse, error)); ok {
+		return returnFunc(context1, getBlockUpdateRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *grpc0.GetBlockUpdateRequest) *grpc0.GetBlockUpdateResponse); ok {
+		r0 = returnFunc(context1, getBlockUpdateRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*grpc0.GetBlockUpdateResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *grpc0.GetBlockUpdateRequest) error); ok {
+		r1 = returnFunc(context1, getBlockUpdateRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGrpcHandlers_GetBlockUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockUpdate'
+type MockGrpcHandlers_GetBlockUpdate_Call struct {
+	*mock.Call
+}
+
+// GetBlockUpdate is a helper method to define mock.On call
+//   - context1 context.Context
+//   - getBlockUpdateRequest *grpc0.GetBlockUpdateRequest
+func (_e *MockGrpcHandlers_Expecter) GetBlockUpdate(context1 interface{}, getBlockUpdateRequest interface{}) *MockGrpcHandlers_GetBlockUpdate_Call {
+	return &MockGrpcHandlers_GetBlockUpdate_Call{Call: _e.mock.On("GetBlockUpdate", context1, getBlockUpdateRequest)}
+}
+
+func (_c *MockGrpcHandlers_GetBlockUpdate_Call) Run(run func(context1 context.Context, getBlockUpdateRequest *grpc0.GetBlockUpdateRequest)) *MockGrpcHandlers_GetBlockUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *grpc0.GetBlockUpdateRequest
+		if args[1] != nil {
+			arg1 = args[1].(*grpc0.GetBlockUpdateRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGrpcHandlers_GetBlockUpdate_Call) Return(getBlockUpdateResponse *grpc0.GetBlockUpdateResponse, err error) *MockGrpcHandlers_GetBlockUpdate_Call {
+	_c.Call.Return(getBlockUpdateResponse, err)
+	return _c
+}
+
+func (_c *MockGrpcHandlers_GetBlockUpdate_Call) RunAndReturn(run func(context1 context.Context, getBlockUpdateRequest *grpc0.GetBlockUpdateRequest) (*grpc0.GetBlockUpdateResponse, error)) *MockGrpcHandlers_GetBlockUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlockUpdatesRange provides a mock function for the type MockGrpcHandlers
+func (_mock *MockGrpcHandlers) GetBlockUpdatesRange(context1 context.Context, getBlockUpdatesRangeRequest *grpc0.GetBlockUpdatesRangeRequest) (*grpc0.GetBlockUpdatesRangeResponse, error) {
+	ret := _mock.Called(context1, getBlockUpdatesRangeRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockUpdatesRange")
+	}
+
+	var r0 *grpc0.GetBlockUpdatesRangeResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *grpc0.GetBlockUpdatesRangeRequest) (*grpc0.GetBlockUpdatesRangeResponse, error)); ok {
+		return returnFunc(context1, getBlockUpdatesRangeRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *grpc0.GetBlockUpdatesRangeRequest) *grpc0.GetBlockUpdatesRangeResponse); ok {
+		r0 = returnFunc(context1, getBlockUpdatesRangeRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*grpc0.GetBlockUpdatesRangeResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *grpc0.GetBlockUpdatesRangeRequest) error); ok {
+		r1 = returnFunc(context1, getBlockUpdatesRangeRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGrpcHandlers_GetBlockUpdatesRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockUpdatesRange'
+type MockGrpcHandlers_GetBlockUpdatesRange_Call struct {
+	*mock.Call
+}
+
+// GetBlockUpdatesRange is a helper method to define mock.On call
+//   - context1 context.Context
+//   - getBlockUpdatesRangeRequest *grpc0.GetBlockUpdatesRangeRequest
+func (_e *MockGrpcHandlers_Expecter) GetBlockUpdatesRange(context1 interface{}, getBlockUpdatesRangeRequest interface{}) *MockGrpcHandlers_GetBlockUpdatesRange_Call {
+	return &MockGrpcHandlers_GetBlockUpdatesRange_Call{Call: _e.mock.On("GetBlockUpdatesRange", context1, getBlockUpdatesRangeRequest)}
+}
+
+func (_c *MockGrpcHandlers_GetBlockUpdatesRange_Call) Run(run func(context1 context.Context, getBlockUpdatesRangeRequest *grpc0.GetBlockUpdatesRangeRequest)) *MockGrpcHandlers_GetBlockUpdatesRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *grpc0.GetBlockUpdatesRangeRequest
+		if args[1] != nil {
+			arg1 = args[1].(*grpc0.GetBlockUpdatesRangeRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGrpcHandlers_GetBlockUpdatesRange_Call) Return(getBlockUpdatesRangeResponse *grpc0.GetBlockUpdatesRangeResponse, err error) *MockGrpcHandlers_GetBlockUpdatesRange_Call {
+	_c.Call.Return(getBlockUpdatesRangeResponse, err)
+	return _c
+}
+
+func (_c *MockGrpcHandlers_GetBlockUpdatesRange_Call) RunAndReturn(run func(context1 context.Context, getBlockUpdatesRangeRequest *grpc0.GetBlockUpdatesRangeRequest) (*grpc0.GetBlockUpdatesRangeResponse, error)) *MockGrpcHandlers_GetBlockUpdatesRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCumulativeScore provides a mock function for the type MockGrpcHandlers
 func (_mock *MockGrpcHandlers) GetCumulativeScore(context1 context.Context, empty *emptypb.Empty) (*grpc.ScoreResponse, error) {
 	ret := _mock.Called(context1, empty)
@@ -1290,3 +1427,60 @@ func (_c *MockGrpcHandlers_Sign_Call) RunAndReturn(run func(context1 context.Con
 	_c.Call.Return(run)
 	return _c
 }
+
+// Subscribe provides a mock function for the type MockGrpcHandlers
+func (_mock *MockGrpcHandlers) Subscribe(subscribeRequest *grpc0.SubscribeRequest, blockchainUpdatesApi_SubscribeServer grpc0.BlockchainUpdatesApi_SubscribeServer) error {
+	ret := _mock.Called(subscribeRequest, blockchainUpdatesApi_SubscribeServer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*grpc0.SubscribeRequest, grpc0.BlockchainUpdatesApi_SubscribeServer) error); ok {
+		r0 = returnFunc(subscribeRequest, blockchainUpdatesApi_SubscribeServer)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGrpcHandlers_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockGrpcHandlers_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - subscribeRequest *grpc0.SubscribeRequest
+//   - blockchainUpdatesApi_SubscribeServer grpc0.BlockchainUpdatesApi_SubscribeServer
+func (_e *MockGrpcHandlers_Expecter) Subscribe(subscribeRequest interface{}, blockchainUpdatesApi_SubscribeServer interface{}) *MockGrpcHandlers_Subscribe_Call {
+	return &MockGrpcHandlers_Subscribe_Call{Call: _e.mock.On("Subscribe", subscribeRequest, blockchainUpdatesApi_SubscribeServer)}
+}
+
+func (_c *MockGrpcHandlers_Subscribe_Call) Run(run func(subscribeRequest *grpc0.SubscribeRequest, blockchainUpdatesApi_SubscribeServer grpc0.BlockchainUpdatesApi_SubscribeServer)) *MockGrpcHandlers_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *grpc0.SubscribeRequest
+		if args[0] != nil {
+			arg0 = args[0].(*grpc0.SubscribeRequest)
+		}
+		var arg1 grpc0.BlockchainUpdatesApi_SubscribeServer
+		if args[1] != nil {
+			arg1 = args[1].(grpc0.BlockchainUpdatesApi_SubscribeServer)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGrpcHandlers_Subscribe_Call) Return(err error) *MockGrpcHandlers_Subscribe_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGrpcHandlers_Subscribe_Call) RunAndReturn(run func(subscribeRequest *grpc0.SubscribeRequest, blockchainUpdatesApi_SubscribeServer grpc0.BlockchainUpdatesApi_SubscribeServer) error) *MockGrpcHandlers_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -1,12 +1,17 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/errs"
@@ -16,7 +21,19 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
 	"github.com/wavesplatform/gowaves/pkg/util/iterators"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+)
+
+const (
+	// dryRunMetadataKey is the name of the gRPC metadata entry which turns Broadcast into the transaction dry-run.
+	dryRunMetadataKey = "dry-run"
+	// complexityMetadataKey is the name of the trailer entry with the complexity spent by the dry-run transaction.
+	complexityMetadataKey = "complexity"
+	// snapshotMetadataKey is the name of the trailer entry with the serialized TransactionStateSnapshot
+	// of the dry-run transaction.
+	snapshotMetadataKey = "snapshot-bin"
 )
 
 type getTransactionsHandler struct {
@@ -74,12 +91,70 @@ func (s *Server) GetTransactions(req *g.TransactionsRequest, srv g.TransactionsA
 	return nil
 }
 
+// GetTransactionSnapshots streams snapshots of the confirmed transactions with the requested IDs.
+// Unknown transactions are skipped.
 func (s *Server) GetTransactionSnapshots(
 	req *g.TransactionSnapshotsRequest,
 	srv g.TransactionsApi_GetTransactionSnapshotsServer,
 ) error {
-	unimplementedServer := g.UnimplementedTransactionsApiServer{} // TODO: implement GetTransactionSnapshots
-	return unimplementedServer.GetTransactionSnapshots(req, srv)
+	lookup := &txSnapshotsLookup{st: s.state, scheme: s.scheme}
+	for _, id := range req.TransactionIds {
+		snapshot, err := lookup.snapshotByID(id)
+		if err != nil {
+			if stateerr.IsNotFound(err) {
+				continue
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+		res := &g.TransactionSnapshotResponse{Id: id, Snapshot: snapshot}
+		if err := srv.Send(res); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+	return nil
+}
+
+// txSnapshotsLookup finds snapshots of transactions by their IDs.
+// It keeps the block and snapshots of the last requested height, because transactions are often requested in
+// batches from the same block.
+type txSnapshotsLookup struct {
+	st        state.StateInfo
+	scheme    proto.Scheme
+	height    proto.Height
+	block     *proto.Block
+	snapshots proto.BlockSnapshot
+}
+
+func (l *txSnapshotsLookup) snapshotByID(id []byte) (*pb.TransactionStateSnapshot, error) {
+	height, err := l.st.TransactionHeightByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if l.block == nil || l.height != height {
+		block, bErr := l.st.BlockByHeight(height)
+		if bErr != nil {
+			return nil, errors.Wrapf(bErr, "failed to get block at height %d", height)
+		}
+		snapshots, sErr := l.st.SnapshotsAtHeight(height)
+		if sErr != nil {
+			return nil, errors.Wrapf(sErr, "failed to get block snapshots at height %d", height)
+		}
+		l.height, l.block, l.snapshots = height, block, snapshots
+	}
+	for i, tx := range l.block.Transactions {
+		txID, idErr := tx.GetID(l.scheme)
+		if idErr != nil {
+			return nil, errors.Wrap(idErr, "failed to get transaction ID")
+		}
+		if !bytes.Equal(txID, id) {
+			continue
+		}
+		if i >= len(l.snapshots.TxSnapshots) {
+			return nil, errors.Errorf("no snapshot for transaction %d in block at height %d", i, height)
+		}
+		return proto.TxSnapshotsToProtobuf(l.snapshots.TxSnapshots[i])
+	}
+	return nil, errors.Errorf("transaction not found in block at height %d", height)
 }
 
 type getStateChangesHandler struct {
@@ -220,8 +295,102 @@ func (s *Server) GetUnconfirmed(req *g.TransactionsRequest, srv g.TransactionsAp
 	return nil
 }
 
-func (s *Server) Sign(ctx context.Context, req *g.SignRequest) (*pb.SignedTransaction, error) {
-	return g.UnimplementedTransactionsApiServer{}.Sign(ctx, req)
+// Sign builds the transaction from the request, fills its missing sender public key, chain ID, timestamp and fee,
+// and signs it with the wallet key of the requested signer. The missing fee is set to the minimal fee in the fee asset
+// of the transaction, calculated by the same rules as on transaction validation.
+// If the signer public key is not specified, the sender public key is used instead.
+func (s *Server) Sign(_ context.Context, req *g.SignRequest) (*pb.SignedTransaction, error) {
+	if req.Transaction == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty transaction")
+	}
+	if s.wallet == nil {
+		return nil, status.Error(codes.FailedPrecondition, "wallet is not available")
+	}
+	txProto, ok := protobuf.Clone(req.Transaction).(*pb.Transaction) // don't modify the request
+	if !ok {
+		return nil, status.Error(codes.Internal, "failed to clone transaction")
+	}
+	signerPKBytes := req.SignerPublicKey
+	if len(signerPKBytes) == 0 {
+		signerPKBytes = txProto.SenderPublicKey
+	}
+	signerPK, err := crypto.NewPublicKeyFromBytes(signerPKBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid signer public key: %v", err)
+	}
+	if len(txProto.SenderPublicKey) == 0 {
+		txProto.SenderPublicKey = signerPK.Bytes()
+	}
+	if txProto.ChainId == 0 {
+		txProto.ChainId = int32(s.scheme)
+	}
+	if txProto.Timestamp == 0 {
+		txProto.Timestamp = int64(proto.NewTimestampFromTime(s.now()))
+	}
+	if txProto.Fee == nil {
+		txProto.Fee = &pb.Amount{}
+	}
+	c := proto.ProtobufConverter{FallbackChainID: s.scheme}
+	tx, err := c.Transaction(txProto)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if txProto.Fee.Amount == 0 {
+		if tx, err = s.fillMinFee(c, txProto, tx); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.wallet.SignTransactionWith(signerPK, tx); err != nil {
+		if errors.Is(err, wallet.ErrPublicKeyNotFound) {
+			return nil, status.Errorf(codes.InvalidArgument, "no private key for public key %s in wallet", signerPK)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	lightNodeActivated, err := s.state.IsActivated(int16(settings.LightNode))
+	if err != nil {
+		return nil, apiError(err)
+	}
+	vp := proto.TransactionValidationParams{Scheme: s.scheme, CheckVersion: lightNodeActivated}
+	if _, err := tx.Validate(vp); err != nil {
+		return nil, apiError(err)
+	}
+	res, err := tx.ToProtobufSigned(s.scheme)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return res, nil
+}
+
+// fillMinFee sets the minimal fee in the fee asset of the transaction by the rules of transaction validation.
+// The fee of some transactions depends on their size, so the fee is recalculated until it stops changing.
+func (s *Server) fillMinFee(
+	c proto.ProtobufConverter,
+	txProto *pb.Transaction,
+	tx proto.Transaction,
+) (proto.Transaction, error) {
+	for {
+		_, fee, err := s.state.MinFee(tx)
+		if err != nil {
+			if stateerr.IsInvalidInput(err) {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			return nil, apiError(err)
+		}
+		if uint64(txProto.Fee.Amount) >= fee {
+			return tx, nil
+		}
+		txProto.Fee.Amount = int64(fee)
+		if tx, err = c.Transaction(txProto); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+}
+
+func (s *Server) now() time.Time {
+	if s.services.Time != nil {
+		return s.services.Time.Now()
+	}
+	return time.Now()
 }
 
 func (s *Server) Broadcast(ctx context.Context, tx *pb.SignedTransaction) (out *pb.SignedTransaction, err error) {
@@ -239,6 +408,9 @@ func (s *Server) Broadcast(ctx context.Context, tx *pb.SignedTransaction) (out *
 	if err != nil {
 		return nil, apiError(err)
 	}
+	if isDryRun(ctx) {
+		return s.dryRun(ctx, tx, t)
+	}
 	err = broadcast(ctx, s.services.InternalChannel, t)
 	if err != nil {
 		return nil, apiError(err)
@@ -246,6 +418,52 @@ func (s *Server) Broadcast(ctx context.Context, tx *pb.SignedTransaction) (out *
 	return tx, nil
 }
 
+// dryRun validates the transaction against the current state without putting it into the UTX pool.
+// Spent complexity and the transaction snapshot are returned in the trailer of the response.
+func (s *Server) dryRun(
+	ctx context.Context,
+	txProto *pb.SignedTransaction,
+	tx proto.Transaction,
+) (*pb.SignedTransaction, error) {
+	if s.services.State == nil {
+		return nil, status.Error(codes.FailedPrecondition, "state is not available")
+	}
+	verifySignature := len(txProto.GetProofs()) != 0 || len(txProto.GetEthereumTransaction()) != 0
+	snapshot, complexity, err := s.services.State.DryRunTx(tx, proto.NewTimestampFromTime(s.now()), verifySignature)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	snapshotProto, err := proto.TxSnapshotsToProtobuf(snapshot)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	snapshotBytes, err := protobuf.Marshal(snapshotProto)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	trailer := metadata.Pairs(
+		complexityMetadataKey, strconv.FormatUint(complexity, 10),
+		snapshotMetadataKey, string(snapshotBytes),
+	)
+	if err := grpc.SetTrailer(ctx, trailer); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return txProto, nil
+}
+
+func isDryRun(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(dryRunMetadataKey)
+	if len(values) == 0 {
+		return false
+	}
+	dryRun, err := strconv.ParseBool(values[0])
+	return err == nil && dryRun
+}
+
 func apiError(err error) error {
 	err = errors.Cause(err)
 	switch e := err.(type) {
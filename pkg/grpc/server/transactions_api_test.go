@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log"
 	"net"
@@ -14,7 +15,9 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	pb "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves"
@@ -22,7 +25,9 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/libs/ntptime"
 	"github.com/wavesplatform/gowaves/pkg/miner/utxpool"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
 	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
 )
 
 func TestGetTransactions(t *testing.T) {
@@ -161,6 +166,39 @@ func TestGetStatuses(t *testing.T) {
 	assert.Equal(t, io.EOF, err)
 }
 
+func TestGetTransactionSnapshots(t *testing.T) {
+	params := defaultStateParams()
+	st := newTestState(t, true, params, settings.MustMainNetSettings())
+	ctx := withAutoCancel(t, context.Background())
+	err := server.initServer(st, nil, createTestNetWallet(t))
+	require.NoError(t, err)
+
+	conn := connectAutoClose(t, grpcTestAddr)
+	cl := g.NewTransactionsApiClient(conn)
+
+	genesis, err := st.BlockByHeight(1)
+	require.NoError(t, err)
+	blockSnapshots, err := st.SnapshotsAtHeight(1)
+	require.NoError(t, err)
+	require.Len(t, blockSnapshots.TxSnapshots, len(genesis.Transactions))
+	const txNum = 2
+	id, err := genesis.Transactions[txNum].GetID(server.scheme)
+	require.NoError(t, err)
+	expected, err := proto.TxSnapshotsToProtobuf(blockSnapshots.TxSnapshots[txNum])
+	require.NoError(t, err)
+
+	req := &g.TransactionSnapshotsRequest{TransactionIds: [][]byte{{2}, id}} // first ID is unknown
+	stream, err := cl.GetTransactionSnapshots(ctx, req)
+	require.NoError(t, err)
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, id, res.Id)
+	assert.True(t, protobuf.Equal(expected, res.Snapshot))
+	assert.NotEmpty(t, res.Snapshot.Balances)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
 func TestGetUnconfirmed(t *testing.T) {
 	bs := settings.MustMainNetSettings()
 	params := defaultStateParams()
@@ -251,9 +289,13 @@ func TestGetUnconfirmed(t *testing.T) {
 
 func TestSign(t *testing.T) {
 	params := defaultStateParams()
-	st := newTestState(t, true, params, settings.MustMainNetSettings())
+	sets := settings.MustMainNetSettings()
+	// Activate the features required by the transactions versions to calculate their fees.
+	sets.PreactivatedFeatures = []int16{int16(settings.MassTransfer), int16(settings.SmartAccounts),
+		int16(settings.BlockV5)}
+	st := newTestState(t, true, params, sets)
 	ctx := withAutoCancel(t, context.Background())
-	sch := createTestNetWallet(t)
+	sch := createWallet(t, proto.MainNetScheme)
 
 	err := server.initServer(st, nil, sch)
 	require.NoError(t, err)
@@ -265,21 +307,69 @@ func TestSign(t *testing.T) {
 	addr, err := proto.NewAddressFromString("3PAWwWa6GbwcJaFzwqXQN5KQm7H96Y7SHTQ")
 	require.NoError(t, err)
 	waves := proto.NewOptionalAssetWaves()
-	tx := proto.NewUnsignedTransferWithSig(pk, waves, waves, 100, 1, 100, proto.NewRecipientFromAddress(addr), []byte("attachment"))
-	err = tx.GenerateID(server.scheme)
-	require.NoError(t, err)
-	require.NoError(t, err)
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, waves, waves, 100, 1, 0,
+		proto.NewRecipientFromAddress(addr), []byte("attachment"))
 	txProto, err := tx.ToProtobuf(server.scheme)
 	require.NoError(t, err)
 
 	cl := g.NewTransactionsApiClient(conn)
 	req := &g.SignRequest{Transaction: txProto, SignerPublicKey: pk.Bytes()}
-	_, err = cl.Sign(ctx, req)
-	require.Error(t, err)
-	s, ok := status.FromError(err)
-	require.True(t, ok)
-	require.Equal(t, codes.Unimplemented, s.Code())
-	require.Equal(t, "method Sign not implemented", s.Message())
+
+	t.Run("no-api-key", func(t *testing.T) {
+		_, sErr := cl.Sign(ctx, req)
+		require.Error(t, sErr)
+		s, ok := status.FromError(sErr)
+		require.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, s.Code())
+	})
+	t.Run("invalid-api-key", func(t *testing.T) {
+		_, sErr := cl.Sign(metadata.AppendToOutgoingContext(ctx, apiKeyMetadataKey, "invalid"), req)
+		require.Error(t, sErr)
+		s, ok := status.FromError(sErr)
+		require.True(t, ok)
+		assert.Equal(t, codes.Unauthenticated, s.Code())
+	})
+	t.Run("success", func(t *testing.T) {
+		authCtx := metadata.AppendToOutgoingContext(ctx, apiKeyMetadataKey, testAPIKey)
+		res, sErr := cl.Sign(authCtx, req)
+		require.NoError(t, sErr)
+		c := proto.ProtobufConverter{FallbackChainID: server.scheme}
+		signed, cErr := c.SignedTransaction(res)
+		require.NoError(t, cErr)
+		transfer, ok := signed.(*proto.TransferWithProofs)
+		require.True(t, ok)
+		assert.Equal(t, uint64(state.FeeUnit), transfer.Fee) // fee is filled with minimal value
+		assert.Equal(t, uint64(100), transfer.Timestamp)
+		verified, vErr := transfer.Verify(server.scheme, pk)
+		require.NoError(t, vErr)
+		assert.True(t, verified)
+	})
+	t.Run("mass-transfer-fee", func(t *testing.T) {
+		authCtx := metadata.AppendToOutgoingContext(ctx, apiKeyMetadataKey, testAPIKey)
+		rcp := proto.NewRecipientFromAddress(addr)
+		transfers := []proto.MassTransferEntry{{Recipient: rcp, Amount: 1}, {Recipient: rcp, Amount: 2},
+			{Recipient: rcp, Amount: 3}}
+		mtx := proto.NewUnsignedMassTransferWithProofs(2, pk, waves, transfers, 0, 100, nil)
+		mtxProto, mErr := mtx.ToProtobuf(server.scheme)
+		require.NoError(t, mErr)
+		res, sErr := cl.Sign(authCtx, &g.SignRequest{Transaction: mtxProto, SignerPublicKey: pk.Bytes()})
+		require.NoError(t, sErr)
+		c := proto.ProtobufConverter{FallbackChainID: server.scheme}
+		signed, cErr := c.SignedTransaction(res)
+		require.NoError(t, cErr)
+		massTransfer, ok := signed.(*proto.MassTransferWithProofs)
+		require.True(t, ok)
+		assert.Equal(t, uint64(3*state.FeeUnit), massTransfer.Fee) // base fee and the fee for each two transfers
+	})
+	t.Run("unknown-signer", func(t *testing.T) {
+		authCtx := metadata.AppendToOutgoingContext(ctx, apiKeyMetadataKey, testAPIKey)
+		otherReq := &g.SignRequest{Transaction: txProto, SignerPublicKey: make([]byte, crypto.PublicKeySize)}
+		_, sErr := cl.Sign(authCtx, otherReq)
+		require.Error(t, sErr)
+		s, ok := status.FromError(sErr)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+	})
 }
 
 func TestBroadcast(t *testing.T) {
@@ -310,3 +400,65 @@ func TestBroadcast(t *testing.T) {
 	_, err = cl.Broadcast(ctx, &pb.SignedTransaction{})
 	require.NoError(t, err)
 }
+
+func TestBroadcastDryRun(t *testing.T) {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("test"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	waves := proto.NewOptionalAssetWaves()
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, waves, waves, 1000, 100, 100000,
+		proto.NewRecipientFromAddress(addr), nil,
+	)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+	txProto, err := tx.ToProtobufSigned(proto.TestNetScheme)
+	require.NoError(t, err)
+	snapshot := []proto.AtomicSnapshot{
+		&proto.TransactionStatusSnapshot{Status: proto.TransactionSucceeded},
+		&proto.WavesBalanceSnapshot{Address: addr, Balance: 900},
+	}
+
+	st := state.NewMockState(t)
+	st.EXPECT().IsActivated(int16(settings.LightNode)).Return(false, nil)
+	st.EXPECT().DryRunTx(mock.Anything, mock.Anything, true).Return(snapshot, 42, nil).Once()
+	st.EXPECT().DryRunTx(mock.Anything, mock.Anything, true).Return(nil, 0, errors.New("invalid tx")).Once()
+	s, err := NewServer(services.Services{Scheme: proto.TestNetScheme, State: st}, testAPIKey, nil)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer lis.Close()
+	go func() {
+		if sErr := s.grpcServer.Serve(lis); sErr != nil {
+			log.Fatalf("server.Run(): %v\n", sErr)
+		}
+	}()
+	defer s.grpcServer.Stop()
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		clErr := conn.Close()
+		require.NoError(t, clErr)
+	}()
+	cl := g.NewTransactionsApiClient(conn)
+	ctx := metadata.AppendToOutgoingContext(t.Context(), dryRunMetadataKey, "true", apiKeyMetadataKey, testAPIKey)
+
+	var trailer metadata.MD
+	res, err := cl.Broadcast(ctx, txProto, grpc.Trailer(&trailer))
+	require.NoError(t, err)
+	assert.True(t, protobuf.Equal(txProto, res))
+	assert.Equal(t, []string{"42"}, trailer.Get(complexityMetadataKey))
+	values := trailer.Get(snapshotMetadataKey)
+	require.Len(t, values, 1)
+	var snapshotProto pb.TransactionStateSnapshot
+	require.NoError(t, protobuf.Unmarshal([]byte(values[0]), &snapshotProto))
+	actual, err := proto.TxSnapshotsFromProtobuf(proto.TestNetScheme, &snapshotProto)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, snapshot, actual)
+
+	_, err = cl.Broadcast(ctx, txProto)
+	s2, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, s2.Code())
+	assert.Equal(t, "invalid tx", s2.Message())
+}
@@ -0,0 +1,9 @@
+package keyvalue
+
+//go:generate go run github.com/dmarkham/enumer@v1.6.1 -type Backend -trimprefix Backend -text -output backend_string.go
+type Backend byte
+
+const (
+	BackendLevelDB Backend = iota
+	BackendPebble
+)
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"io"
 	"log/slog"
+	"math"
 	"os"
 	"runtime/debug"
 
@@ -18,6 +19,7 @@ import (
 type BloomFilter interface {
 	notInTheSet(data []byte) (bool, error)
 	add(data []byte) error
+	stats() BloomFilterStats
 	Params() BloomFilterParams
 	io.WriterTo
 }
@@ -33,6 +35,20 @@ type BloomFilterParams struct {
 	DisableBloomFilter bool
 }
 
+// BloomFilterStats describes the filling of the bloom filter and its efficiency.
+type BloomFilterStats struct {
+	Enabled bool
+	// Capacity and ConfiguredFalsePositiveProbability are the parameters the filter is created with.
+	Capacity                           uint64
+	ConfiguredFalsePositiveProbability float64
+	// Additions is the number of added keys including repeatedly added ones.
+	Additions uint64
+	// FilledRatio is the share of set bits of the filter.
+	FilledRatio float64
+	// EstimatedFalsePositiveProbability is calculated by the current filling of the filter.
+	EstimatedFalsePositiveProbability float64
+}
+
 func NewBloomFilterParams(capacity uint64, probability float64, store store) BloomFilterParams {
 	return BloomFilterParams{
 		BloomFilterCapacity:      capacity,
@@ -54,6 +70,18 @@ func (bf *bloomFilter) Params() BloomFilterParams {
 	return bf.params
 }
 
+func (bf *bloomFilter) stats() BloomFilterStats {
+	filled := bf.filter.PreciseFilledRatio()
+	return BloomFilterStats{
+		Enabled:                            true,
+		Capacity:                           bf.params.BloomFilterCapacity,
+		ConfiguredFalsePositiveProbability: bf.params.FalsePositiveProbability,
+		Additions:                          bf.filter.N(),
+		FilledRatio:                        filled,
+		EstimatedFalsePositiveProbability:  math.Pow(filled, float64(bf.filter.K())),
+	}
+}
+
 func (bf *bloomFilter) ReadFrom(r io.Reader) (n int64, err error) {
 	return bf.filter.ReadFrom(r)
 }
@@ -16,6 +16,10 @@ func (bloomFilterStub) notInTheSet([]byte) (bool, error) {
 	return false, nil
 }
 
+func (a bloomFilterStub) stats() BloomFilterStats {
+	return BloomFilterStats{}
+}
+
 func (a bloomFilterStub) Params() BloomFilterParams {
 	return a.params
 }
@@ -0,0 +1,56 @@
+package keyvalue
+
+import (
+	"crypto/rand"
+
+	"github.com/pkg/errors"
+)
+
+const probeKeySize = 1 + 32 // prefix byte and a digest, the typical key of the state
+
+// BloomFilterStats returns the parameters and the filling of the bloom filter.
+func (k *KeyVal) BloomFilterStats() BloomFilterStats {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.filter.stats()
+}
+
+// ProbeBloomFilter looks up the given number of random keys, which are absent in the database with overwhelming
+// probability, and returns the number of keys passed by the bloom filter.
+// The lookups bypass the cache.
+func (k *KeyVal) ProbeBloomFilter(count int) (uint64, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if !k.filter.stats().Enabled {
+		return 0, errors.New("bloom filter is disabled")
+	}
+	key := make([]byte, probeKeySize)
+	var falsePositives uint64
+	for range count {
+		if _, err := rand.Read(key); err != nil {
+			return 0, errors.Wrap(err, "failed to generate random key")
+		}
+		notInTheSet, err := k.filter.notInTheSet(key)
+		if err != nil {
+			return 0, err
+		}
+		if notInTheSet {
+			continue
+		}
+		has, err := k.db.has(key)
+		if err != nil {
+			return 0, err
+		}
+		if !has {
+			falsePositives++
+		}
+	}
+	return falsePositives, nil
+}
+
+// Compact compacts the underlying storage for the keys with the given prefix, or the whole storage if
+// the prefix is empty. Deleted and overwritten values are discarded and the data is rewritten to the last level.
+// The database stays available for reads and writes during the compaction.
+func (k *KeyVal) Compact(prefix []byte) error {
+	return k.db.compact(prefix)
+}
@@ -0,0 +1,75 @@
+package keyvalue
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilterStats(t *testing.T) {
+	const (
+		count    = 1000
+		probes   = 10000
+		fpp      = 0.01
+		fppLimit = 0.03
+	)
+	params := KeyValParams{
+		CacheParams:       CacheParams{cacheSize},
+		BloomFilterParams: BloomFilterParams{count, fpp, NoOpStore{}, false},
+	}
+	kv, err := NewKeyVal(t.TempDir(), params)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, kv.Close()) }()
+	batch, err := kv.NewBatch()
+	require.NoError(t, err)
+	for i := range count {
+		batch.Put(fmt.Appendf(nil, "key%d", i), []byte{1})
+	}
+	require.NoError(t, kv.Flush(batch))
+
+	stats := kv.BloomFilterStats()
+	assert.True(t, stats.Enabled)
+	assert.Equal(t, uint64(count), stats.Additions)
+	assert.InDelta(t, fpp, stats.EstimatedFalsePositiveProbability, fpp)
+
+	fp, err := kv.ProbeBloomFilter(probes)
+	require.NoError(t, err)
+	assert.Less(t, float64(fp)/probes, fppLimit)
+
+	params.DisableBloomFilter = true
+	disabled, err := NewKeyVal(t.TempDir(), params)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, disabled.Close()) }()
+	assert.False(t, disabled.BloomFilterStats().Enabled)
+	_, err = disabled.ProbeBloomFilter(probes)
+	assert.Error(t, err)
+}
+
+func TestCompact(t *testing.T) {
+	for _, backend := range BackendValues() {
+		t.Run(backend.String(), func(t *testing.T) {
+			params := KeyValParams{
+				CacheParams:       CacheParams{cacheSize},
+				BloomFilterParams: BloomFilterParams{n, falsePositiveProbability, NoOpStore{}, true},
+				Backend:           backend,
+			}
+			kv, err := NewKeyVal(t.TempDir(), params)
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, kv.Close()) }()
+			require.NoError(t, kv.Compact(nil), "empty database")
+			for i := range 100 {
+				require.NoError(t, kv.Put([]byte{byte(i % 2), byte(i)}, []byte{byte(i)}))
+			}
+			require.NoError(t, kv.Delete([]byte{0, 0}))
+			require.NoError(t, kv.Compact([]byte{1}))
+			require.NoError(t, kv.Compact(nil))
+			v, err := kv.Get([]byte{1, 99})
+			require.NoError(t, err)
+			assert.Equal(t, []byte{99}, v)
+			_, err = kv.Get([]byte{0, 0})
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
@@ -71,20 +71,6 @@ func (b *batch) addToCache(cache *freecache.Cache) {
 	b.mu.Unlock()
 }
 
-func (b *batch) leveldbBatch() *leveldb.Batch {
-	b.mu.Lock()
-	leveldbBatch := new(leveldb.Batch)
-	for _, pair := range b.pairs {
-		if pair.deletion {
-			leveldbBatch.Delete(pair.key)
-		} else {
-			leveldbBatch.Put(pair.key, pair.value)
-		}
-	}
-	b.mu.Unlock()
-	return leveldbBatch
-}
-
 func (b *batch) Reset() {
 	b.mu.Lock()
 	b.pairs = nil
@@ -92,10 +78,11 @@ func (b *batch) Reset() {
 }
 
 type KeyVal struct {
-	db     *leveldb.DB
-	filter BloomFilter
-	cache  *freecache.Cache
-	mu     *sync.RWMutex
+	db      storage
+	backend Backend
+	filter  BloomFilter
+	cache   *freecache.Cache
+	mu      *sync.RWMutex
 }
 
 func initBloomFilter(kv *KeyVal, params BloomFilterParams) error {
@@ -141,53 +128,22 @@ type KeyValParams struct {
 	CompactionTotalSize    int
 	OpenFilesCacheCapacity int
 	CompressionAlgo        CompressionAlgo
+	Backend                Backend
 }
 
 func NewKeyVal(path string, params KeyValParams) (*KeyVal, error) {
-	currentFDs, err := fdlimit.CurrentFDs()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get current file descriptors count")
-	}
-	openFilesCacheCapacity := params.OpenFilesCacheCapacity
-
-	slog.Debug("Parameter leveldb.opt.Options.OpenFilesCacheCapacity has been evaluated",
-		"value", openFilesCacheCapacity, "CurrentFDs", currentFDs)
-
-	dbOptions := &opt.Options{
-		WriteBuffer:            params.WriteBuffer,
-		CompactionTableSize:    params.CompactionTableSize,
-		CompactionTotalSize:    params.CompactionTotalSize,
-		OpenFilesCacheCapacity: openFilesCacheCapacity,
-		Strict:                 opt.DefaultStrict | opt.StrictManifest,
-		Compression:            opt.Compression(params.CompressionAlgo),
-	}
-	db, err := openLevelDB(path, dbOptions)
+	db, err := openStorage(path, params)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to open key-value db by path '%s'", path)
 	}
 	cache := freecache.NewCache(params.CacheSize)
-	kv := &KeyVal{db: db, cache: cache, mu: &sync.RWMutex{}}
+	kv := &KeyVal{db: db, backend: params.Backend, cache: cache, mu: &sync.RWMutex{}}
 	if bfErr := initBloomFilter(kv, params.BloomFilterParams); bfErr != nil {
 		return nil, errors.Wrap(bfErr, "failed to initialize bloom filter")
 	}
 	return kv, nil
 }
 
-func openLevelDB(path string, dbOptions *opt.Options) (*leveldb.DB, error) {
-	db, err := leveldb.OpenFile(path, dbOptions)
-	if err == nil { // If no error, then the database is opened successfully.
-		return db, nil
-	}
-	slog.Warn("Failed to open leveldb DB", slog.String("path", path), logging.Error(err))
-	slog.Info("Attempting to recover corrupted leveldb, may take a while...", "path", path)
-	db, rErr := leveldb.RecoverFile(path, dbOptions)
-	if rErr != nil {
-		return nil, errors.Wrap(rErr, "failed to recover leveldb")
-	}
-	slog.Info("Recovered leveldb db", "path", path)
-	return db, nil
-}
-
 func (k *KeyVal) NewBatch() (Batch, error) {
 	return &batch{mu: &sync.Mutex{}}, nil
 }
@@ -215,12 +171,12 @@ func (k *KeyVal) Get(key []byte) ([]byte, error) {
 			return nil, ErrNotFound
 		}
 	}
-	val, err := k.db.Get(key, nil)
-	if errors.Is(err, leveldb.ErrNotFound) {
-		return nil, ErrNotFound
+	val, err := k.db.get(key)
+	if err != nil {
+		return nil, err
 	}
 	k.addToCache(key, val)
-	return val, err
+	return val, nil
 }
 
 func (k *KeyVal) Has(key []byte) (bool, error) {
@@ -238,20 +194,20 @@ func (k *KeyVal) Has(key []byte) (bool, error) {
 	if _, err := k.cache.Get(key); err == nil {
 		return true, nil
 	}
-	return k.db.Has(key, nil)
+	return k.db.has(key)
 }
 
 func (k *KeyVal) Delete(key []byte) error {
 	k.mu.Lock()
 	defer k.mu.Unlock()
 	k.cache.Del(key)
-	return k.db.Delete(key, nil)
+	return k.db.delete(key)
 }
 
 func (k *KeyVal) Put(key, val []byte) error {
 	k.mu.Lock()
 	defer k.mu.Unlock()
-	if err := k.db.Put(key, val, nil); err != nil {
+	if err := k.db.put(key, val); err != nil {
 		return err
 	}
 	if err := k.filter.add(key); err != nil {
@@ -266,9 +222,12 @@ func (k *KeyVal) Flush(b1 Batch) error {
 	defer k.mu.Unlock()
 	b, ok := b1.(*batch)
 	if !ok {
-		return errors.New("can't convert Batch interface to leveldb batch")
+		return errors.New("can't convert Batch interface to key-value batch")
 	}
-	if err := k.db.Write(b.leveldbBatch(), nil); err != nil {
+	b.mu.Lock()
+	err := k.db.write(b.pairs, false)
+	b.mu.Unlock()
+	if err != nil {
 		return err
 	}
 	b.addToCache(k.cache)
@@ -282,11 +241,7 @@ func (k *KeyVal) Flush(b1 Batch) error {
 func (k *KeyVal) NewKeyIterator(prefix []byte) (Iterator, error) {
 	k.mu.RLock()
 	defer k.mu.RUnlock()
-	if prefix != nil {
-		return k.db.NewIterator(util.BytesPrefix(prefix), nil), nil
-	} else {
-		return k.db.NewIterator(nil, nil), nil
-	}
+	return k.db.newIterator(prefix)
 }
 
 func (k *KeyVal) Close() error {
@@ -299,5 +254,139 @@ func (k *KeyVal) Close() error {
 	} else {
 		slog.Info("Bloom filter stored successfully")
 	}
-	return k.db.Close()
+	return k.db.close()
+}
+
+type leveldbStorage struct {
+	db *leveldb.DB
+}
+
+func openLevelDBStorage(path string, params KeyValParams) (*leveldbStorage, error) {
+	currentFDs, err := fdlimit.CurrentFDs()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current file descriptors count")
+	}
+	openFilesCacheCapacity := params.OpenFilesCacheCapacity
+
+	slog.Debug("Parameter leveldb.opt.Options.OpenFilesCacheCapacity has been evaluated",
+		"value", openFilesCacheCapacity, "CurrentFDs", currentFDs)
+
+	dbOptions := &opt.Options{
+		WriteBuffer:            params.WriteBuffer,
+		CompactionTableSize:    params.CompactionTableSize,
+		CompactionTotalSize:    params.CompactionTotalSize,
+		OpenFilesCacheCapacity: openFilesCacheCapacity,
+		Strict:                 opt.DefaultStrict | opt.StrictManifest,
+		Compression:            opt.Compression(params.CompressionAlgo),
+	}
+	db, err := openLevelDB(path, dbOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &leveldbStorage{db: db}, nil
+}
+
+func openLevelDB(path string, dbOptions *opt.Options) (*leveldb.DB, error) {
+	db, err := leveldb.OpenFile(path, dbOptions)
+	if err == nil { // If no error, then the database is opened successfully.
+		return db, nil
+	}
+	slog.Warn("Failed to open leveldb DB", slog.String("path", path), logging.Error(err))
+	slog.Info("Attempting to recover corrupted leveldb, may take a while...", "path", path)
+	db, rErr := leveldb.RecoverFile(path, dbOptions)
+	if rErr != nil {
+		return nil, errors.Wrap(rErr, "failed to recover leveldb")
+	}
+	slog.Info("Recovered leveldb db", "path", path)
+	return db, nil
+}
+
+func createLevelDBStorage(path string, algo CompressionAlgo) (*leveldbStorage, error) {
+	db, err := leveldb.OpenFile(path, &opt.Options{
+		ErrorIfExist: true,
+		Strict:       opt.DefaultStrict | opt.StrictManifest,
+		Compression:  opt.Compression(algo),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &leveldbStorage{db: db}, nil
+}
+
+func (s *leveldbStorage) get(key []byte) ([]byte, error) {
+	val, err := s.db.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return val, err
+}
+
+func (s *leveldbStorage) has(key []byte) (bool, error) {
+	return s.db.Has(key, nil)
+}
+
+func (s *leveldbStorage) put(key, val []byte) error {
+	return s.db.Put(key, val, nil)
+}
+
+func (s *leveldbStorage) delete(key []byte) error {
+	return s.db.Delete(key, nil)
+}
+
+func (s *leveldbStorage) write(pairs []pair, sync bool) error {
+	b := new(leveldb.Batch)
+	for _, p := range pairs {
+		if p.deletion {
+			b.Delete(p.key)
+		} else {
+			b.Put(p.key, p.value)
+		}
+	}
+	return s.db.Write(b, &opt.WriteOptions{Sync: sync})
+}
+
+func (s *leveldbStorage) newIterator(prefix []byte) (Iterator, error) {
+	if prefix != nil {
+		return s.db.NewIterator(util.BytesPrefix(prefix), nil), nil
+	}
+	return s.db.NewIterator(nil, nil), nil
+}
+
+func (s *leveldbStorage) newSnapshot() (storageSnapshot, error) {
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get leveldb snapshot")
+	}
+	return &leveldbSnapshot{snap: snap}, nil
+}
+
+func (s *leveldbStorage) compact(prefix []byte) error {
+	return s.db.CompactRange(*util.BytesPrefix(prefix))
+}
+
+func (s *leveldbStorage) close() error {
+	return s.db.Close()
+}
+
+type leveldbSnapshot struct {
+	snap *leveldb.Snapshot
+}
+
+func (s *leveldbSnapshot) get(key []byte) ([]byte, error) {
+	val, err := s.snap.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return val, err
+}
+
+func (s *leveldbSnapshot) newIterator(prefix []byte) (Iterator, error) {
+	if prefix != nil {
+		return s.snap.NewIterator(util.BytesPrefix(prefix), nil), nil
+	}
+	return s.snap.NewIterator(nil, nil), nil
+}
+
+func (s *leveldbSnapshot) release() {
+	s.snap.Release()
 }
@@ -1,9 +1,11 @@
 package keyvalue
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -14,6 +16,14 @@ const (
 )
 
 func TestKeyVal(t *testing.T) {
+	for _, backend := range BackendValues() {
+		t.Run(backend.String(), func(t *testing.T) {
+			testKeyVal(t, backend)
+		})
+	}
+}
+
+func testKeyVal(t *testing.T, backend Backend) {
 	dbDir := t.TempDir()
 	params := KeyValParams{
 		CacheParams:         CacheParams{cacheSize},
@@ -21,6 +31,7 @@ func TestKeyVal(t *testing.T) {
 		WriteBuffer:         writeBuffer,
 		CompactionTableSize: sstableSize,
 		CompactionTotalSize: compactionTotalSize,
+		Backend:             backend,
 	}
 	kv, err := NewKeyVal(dbDir, params)
 	assert.NoError(t, err, "NewKeyVal() failed")
@@ -97,3 +108,55 @@ func TestKeyVal(t *testing.T) {
 	err = iter.Error()
 	assert.NoError(t, err, "iterator error")
 }
+
+func TestKeyValSnapshot(t *testing.T) {
+	for _, backend := range BackendValues() {
+		t.Run(backend.String(), func(t *testing.T) {
+			testKeyValSnapshot(t, backend)
+		})
+	}
+}
+
+func testKeyValSnapshot(t *testing.T, backend Backend) {
+	params := KeyValParams{
+		CacheParams:         CacheParams{cacheSize},
+		BloomFilterParams:   BloomFilterParams{n, falsePositiveProbability, NoOpStore{}, false},
+		WriteBuffer:         writeBuffer,
+		CompactionTableSize: sstableSize,
+		CompactionTotalSize: compactionTotalSize,
+		Backend:             backend,
+	}
+	kv, err := NewKeyVal(t.TempDir(), params)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, kv.Close())
+	})
+	key0, val0 := []byte("key0"), []byte("value0")
+	key1, val1 := []byte("key1"), []byte("value1")
+	require.NoError(t, kv.Put(key0, val0))
+
+	snap, err := kv.NewSnapshot()
+	require.NoError(t, err)
+	defer snap.Release()
+	require.NoError(t, kv.Put(key1, val1))
+	require.NoError(t, kv.Delete(key0))
+
+	v, err := snap.Get(key0)
+	require.NoError(t, err)
+	assert.Equal(t, val0, v)
+	_, err = snap.Get(key1)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	path := filepath.Join(t.TempDir(), "copy")
+	require.NoError(t, snap.SaveTo(t.Context(), path, CompressionDefault))
+	assert.Error(t, snap.SaveTo(t.Context(), path, CompressionDefault), "existing db must not be overwritten")
+	cp, err := NewKeyVal(path, params)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, cp.Close()) }()
+	v, err = cp.Get(key0)
+	require.NoError(t, err)
+	assert.Equal(t, val0, v)
+	has, err := cp.Has(key1)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
@@ -0,0 +1,285 @@
+package keyvalue
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/ccoveille/go-safecast/v2"
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+)
+
+// pebbleLevels is the number of LSM tree levels of Pebble database.
+const pebbleLevels = 7
+
+// pebbleLogger redirects Pebble's own messages to the application log.
+type pebbleLogger struct{}
+
+func (pebbleLogger) Infof(format string, args ...any) {
+	slog.Debug(fmt.Sprintf(format, args...), "component", "pebble")
+}
+
+func (pebbleLogger) Fatalf(format string, args ...any) {
+	slog.Error(fmt.Sprintf(format, args...), "component", "pebble")
+	panic(fmt.Sprintf(format, args...))
+}
+
+func pebbleCompression(algo CompressionAlgo) (pebble.Compression, error) {
+	switch algo {
+	case CompressionDefault, CompressionSnappy:
+		return pebble.SnappyCompression, nil
+	case CompressionNone:
+		return pebble.NoCompression, nil
+	case CompressionZSTD:
+		return pebble.ZstdCompression, nil
+	default:
+		return 0, errors.Errorf("compression algorithm %q is not supported by Pebble backend", algo)
+	}
+}
+
+func pebbleOptions(params KeyValParams, create bool) (*pebble.Options, error) {
+	compression, err := pebbleCompression(params.CompressionAlgo)
+	if err != nil {
+		return nil, err
+	}
+	memTableSize, err := safecast.Convert[uint64](params.WriteBuffer)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid write buffer size")
+	}
+	opts := &pebble.Options{
+		ErrorIfExists:      create,
+		FormatMajorVersion: pebble.FormatNewest,
+		Logger:             pebbleLogger{},
+		MemTableSize:       memTableSize,
+		MaxOpenFiles:       params.OpenFilesCacheCapacity,
+		LBaseMaxBytes:      int64(params.CompactionTotalSize),
+		Levels:             make([]pebble.LevelOptions, pebbleLevels),
+	}
+	// Sizes of tables on the next levels are doubled by Pebble.
+	opts.Levels[0].TargetFileSize = int64(params.CompactionTableSize)
+	for i := range opts.Levels {
+		opts.Levels[i].Compression = compression
+	}
+	return opts, nil
+}
+
+type pebbleStorage struct {
+	db *pebble.DB
+}
+
+func openPebbleStorage(path string, params KeyValParams, create bool) (*pebbleStorage, error) {
+	opts, err := pebbleOptions(params, create)
+	if err != nil {
+		return nil, err
+	}
+	db, err := pebble.Open(path, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open pebble db")
+	}
+	return &pebbleStorage{db: db}, nil
+}
+
+type pebbleReader interface {
+	Get(key []byte) ([]byte, io.Closer, error)
+	NewIter(o *pebble.IterOptions) (*pebble.Iterator, error)
+}
+
+func pebbleGet(r pebbleReader, key []byte) ([]byte, error) {
+	val, closer, err := r.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	res := make([]byte, len(val)) // the value is valid only until the closer is closed
+	copy(res, val)
+	if clErr := closer.Close(); clErr != nil {
+		return nil, clErr
+	}
+	return res, nil
+}
+
+func pebbleNewIterator(r pebbleReader, prefix []byte) (Iterator, error) {
+	var opts *pebble.IterOptions
+	if len(prefix) != 0 {
+		opts = &pebble.IterOptions{LowerBound: prefix, UpperBound: prefixUpperBound(prefix)}
+	}
+	it, err := r.NewIter(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &pebbleIterator{it: it}, nil
+}
+
+// prefixUpperBound returns the smallest key which is greater than all keys with the prefix,
+// or nil if there is no such key.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if c := prefix[i]; c < 0xff {
+			limit := make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			return limit
+		}
+	}
+	return nil
+}
+
+func (s *pebbleStorage) get(key []byte) ([]byte, error) {
+	return pebbleGet(s.db, key)
+}
+
+func (s *pebbleStorage) has(key []byte) (bool, error) {
+	_, err := pebbleGet(s.db, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *pebbleStorage) put(key, val []byte) error {
+	return s.db.Set(key, val, pebble.NoSync)
+}
+
+func (s *pebbleStorage) delete(key []byte) error {
+	return s.db.Delete(key, pebble.NoSync)
+}
+
+func (s *pebbleStorage) write(pairs []pair, sync bool) error {
+	b := s.db.NewBatch()
+	defer func() { _ = b.Close() }()
+	for _, p := range pairs {
+		var err error
+		if p.deletion {
+			err = b.Delete(p.key, nil)
+		} else {
+			err = b.Set(p.key, p.value, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	opts := pebble.NoSync
+	if sync {
+		opts = pebble.Sync
+	}
+	return b.Commit(opts)
+}
+
+func (s *pebbleStorage) newIterator(prefix []byte) (Iterator, error) {
+	return pebbleNewIterator(s.db, prefix)
+}
+
+func (s *pebbleStorage) newSnapshot() (storageSnapshot, error) {
+	return &pebbleSnapshot{snap: s.db.NewSnapshot()}, nil
+}
+
+func (s *pebbleStorage) compact(prefix []byte) error {
+	end := prefixUpperBound(prefix)
+	if end == nil { // the range is open, it's closed by the key following the last one
+		it, err := pebbleNewIterator(s.db, prefix)
+		if err != nil {
+			return err
+		}
+		if !it.Last() {
+			it.Release()
+			return it.Error() // nothing to compact
+		}
+		end = append(SafeKey(it), 0)
+		it.Release()
+		if itErr := it.Error(); itErr != nil {
+			return itErr
+		}
+	}
+	return s.db.Compact(prefix, end, true)
+}
+
+func (s *pebbleStorage) close() error {
+	return s.db.Close()
+}
+
+type pebbleSnapshot struct {
+	snap *pebble.Snapshot
+}
+
+func (s *pebbleSnapshot) get(key []byte) ([]byte, error) {
+	return pebbleGet(s.snap, key)
+}
+
+func (s *pebbleSnapshot) newIterator(prefix []byte) (Iterator, error) {
+	return pebbleNewIterator(s.snap, prefix)
+}
+
+func (s *pebbleSnapshot) release() {
+	if err := s.snap.Close(); err != nil {
+		slog.Warn("Failed to release pebble snapshot", logging.Error(err))
+	}
+}
+
+// pebbleIterator adapts Pebble iterator to the Iterator semantic of LevelDB: freshly created iterator is positioned
+// before the first key, so the first call to Next moves it to the first key and Prev moves it to the last one.
+type pebbleIterator struct {
+	it         *pebble.Iterator
+	positioned bool
+	err        error
+}
+
+func (i *pebbleIterator) Key() []byte {
+	if !i.positioned || !i.it.Valid() {
+		return nil
+	}
+	return i.it.Key()
+}
+
+func (i *pebbleIterator) Value() []byte {
+	if !i.positioned || !i.it.Valid() {
+		return nil
+	}
+	return i.it.Value()
+}
+
+func (i *pebbleIterator) Next() bool {
+	if !i.positioned {
+		return i.First()
+	}
+	return i.it.Next()
+}
+
+func (i *pebbleIterator) Prev() bool {
+	if !i.positioned {
+		return i.Last()
+	}
+	return i.it.Prev()
+}
+
+func (i *pebbleIterator) First() bool {
+	i.positioned = true
+	return i.it.First()
+}
+
+func (i *pebbleIterator) Last() bool {
+	i.positioned = true
+	return i.it.Last()
+}
+
+func (i *pebbleIterator) Error() error {
+	if i.it == nil { // the iterator is released
+		return i.err
+	}
+	return i.it.Error()
+}
+
+func (i *pebbleIterator) Release() {
+	if i.it == nil {
+		return
+	}
+	i.err = i.it.Close()
+	i.it = nil
+}
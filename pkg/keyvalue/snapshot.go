@@ -0,0 +1,62 @@
+package keyvalue
+
+import (
+	"context"
+	stderrs "errors"
+
+	"github.com/pkg/errors"
+)
+
+const snapshotBatchSize = 4 * 1024 * 1024 // 4 MiB of keys and values written to the copy at once
+
+// Snapshot is a consistent read-only view of the database at the moment of its creation.
+// Writes to the database made after the creation are not visible through the snapshot.
+type Snapshot struct {
+	snap    storageSnapshot
+	backend Backend
+}
+
+// NewSnapshot creates the snapshot of the database, it must be released after use.
+func (k *KeyVal) NewSnapshot() (*Snapshot, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	snap, err := k.db.newSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{snap: snap, backend: k.backend}, nil
+}
+
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	return s.snap.get(key)
+}
+
+func (s *Snapshot) NewKeyIterator(prefix []byte) (Iterator, error) {
+	return s.snap.newIterator(prefix)
+}
+
+// SaveTo writes all keys and values of the snapshot to the new database of the same backend at the given path.
+// The path must not exist. The partially written database is left in place if the context is canceled.
+//
+//nolint:nonamedreturns // needs in defer
+func (s *Snapshot) SaveTo(ctx context.Context, path string, algo CompressionAlgo) (retErr error) {
+	db, err := createStorage(path, s.backend, algo)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create key-value db by path '%s'", path)
+	}
+	defer func() {
+		if clErr := db.close(); clErr != nil {
+			retErr = stderrs.Join(retErr, errors.Wrap(clErr, "failed to close key-value db"))
+		}
+	}()
+	iter, err := s.snap.newIterator(nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate over snapshot")
+	}
+	defer iter.Release()
+	return copyPairs(ctx, iter, db)
+}
+
+func (s *Snapshot) Release() {
+	s.snap.release()
+}
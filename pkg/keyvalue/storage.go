@@ -0,0 +1,115 @@
+package keyvalue
+
+import (
+	"context"
+	stderrs "errors"
+
+	"github.com/pkg/errors"
+)
+
+// storage is the on-disk key-value store behind KeyVal, it's implemented by each Backend.
+type storage interface {
+	// get returns ErrNotFound if there is no value for the key.
+	get(key []byte) ([]byte, error)
+	has(key []byte) (bool, error)
+	put(key, val []byte) error
+	delete(key []byte) error
+	// write atomically applies the pairs, sync forces the data to be written to the disk.
+	write(pairs []pair, sync bool) error
+	newIterator(prefix []byte) (Iterator, error)
+	newSnapshot() (storageSnapshot, error)
+	// compact compacts the range of keys with the prefix, the whole storage if the prefix is empty.
+	compact(prefix []byte) error
+	close() error
+}
+
+type storageSnapshot interface {
+	get(key []byte) ([]byte, error)
+	newIterator(prefix []byte) (Iterator, error)
+	release()
+}
+
+// openStorage opens the existing database at path or creates a new one.
+func openStorage(path string, params KeyValParams) (storage, error) {
+	switch params.Backend {
+	case BackendLevelDB:
+		return openLevelDBStorage(path, params)
+	case BackendPebble:
+		return openPebbleStorage(path, params, false)
+	default:
+		return nil, errors.Errorf("unsupported key-value backend %q", params.Backend)
+	}
+}
+
+// createStorage creates a new database at path, it fails if the database already exists.
+func createStorage(path string, backend Backend, algo CompressionAlgo) (storage, error) {
+	switch backend {
+	case BackendLevelDB:
+		return createLevelDBStorage(path, algo)
+	case BackendPebble:
+		return openPebbleStorage(path, KeyValParams{Backend: backend, CompressionAlgo: algo}, true)
+	default:
+		return nil, errors.Errorf("unsupported key-value backend %q", backend)
+	}
+}
+
+// copyPairs writes all keys and values from the iterator to the storage in batches of limited size.
+// The last batch is synced to the disk.
+func copyPairs(ctx context.Context, iter Iterator, dst storage) error {
+	var (
+		pairs []pair
+		size  int
+	)
+	for iter.Next() {
+		pairs = append(pairs, pair{key: SafeKey(iter), value: SafeValue(iter)})
+		size += len(iter.Key()) + len(iter.Value())
+		if size < snapshotBatchSize {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := dst.write(pairs, false); err != nil {
+			return errors.Wrap(err, "failed to write batch")
+		}
+		pairs, size = pairs[:0], 0
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "failed to iterate over source database")
+	}
+	if err := dst.write(pairs, true); err != nil {
+		return errors.Wrap(err, "failed to write batch")
+	}
+	return nil
+}
+
+// Copy writes all keys and values of the database at srcPath to the new database of the given backend at dstPath.
+// The database is copied with the same compression algorithm, the dstPath must not exist.
+//
+//nolint:nonamedreturns // needs in defer
+func Copy(ctx context.Context, srcPath string, src KeyValParams, dstPath string, dst Backend) (retErr error) {
+	from, err := openStorage(srcPath, src)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open key-value db by path '%s'", srcPath)
+	}
+	defer func() {
+		if clErr := from.close(); clErr != nil {
+			retErr = stderrs.Join(retErr, errors.Wrap(clErr, "failed to close source key-value db"))
+		}
+	}()
+	to, err := createStorage(dstPath, dst, src.CompressionAlgo)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create key-value db by path '%s'", dstPath)
+	}
+	defer func() {
+		if clErr := to.close(); clErr != nil {
+			retErr = stderrs.Join(retErr, errors.Wrap(clErr, "failed to close target key-value db"))
+		}
+	}()
+	iter, err := from.newIterator(nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate over source key-value db")
+	}
+	defer iter.Release()
+	return copyPairs(ctx, iter, to)
+}
@@ -0,0 +1,65 @@
+package keyvalue
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopy(t *testing.T) {
+	for _, test := range []struct {
+		src, dst Backend
+	}{
+		{BackendLevelDB, BackendPebble},
+		{BackendPebble, BackendLevelDB},
+	} {
+		t.Run(fmt.Sprintf("%s-%s", test.src, test.dst), func(t *testing.T) {
+			params := KeyValParams{
+				CacheParams:       CacheParams{cacheSize},
+				BloomFilterParams: BloomFilterParams{n, falsePositiveProbability, NoOpStore{}, false},
+				CompressionAlgo:   CompressionZSTD,
+				Backend:           test.src,
+			}
+			srcPath := filepath.Join(t.TempDir(), "src")
+			kv, err := NewKeyVal(srcPath, params)
+			require.NoError(t, err)
+			const count = 1000
+			batch, err := kv.NewBatch()
+			require.NoError(t, err)
+			for i := range count {
+				batch.Put(fmt.Appendf(nil, "key%04d", i), fmt.Appendf(nil, "value%d", i))
+			}
+			require.NoError(t, kv.Flush(batch))
+			require.NoError(t, kv.Close())
+
+			dstPath := filepath.Join(t.TempDir(), "dst")
+			require.NoError(t, Copy(t.Context(), srcPath, params, dstPath, test.dst))
+			assert.Error(t, Copy(t.Context(), srcPath, params, dstPath, test.dst), "existing db must not be overwritten")
+
+			params.Backend = test.dst
+			cp, err := NewKeyVal(dstPath, params)
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, cp.Close()) }()
+			iter, err := cp.NewKeyIterator(nil)
+			require.NoError(t, err)
+			i := 0
+			for iter.Next() {
+				assert.Equal(t, fmt.Appendf(nil, "key%04d", i), iter.Key())
+				assert.Equal(t, fmt.Appendf(nil, "value%d", i), iter.Value())
+				i++
+			}
+			iter.Release()
+			require.NoError(t, iter.Error())
+			assert.Equal(t, count, i)
+		})
+	}
+}
+
+func TestPrefixUpperBound(t *testing.T) {
+	assert.Equal(t, []byte{1, 3}, prefixUpperBound([]byte{1, 2}))
+	assert.Equal(t, []byte{2}, prefixUpperBound([]byte{1, 0xff}))
+	assert.Nil(t, prefixUpperBound([]byte{0xff, 0xff}))
+}
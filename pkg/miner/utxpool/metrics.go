@@ -0,0 +1,43 @@
+package utxpool
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const utxMetricsNamespace = "utx"
+
+// Reasons of transactions removal from the pool or rejection.
+const (
+	reasonPoolFull    = "pool_full"    // evicted by transaction with higher priority or fee per byte
+	reasonExpired     = "expired"      // TTL of transaction is over
+	reasonInvalid     = "invalid"      // transaction became invalid, removed during the pool cleanup
+	reasonSenderCount = "sender_count" // sender's transactions count quota exceeded
+	reasonSenderBytes = "sender_bytes" // sender's transactions size quota exceeded
+)
+
+var (
+	metricUtxEvictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: utxMetricsNamespace,
+			Name:      "evictions",
+			Help:      "Number of transactions removed from UTX pool before mining by reason",
+		},
+		[]string{"reason"},
+	)
+
+	metricUtxRejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: utxMetricsNamespace,
+			Name:      "rejections",
+			Help:      "Number of transactions not accepted to UTX pool because of its limits by reason",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricUtxEvictions,
+		metricUtxRejections,
+	)
+}
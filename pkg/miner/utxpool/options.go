@@ -0,0 +1,84 @@
+package utxpool
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	senderCountKey = "sender-count"
+	senderBytesKey = "sender-bytes"
+	ttlKey         = "ttl"
+	priorityKey    = "priority"
+)
+
+// Options configure the limits and policies of the UTX pool.
+type Options struct {
+	// SenderMaxCount is the maximum number of transactions from one sender in the pool, zero means no limit.
+	SenderMaxCount int
+	// SenderMaxBytes is the maximum total size of transactions from one sender in the pool, zero means no limit.
+	SenderMaxBytes uint64
+	// TTL is the time after which a transaction is removed from the pool, zero means that transactions never expire.
+	TTL time.Duration
+	// PriorityAddresses are the senders whose transactions are mined first, never evicted by other transactions
+	// and not limited by the per sender quotas.
+	PriorityAddresses []proto.WavesAddress
+}
+
+// DefaultOptions returns the options without limits, transactions stay in the pool until they are mined or
+// become invalid.
+func DefaultOptions() Options {
+	return Options{}
+}
+
+// NewOptionsFromString parses UTX pool options given in form of URL query, for example
+// "sender-count=100&sender-bytes=1048576&ttl=1h&priority=<address>&priority=<address>".
+// Missing options are set to defaults.
+func NewOptionsFromString(s string, scheme proto.Scheme) (Options, error) {
+	opts := DefaultOptions()
+	query, err := url.ParseQuery(strings.TrimSpace(s))
+	if err != nil {
+		return Options{}, errors.Wrap(err, "invalid UTX pool options")
+	}
+	if v := query.Get(senderCountKey); v != "" {
+		cnt, cErr := strconv.ParseUint(v, 10, 31)
+		if cErr != nil {
+			return Options{}, errors.Wrapf(cErr, "invalid value for key '%s'", senderCountKey)
+		}
+		opts.SenderMaxCount = int(cnt)
+	}
+	if v := query.Get(senderBytesKey); v != "" {
+		size, sErr := strconv.ParseUint(v, 10, 64)
+		if sErr != nil {
+			return Options{}, errors.Wrapf(sErr, "invalid value for key '%s'", senderBytesKey)
+		}
+		opts.SenderMaxBytes = size
+	}
+	if v := query.Get(ttlKey); v != "" {
+		ttl, tErr := time.ParseDuration(v)
+		if tErr != nil {
+			return Options{}, errors.Wrapf(tErr, "invalid value for key '%s'", ttlKey)
+		}
+		if ttl < 0 {
+			return Options{}, errors.Errorf("negative value for key '%s'", ttlKey)
+		}
+		opts.TTL = ttl
+	}
+	for _, v := range query[priorityKey] {
+		addr, aErr := proto.NewAddressFromString(v)
+		if aErr != nil {
+			return Options{}, errors.Wrapf(aErr, "invalid value for key '%s'", priorityKey)
+		}
+		if ok, vErr := addr.Valid(scheme); !ok {
+			return Options{}, errors.Errorf("invalid priority address '%s': %v", v, vErr)
+		}
+		opts.PriorityAddresses = append(opts.PriorityAddresses, addr)
+	}
+	return opts, nil
+}
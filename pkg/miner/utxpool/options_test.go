@@ -0,0 +1,37 @@
+package utxpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestNewOptionsFromString(t *testing.T) {
+	addr := proto.MustAddressFromString("3PAWwWa6GbwcJaFzwqXQN5KQm7H96Y7SHTQ")
+	for _, test := range []struct {
+		s    string
+		opts Options
+		err  bool
+	}{
+		{s: "", opts: Options{}},
+		{s: "sender-count=10&sender-bytes=2048&ttl=30m", opts: Options{10, 2048, 30 * time.Minute, nil}},
+		{s: "ttl=0&priority=" + addr.String(), opts: Options{PriorityAddresses: []proto.WavesAddress{addr}}},
+		{s: "sender-count=-1", err: true},
+		{s: "sender-bytes=x", err: true},
+		{s: "ttl=-1s", err: true},
+		{s: "priority=xxx", err: true},
+		{s: "priority=3MsNzusNWfDbWbtYRuYLUG4hpGoYj4Wfp4W", err: true}, // testnet address
+	} {
+		opts, err := NewOptionsFromString(test.s, proto.MainNetScheme)
+		if test.err {
+			assert.Error(t, err, test.s)
+			continue
+		}
+		require.NoError(t, err, test.s)
+		assert.Equal(t, test.opts, opts, test.s)
+	}
+}
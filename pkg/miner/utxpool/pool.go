@@ -2,10 +2,12 @@ package utxpool
 
 import (
 	"container/heap"
+	"container/list"
 	"context"
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/mr-tron/base58"
 	"github.com/pkg/errors"
@@ -18,8 +20,23 @@ import (
 )
 
 type heapItem struct {
-	tx    *types.TransactionWithBytes
-	index int // The index of the item in the heap.
+	tx         *types.TransactionWithBytes
+	index      int // The index of the item in the heap.
+	evictIndex int // The index of the item in the eviction heap.
+	id         crypto.Digest
+	sender     proto.WavesAddress
+	priority   bool // Transaction from the sender with priority.
+	feePerByte uint64
+	added      time.Time
+	queued     *list.Element // The element of the queue of transactions in order of addition.
+}
+
+// before reports whether the transaction of the item a should be mined before the transaction of the item b.
+func (a *heapItem) before(b *heapItem) bool {
+	if a.priority != b.priority {
+		return a.priority
+	}
+	return a.feePerByte > b.feePerByte
 }
 
 type transactionsHeap []*heapItem
@@ -27,8 +44,7 @@ type transactionsHeap []*heapItem
 func (a transactionsHeap) Len() int { return len(a) }
 
 func (a transactionsHeap) Less(i, j int) bool {
-	// skip division by zero, check it when we add the transaction
-	return a[i].tx.T.GetFee()/uint64(len(a[i].tx.B)) > a[j].tx.T.GetFee()/uint64(len(a[j].tx.B))
+	return a[i].before(a[j])
 }
 
 func (a transactionsHeap) Swap(i, j int) {
@@ -62,22 +78,91 @@ func (a *transactionsHeap) Pop() any {
 	return item
 }
 
+// evictionHeap keeps the transaction that should be evicted first on top.
+type evictionHeap []*heapItem
+
+func (a evictionHeap) Len() int { return len(a) }
+
+func (a evictionHeap) Less(i, j int) bool {
+	return a[j].before(a[i])
+}
+
+func (a evictionHeap) Swap(i, j int) {
+	a[i], a[j] = a[j], a[i]
+	a[i].evictIndex = i
+	a[j].evictIndex = j
+}
+
+func (a *evictionHeap) Push(x any) {
+	item, ok := x.(*heapItem)
+	if !ok || item == nil {
+		panic(fmt.Sprintf("evictionHeap.Push: unexpected item type %T", x))
+	}
+	if item.evictIndex != -1 {
+		panic("evictionHeap.Push: item already in heap")
+	}
+	item.evictIndex = len(*a)
+	*a = append(*a, item)
+}
+
+func (a *evictionHeap) Pop() any {
+	old := *a
+	n := len(old)
+	if n == 0 {
+		return nil
+	}
+	item := old[n-1]
+	item.evictIndex = -1 // For safety, mark as no longer in the heap.
+	old[n-1] = nil       // Avoid holding stale pointer.
+	*a = old[:n-1]
+	return item
+}
+
+type senderUsage struct {
+	count int
+	bytes uint64
+}
+
 type UtxImpl struct {
 	mu             sync.Mutex
 	transactions   transactionsHeap
-	transactionIds map[crypto.Digest]struct{}
+	eviction       evictionHeap
+	queue          *list.List // Transactions in order of addition, the oldest first.
+	transactionIds map[crypto.Digest]*heapItem
+	senders        map[proto.WavesAddress]*senderUsage
+	priority       map[proto.WavesAddress]struct{}
 	sizeLimit      uint64 // max transaction size in bytes
 	curSize        uint64
 	validator      Validator
 	settings       *settings.BlockchainSettings
+	opts           Options
+	now            func() time.Time
 }
 
 func New(sizeLimit uint64, validator Validator, settings *settings.BlockchainSettings) *UtxImpl {
+	return NewWithOptions(sizeLimit, validator, settings, DefaultOptions())
+}
+
+func NewWithOptions(
+	sizeLimit uint64,
+	validator Validator,
+	settings *settings.BlockchainSettings,
+	opts Options,
+) *UtxImpl {
+	priority := make(map[proto.WavesAddress]struct{}, len(opts.PriorityAddresses))
+	for _, addr := range opts.PriorityAddresses {
+		priority[addr] = struct{}{}
+	}
 	return &UtxImpl{
-		transactionIds: make(map[crypto.Digest]struct{}),
+		queue:          list.New(),
+		transactionIds: make(map[crypto.Digest]*heapItem),
+		senders:        make(map[proto.WavesAddress]*senderUsage),
+		priority:       priority,
 		sizeLimit:      sizeLimit,
 		validator:      validator,
 		settings:       settings,
+		opts:           opts,
+		now:            time.Now,
 	}
 }
 
@@ -113,13 +198,15 @@ func (a *UtxImpl) Clean(ctx context.Context, shouldDrop func(tx proto.Transactio
 		checked++
 	}
 
-	// Now remove the dropped transactions from the heap.
+	// Now remove the dropped transactions from the pool.
 	a.mu.Lock()
 	for _, it := range drop {
-		if it.index >= 0 {
-			heap.Remove(&a.transactions, it.index)
+		if it.index >= 0 { // Transaction is still in the pool.
+			a.remove(it)
+			metricUtxEvictions.WithLabelValues(reasonInvalid).Inc()
 		}
 	}
+	a.expire()
 	a.mu.Unlock()
 	return checked, len(drop)
 }
@@ -166,6 +253,7 @@ func (a *UtxImpl) addWithBytes(st types.UtxPoolValidatorState, tx proto.Transact
 }
 
 // addWithBytesOptValidation has optional tx validation. User is responsible for the validator closure.
+// If the pool is full, transactions that should be mined after the new one are evicted to free space for it.
 func (a *UtxImpl) addWithBytesOptValidation(
 	tx proto.Transaction,
 	b []byte,
@@ -174,9 +262,10 @@ func (a *UtxImpl) addWithBytesOptValidation(
 	if len(b) == 0 {
 		return errors.New("transaction with empty bytes")
 	}
+	size := uint64(len(b))
 	// check if size exceeds limit
-	if a.curSize+uint64(len(b)) > a.sizeLimit {
-		return errors.Errorf("size overflow, curSize: %d, limit: %d", a.curSize, a.sizeLimit)
+	if size > a.sizeLimit {
+		return errors.Errorf("size overflow, transaction size: %d, limit: %d", size, a.sizeLimit)
 	}
 	if err := tx.GenerateID(a.settings.AddressSchemeCharacter); err != nil {
 		return errors.Errorf("failed to generate ID: %v", err)
@@ -188,9 +277,23 @@ func (a *UtxImpl) addWithBytesOptValidation(
 	if a.exists(tx) {
 		return proto.NewInfoMsg(errors.Errorf("transaction with id %s exists", base58.Encode(tID)))
 	}
-	if optionalTxValidator != nil {
-		if vErr := optionalTxValidator(tx); vErr != nil {
-			return errors.Wrapf(vErr, "transaction with id %s failed validation", base58.Encode(tID))
+	id, err := crypto.NewDigestFromBytes(tID)
+	if err != nil {
+		return fmt.Errorf("failed to create digest from tx id: %w", err)
+	}
+	sender, err := tx.GetSender(a.settings.AddressSchemeCharacter)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get sender of transaction %s", base58.Encode(tID))
+	}
+	senderAddr, err := sender.ToWavesAddress(a.settings.AddressSchemeCharacter)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get sender address of transaction %s", base58.Encode(tID))
+	}
+	a.expire()
+	_, priority := a.priority[senderAddr]
+	if !priority {
+		if qErr := a.checkSenderQuotas(senderAddr, size); qErr != nil {
+			return qErr
 		}
 	}
 	it := &heapItem{
@@ -198,22 +301,127 @@ func (a *UtxImpl) addWithBytesOptValidation(
 			T: tx,
 			B: b,
 		},
-		index: -1, // Not in heap yet.
+		index:      -1, // Not in heap yet.
+		evictIndex: -1, // Not in heap yet.
+		id:         id,
+		sender:     senderAddr,
+		priority:   priority,
+		feePerByte: tx.GetFee() / size,
 	}
+	victims, ok := a.victims(it, size)
+	if !ok {
+		return errors.Errorf("size overflow, curSize: %d, limit: %d", a.curSize, a.sizeLimit)
+	}
+	if optionalTxValidator != nil {
+		if vErr := optionalTxValidator(tx); vErr != nil {
+			return errors.Wrapf(vErr, "transaction with id %s failed validation", base58.Encode(tID))
+		}
+	}
+	for _, v := range victims {
+		a.remove(v)
+		metricUtxEvictions.WithLabelValues(reasonPoolFull).Inc()
+	}
+	it.added = a.now()
+	it.queued = a.queue.PushBack(it)
 	heap.Push(&a.transactions, it)
-	idb, err := tx.GetID(a.settings.AddressSchemeCharacter)
-	if err != nil {
-		return fmt.Errorf("failed to get tx id: %w", err)
+	heap.Push(&a.eviction, it)
+	a.transactionIds[id] = it
+	a.curSize += size
+	usage, ok := a.senders[senderAddr]
+	if !ok {
+		usage = &senderUsage{}
+		a.senders[senderAddr] = usage
 	}
-	id, err := crypto.NewDigestFromBytes(idb)
-	if err != nil {
-		return fmt.Errorf("failed to create digest from tx id: %w", err)
+	usage.count++
+	usage.bytes += size
+	return nil
+}
+
+func (a *UtxImpl) checkSenderQuotas(sender proto.WavesAddress, size uint64) error {
+	usage, ok := a.senders[sender]
+	if !ok {
+		usage = &senderUsage{}
+	}
+	if limit := a.opts.SenderMaxCount; limit > 0 && usage.count+1 > limit {
+		metricUtxRejections.WithLabelValues(reasonSenderCount).Inc()
+		return errors.Errorf("sender %s exceeded transactions count quota %d", sender.String(), limit)
+	}
+	if limit := a.opts.SenderMaxBytes; limit > 0 && usage.bytes+size > limit {
+		metricUtxRejections.WithLabelValues(reasonSenderBytes).Inc()
+		return errors.Errorf("sender %s exceeded transactions size quota %d bytes", sender.String(), limit)
 	}
-	a.transactionIds[id] = struct{}{}
-	a.curSize += uint64(len(b))
 	return nil
 }
 
+// victims returns the transactions that should be evicted to free space for the given item.
+// It returns false if there is not enough space even after eviction of all transactions that
+// should be mined after the given one. The pool is not modified.
+func (a *UtxImpl) victims(it *heapItem, size uint64) ([]*heapItem, bool) {
+	var (
+		victims []*heapItem
+		freed   uint64
+	)
+	for a.curSize-freed+size > a.sizeLimit && a.eviction.Len() > 0 && it.before(a.eviction[0]) {
+		v, ok := heap.Pop(&a.eviction).(*heapItem)
+		if !ok {
+			panic("UtxImpl victims: unexpected type from heap.Pop")
+		}
+		victims = append(victims, v)
+		freed += uint64(len(v.tx.B))
+	}
+	for _, v := range victims { // Restore eviction heap.
+		heap.Push(&a.eviction, v)
+	}
+	if a.curSize-freed+size > a.sizeLimit {
+		metricUtxRejections.WithLabelValues(reasonPoolFull).Inc()
+		return nil, false
+	}
+	return victims, true
+}
+
+// expire removes transactions that spent in the pool more time than TTL.
+func (a *UtxImpl) expire() {
+	if a.opts.TTL <= 0 {
+		return
+	}
+	now := a.now()
+	for e := a.queue.Front(); e != nil; e = a.queue.Front() {
+		it, ok := e.Value.(*heapItem)
+		if !ok {
+			panic(fmt.Sprintf("UtxImpl expire: unexpected queue item type %T", e.Value))
+		}
+		if now.Sub(it.added) < a.opts.TTL {
+			return
+		}
+		a.remove(it)
+		metricUtxEvictions.WithLabelValues(reasonExpired).Inc()
+	}
+}
+
+// remove removes the item from the pool.
+func (a *UtxImpl) remove(it *heapItem) {
+	if it.index >= 0 {
+		heap.Remove(&a.transactions, it.index)
+	}
+	if it.evictIndex >= 0 {
+		heap.Remove(&a.eviction, it.evictIndex)
+	}
+	a.queue.Remove(it.queued)
+	delete(a.transactionIds, it.id)
+	size := uint64(len(it.tx.B))
+	if size > a.curSize {
+		panic(fmt.Sprintf("UtxImpl remove: size of transaction %d > than current size %d", size, a.curSize))
+	}
+	a.curSize -= size
+	if usage, ok := a.senders[it.sender]; ok {
+		usage.count--
+		usage.bytes -= size
+		if usage.count == 0 {
+			delete(a.senders, it.sender)
+		}
+	}
+}
+
 func (a *UtxImpl) exists(tx proto.Transaction) bool {
 	idb, err := tx.GetID(a.settings.AddressSchemeCharacter)
 	if err != nil {
@@ -248,26 +456,12 @@ func (a *UtxImpl) Pop() *types.TransactionWithBytes {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	a.expire()
 	if a.transactions.Len() == 0 {
 		return nil
 	}
-	it, ok := heap.Pop(&a.transactions).(*heapItem)
-	if !ok {
-		panic("UtxImpl Pop: unexpected type from heap.Pop")
-	}
-	idb, err := it.tx.T.GetID(a.settings.AddressSchemeCharacter)
-	if err != nil {
-		return nil
-	}
-	id, err := crypto.NewDigestFromBytes(idb)
-	if err != nil {
-		return nil
-	}
-	delete(a.transactionIds, id)
-	if uint64(len(it.tx.B)) > a.curSize {
-		panic(fmt.Sprintf("UtxImpl Pop: size of transaction %d > than current size %d", len(it.tx.B), a.curSize))
-	}
-	a.curSize -= uint64(len(it.tx.B))
+	it := a.transactions[0]
+	a.remove(it)
 	return it.tx
 }
 
@@ -2,7 +2,9 @@ package utxpool
 
 import (
 	"bytes"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -15,8 +17,9 @@ import (
 )
 
 type transaction struct {
-	fee uint64
-	id  []byte
+	fee    uint64
+	id     []byte
+	sender proto.WavesAddress
 }
 
 func (a transaction) BinarySize() int {
@@ -108,7 +111,7 @@ func (a transaction) GetSenderPK() crypto.PublicKey {
 }
 
 func (a transaction) GetSender(_ proto.Scheme) (proto.Address, error) {
-	panic("not implemented")
+	return a.sender, nil
 }
 
 func id(b []byte, fee uint64) *transaction {
@@ -175,7 +178,7 @@ func TestUtxImpl_AllTransactions(t *testing.T) {
 	var noState types.UtxPoolValidatorState
 
 	a := New(10, NoOpValidator{}, settings.MustMainNetSettings())
-	_ = a.AddWithBytes(noState, id([]byte{1, 2, 3}, 10), bytes.Repeat([]byte{1, 2}, 5))
+	_ = a.AddWithBytes(noState, id(bytes.Repeat([]byte{1, 2, 3}, 11)[:32], 10), bytes.Repeat([]byte{1, 2}, 5))
 	require.Len(t, a.AllTransactions(), 1)
 }
 
@@ -189,3 +192,91 @@ func TestUtxImpl_TransactionExists(t *testing.T) {
 	require.True(t, a.ExistsByID(byte_helpers.BurnWithSig.Transaction.ID.Bytes()))
 	require.False(t, a.ExistsByID(byte_helpers.TransferWithSig.Transaction.ID.Bytes()))
 }
+
+func sent(b byte, fee uint64, sender byte) *transaction {
+	return &transaction{fee: fee, id: bytes.Repeat([]byte{b}, 32), sender: proto.WavesAddress{sender}}
+}
+
+func TestUtxPool_SenderQuotas(t *testing.T) {
+	var noState types.UtxPoolValidatorState
+	a := NewWithOptions(10000, NoOpValidator{}, settings.MustMainNetSettings(),
+		Options{SenderMaxCount: 2, SenderMaxBytes: 5, PriorityAddresses: []proto.WavesAddress{{3}}},
+	)
+	require.NoError(t, a.AddWithBytes(noState, sent(1, 20, 1), []byte{1}))
+	require.NoError(t, a.AddWithBytes(noState, sent(2, 20, 1), []byte{1}))
+	require.Error(t, a.AddWithBytes(noState, sent(3, 20, 1), []byte{1}))                  // count quota
+	require.Error(t, a.AddWithBytes(noState, sent(4, 10, 2), bytes.Repeat([]byte{1}, 6))) // bytes quota
+	require.NoError(t, a.AddWithBytes(noState, sent(5, 10, 2), bytes.Repeat([]byte{1}, 5)))
+	// priority sender is not limited
+	for i := range 3 {
+		require.NoError(t, a.AddWithBytes(noState, sent(byte(6+i), 10, 3), bytes.Repeat([]byte{1}, 6)))
+	}
+	require.Equal(t, 6, a.Len())
+
+	// quota is released after transaction leaves the pool
+	for range 4 { // priority transactions go first
+		a.Pop()
+	}
+	require.NoError(t, a.AddWithBytes(noState, sent(3, 20, 1), []byte{1}))
+}
+
+func TestUtxPool_PriorityAndEviction(t *testing.T) {
+	var noState types.UtxPoolValidatorState
+	a := NewWithOptions(3, NoOpValidator{}, settings.MustMainNetSettings(),
+		Options{PriorityAddresses: []proto.WavesAddress{{9}}},
+	)
+	require.NoError(t, a.AddWithBytes(noState, sent(1, 5, 1), []byte{1}))
+	require.NoError(t, a.AddWithBytes(noState, sent(2, 1, 1), []byte{1}))
+	require.NoError(t, a.AddWithBytes(noState, sent(3, 7, 1), []byte{1}))
+	// the transaction with the lowest fee per byte is evicted
+	require.NoError(t, a.AddWithBytes(noState, sent(4, 6, 2), []byte{1}))
+	require.False(t, a.ExistsByID(bytes.Repeat([]byte{2}, 32)))
+	// the transaction with fee per byte not higher than the lowest one in the pool is rejected
+	require.Error(t, a.AddWithBytes(noState, sent(5, 5, 2), []byte{1}))
+	require.Equal(t, 3, a.Len())
+	// priority transaction evicts others regardless of fee
+	require.NoError(t, a.AddWithBytes(noState, sent(6, 0, 9), []byte{1, 2}))
+	require.Equal(t, 2, a.Len())
+	require.EqualValues(t, 3, a.CurSize())
+
+	require.EqualValues(t, 0, a.Pop().T.GetFee())
+	require.EqualValues(t, 7, a.Pop().T.GetFee())
+	require.Nil(t, a.Pop())
+	require.EqualValues(t, 0, a.CurSize())
+}
+
+func TestUtxPool_TTL(t *testing.T) {
+	var noState types.UtxPoolValidatorState
+	now := time.Unix(1000, 0)
+	a := NewWithOptions(10000, NoOpValidator{}, settings.MustMainNetSettings(), Options{TTL: time.Minute})
+	a.now = func() time.Time { return now }
+
+	require.NoError(t, a.AddWithBytes(noState, sent(1, 10, 1), []byte{1}))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, a.AddWithBytes(noState, sent(2, 1, 1), []byte{1}))
+	now = now.Add(30 * time.Second)
+	// the first transaction is expired
+	tx := a.Pop()
+	require.NotNil(t, tx)
+	require.EqualValues(t, 1, tx.T.GetFee())
+	require.EqualValues(t, 0, a.CurSize())
+	require.False(t, a.ExistsByID(bytes.Repeat([]byte{1}, 32)))
+}
+
+func TestUtxPool_CleanBookkeeping(t *testing.T) {
+	var noState types.UtxPoolValidatorState
+	a := NewWithOptions(10000, NoOpValidator{}, settings.MustMainNetSettings(), Options{SenderMaxCount: 1})
+	require.NoError(t, a.AddWithBytes(noState, sent(1, 10, 1), []byte{1, 2}))
+	require.NoError(t, a.AddWithBytes(noState, sent(2, 20, 2), []byte{1}))
+
+	checked, dropped := a.Clean(context.Background(), func(tx proto.Transaction) bool {
+		return tx.GetFee() == 10
+	})
+	require.Equal(t, 2, checked)
+	require.Equal(t, 1, dropped)
+	require.Equal(t, 1, a.Len())
+	require.EqualValues(t, 1, a.CurSize())
+	require.False(t, a.ExistsByID(bytes.Repeat([]byte{1}, 32)))
+	// sender quota is released
+	require.NoError(t, a.AddWithBytes(noState, sent(3, 10, 1), []byte{1}))
+}
@@ -113,6 +113,9 @@ func sendSignatures(services services.Services, block *proto.BlockHeader, p peer
 			logging.Error(err))
 		return
 	}
+	if height < services.State.PrunedHeight() { // the next blocks are pruned and can't be requested by the peer
+		return
+	}
 
 	var out []crypto.Signature
 	out = append(out, block.BlockSignature)
@@ -141,6 +144,9 @@ func sendBlockIds(services services.Services, block *proto.BlockHeader, p peer.P
 			logging.Error(err))
 		return
 	}
+	if height < services.State.PrunedHeight() { // the next blocks are pruned and can't be requested by the peer
+		return
+	}
 
 	var out []proto.BlockID
 	out = append(out, block.BlockID())
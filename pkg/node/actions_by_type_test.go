@@ -1,17 +1,20 @@
 package node
 
 import (
+	"errors"
 	"log/slog"
 	"net"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/node/peers"
 	"github.com/wavesplatform/gowaves/pkg/node/peers/storage"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
 )
 
 func TestPeersAction(t *testing.T) {
@@ -34,3 +37,26 @@ func TestPeersAction(t *testing.T) {
 	}, nil, slog.New(slog.DiscardHandler))
 	require.NoError(t, err)
 }
+
+func TestGetBlockIdsActionPrunedBlocks(t *testing.T) {
+	common := &proto.BlockHeader{BlockSignature: crypto.Signature{1}}
+	next := &proto.BlockHeader{BlockSignature: crypto.Signature{2}}
+	msg := &proto.GetBlockIDsMessage{Blocks: []proto.BlockID{common.BlockID()}}
+
+	st := state.NewMockState(t)
+	st.EXPECT().Header(common.BlockID()).Return(common, nil)
+	st.EXPECT().BlockIDToHeight(common.BlockID()).Return(5, nil)
+	st.EXPECT().PrunedHeight().Return(10).Once()
+	p := peer.NewMockPeer(t)
+	_, err := GetBlockIdsAction(services.Services{State: st}, peer.ProtoMessage{ID: p, Message: msg}, nil,
+		slog.New(slog.DiscardHandler))
+	require.NoError(t, err) // nothing is sent, blocks following the common one are pruned
+
+	st.EXPECT().PrunedHeight().Return(5).Once()
+	st.EXPECT().HeaderByHeight(proto.Height(6)).Return(next, nil)
+	st.EXPECT().HeaderByHeight(proto.Height(7)).Return(nil, errors.New("not found"))
+	p.EXPECT().SendMessage(&proto.BlockIDsMessage{Blocks: []proto.BlockID{common.BlockID(), next.BlockID()}}).Return()
+	_, err = GetBlockIdsAction(services.Services{State: st}, peer.ProtoMessage{ID: p, Message: msg}, nil,
+		slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+}
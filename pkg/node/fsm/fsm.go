@@ -2,6 +2,7 @@ package fsm
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync/atomic"
 	"time"
@@ -274,8 +275,14 @@ func NewFSM(
 		return state.Name, nil
 	}, func(_ context.Context, s stateless.State) error {
 		state.Name = s
+		if services.FSMStatus != nil {
+			services.FSMStatus.Set(fmt.Sprint(s))
+		}
 		return nil
 	}, stateless.FiringQueued)
+	if services.FSMStatus != nil {
+		services.FSMStatus.Set(fmt.Sprint(state.Name))
+	}
 
 	// TODO: Consider using fsm.SetTriggerParameters to configure events parameters.
 	// Probably it will help to eliminate parameters validation.
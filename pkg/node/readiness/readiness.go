@@ -0,0 +1,190 @@
+// Package readiness checks that the node is caught up with the network and is ready to serve API requests.
+// The checks are shared by the REST API readiness probe and the gRPC health checking service.
+package readiness
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/node/fsm"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+const (
+	DefaultMaxHeightLag = 5
+	// DefaultDirCheckInterval is the default minimal interval between the checks of the state directory.
+	DefaultDirCheckInterval = 5 * time.Second
+)
+
+// Options configure the checks of node readiness to serve requests.
+type Options struct {
+	// MaxHeightLag is the maximum number of blocks the node can lag behind the peer with the best score.
+	MaxHeightLag uint64
+	// DataDir is the state directory which is checked for writability, the check is skipped if it's empty.
+	DataDir string
+	// DirCheckInterval is the minimal interval between the checks of the state directory writability,
+	// DefaultDirCheckInterval is used if it's zero.
+	DirCheckInterval time.Duration
+}
+
+func DefaultOptions() *Options {
+	return &Options{MaxHeightLag: DefaultMaxHeightLag, DirCheckInterval: DefaultDirCheckInterval}
+}
+
+// Status is the result of the node readiness check.
+type Status struct {
+	Ready          bool         `json:"ready"`
+	FSMState       string       `json:"fsmState"`
+	Height         proto.Height `json:"height"`
+	HeightLag      uint64       `json:"heightLag"`
+	ConnectedPeers int          `json:"connectedPeers"`
+	MinPeers       int          `json:"minPeers"`
+	DBWritable     bool         `json:"dbWritable"`
+	Reasons        []string     `json:"reasons,omitempty"`
+}
+
+func (s *Status) notReady(format string, args ...any) {
+	s.Ready = false
+	s.Reasons = append(s.Reasons, fmt.Sprintf(format, args...))
+}
+
+// Checker checks the node readiness. The result of the state directory writability check is reused
+// during the check interval, so the frequent checks made on requests don't touch the disk each time.
+type Checker struct {
+	services services.Services
+	opts     Options
+
+	mu         sync.Mutex
+	dirErr     error
+	dirChecked time.Time // Zero if the directory wasn't checked yet.
+}
+
+// NewChecker creates the readiness checker, the default options are used if opts is nil.
+func NewChecker(svs services.Services, opts *Options) *Checker {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	c := &Checker{services: svs, opts: *opts}
+	if c.opts.DirCheckInterval == 0 {
+		c.opts.DirCheckInterval = DefaultDirCheckInterval
+	}
+	return c
+}
+
+// Check checks that the node is caught up with the network: the FSM is not syncing or halted,
+// the height lag behind the best peer is acceptable, there are enough connected peers and the state
+// directory is writable.
+func (c *Checker) Check() (Status, error) {
+	svs := c.services
+	dirErr := c.checkDir()
+	s := Status{Ready: true, FSMState: svs.FSMStatus.Get(), MinPeers: svs.MinPeersMining, DBWritable: dirErr == nil}
+	switch s.FSMState {
+	case "":
+		s.notReady("node is not started")
+	case fsm.IdleStateName, fsm.SyncStateName, fsm.HaltStateName:
+		s.notReady("node is in %s state", s.FSMState)
+	}
+
+	height, err := svs.State.Height()
+	if err != nil {
+		return Status{}, errors.Wrap(err, "failed to get height")
+	}
+	s.Height = height
+	score, err := svs.State.CurrentScore()
+	if err != nil {
+		return Status{}, errors.Wrap(err, "failed to get current score")
+	}
+	blockScore, err := lastBlockScore(svs, height, score)
+	if err != nil {
+		return Status{}, err
+	}
+	bestScore := new(big.Int)
+	svs.Peers.EachConnected(func(_ peer.Peer, sc *proto.Score) {
+		s.ConnectedPeers++
+		if sc != nil && sc.Cmp(bestScore) > 0 {
+			bestScore.Set(sc)
+		}
+	})
+	s.HeightLag = heightLag(score, bestScore, blockScore)
+	if s.HeightLag > c.opts.MaxHeightLag {
+		s.notReady("height lag %d is greater than %d", s.HeightLag, c.opts.MaxHeightLag)
+	}
+	if s.ConnectedPeers < s.MinPeers {
+		s.notReady("%d connected peers is less than %d", s.ConnectedPeers, s.MinPeers)
+	}
+	if dirErr != nil {
+		s.notReady("state directory is not writable: %v", dirErr)
+	}
+	return s, nil
+}
+
+// checkDir returns the result of the state directory writability check, the directory is checked again
+// if the previous check is older than the check interval.
+func (c *Checker) checkDir() error {
+	if c.opts.DataDir == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if c.dirChecked.IsZero() || now.Sub(c.dirChecked) >= c.opts.DirCheckInterval {
+		c.dirErr = checkDirWritable(c.opts.DataDir)
+		c.dirChecked = now
+	}
+	return c.dirErr
+}
+
+func (c *Checker) now() time.Time {
+	if c.services.Time != nil {
+		return c.services.Time.Now()
+	}
+	return time.Now()
+}
+
+// lastBlockScore returns the score of the last block, it's used to estimate the lag in blocks.
+func lastBlockScore(svs services.Services, height proto.Height, score *big.Int) (*big.Int, error) {
+	if height <= 1 {
+		return new(big.Int).Set(score), nil
+	}
+	prev, err := svs.State.ScoreAtHeight(height - 1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get score at height %d", height-1)
+	}
+	return new(big.Int).Sub(score, prev), nil
+}
+
+// heightLag estimates how many blocks the node lags behind the peer with the best score.
+func heightLag(score, bestScore, blockScore *big.Int) uint64 {
+	if bestScore.Cmp(score) <= 0 || blockScore.Sign() <= 0 {
+		return 0
+	}
+	diff := new(big.Int).Sub(bestScore, score)
+	lag, rem := new(big.Int).QuoRem(diff, blockScore, new(big.Int))
+	if rem.Sign() > 0 {
+		lag.Add(lag, big.NewInt(1))
+	}
+	if !lag.IsUint64() {
+		return ^uint64(0)
+	}
+	return lag.Uint64()
+}
+
+func checkDirWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".readiness-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	if clErr := f.Close(); clErr != nil {
+		_ = os.Remove(name)
+		return clErr
+	}
+	return os.Remove(name)
+}
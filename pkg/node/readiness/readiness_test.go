@@ -0,0 +1,119 @@
+package readiness
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/node/fsm"
+	"github.com/wavesplatform/gowaves/pkg/node/peers"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func newServices(t *testing.T, fsmState string, peerScores ...int64) services.Services {
+	st := state.NewMockState(t)
+	st.EXPECT().Height().Return(proto.Height(10), nil)
+	st.EXPECT().CurrentScore().Return(big.NewInt(1000), nil)
+	st.EXPECT().ScoreAtHeight(proto.Height(9)).Return(big.NewInt(900), nil)
+	pm := peers.NewMockPeerManager(t)
+	pm.EXPECT().EachConnected(mock.Anything).Run(func(f func(peer.Peer, *proto.Score)) {
+		for _, s := range peerScores {
+			f(peer.NewMockPeer(t), big.NewInt(s))
+		}
+	})
+	status := services.NewFSMStatus()
+	if fsmState != "" {
+		status.Set(fsmState)
+	}
+	return services.Services{State: st, Peers: pm, FSMStatus: status, MinPeersMining: 2}
+}
+
+func TestChecker(t *testing.T) {
+	opts := &Options{MaxHeightLag: 2, DataDir: t.TempDir()}
+	t.Run("ready", func(t *testing.T) {
+		r, err := NewChecker(newServices(t, fsm.NGStateName, 1000, 1150), opts).Check()
+		require.NoError(t, err)
+		assert.True(t, r.Ready)
+		assert.Empty(t, r.Reasons)
+		assert.EqualValues(t, 10, r.Height)
+		assert.EqualValues(t, 2, r.HeightLag) // 150 of score is 1.5 blocks
+		assert.Equal(t, 2, r.ConnectedPeers)
+		assert.True(t, r.DBWritable)
+		entries, err := os.ReadDir(opts.DataDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+	t.Run("syncing", func(t *testing.T) {
+		r, err := NewChecker(newServices(t, fsm.SyncStateName, 1000, 1500), opts).Check()
+		require.NoError(t, err)
+		assert.False(t, r.Ready)
+		assert.Equal(t, fsm.SyncStateName, r.FSMState)
+		assert.EqualValues(t, 5, r.HeightLag)
+		assert.Len(t, r.Reasons, 2)
+	})
+	t.Run("not-started", func(t *testing.T) {
+		r, err := NewChecker(newServices(t, "", 1000, 1000), opts).Check()
+		require.NoError(t, err)
+		assert.False(t, r.Ready)
+		assert.Len(t, r.Reasons, 1)
+	})
+	t.Run("not-enough-peers", func(t *testing.T) {
+		r, err := NewChecker(newServices(t, fsm.NGStateName, 900), opts).Check()
+		require.NoError(t, err)
+		assert.False(t, r.Ready)
+		assert.Zero(t, r.HeightLag)
+		assert.Equal(t, 1, r.ConnectedPeers)
+		assert.Len(t, r.Reasons, 1)
+	})
+	t.Run("not-writable", func(t *testing.T) {
+		missing := &Options{MaxHeightLag: 2, DataDir: filepath.Join(t.TempDir(), "missing")}
+		r, err := NewChecker(newServices(t, fsm.NGStateName, 1000, 1000), missing).Check()
+		require.NoError(t, err)
+		assert.False(t, r.Ready)
+		assert.False(t, r.DBWritable)
+		assert.Len(t, r.Reasons, 1)
+	})
+	t.Run("dir-rechecked", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "state")
+		require.NoError(t, os.Mkdir(dir, 0750))
+		svs := newServices(t, fsm.NGStateName, 1000, 1000)
+		clock := &testTime{now: time.Now()}
+		svs.Time = clock
+		c := NewChecker(svs, &Options{MaxHeightLag: 2, DataDir: dir, DirCheckInterval: time.Minute})
+		r, err := c.Check()
+		require.NoError(t, err)
+		assert.True(t, r.DBWritable)
+		require.NoError(t, os.Remove(dir))
+		// The result of the previous check is used within the interval.
+		clock.now = clock.now.Add(time.Minute - time.Second)
+		r, err = c.Check()
+		require.NoError(t, err)
+		assert.True(t, r.Ready)
+		assert.True(t, r.DBWritable)
+		assert.NoDirExists(t, dir)
+		// The directory is checked again after the interval.
+		clock.now = clock.now.Add(time.Second)
+		r, err = c.Check()
+		require.NoError(t, err)
+		assert.False(t, r.Ready)
+		assert.False(t, r.DBWritable)
+		assert.Len(t, r.Reasons, 1)
+	})
+}
+
+type testTime struct {
+	now time.Time
+}
+
+func (t *testTime) Now() time.Time {
+	return t.now
+}
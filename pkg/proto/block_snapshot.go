@@ -72,6 +72,26 @@ func (bs *BlockSnapshot) UnmarshalBinary(data []byte, scheme Scheme) error {
 	return nil
 }
 
+// MarshalBinaryImport marshals block snapshot to binary data in the format of import files.
+// The data contains the size-prefixed transaction snapshots without the count of snapshots.
+// The data is read back by UnmarshalBinaryImport.
+func (bs BlockSnapshot) MarshalBinaryImport() ([]byte, error) {
+	var result []byte
+	for _, ts := range bs.TxSnapshots {
+		res, err := TxSnapshotsToProtobuf(ts)
+		if err != nil {
+			return nil, err
+		}
+		tsBytes, err := res.MarshalVTStrict()
+		if err != nil {
+			return nil, err
+		}
+		result = binary.BigEndian.AppendUint32(result, uint32(len(tsBytes)))
+		result = append(result, tsBytes...)
+	}
+	return result, nil
+}
+
 // UnmarshalBinaryImport unmarshals block snapshot from binary data.
 // It does not read block snapshot size from the data.
 // It reads snapshots until the end of the data.
@@ -112,14 +132,11 @@ func (bs *BlockSnapshot) UnmarshalBinaryImport(data []byte, scheme Scheme) error
 }
 
 func (bs BlockSnapshot) ToProtobuf() ([]*g.TransactionStateSnapshot, error) {
-	data := make([]g.TransactionStateSnapshot, len(bs.TxSnapshots))
 	res := make([]*g.TransactionStateSnapshot, len(bs.TxSnapshots))
 	for i, ts := range bs.TxSnapshots {
-		tsProto := &data[i]
-		for _, atomicSnapshot := range ts {
-			if err := atomicSnapshot.AppendToProtobuf(tsProto); err != nil {
-				return nil, errors.Wrap(err, "failed to marshall TransactionSnapshot to proto")
-			}
+		tsProto, err := TxSnapshotsToProtobuf(ts)
+		if err != nil {
+			return nil, err
 		}
 		res[i] = tsProto
 	}
@@ -164,6 +181,19 @@ func (bs *BlockSnapshot) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// TxSnapshot is a list of atomic snapshots of a single transaction.
+type TxSnapshot []AtomicSnapshot
+
+func (ts TxSnapshot) MarshalJSON() ([]byte, error) {
+	var js txSnapshotJSON
+	for _, snapshot := range ts {
+		if err := snapshot.Apply(&js); err != nil {
+			return nil, err
+		}
+	}
+	return js.MarshalJSON()
+}
+
 type balanceSnapshotJSON struct {
 	Address WavesAddress  `json:"address"`
 	Asset   OptionalAsset `json:"asset"`
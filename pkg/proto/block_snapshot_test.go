@@ -359,3 +359,44 @@ func Test_txSnapshotJSON_MarshalJSON_UnmarshalJSON(t *testing.T) {
 	assert.Len(t, unmEmptyBs.TxSnapshots, 0)
 	assert.Nil(t, unmEmptyBs.TxSnapshots)
 }
+
+func TestTxSnapshot_MarshalJSON(t *testing.T) {
+	addr, err := proto.NewAddressFromString("3NA26AC1aLjj6uYnuoTahauhUPPPB3VBPUe")
+	require.NoError(t, err)
+	ts := proto.TxSnapshot{
+		&proto.TransactionStatusSnapshot{Status: proto.TransactionSucceeded},
+		&proto.WavesBalanceSnapshot{Address: addr, Balance: 100},
+	}
+	data, err := json.Marshal(ts)
+	require.NoError(t, err)
+	bsData, err := json.Marshal(proto.BlockSnapshot{TxSnapshots: [][]proto.AtomicSnapshot{ts}})
+	require.NoError(t, err)
+	assert.JSONEq(t, "["+string(data)+"]", string(bsData))
+
+	_, err = json.Marshal(proto.TxSnapshot{})
+	assert.Error(t, err) // transaction status is mandatory
+}
+
+func TestBlockSnapshot_MarshalBinaryImport(t *testing.T) {
+	addr, err := proto.NewAddressFromString("3NA26AC1aLjj6uYnuoTahauhUPPPB3VBPUe")
+	require.NoError(t, err)
+	bs := proto.BlockSnapshot{TxSnapshots: [][]proto.AtomicSnapshot{
+		{
+			&proto.WavesBalanceSnapshot{Address: addr, Balance: 100},
+			&proto.TransactionStatusSnapshot{Status: proto.TransactionSucceeded},
+		},
+		{
+			&proto.LeaseBalanceSnapshot{Address: addr, LeaseIn: 1, LeaseOut: 2},
+			&proto.TransactionStatusSnapshot{Status: proto.TransactionFailed},
+		},
+	}}
+	data, err := bs.MarshalBinaryImport()
+	require.NoError(t, err)
+	var restored proto.BlockSnapshot
+	require.NoError(t, restored.UnmarshalBinaryImport(data, proto.TestNetScheme))
+	assert.Equal(t, bs, restored)
+
+	data, err = proto.BlockSnapshot{}.MarshalBinaryImport()
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
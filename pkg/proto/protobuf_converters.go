@@ -398,6 +398,17 @@ func TxSnapshotsFromProtobuf(scheme Scheme, txSnapshotProto *g.TransactionStateS
 	return txSnapshots, nil
 }
 
+// TxSnapshotsToProtobuf serializes AtomicSnapshot slice of a single transaction into protobuf message.
+func TxSnapshotsToProtobuf(txSnapshots []AtomicSnapshot) (*g.TransactionStateSnapshot, error) {
+	res := &g.TransactionStateSnapshot{}
+	for _, atomicSnapshot := range txSnapshots {
+		if err := atomicSnapshot.AppendToProtobuf(res); err != nil {
+			return nil, errors.Wrap(err, "failed to marshall TransactionSnapshot to proto")
+		}
+	}
+	return res, nil
+}
+
 func BlockSnapshotFromProtobuf(scheme Scheme, blockSnapshot []*g.TransactionStateSnapshot) (BlockSnapshot, error) {
 	res := BlockSnapshot{TxSnapshots: make([][]AtomicSnapshot, 0, len(blockSnapshot))}
 	for _, ts := range blockSnapshot {
@@ -4218,6 +4218,12 @@ func (b *FullWavesBalance) ToProtobuf() *pb.BalanceResponse_WavesBalances {
 	}
 }
 
+// AssetBalance is a balance of an asset on some account.
+type AssetBalance struct {
+	AssetID crypto.Digest
+	Balance uint64
+}
+
 type StateHash struct {
 	BlockID BlockID
 	SumHash crypto.Digest
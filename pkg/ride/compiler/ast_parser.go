@@ -4,8 +4,6 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
-	"io/fs"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
@@ -64,6 +62,22 @@ type importPath struct {
 	node *node32
 }
 
+// imports is the state of library imports shared by the script and all its libraries.
+type imports struct {
+	resolver ImportResolver
+	main     string          // the path of the main script, used to resolve its imports
+	loading  []string        // the chain of libraries being loaded, used to detect cyclic imports
+	loaded   map[string]bool // the libraries that have already been loaded
+}
+
+func newImports(resolver ImportResolver, main string) *imports {
+	im := &imports{resolver: resolver, main: main, loaded: make(map[string]bool)}
+	if main != "" {
+		im.loading = append(im.loading, main)
+	}
+	return im
+}
+
 type astParser struct {
 	node   *node32
 	tree   *ast.Tree
@@ -80,6 +94,8 @@ type astParser struct {
 	importPaths []importPath
 	isLibrary   bool
 	fileName    string
+	imports     *imports
+	anyResult   bool // allows expression to return a value of any type
 }
 
 func newASTParser(node *node32, buffer []rune) astParser {
@@ -100,6 +116,7 @@ func newASTParser(node *node32, buffer []rune) astParser {
 		errorsList: []error{},
 		stack:      newStack(),
 		scriptType: accountScript,
+		imports:    newImports(NewFileResolver(), ""),
 	}
 }
 
@@ -191,20 +208,35 @@ func (p *astParser) loadLib(lib *astParser) {
 }
 
 func (p *astParser) loadImport() {
+	importer := p.fileName
+	if !p.isLibrary {
+		importer = p.imports.main
+	}
+	imported := make(map[string]bool)
 	for _, path := range p.importPaths {
-		if _, err := os.Stat(path.path); err != nil {
-			if errors.Is(err, fs.ErrNotExist) {
+		lib, err := p.imports.resolver.Resolve(path.path, importer)
+		if err != nil {
+			if errors.Is(err, ErrLibraryNotFound) {
 				p.addError(path.node.token32, "File '%s' doesn't exist", path.path)
 				continue
 			}
+			p.addError(path.node.token32, "Failed to import '%s': %v", path.path, err)
+			continue
 		}
-
-		buffer, err := os.ReadFile(path.path)
-		if err != nil {
-			p.addError(path.node.token32, "File '%s' not readable: %v", path.path, err)
+		if imported[lib.Path] {
+			p.addError(path.node.token32, "Library '%s' is imported more than once", path.path)
 			continue
 		}
-		rawP := Parser{Buffer: string(buffer)}
+		imported[lib.Path] = true
+		if i := slices.Index(p.imports.loading, lib.Path); i >= 0 {
+			cycle := append(slices.Clone(p.imports.loading[i:]), lib.Path)
+			p.addError(path.node.token32, "Cyclic import of library '%s': %s", path.path, strings.Join(cycle, " -> "))
+			continue
+		}
+		if p.imports.loaded[lib.Path] {
+			continue // the library is imported by another library, its declarations are already loaded
+		}
+		rawP := Parser{Buffer: string(lib.Code)}
 		err = rawP.Init()
 		if err != nil {
 			p.addError(path.node.token32, "Failed to parse file '%s': %v", path.path, err)
@@ -235,9 +267,13 @@ func (p *astParser) loadImport() {
 			stdObjects: p.stdObjects,
 			stdTypes:   p.stdTypes,
 			isLibrary:  true,
-			fileName:   path.path,
+			fileName:   lib.Path,
+			imports:    p.imports,
 		}
+		p.imports.loading = append(p.imports.loading, lib.Path)
 		parser.parse()
+		p.imports.loading = p.imports.loading[:len(p.imports.loading)-1]
+		p.imports.loaded[lib.Path] = true
 		p.loadLib(&parser)
 	}
 }
@@ -271,7 +307,7 @@ func (p *astParser) ruleScriptRootHandler(node *node32) {
 		p.addError(curNode.token32, "No expression defined")
 		return
 	}
-	if !s.BooleanType.Equal(varType) {
+	if !p.anyResult && !s.BooleanType.Equal(varType) {
 		p.addError(curNode.token32, "Script should return 'Boolean', but '%s' returned", varType)
 		return
 	}
@@ -317,6 +317,22 @@ x()
 	}
 }
 
+func TestExprAnyResult(t *testing.T) {
+	const code = `
+{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE EXPRESSION #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+1 + 2
+`
+	_, errs := CompileToTree(code)
+	require.NotEmpty(t, errs)
+	assert.Equal(t, "(5:1, 6:0): Script should return 'Boolean', but 'Int' returned", errs[0].Error())
+	tree, errs := CompileExpressionToTree(code)
+	require.Empty(t, errs)
+	assert.False(t, tree.IsDApp())
+	assert.NotNil(t, tree.Verifier)
+}
+
 func TestBuildInVars(t *testing.T) {
 	for _, test := range []struct {
 		code     string
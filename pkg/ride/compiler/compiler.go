@@ -8,6 +8,24 @@ import (
 //go:generate peg -output=parser.peg.go ride.peg
 
 func CompileToTree(code string) (*ast.Tree, []error) {
+	return compileToTree(code, false, nil)
+}
+
+// CompileToTreeWithImports compiles the code like CompileToTree, the imported libraries are resolved by
+// the given resolver. The path is the location of the script, it's used to resolve the relative imports
+// and may be empty.
+func CompileToTreeWithImports(code, path string, resolver ImportResolver) (*ast.Tree, []error) {
+	return compileToTree(code, false, newImports(resolver, path))
+}
+
+// CompileExpressionToTree compiles the code like CompileToTree, but the expression script
+// is allowed to return a value of any type, not only Boolean.
+// The resulting tree is intended for evaluation only and must not be used as an account or asset script.
+func CompileExpressionToTree(code string) (*ast.Tree, []error) {
+	return compileToTree(code, true, nil)
+}
+
+func compileToTree(code string, anyResult bool, im *imports) (*ast.Tree, []error) {
 	pp := Parser{Buffer: code}
 	err := pp.Init()
 	if err != nil {
@@ -18,6 +36,10 @@ func CompileToTree(code string) (*ast.Tree, []error) {
 		return nil, []error{err}
 	}
 	ap := newASTParser(pp.AST(), pp.buffer)
+	ap.anyResult = anyResult
+	if im != nil {
+		ap.imports = im
+	}
 	ap.parse()
 	if len(ap.errorsList) > 0 {
 		return nil, ap.errorsList
@@ -26,7 +48,16 @@ func CompileToTree(code string) (*ast.Tree, []error) {
 }
 
 func Compile(code string, compact, removeUnused bool) ([]byte, []error) {
-	tree, errs := CompileToTree(code)
+	return compile(code, compact, removeUnused, nil)
+}
+
+// CompileWithImports compiles the code like Compile, the imported libraries are resolved by the given resolver.
+func CompileWithImports(code, path string, resolver ImportResolver, compact, removeUnused bool) ([]byte, []error) {
+	return compile(code, compact, removeUnused, newImports(resolver, path))
+}
+
+func compile(code string, compact, removeUnused bool, im *imports) ([]byte, []error) {
+	tree, errs := compileToTree(code, false, im)
 	if len(errs) > 0 {
 		return nil, errs
 	}
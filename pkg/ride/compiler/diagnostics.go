@@ -0,0 +1,72 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Position is the position in the source code, the line and the column are counted from 1.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Diagnostic is the compilation error with the range of the source code it refers to.
+type Diagnostic struct {
+	File    string // the path of the imported library the error was found in, empty for the main script
+	Begin   Position
+	End     Position
+	Message string
+}
+
+// Diagnose compiles the code like CompileToTreeWithImports and returns the compilation errors with their positions.
+func Diagnose(code, path string, resolver ImportResolver) []Diagnostic {
+	pp := Parser{Buffer: code}
+	if err := pp.Init(); err != nil {
+		return []Diagnostic{{Message: err.Error()}}
+	}
+	if err := pp.Parse(); err != nil {
+		return []Diagnostic{newDiagnostic(err)}
+	}
+	ap := newASTParser(pp.AST(), pp.buffer)
+	ap.imports = newImports(resolver, path)
+	ap.parse()
+	r := make([]Diagnostic, len(ap.errorsList))
+	for i, err := range ap.errorsList {
+		r[i] = newDiagnostic(err)
+	}
+	return r
+}
+
+func newDiagnostic(err error) Diagnostic {
+	var (
+		ae *astError
+		pe *parseError
+	)
+	switch {
+	case errors.As(err, &ae):
+		return Diagnostic{
+			File:    ae.prefix,
+			Begin:   Position{Line: ae.begin.line, Column: ae.begin.symbol},
+			End:     Position{Line: ae.end.line, Column: ae.end.symbol},
+			Message: ae.msg,
+		}
+	case errors.As(err, &pe):
+		begin, end := int(pe.max.begin), int(pe.max.end)
+		translations := translatePositions(pe.p.buffer, []int{begin, end})
+		return Diagnostic{
+			Begin:   newPosition(translations[begin]),
+			End:     newPosition(translations[end]),
+			Message: fmt.Sprintf("Parse error near %s", rul3s[pe.max.pegRule]),
+		}
+	default:
+		return Diagnostic{Message: err.Error()}
+	}
+}
+
+// newPosition converts the position of the parser to the position in the code. The parser reports the line break
+// as the zero column of the next line, it is moved to the first column.
+func newPosition(p textPosition) Position {
+	return Position{Line: p.line, Column: max(p.symbol, 1)}
+}
@@ -950,7 +950,7 @@ func (p *Parser) Init(options ...func(*Parser) error) error {
 			position, tokenIndex = position61, tokenIndex61
 			return false
 		},
-		/* 11 PathString <- <('_' / [a-z] / [A-Z] / [0-9] / '-' / '/' / '.')+> */
+		/* 11 PathString <- <('_' / [a-z] / [A-Z] / [0-9] / '-' / '/' / '.' / ':')+> */
 		func() bool {
 			position65, tokenIndex65 := position, tokenIndex
 			{
@@ -1000,6 +1000,13 @@ func (p *Parser) Init(options ...func(*Parser) error) error {
 				l75:
 					position, tokenIndex = position69, tokenIndex69
 					if buffer[position] != rune('.') {
+						goto l76
+					}
+					position++
+					goto l69
+				l76:
+					position, tokenIndex = position69, tokenIndex69
+					if buffer[position] != rune(':') {
 						goto l65
 					}
 					position++
@@ -1009,55 +1016,62 @@ func (p *Parser) Init(options ...func(*Parser) error) error {
 				{
 					position68, tokenIndex68 := position, tokenIndex
 					{
-						position76, tokenIndex76 := position, tokenIndex
+						position77, tokenIndex77 := position, tokenIndex
 						if buffer[position] != rune('_') {
-							goto l77
-						}
-						position++
-						goto l76
-					l77:
-						position, tokenIndex = position76, tokenIndex76
-						if c := buffer[position]; c < rune('a') || c > rune('z') {
 							goto l78
 						}
 						position++
-						goto l76
+						goto l77
 					l78:
-						position, tokenIndex = position76, tokenIndex76
-						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						position, tokenIndex = position77, tokenIndex77
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
 							goto l79
 						}
 						position++
-						goto l76
+						goto l77
 					l79:
-						position, tokenIndex = position76, tokenIndex76
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
+						position, tokenIndex = position77, tokenIndex77
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
 							goto l80
 						}
 						position++
-						goto l76
+						goto l77
 					l80:
-						position, tokenIndex = position76, tokenIndex76
-						if buffer[position] != rune('-') {
+						position, tokenIndex = position77, tokenIndex77
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
 							goto l81
 						}
 						position++
-						goto l76
+						goto l77
 					l81:
-						position, tokenIndex = position76, tokenIndex76
-						if buffer[position] != rune('/') {
+						position, tokenIndex = position77, tokenIndex77
+						if buffer[position] != rune('-') {
 							goto l82
 						}
 						position++
-						goto l76
+						goto l77
 					l82:
-						position, tokenIndex = position76, tokenIndex76
+						position, tokenIndex = position77, tokenIndex77
+						if buffer[position] != rune('/') {
+							goto l83
+						}
+						position++
+						goto l77
+					l83:
+						position, tokenIndex = position77, tokenIndex77
 						if buffer[position] != rune('.') {
+							goto l84
+						}
+						position++
+						goto l77
+					l84:
+						position, tokenIndex = position77, tokenIndex77
+						if buffer[position] != rune(':') {
 							goto l68
 						}
 						position++
 					}
-				l76:
+				l77:
 					goto l67
 				l68:
 					position, tokenIndex = position68, tokenIndex68
@@ -1071,78 +1085,62 @@ func (p *Parser) Init(options ...func(*Parser) error) error {
 		},
 		/* 12 Paths <- <(PathString (',' WS* PathString)*)> */
 		func() bool {
-			position83, tokenIndex83 := position, tokenIndex
+			position85, tokenIndex85 := position, tokenIndex
 			{
-				position84 := position
+				position86 := position
 				if !_rules[rulePathString]() {
-					goto l83
+					goto l85
 				}
-			l85:
+			l87:
 				{
-					position86, tokenIndex86 := position, tokenIndex
+					position88, tokenIndex88 := position, tokenIndex
 					if buffer[position] != rune(',') {
-						goto l86
+						goto l88
 					}
 					position++
-				l87:
+				l89:
 					{
-						position88, tokenIndex88 := position, tokenIndex
+						position90, tokenIndex90 := position, tokenIndex
 						if !_rules[ruleWS]() {
-							goto l88
+							goto l90
 						}
-						goto l87
-					l88:
-						position, tokenIndex = position88, tokenIndex88
+						goto l89
+					l90:
+						position, tokenIndex = position90, tokenIndex90
 					}
 					if !_rules[rulePathString]() {
-						goto l86
+						goto l88
 					}
-					goto l85
-				l86:
-					position, tokenIndex = position86, tokenIndex86
+					goto l87
+				l88:
+					position, tokenIndex = position88, tokenIndex88
 				}
-				add(rulePaths, position84)
+				add(rulePaths, position86)
 			}
 			return true
-		l83:
-			position, tokenIndex = position83, tokenIndex83
+		l85:
+			position, tokenIndex = position85, tokenIndex85
 			return false
 		},
 		/* 13 Directive <- <('{' '-' '#' WS+ DirectiveName WS+ (IntString / UpperCaseString / Paths) WS+ ('#' '-' '}'))> */
 		func() bool {
-			position89, tokenIndex89 := position, tokenIndex
+			position91, tokenIndex91 := position, tokenIndex
 			{
-				position90 := position
+				position92 := position
 				if buffer[position] != rune('{') {
-					goto l89
+					goto l91
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l89
+					goto l91
 				}
 				position++
 				if buffer[position] != rune('#') {
-					goto l89
+					goto l91
 				}
 				position++
 				if !_rules[ruleWS]() {
-					goto l89
-				}
-			l91:
-				{
-					position92, tokenIndex92 := position, tokenIndex
-					if !_rules[ruleWS]() {
-						goto l92
-					}
 					goto l91
-				l92:
-					position, tokenIndex = position92, tokenIndex92
-				}
-				if !_rules[ruleDirectiveName]() {
-					goto l89
-				}
-				if !_rules[ruleWS]() {
-					goto l89
 				}
 			l93:
 				{
@@ -1154,5163 +1152,5179 @@ func (p *Parser) Init(options ...func(*Parser) error) error {
 				l94:
 					position, tokenIndex = position94, tokenIndex94
 				}
+				if !_rules[ruleDirectiveName]() {
+					goto l91
+				}
+				if !_rules[ruleWS]() {
+					goto l91
+				}
+			l95:
 				{
-					position95, tokenIndex95 := position, tokenIndex
-					if !_rules[ruleIntString]() {
+					position96, tokenIndex96 := position, tokenIndex
+					if !_rules[ruleWS]() {
 						goto l96
 					}
 					goto l95
 				l96:
-					position, tokenIndex = position95, tokenIndex95
+					position, tokenIndex = position96, tokenIndex96
+				}
+				{
+					position97, tokenIndex97 := position, tokenIndex
+					if !_rules[ruleIntString]() {
+						goto l98
+					}
+					goto l97
+				l98:
+					position, tokenIndex = position97, tokenIndex97
 					if !_rules[ruleUpperCaseString]() {
-						goto l97
+						goto l99
 					}
-					goto l95
-				l97:
-					position, tokenIndex = position95, tokenIndex95
+					goto l97
+				l99:
+					position, tokenIndex = position97, tokenIndex97
 					if !_rules[rulePaths]() {
-						goto l89
+						goto l91
 					}
 				}
-			l95:
+			l97:
 				if !_rules[ruleWS]() {
-					goto l89
+					goto l91
 				}
-			l98:
+			l100:
 				{
-					position99, tokenIndex99 := position, tokenIndex
+					position101, tokenIndex101 := position, tokenIndex
 					if !_rules[ruleWS]() {
-						goto l99
+						goto l101
 					}
-					goto l98
-				l99:
-					position, tokenIndex = position99, tokenIndex99
+					goto l100
+				l101:
+					position, tokenIndex = position101, tokenIndex101
 				}
 				if buffer[position] != rune('#') {
-					goto l89
+					goto l91
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l89
+					goto l91
 				}
 				position++
 				if buffer[position] != rune('}') {
-					goto l89
+					goto l91
 				}
 				position++
-				add(ruleDirective, position90)
+				add(ruleDirective, position92)
 			}
 			return true
-		l89:
-			position, tokenIndex = position89, tokenIndex89
+		l91:
+			position, tokenIndex = position91, tokenIndex91
 			return false
 		},
 		/* 14 Declaration <- <((Variable / StrictVariable / Func) _ ';'?)> */
 		func() bool {
-			position100, tokenIndex100 := position, tokenIndex
+			position102, tokenIndex102 := position, tokenIndex
 			{
-				position101 := position
+				position103 := position
 				{
-					position102, tokenIndex102 := position, tokenIndex
+					position104, tokenIndex104 := position, tokenIndex
 					if !_rules[ruleVariable]() {
-						goto l103
+						goto l105
 					}
-					goto l102
-				l103:
-					position, tokenIndex = position102, tokenIndex102
+					goto l104
+				l105:
+					position, tokenIndex = position104, tokenIndex104
 					if !_rules[ruleStrictVariable]() {
-						goto l104
+						goto l106
 					}
-					goto l102
-				l104:
-					position, tokenIndex = position102, tokenIndex102
+					goto l104
+				l106:
+					position, tokenIndex = position104, tokenIndex104
 					if !_rules[ruleFunc]() {
-						goto l100
+						goto l102
 					}
 				}
-			l102:
+			l104:
 				if !_rules[rule_]() {
-					goto l100
+					goto l102
 				}
 				{
-					position105, tokenIndex105 := position, tokenIndex
+					position107, tokenIndex107 := position, tokenIndex
 					if buffer[position] != rune(';') {
-						goto l105
+						goto l107
 					}
 					position++
-					goto l106
-				l105:
-					position, tokenIndex = position105, tokenIndex105
+					goto l108
+				l107:
+					position, tokenIndex = position107, tokenIndex107
 				}
-			l106:
-				add(ruleDeclaration, position101)
+			l108:
+				add(ruleDeclaration, position103)
 			}
 			return true
-		l100:
-			position, tokenIndex = position100, tokenIndex100
+		l102:
+			position, tokenIndex = position102, tokenIndex102
 			return false
 		},
 		/* 15 Variable <- <('l' 'e' 't' _ (Identifier / TupleRef) _ '=' _ Expr)> */
 		func() bool {
-			position107, tokenIndex107 := position, tokenIndex
+			position109, tokenIndex109 := position, tokenIndex
 			{
-				position108 := position
+				position110 := position
 				if buffer[position] != rune('l') {
-					goto l107
+					goto l109
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l107
+					goto l109
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l107
+					goto l109
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l107
+					goto l109
 				}
 				{
-					position109, tokenIndex109 := position, tokenIndex
+					position111, tokenIndex111 := position, tokenIndex
 					if !_rules[ruleIdentifier]() {
-						goto l110
+						goto l112
 					}
-					goto l109
-				l110:
-					position, tokenIndex = position109, tokenIndex109
+					goto l111
+				l112:
+					position, tokenIndex = position111, tokenIndex111
 					if !_rules[ruleTupleRef]() {
-						goto l107
+						goto l109
 					}
 				}
-			l109:
+			l111:
 				if !_rules[rule_]() {
-					goto l107
+					goto l109
 				}
 				if buffer[position] != rune('=') {
-					goto l107
+					goto l109
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l107
+					goto l109
 				}
 				if !_rules[ruleExpr]() {
-					goto l107
+					goto l109
 				}
-				add(ruleVariable, position108)
+				add(ruleVariable, position110)
 			}
 			return true
-		l107:
-			position, tokenIndex = position107, tokenIndex107
+		l109:
+			position, tokenIndex = position109, tokenIndex109
 			return false
 		},
 		/* 16 StrictVariable <- <('s' 't' 'r' 'i' 'c' 't' _ (Identifier / TupleRef) _ '=' _ Expr)> */
 		func() bool {
-			position111, tokenIndex111 := position, tokenIndex
+			position113, tokenIndex113 := position, tokenIndex
 			{
-				position112 := position
+				position114 := position
 				if buffer[position] != rune('s') {
-					goto l111
+					goto l113
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l111
+					goto l113
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l111
+					goto l113
 				}
 				position++
 				if buffer[position] != rune('i') {
-					goto l111
+					goto l113
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l111
+					goto l113
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l111
+					goto l113
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l111
+					goto l113
 				}
 				{
-					position113, tokenIndex113 := position, tokenIndex
+					position115, tokenIndex115 := position, tokenIndex
 					if !_rules[ruleIdentifier]() {
-						goto l114
+						goto l116
 					}
-					goto l113
-				l114:
-					position, tokenIndex = position113, tokenIndex113
+					goto l115
+				l116:
+					position, tokenIndex = position115, tokenIndex115
 					if !_rules[ruleTupleRef]() {
-						goto l111
+						goto l113
 					}
 				}
-			l113:
+			l115:
 				if !_rules[rule_]() {
-					goto l111
+					goto l113
 				}
 				if buffer[position] != rune('=') {
-					goto l111
+					goto l113
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l111
+					goto l113
 				}
 				if !_rules[ruleExpr]() {
-					goto l111
+					goto l113
 				}
-				add(ruleStrictVariable, position112)
+				add(ruleStrictVariable, position114)
 			}
 			return true
-		l111:
-			position, tokenIndex = position111, tokenIndex111
+		l113:
+			position, tokenIndex = position113, tokenIndex113
 			return false
 		},
 		/* 17 Func <- <('f' 'u' 'n' 'c' _ Identifier _ '(' _ FuncArgSeq? _ ')' _ '=' _ Expr)> */
 		func() bool {
-			position115, tokenIndex115 := position, tokenIndex
+			position117, tokenIndex117 := position, tokenIndex
 			{
-				position116 := position
+				position118 := position
 				if buffer[position] != rune('f') {
-					goto l115
+					goto l117
 				}
 				position++
 				if buffer[position] != rune('u') {
-					goto l115
+					goto l117
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l115
+					goto l117
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l115
+					goto l117
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l115
+					goto l117
 				}
 				if !_rules[ruleIdentifier]() {
-					goto l115
+					goto l117
 				}
 				if !_rules[rule_]() {
-					goto l115
+					goto l117
 				}
 				if buffer[position] != rune('(') {
-					goto l115
+					goto l117
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l115
+					goto l117
 				}
 				{
-					position117, tokenIndex117 := position, tokenIndex
+					position119, tokenIndex119 := position, tokenIndex
 					if !_rules[ruleFuncArgSeq]() {
-						goto l117
+						goto l119
 					}
-					goto l118
-				l117:
-					position, tokenIndex = position117, tokenIndex117
+					goto l120
+				l119:
+					position, tokenIndex = position119, tokenIndex119
 				}
-			l118:
+			l120:
 				if !_rules[rule_]() {
-					goto l115
+					goto l117
 				}
 				if buffer[position] != rune(')') {
-					goto l115
+					goto l117
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l115
+					goto l117
 				}
 				if buffer[position] != rune('=') {
-					goto l115
+					goto l117
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l115
+					goto l117
 				}
 				if !_rules[ruleExpr]() {
-					goto l115
+					goto l117
 				}
-				add(ruleFunc, position116)
+				add(ruleFunc, position118)
 			}
 			return true
-		l115:
-			position, tokenIndex = position115, tokenIndex115
+		l117:
+			position, tokenIndex = position117, tokenIndex117
 			return false
 		},
 		/* 18 FuncArgSeq <- <(FuncArg (_ ',' _ FuncArgSeq)?)> */
 		func() bool {
-			position119, tokenIndex119 := position, tokenIndex
+			position121, tokenIndex121 := position, tokenIndex
 			{
-				position120 := position
+				position122 := position
 				if !_rules[ruleFuncArg]() {
-					goto l119
+					goto l121
 				}
 				{
-					position121, tokenIndex121 := position, tokenIndex
+					position123, tokenIndex123 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l121
+						goto l123
 					}
 					if buffer[position] != rune(',') {
-						goto l121
+						goto l123
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l121
+						goto l123
 					}
 					if !_rules[ruleFuncArgSeq]() {
-						goto l121
+						goto l123
 					}
-					goto l122
-				l121:
-					position, tokenIndex = position121, tokenIndex121
+					goto l124
+				l123:
+					position, tokenIndex = position123, tokenIndex123
 				}
-			l122:
-				add(ruleFuncArgSeq, position120)
+			l124:
+				add(ruleFuncArgSeq, position122)
 			}
 			return true
-		l119:
-			position, tokenIndex = position119, tokenIndex119
+		l121:
+			position, tokenIndex = position121, tokenIndex121
 			return false
 		},
 		/* 19 FuncArg <- <(Identifier _ ':' _ Types)> */
 		func() bool {
-			position123, tokenIndex123 := position, tokenIndex
+			position125, tokenIndex125 := position, tokenIndex
 			{
-				position124 := position
+				position126 := position
 				if !_rules[ruleIdentifier]() {
-					goto l123
+					goto l125
 				}
 				if !_rules[rule_]() {
-					goto l123
+					goto l125
 				}
 				if buffer[position] != rune(':') {
-					goto l123
+					goto l125
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l123
+					goto l125
 				}
 				if !_rules[ruleTypes]() {
-					goto l123
+					goto l125
 				}
-				add(ruleFuncArg, position124)
+				add(ruleFuncArg, position126)
 			}
 			return true
-		l123:
-			position, tokenIndex = position123, tokenIndex123
+		l125:
+			position, tokenIndex = position125, tokenIndex125
 			return false
 		},
 		/* 20 AnnotatedFunc <- <(AnnotationSeq _ Func)> */
 		func() bool {
-			position125, tokenIndex125 := position, tokenIndex
+			position127, tokenIndex127 := position, tokenIndex
 			{
-				position126 := position
+				position128 := position
 				if !_rules[ruleAnnotationSeq]() {
-					goto l125
+					goto l127
 				}
 				if !_rules[rule_]() {
-					goto l125
+					goto l127
 				}
 				if !_rules[ruleFunc]() {
-					goto l125
+					goto l127
 				}
-				add(ruleAnnotatedFunc, position126)
+				add(ruleAnnotatedFunc, position128)
 			}
 			return true
-		l125:
-			position, tokenIndex = position125, tokenIndex125
+		l127:
+			position, tokenIndex = position127, tokenIndex127
 			return false
 		},
 		/* 21 Annotation <- <('@' Identifier _ '(' _ IdentifierSeq _ ')')> */
 		func() bool {
-			position127, tokenIndex127 := position, tokenIndex
+			position129, tokenIndex129 := position, tokenIndex
 			{
-				position128 := position
+				position130 := position
 				if buffer[position] != rune('@') {
-					goto l127
+					goto l129
 				}
 				position++
 				if !_rules[ruleIdentifier]() {
-					goto l127
+					goto l129
 				}
 				if !_rules[rule_]() {
-					goto l127
+					goto l129
 				}
 				if buffer[position] != rune('(') {
-					goto l127
+					goto l129
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l127
+					goto l129
 				}
 				if !_rules[ruleIdentifierSeq]() {
-					goto l127
+					goto l129
 				}
 				if !_rules[rule_]() {
-					goto l127
+					goto l129
 				}
 				if buffer[position] != rune(')') {
-					goto l127
+					goto l129
 				}
 				position++
-				add(ruleAnnotation, position128)
+				add(ruleAnnotation, position130)
 			}
 			return true
-		l127:
-			position, tokenIndex = position127, tokenIndex127
+		l129:
+			position, tokenIndex = position129, tokenIndex129
 			return false
 		},
 		/* 22 IdentifierSeq <- <(Identifier (_ ',' _ IdentifierSeq)?)> */
 		func() bool {
-			position129, tokenIndex129 := position, tokenIndex
+			position131, tokenIndex131 := position, tokenIndex
 			{
-				position130 := position
+				position132 := position
 				if !_rules[ruleIdentifier]() {
-					goto l129
+					goto l131
 				}
 				{
-					position131, tokenIndex131 := position, tokenIndex
+					position133, tokenIndex133 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l131
+						goto l133
 					}
 					if buffer[position] != rune(',') {
-						goto l131
+						goto l133
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l131
+						goto l133
 					}
 					if !_rules[ruleIdentifierSeq]() {
-						goto l131
+						goto l133
 					}
-					goto l132
-				l131:
-					position, tokenIndex = position131, tokenIndex131
+					goto l134
+				l133:
+					position, tokenIndex = position133, tokenIndex133
 				}
-			l132:
-				add(ruleIdentifierSeq, position130)
+			l134:
+				add(ruleIdentifierSeq, position132)
 			}
 			return true
-		l129:
-			position, tokenIndex = position129, tokenIndex129
+		l131:
+			position, tokenIndex = position131, tokenIndex131
 			return false
 		},
 		/* 23 AnnotationSeq <- <(Annotation (_ AnnotationSeq)?)> */
 		func() bool {
-			position133, tokenIndex133 := position, tokenIndex
+			position135, tokenIndex135 := position, tokenIndex
 			{
-				position134 := position
+				position136 := position
 				if !_rules[ruleAnnotation]() {
-					goto l133
+					goto l135
 				}
 				{
-					position135, tokenIndex135 := position, tokenIndex
+					position137, tokenIndex137 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l135
+						goto l137
 					}
 					if !_rules[ruleAnnotationSeq]() {
-						goto l135
+						goto l137
 					}
-					goto l136
-				l135:
-					position, tokenIndex = position135, tokenIndex135
+					goto l138
+				l137:
+					position, tokenIndex = position137, tokenIndex137
 				}
-			l136:
-				add(ruleAnnotationSeq, position134)
+			l138:
+				add(ruleAnnotationSeq, position136)
 			}
 			return true
-		l133:
-			position, tokenIndex = position133, tokenIndex133
+		l135:
+			position, tokenIndex = position135, tokenIndex135
 			return false
 		},
 		/* 24 OrOp <- <('|' '|')> */
 		func() bool {
-			position137, tokenIndex137 := position, tokenIndex
+			position139, tokenIndex139 := position, tokenIndex
 			{
-				position138 := position
+				position140 := position
 				if buffer[position] != rune('|') {
-					goto l137
+					goto l139
 				}
 				position++
 				if buffer[position] != rune('|') {
-					goto l137
+					goto l139
 				}
 				position++
-				add(ruleOrOp, position138)
+				add(ruleOrOp, position140)
 			}
 			return true
-		l137:
-			position, tokenIndex = position137, tokenIndex137
+		l139:
+			position, tokenIndex = position139, tokenIndex139
 			return false
 		},
 		/* 25 AndOp <- <('&' '&')> */
 		func() bool {
-			position139, tokenIndex139 := position, tokenIndex
+			position141, tokenIndex141 := position, tokenIndex
 			{
-				position140 := position
+				position142 := position
 				if buffer[position] != rune('&') {
-					goto l139
+					goto l141
 				}
 				position++
 				if buffer[position] != rune('&') {
-					goto l139
+					goto l141
 				}
 				position++
-				add(ruleAndOp, position140)
+				add(ruleAndOp, position142)
 			}
 			return true
-		l139:
-			position, tokenIndex = position139, tokenIndex139
+		l141:
+			position, tokenIndex = position141, tokenIndex141
 			return false
 		},
 		/* 26 EqGroupOp <- <(EqOp / NeOp)> */
 		func() bool {
-			position141, tokenIndex141 := position, tokenIndex
+			position143, tokenIndex143 := position, tokenIndex
 			{
-				position142 := position
+				position144 := position
 				{
-					position143, tokenIndex143 := position, tokenIndex
+					position145, tokenIndex145 := position, tokenIndex
 					if !_rules[ruleEqOp]() {
-						goto l144
+						goto l146
 					}
-					goto l143
-				l144:
-					position, tokenIndex = position143, tokenIndex143
+					goto l145
+				l146:
+					position, tokenIndex = position145, tokenIndex145
 					if !_rules[ruleNeOp]() {
-						goto l141
+						goto l143
 					}
 				}
-			l143:
-				add(ruleEqGroupOp, position142)
+			l145:
+				add(ruleEqGroupOp, position144)
 			}
 			return true
-		l141:
-			position, tokenIndex = position141, tokenIndex141
+		l143:
+			position, tokenIndex = position143, tokenIndex143
 			return false
 		},
 		/* 27 EqOp <- <('=' '=')> */
 		func() bool {
-			position145, tokenIndex145 := position, tokenIndex
+			position147, tokenIndex147 := position, tokenIndex
 			{
-				position146 := position
+				position148 := position
 				if buffer[position] != rune('=') {
-					goto l145
+					goto l147
 				}
 				position++
 				if buffer[position] != rune('=') {
-					goto l145
+					goto l147
 				}
 				position++
-				add(ruleEqOp, position146)
+				add(ruleEqOp, position148)
 			}
 			return true
-		l145:
-			position, tokenIndex = position145, tokenIndex145
+		l147:
+			position, tokenIndex = position147, tokenIndex147
 			return false
 		},
 		/* 28 NeOp <- <('!' '=')> */
 		func() bool {
-			position147, tokenIndex147 := position, tokenIndex
+			position149, tokenIndex149 := position, tokenIndex
 			{
-				position148 := position
+				position150 := position
 				if buffer[position] != rune('!') {
-					goto l147
+					goto l149
 				}
 				position++
 				if buffer[position] != rune('=') {
-					goto l147
+					goto l149
 				}
 				position++
-				add(ruleNeOp, position148)
+				add(ruleNeOp, position150)
 			}
 			return true
-		l147:
-			position, tokenIndex = position147, tokenIndex147
+		l149:
+			position, tokenIndex = position149, tokenIndex149
 			return false
 		},
 		/* 29 CompareGroupOp <- <(GtOp / GeOp / LtOp / LeOp)> */
 		func() bool {
-			position149, tokenIndex149 := position, tokenIndex
+			position151, tokenIndex151 := position, tokenIndex
 			{
-				position150 := position
+				position152 := position
 				{
-					position151, tokenIndex151 := position, tokenIndex
+					position153, tokenIndex153 := position, tokenIndex
 					if !_rules[ruleGtOp]() {
-						goto l152
+						goto l154
 					}
-					goto l151
-				l152:
-					position, tokenIndex = position151, tokenIndex151
+					goto l153
+				l154:
+					position, tokenIndex = position153, tokenIndex153
 					if !_rules[ruleGeOp]() {
-						goto l153
+						goto l155
 					}
-					goto l151
-				l153:
-					position, tokenIndex = position151, tokenIndex151
+					goto l153
+				l155:
+					position, tokenIndex = position153, tokenIndex153
 					if !_rules[ruleLtOp]() {
-						goto l154
+						goto l156
 					}
-					goto l151
-				l154:
-					position, tokenIndex = position151, tokenIndex151
+					goto l153
+				l156:
+					position, tokenIndex = position153, tokenIndex153
 					if !_rules[ruleLeOp]() {
-						goto l149
+						goto l151
 					}
 				}
-			l151:
-				add(ruleCompareGroupOp, position150)
+			l153:
+				add(ruleCompareGroupOp, position152)
 			}
 			return true
-		l149:
-			position, tokenIndex = position149, tokenIndex149
+		l151:
+			position, tokenIndex = position151, tokenIndex151
 			return false
 		},
 		/* 30 GtOp <- <('>' !'=')> */
 		func() bool {
-			position155, tokenIndex155 := position, tokenIndex
+			position157, tokenIndex157 := position, tokenIndex
 			{
-				position156 := position
+				position158 := position
 				if buffer[position] != rune('>') {
-					goto l155
+					goto l157
 				}
 				position++
 				{
-					position157, tokenIndex157 := position, tokenIndex
+					position159, tokenIndex159 := position, tokenIndex
 					if buffer[position] != rune('=') {
-						goto l157
+						goto l159
 					}
 					position++
-					goto l155
-				l157:
-					position, tokenIndex = position157, tokenIndex157
+					goto l157
+				l159:
+					position, tokenIndex = position159, tokenIndex159
 				}
-				add(ruleGtOp, position156)
+				add(ruleGtOp, position158)
 			}
 			return true
-		l155:
-			position, tokenIndex = position155, tokenIndex155
+		l157:
+			position, tokenIndex = position157, tokenIndex157
 			return false
 		},
 		/* 31 GeOp <- <('>' '=')> */
 		func() bool {
-			position158, tokenIndex158 := position, tokenIndex
+			position160, tokenIndex160 := position, tokenIndex
 			{
-				position159 := position
+				position161 := position
 				if buffer[position] != rune('>') {
-					goto l158
+					goto l160
 				}
 				position++
 				if buffer[position] != rune('=') {
-					goto l158
+					goto l160
 				}
 				position++
-				add(ruleGeOp, position159)
+				add(ruleGeOp, position161)
 			}
 			return true
-		l158:
-			position, tokenIndex = position158, tokenIndex158
+		l160:
+			position, tokenIndex = position160, tokenIndex160
 			return false
 		},
 		/* 32 LtOp <- <('<' !'=')> */
 		func() bool {
-			position160, tokenIndex160 := position, tokenIndex
+			position162, tokenIndex162 := position, tokenIndex
 			{
-				position161 := position
+				position163 := position
 				if buffer[position] != rune('<') {
-					goto l160
+					goto l162
 				}
 				position++
 				{
-					position162, tokenIndex162 := position, tokenIndex
+					position164, tokenIndex164 := position, tokenIndex
 					if buffer[position] != rune('=') {
-						goto l162
+						goto l164
 					}
 					position++
-					goto l160
-				l162:
-					position, tokenIndex = position162, tokenIndex162
+					goto l162
+				l164:
+					position, tokenIndex = position164, tokenIndex164
 				}
-				add(ruleLtOp, position161)
+				add(ruleLtOp, position163)
 			}
 			return true
-		l160:
-			position, tokenIndex = position160, tokenIndex160
+		l162:
+			position, tokenIndex = position162, tokenIndex162
 			return false
 		},
 		/* 33 LeOp <- <('<' '=')> */
 		func() bool {
-			position163, tokenIndex163 := position, tokenIndex
+			position165, tokenIndex165 := position, tokenIndex
 			{
-				position164 := position
+				position166 := position
 				if buffer[position] != rune('<') {
-					goto l163
+					goto l165
 				}
 				position++
 				if buffer[position] != rune('=') {
-					goto l163
+					goto l165
 				}
 				position++
-				add(ruleLeOp, position164)
+				add(ruleLeOp, position166)
 			}
 			return true
-		l163:
-			position, tokenIndex = position163, tokenIndex163
+		l165:
+			position, tokenIndex = position165, tokenIndex165
 			return false
 		},
 		/* 34 ListGroupOp <- <(ConsOp / ConcatOp / AppendOp)> */
 		func() bool {
-			position165, tokenIndex165 := position, tokenIndex
+			position167, tokenIndex167 := position, tokenIndex
 			{
-				position166 := position
+				position168 := position
 				{
-					position167, tokenIndex167 := position, tokenIndex
+					position169, tokenIndex169 := position, tokenIndex
 					if !_rules[ruleConsOp]() {
-						goto l168
+						goto l170
 					}
-					goto l167
-				l168:
-					position, tokenIndex = position167, tokenIndex167
+					goto l169
+				l170:
+					position, tokenIndex = position169, tokenIndex169
 					if !_rules[ruleConcatOp]() {
-						goto l169
+						goto l171
 					}
-					goto l167
-				l169:
-					position, tokenIndex = position167, tokenIndex167
+					goto l169
+				l171:
+					position, tokenIndex = position169, tokenIndex169
 					if !_rules[ruleAppendOp]() {
-						goto l165
+						goto l167
 					}
 				}
-			l167:
-				add(ruleListGroupOp, position166)
+			l169:
+				add(ruleListGroupOp, position168)
 			}
 			return true
-		l165:
-			position, tokenIndex = position165, tokenIndex165
+		l167:
+			position, tokenIndex = position167, tokenIndex167
 			return false
 		},
 		/* 35 ConsOp <- <(':' ':')> */
 		func() bool {
-			position170, tokenIndex170 := position, tokenIndex
+			position172, tokenIndex172 := position, tokenIndex
 			{
-				position171 := position
+				position173 := position
 				if buffer[position] != rune(':') {
-					goto l170
+					goto l172
 				}
 				position++
 				if buffer[position] != rune(':') {
-					goto l170
+					goto l172
 				}
 				position++
-				add(ruleConsOp, position171)
+				add(ruleConsOp, position173)
 			}
 			return true
-		l170:
-			position, tokenIndex = position170, tokenIndex170
+		l172:
+			position, tokenIndex = position172, tokenIndex172
 			return false
 		},
 		/* 36 ConcatOp <- <('+' '+')> */
 		func() bool {
-			position172, tokenIndex172 := position, tokenIndex
+			position174, tokenIndex174 := position, tokenIndex
 			{
-				position173 := position
+				position175 := position
 				if buffer[position] != rune('+') {
-					goto l172
+					goto l174
 				}
 				position++
 				if buffer[position] != rune('+') {
-					goto l172
+					goto l174
 				}
 				position++
-				add(ruleConcatOp, position173)
+				add(ruleConcatOp, position175)
 			}
 			return true
-		l172:
-			position, tokenIndex = position172, tokenIndex172
+		l174:
+			position, tokenIndex = position174, tokenIndex174
 			return false
 		},
 		/* 37 AppendOp <- <(':' '+')> */
 		func() bool {
-			position174, tokenIndex174 := position, tokenIndex
+			position176, tokenIndex176 := position, tokenIndex
 			{
-				position175 := position
+				position177 := position
 				if buffer[position] != rune(':') {
-					goto l174
+					goto l176
 				}
 				position++
 				if buffer[position] != rune('+') {
-					goto l174
+					goto l176
 				}
 				position++
-				add(ruleAppendOp, position175)
+				add(ruleAppendOp, position177)
 			}
 			return true
-		l174:
-			position, tokenIndex = position174, tokenIndex174
+		l176:
+			position, tokenIndex = position176, tokenIndex176
 			return false
 		},
 		/* 38 SumGroupOp <- <(SumOp / SubOp)> */
 		func() bool {
-			position176, tokenIndex176 := position, tokenIndex
+			position178, tokenIndex178 := position, tokenIndex
 			{
-				position177 := position
+				position179 := position
 				{
-					position178, tokenIndex178 := position, tokenIndex
+					position180, tokenIndex180 := position, tokenIndex
 					if !_rules[ruleSumOp]() {
-						goto l179
+						goto l181
 					}
-					goto l178
-				l179:
-					position, tokenIndex = position178, tokenIndex178
+					goto l180
+				l181:
+					position, tokenIndex = position180, tokenIndex180
 					if !_rules[ruleSubOp]() {
-						goto l176
+						goto l178
 					}
 				}
-			l178:
-				add(ruleSumGroupOp, position177)
+			l180:
+				add(ruleSumGroupOp, position179)
 			}
 			return true
-		l176:
-			position, tokenIndex = position176, tokenIndex176
+		l178:
+			position, tokenIndex = position178, tokenIndex178
 			return false
 		},
 		/* 39 SumOp <- <('+' !'+')> */
 		func() bool {
-			position180, tokenIndex180 := position, tokenIndex
+			position182, tokenIndex182 := position, tokenIndex
 			{
-				position181 := position
+				position183 := position
 				if buffer[position] != rune('+') {
-					goto l180
+					goto l182
 				}
 				position++
 				{
-					position182, tokenIndex182 := position, tokenIndex
+					position184, tokenIndex184 := position, tokenIndex
 					if buffer[position] != rune('+') {
-						goto l182
+						goto l184
 					}
 					position++
-					goto l180
-				l182:
-					position, tokenIndex = position182, tokenIndex182
+					goto l182
+				l184:
+					position, tokenIndex = position184, tokenIndex184
 				}
-				add(ruleSumOp, position181)
+				add(ruleSumOp, position183)
 			}
 			return true
-		l180:
-			position, tokenIndex = position180, tokenIndex180
+		l182:
+			position, tokenIndex = position182, tokenIndex182
 			return false
 		},
 		/* 40 SubOp <- <'-'> */
 		func() bool {
-			position183, tokenIndex183 := position, tokenIndex
+			position185, tokenIndex185 := position, tokenIndex
 			{
-				position184 := position
+				position186 := position
 				if buffer[position] != rune('-') {
-					goto l183
+					goto l185
 				}
 				position++
-				add(ruleSubOp, position184)
+				add(ruleSubOp, position186)
 			}
 			return true
-		l183:
-			position, tokenIndex = position183, tokenIndex183
+		l185:
+			position, tokenIndex = position185, tokenIndex185
 			return false
 		},
 		/* 41 MultGroupOp <- <(MulOp / DivOp / ModOp)> */
 		func() bool {
-			position185, tokenIndex185 := position, tokenIndex
+			position187, tokenIndex187 := position, tokenIndex
 			{
-				position186 := position
+				position188 := position
 				{
-					position187, tokenIndex187 := position, tokenIndex
+					position189, tokenIndex189 := position, tokenIndex
 					if !_rules[ruleMulOp]() {
-						goto l188
+						goto l190
 					}
-					goto l187
-				l188:
-					position, tokenIndex = position187, tokenIndex187
+					goto l189
+				l190:
+					position, tokenIndex = position189, tokenIndex189
 					if !_rules[ruleDivOp]() {
-						goto l189
+						goto l191
 					}
-					goto l187
-				l189:
-					position, tokenIndex = position187, tokenIndex187
+					goto l189
+				l191:
+					position, tokenIndex = position189, tokenIndex189
 					if !_rules[ruleModOp]() {
-						goto l185
+						goto l187
 					}
 				}
-			l187:
-				add(ruleMultGroupOp, position186)
+			l189:
+				add(ruleMultGroupOp, position188)
 			}
 			return true
-		l185:
-			position, tokenIndex = position185, tokenIndex185
+		l187:
+			position, tokenIndex = position187, tokenIndex187
 			return false
 		},
 		/* 42 MulOp <- <'*'> */
 		func() bool {
-			position190, tokenIndex190 := position, tokenIndex
+			position192, tokenIndex192 := position, tokenIndex
 			{
-				position191 := position
+				position193 := position
 				if buffer[position] != rune('*') {
-					goto l190
+					goto l192
 				}
 				position++
-				add(ruleMulOp, position191)
+				add(ruleMulOp, position193)
 			}
 			return true
-		l190:
-			position, tokenIndex = position190, tokenIndex190
+		l192:
+			position, tokenIndex = position192, tokenIndex192
 			return false
 		},
 		/* 43 DivOp <- <'/'> */
 		func() bool {
-			position192, tokenIndex192 := position, tokenIndex
+			position194, tokenIndex194 := position, tokenIndex
 			{
-				position193 := position
+				position195 := position
 				if buffer[position] != rune('/') {
-					goto l192
+					goto l194
 				}
 				position++
-				add(ruleDivOp, position193)
+				add(ruleDivOp, position195)
 			}
 			return true
-		l192:
-			position, tokenIndex = position192, tokenIndex192
+		l194:
+			position, tokenIndex = position194, tokenIndex194
 			return false
 		},
 		/* 44 ModOp <- <'%'> */
 		func() bool {
-			position194, tokenIndex194 := position, tokenIndex
+			position196, tokenIndex196 := position, tokenIndex
 			{
-				position195 := position
+				position197 := position
 				if buffer[position] != rune('%') {
-					goto l194
+					goto l196
 				}
 				position++
-				add(ruleModOp, position195)
+				add(ruleModOp, position197)
 			}
 			return true
-		l194:
-			position, tokenIndex = position194, tokenIndex194
+		l196:
+			position, tokenIndex = position196, tokenIndex196
 			return false
 		},
 		/* 45 UnaryOp <- <(PositiveOp / NegativeOp / NotOp)> */
 		func() bool {
-			position196, tokenIndex196 := position, tokenIndex
+			position198, tokenIndex198 := position, tokenIndex
 			{
-				position197 := position
+				position199 := position
 				{
-					position198, tokenIndex198 := position, tokenIndex
+					position200, tokenIndex200 := position, tokenIndex
 					if !_rules[rulePositiveOp]() {
-						goto l199
+						goto l201
 					}
-					goto l198
-				l199:
-					position, tokenIndex = position198, tokenIndex198
+					goto l200
+				l201:
+					position, tokenIndex = position200, tokenIndex200
 					if !_rules[ruleNegativeOp]() {
-						goto l200
+						goto l202
 					}
-					goto l198
-				l200:
-					position, tokenIndex = position198, tokenIndex198
+					goto l200
+				l202:
+					position, tokenIndex = position200, tokenIndex200
 					if !_rules[ruleNotOp]() {
-						goto l196
+						goto l198
 					}
 				}
-			l198:
-				add(ruleUnaryOp, position197)
+			l200:
+				add(ruleUnaryOp, position199)
 			}
 			return true
-		l196:
-			position, tokenIndex = position196, tokenIndex196
+		l198:
+			position, tokenIndex = position198, tokenIndex198
 			return false
 		},
 		/* 46 PositiveOp <- <'+'> */
 		func() bool {
-			position201, tokenIndex201 := position, tokenIndex
+			position203, tokenIndex203 := position, tokenIndex
 			{
-				position202 := position
+				position204 := position
 				if buffer[position] != rune('+') {
-					goto l201
+					goto l203
 				}
 				position++
-				add(rulePositiveOp, position202)
+				add(rulePositiveOp, position204)
 			}
 			return true
-		l201:
-			position, tokenIndex = position201, tokenIndex201
+		l203:
+			position, tokenIndex = position203, tokenIndex203
 			return false
 		},
 		/* 47 NegativeOp <- <('-' !'#')> */
 		func() bool {
-			position203, tokenIndex203 := position, tokenIndex
+			position205, tokenIndex205 := position, tokenIndex
 			{
-				position204 := position
+				position206 := position
 				if buffer[position] != rune('-') {
-					goto l203
+					goto l205
 				}
 				position++
 				{
-					position205, tokenIndex205 := position, tokenIndex
+					position207, tokenIndex207 := position, tokenIndex
 					if buffer[position] != rune('#') {
-						goto l205
+						goto l207
 					}
 					position++
-					goto l203
-				l205:
-					position, tokenIndex = position205, tokenIndex205
+					goto l205
+				l207:
+					position, tokenIndex = position207, tokenIndex207
 				}
-				add(ruleNegativeOp, position204)
+				add(ruleNegativeOp, position206)
 			}
 			return true
-		l203:
-			position, tokenIndex = position203, tokenIndex203
+		l205:
+			position, tokenIndex = position205, tokenIndex205
 			return false
 		},
 		/* 48 NotOp <- <'!'> */
 		func() bool {
-			position206, tokenIndex206 := position, tokenIndex
+			position208, tokenIndex208 := position, tokenIndex
 			{
-				position207 := position
+				position209 := position
 				if buffer[position] != rune('!') {
-					goto l206
+					goto l208
 				}
 				position++
-				add(ruleNotOp, position207)
+				add(ruleNotOp, position209)
 			}
 			return true
-		l206:
-			position, tokenIndex = position206, tokenIndex206
+		l208:
+			position, tokenIndex = position208, tokenIndex208
 			return false
 		},
 		/* 49 ReservedWords <- <(('l' 'e' 't') / ('s' 't' 'r' 'i' 'c' 't') / ('b' 'a' 's' 'e' '1' '6') / ('b' 'a' 's' 'e' '5' '8') / ('b' 'a' 's' 'e' '6' '4') / ('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e') / ('i' 'f') / ('t' 'h' 'e' 'n') / ('e' 'l' 's' 'e') / ('m' 'a' 't' 'c' 'h') / ('c' 'a' 's' 'e') / ('f' 'u' 'n' 'c') / ('F' 'O' 'L' 'D'))> */
 		func() bool {
-			position208, tokenIndex208 := position, tokenIndex
+			position210, tokenIndex210 := position, tokenIndex
 			{
-				position209 := position
+				position211 := position
 				{
-					position210, tokenIndex210 := position, tokenIndex
+					position212, tokenIndex212 := position, tokenIndex
 					if buffer[position] != rune('l') {
-						goto l211
+						goto l213
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l211
+						goto l213
 					}
 					position++
 					if buffer[position] != rune('t') {
-						goto l211
+						goto l213
 					}
 					position++
-					goto l210
-				l211:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l213:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('s') {
-						goto l212
+						goto l214
 					}
 					position++
 					if buffer[position] != rune('t') {
-						goto l212
+						goto l214
 					}
 					position++
 					if buffer[position] != rune('r') {
-						goto l212
+						goto l214
 					}
 					position++
 					if buffer[position] != rune('i') {
-						goto l212
+						goto l214
 					}
 					position++
 					if buffer[position] != rune('c') {
-						goto l212
+						goto l214
 					}
 					position++
 					if buffer[position] != rune('t') {
-						goto l212
+						goto l214
 					}
 					position++
-					goto l210
-				l212:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l214:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('b') {
-						goto l213
+						goto l215
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l213
+						goto l215
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l213
+						goto l215
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l213
+						goto l215
 					}
 					position++
 					if buffer[position] != rune('1') {
-						goto l213
+						goto l215
 					}
 					position++
 					if buffer[position] != rune('6') {
-						goto l213
+						goto l215
 					}
 					position++
-					goto l210
-				l213:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l215:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('b') {
-						goto l214
+						goto l216
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l214
+						goto l216
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l214
+						goto l216
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l214
+						goto l216
 					}
 					position++
 					if buffer[position] != rune('5') {
-						goto l214
+						goto l216
 					}
 					position++
 					if buffer[position] != rune('8') {
-						goto l214
+						goto l216
 					}
 					position++
-					goto l210
-				l214:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l216:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('b') {
-						goto l215
+						goto l217
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l215
+						goto l217
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l215
+						goto l217
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l215
+						goto l217
 					}
 					position++
 					if buffer[position] != rune('6') {
-						goto l215
+						goto l217
 					}
 					position++
 					if buffer[position] != rune('4') {
-						goto l215
+						goto l217
 					}
 					position++
-					goto l210
-				l215:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l217:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('t') {
-						goto l216
+						goto l218
 					}
 					position++
 					if buffer[position] != rune('r') {
-						goto l216
+						goto l218
 					}
 					position++
 					if buffer[position] != rune('u') {
-						goto l216
+						goto l218
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l216
+						goto l218
 					}
 					position++
-					goto l210
-				l216:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l218:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('f') {
-						goto l217
+						goto l219
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l217
+						goto l219
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l217
+						goto l219
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l217
+						goto l219
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l217
+						goto l219
 					}
 					position++
-					goto l210
-				l217:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l219:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('i') {
-						goto l218
+						goto l220
 					}
 					position++
 					if buffer[position] != rune('f') {
-						goto l218
+						goto l220
 					}
 					position++
-					goto l210
-				l218:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l220:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('t') {
-						goto l219
+						goto l221
 					}
 					position++
 					if buffer[position] != rune('h') {
-						goto l219
+						goto l221
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l219
+						goto l221
 					}
 					position++
 					if buffer[position] != rune('n') {
-						goto l219
+						goto l221
 					}
 					position++
-					goto l210
-				l219:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l221:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('e') {
-						goto l220
+						goto l222
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l220
+						goto l222
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l220
+						goto l222
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l220
+						goto l222
 					}
 					position++
-					goto l210
-				l220:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l222:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('m') {
-						goto l221
+						goto l223
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l221
+						goto l223
 					}
 					position++
 					if buffer[position] != rune('t') {
-						goto l221
+						goto l223
 					}
 					position++
 					if buffer[position] != rune('c') {
-						goto l221
+						goto l223
 					}
 					position++
 					if buffer[position] != rune('h') {
-						goto l221
+						goto l223
 					}
 					position++
-					goto l210
-				l221:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l223:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('c') {
-						goto l222
+						goto l224
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l222
+						goto l224
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l222
+						goto l224
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l222
+						goto l224
 					}
 					position++
-					goto l210
-				l222:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l224:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('f') {
-						goto l223
+						goto l225
 					}
 					position++
 					if buffer[position] != rune('u') {
-						goto l223
+						goto l225
 					}
 					position++
 					if buffer[position] != rune('n') {
-						goto l223
+						goto l225
 					}
 					position++
 					if buffer[position] != rune('c') {
-						goto l223
+						goto l225
 					}
 					position++
-					goto l210
-				l223:
-					position, tokenIndex = position210, tokenIndex210
+					goto l212
+				l225:
+					position, tokenIndex = position212, tokenIndex212
 					if buffer[position] != rune('F') {
-						goto l208
+						goto l210
 					}
 					position++
 					if buffer[position] != rune('O') {
-						goto l208
+						goto l210
 					}
 					position++
 					if buffer[position] != rune('L') {
-						goto l208
+						goto l210
 					}
 					position++
 					if buffer[position] != rune('D') {
-						goto l208
+						goto l210
 					}
 					position++
 				}
-			l210:
-				add(ruleReservedWords, position209)
+			l212:
+				add(ruleReservedWords, position211)
 			}
 			return true
-		l208:
-			position, tokenIndex = position208, tokenIndex208
+		l210:
+			position, tokenIndex = position210, tokenIndex210
 			return false
 		},
 		/* 50 Const <- <(Integer / String / ByteVector / Boolean / List / Tuple)> */
 		func() bool {
-			position224, tokenIndex224 := position, tokenIndex
+			position226, tokenIndex226 := position, tokenIndex
 			{
-				position225 := position
+				position227 := position
 				{
-					position226, tokenIndex226 := position, tokenIndex
+					position228, tokenIndex228 := position, tokenIndex
 					if !_rules[ruleInteger]() {
-						goto l227
-					}
-					goto l226
-				l227:
-					position, tokenIndex = position226, tokenIndex226
-					if !_rules[ruleString]() {
-						goto l228
-					}
-					goto l226
-				l228:
-					position, tokenIndex = position226, tokenIndex226
-					if !_rules[ruleByteVector]() {
 						goto l229
 					}
-					goto l226
+					goto l228
 				l229:
-					position, tokenIndex = position226, tokenIndex226
-					if !_rules[ruleBoolean]() {
+					position, tokenIndex = position228, tokenIndex228
+					if !_rules[ruleString]() {
 						goto l230
 					}
-					goto l226
+					goto l228
 				l230:
-					position, tokenIndex = position226, tokenIndex226
-					if !_rules[ruleList]() {
+					position, tokenIndex = position228, tokenIndex228
+					if !_rules[ruleByteVector]() {
 						goto l231
 					}
-					goto l226
+					goto l228
 				l231:
-					position, tokenIndex = position226, tokenIndex226
+					position, tokenIndex = position228, tokenIndex228
+					if !_rules[ruleBoolean]() {
+						goto l232
+					}
+					goto l228
+				l232:
+					position, tokenIndex = position228, tokenIndex228
+					if !_rules[ruleList]() {
+						goto l233
+					}
+					goto l228
+				l233:
+					position, tokenIndex = position228, tokenIndex228
 					if !_rules[ruleTuple]() {
-						goto l224
+						goto l226
 					}
 				}
-			l226:
-				add(ruleConst, position225)
+			l228:
+				add(ruleConst, position227)
 			}
 			return true
-		l224:
-			position, tokenIndex = position224, tokenIndex224
+		l226:
+			position, tokenIndex = position226, tokenIndex226
 			return false
 		},
 		/* 51 Identifier <- <((!ReservedWords ([A-Z] / [a-z] / ('_' &([A-Z] / [a-z]))) ([A-Z] / [a-z] / [0-9] / ('_' !'_'))*) / (ReservedWords ([A-Z] / [a-z] / [0-9] / ('_' !'_'))+))> */
 		func() bool {
-			position232, tokenIndex232 := position, tokenIndex
+			position234, tokenIndex234 := position, tokenIndex
 			{
-				position233 := position
+				position235 := position
 				{
-					position234, tokenIndex234 := position, tokenIndex
+					position236, tokenIndex236 := position, tokenIndex
 					{
-						position236, tokenIndex236 := position, tokenIndex
+						position238, tokenIndex238 := position, tokenIndex
 						if !_rules[ruleReservedWords]() {
-							goto l236
+							goto l238
 						}
-						goto l235
-					l236:
-						position, tokenIndex = position236, tokenIndex236
+						goto l237
+					l238:
+						position, tokenIndex = position238, tokenIndex238
 					}
 					{
-						position237, tokenIndex237 := position, tokenIndex
+						position239, tokenIndex239 := position, tokenIndex
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l238
+							goto l240
 						}
 						position++
-						goto l237
-					l238:
-						position, tokenIndex = position237, tokenIndex237
+						goto l239
+					l240:
+						position, tokenIndex = position239, tokenIndex239
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l239
+							goto l241
 						}
 						position++
-						goto l237
-					l239:
-						position, tokenIndex = position237, tokenIndex237
+						goto l239
+					l241:
+						position, tokenIndex = position239, tokenIndex239
 						if buffer[position] != rune('_') {
-							goto l235
+							goto l237
 						}
 						position++
 						{
-							position240, tokenIndex240 := position, tokenIndex
+							position242, tokenIndex242 := position, tokenIndex
 							{
-								position241, tokenIndex241 := position, tokenIndex
+								position243, tokenIndex243 := position, tokenIndex
 								if c := buffer[position]; c < rune('A') || c > rune('Z') {
-									goto l242
+									goto l244
 								}
 								position++
-								goto l241
-							l242:
-								position, tokenIndex = position241, tokenIndex241
+								goto l243
+							l244:
+								position, tokenIndex = position243, tokenIndex243
 								if c := buffer[position]; c < rune('a') || c > rune('z') {
-									goto l235
+									goto l237
 								}
 								position++
 							}
-						l241:
-							position, tokenIndex = position240, tokenIndex240
+						l243:
+							position, tokenIndex = position242, tokenIndex242
 						}
 					}
-				l237:
-				l243:
+				l239:
+				l245:
 					{
-						position244, tokenIndex244 := position, tokenIndex
+						position246, tokenIndex246 := position, tokenIndex
 						{
-							position245, tokenIndex245 := position, tokenIndex
+							position247, tokenIndex247 := position, tokenIndex
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l246
+								goto l248
 							}
 							position++
-							goto l245
-						l246:
-							position, tokenIndex = position245, tokenIndex245
+							goto l247
+						l248:
+							position, tokenIndex = position247, tokenIndex247
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l247
+								goto l249
 							}
 							position++
-							goto l245
-						l247:
-							position, tokenIndex = position245, tokenIndex245
+							goto l247
+						l249:
+							position, tokenIndex = position247, tokenIndex247
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l248
+								goto l250
 							}
 							position++
-							goto l245
-						l248:
-							position, tokenIndex = position245, tokenIndex245
+							goto l247
+						l250:
+							position, tokenIndex = position247, tokenIndex247
 							if buffer[position] != rune('_') {
-								goto l244
+								goto l246
 							}
 							position++
 							{
-								position249, tokenIndex249 := position, tokenIndex
+								position251, tokenIndex251 := position, tokenIndex
 								if buffer[position] != rune('_') {
-									goto l249
+									goto l251
 								}
 								position++
-								goto l244
-							l249:
-								position, tokenIndex = position249, tokenIndex249
+								goto l246
+							l251:
+								position, tokenIndex = position251, tokenIndex251
 							}
 						}
-					l245:
-						goto l243
-					l244:
-						position, tokenIndex = position244, tokenIndex244
+					l247:
+						goto l245
+					l246:
+						position, tokenIndex = position246, tokenIndex246
 					}
-					goto l234
-				l235:
-					position, tokenIndex = position234, tokenIndex234
+					goto l236
+				l237:
+					position, tokenIndex = position236, tokenIndex236
 					if !_rules[ruleReservedWords]() {
-						goto l232
+						goto l234
 					}
 					{
-						position252, tokenIndex252 := position, tokenIndex
+						position254, tokenIndex254 := position, tokenIndex
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l253
+							goto l255
 						}
 						position++
-						goto l252
-					l253:
-						position, tokenIndex = position252, tokenIndex252
+						goto l254
+					l255:
+						position, tokenIndex = position254, tokenIndex254
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l254
+							goto l256
 						}
 						position++
-						goto l252
-					l254:
-						position, tokenIndex = position252, tokenIndex252
+						goto l254
+					l256:
+						position, tokenIndex = position254, tokenIndex254
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l255
+							goto l257
 						}
 						position++
-						goto l252
-					l255:
-						position, tokenIndex = position252, tokenIndex252
+						goto l254
+					l257:
+						position, tokenIndex = position254, tokenIndex254
 						if buffer[position] != rune('_') {
-							goto l232
+							goto l234
 						}
 						position++
 						{
-							position256, tokenIndex256 := position, tokenIndex
+							position258, tokenIndex258 := position, tokenIndex
 							if buffer[position] != rune('_') {
-								goto l256
+								goto l258
 							}
 							position++
-							goto l232
-						l256:
-							position, tokenIndex = position256, tokenIndex256
+							goto l234
+						l258:
+							position, tokenIndex = position258, tokenIndex258
 						}
 					}
+				l254:
 				l252:
-				l250:
 					{
-						position251, tokenIndex251 := position, tokenIndex
+						position253, tokenIndex253 := position, tokenIndex
 						{
-							position257, tokenIndex257 := position, tokenIndex
+							position259, tokenIndex259 := position, tokenIndex
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l258
+								goto l260
 							}
 							position++
-							goto l257
-						l258:
-							position, tokenIndex = position257, tokenIndex257
+							goto l259
+						l260:
+							position, tokenIndex = position259, tokenIndex259
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l259
+								goto l261
 							}
 							position++
-							goto l257
-						l259:
-							position, tokenIndex = position257, tokenIndex257
+							goto l259
+						l261:
+							position, tokenIndex = position259, tokenIndex259
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l260
+								goto l262
 							}
 							position++
-							goto l257
-						l260:
-							position, tokenIndex = position257, tokenIndex257
+							goto l259
+						l262:
+							position, tokenIndex = position259, tokenIndex259
 							if buffer[position] != rune('_') {
-								goto l251
+								goto l253
 							}
 							position++
 							{
-								position261, tokenIndex261 := position, tokenIndex
+								position263, tokenIndex263 := position, tokenIndex
 								if buffer[position] != rune('_') {
-									goto l261
+									goto l263
 								}
 								position++
-								goto l251
-							l261:
-								position, tokenIndex = position261, tokenIndex261
+								goto l253
+							l263:
+								position, tokenIndex = position263, tokenIndex263
 							}
 						}
-					l257:
-						goto l250
-					l251:
-						position, tokenIndex = position251, tokenIndex251
+					l259:
+						goto l252
+					l253:
+						position, tokenIndex = position253, tokenIndex253
 					}
 				}
-			l234:
-				add(ruleIdentifier, position233)
+			l236:
+				add(ruleIdentifier, position235)
 			}
 			return true
-		l232:
-			position, tokenIndex = position232, tokenIndex232
+		l234:
+			position, tokenIndex = position234, tokenIndex234
 			return false
 		},
 		/* 52 Type <- <(([A-Z] / [a-z]) ([A-Z] / [a-z] / [0-9])*)> */
 		func() bool {
-			position262, tokenIndex262 := position, tokenIndex
+			position264, tokenIndex264 := position, tokenIndex
 			{
-				position263 := position
+				position265 := position
 				{
-					position264, tokenIndex264 := position, tokenIndex
+					position266, tokenIndex266 := position, tokenIndex
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l265
+						goto l267
 					}
 					position++
-					goto l264
-				l265:
-					position, tokenIndex = position264, tokenIndex264
+					goto l266
+				l267:
+					position, tokenIndex = position266, tokenIndex266
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l262
+						goto l264
 					}
 					position++
 				}
-			l264:
 			l266:
+			l268:
 				{
-					position267, tokenIndex267 := position, tokenIndex
+					position269, tokenIndex269 := position, tokenIndex
 					{
-						position268, tokenIndex268 := position, tokenIndex
+						position270, tokenIndex270 := position, tokenIndex
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l269
+							goto l271
 						}
 						position++
-						goto l268
-					l269:
-						position, tokenIndex = position268, tokenIndex268
+						goto l270
+					l271:
+						position, tokenIndex = position270, tokenIndex270
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l270
+							goto l272
 						}
 						position++
-						goto l268
-					l270:
-						position, tokenIndex = position268, tokenIndex268
+						goto l270
+					l272:
+						position, tokenIndex = position270, tokenIndex270
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l267
+							goto l269
 						}
 						position++
 					}
-				l268:
-					goto l266
-				l267:
-					position, tokenIndex = position267, tokenIndex267
+				l270:
+					goto l268
+				l269:
+					position, tokenIndex = position269, tokenIndex269
 				}
-				add(ruleType, position263)
+				add(ruleType, position265)
 			}
 			return true
-		l262:
-			position, tokenIndex = position262, tokenIndex262
+		l264:
+			position, tokenIndex = position264, tokenIndex264
 			return false
 		},
 		/* 53 GenericType <- <(Type _ '[' _ Types _ ']')> */
 		func() bool {
-			position271, tokenIndex271 := position, tokenIndex
+			position273, tokenIndex273 := position, tokenIndex
 			{
-				position272 := position
+				position274 := position
 				if !_rules[ruleType]() {
-					goto l271
+					goto l273
 				}
 				if !_rules[rule_]() {
-					goto l271
+					goto l273
 				}
 				if buffer[position] != rune('[') {
-					goto l271
+					goto l273
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l271
+					goto l273
 				}
 				if !_rules[ruleTypes]() {
-					goto l271
+					goto l273
 				}
 				if !_rules[rule_]() {
-					goto l271
+					goto l273
 				}
 				if buffer[position] != rune(']') {
-					goto l271
+					goto l273
 				}
 				position++
-				add(ruleGenericType, position272)
+				add(ruleGenericType, position274)
 			}
 			return true
-		l271:
-			position, tokenIndex = position271, tokenIndex271
+		l273:
+			position, tokenIndex = position273, tokenIndex273
 			return false
 		},
 		/* 54 TupleType <- <('(' _ Types _ (',' _ Types)+ _ ')')> */
 		func() bool {
-			position273, tokenIndex273 := position, tokenIndex
+			position275, tokenIndex275 := position, tokenIndex
 			{
-				position274 := position
+				position276 := position
 				if buffer[position] != rune('(') {
-					goto l273
+					goto l275
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l273
+					goto l275
 				}
 				if !_rules[ruleTypes]() {
-					goto l273
+					goto l275
 				}
 				if !_rules[rule_]() {
-					goto l273
+					goto l275
 				}
 				if buffer[position] != rune(',') {
-					goto l273
+					goto l275
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l273
+					goto l275
 				}
 				if !_rules[ruleTypes]() {
-					goto l273
+					goto l275
 				}
-			l275:
+			l277:
 				{
-					position276, tokenIndex276 := position, tokenIndex
+					position278, tokenIndex278 := position, tokenIndex
 					if buffer[position] != rune(',') {
-						goto l276
+						goto l278
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l276
+						goto l278
 					}
 					if !_rules[ruleTypes]() {
-						goto l276
+						goto l278
 					}
-					goto l275
-				l276:
-					position, tokenIndex = position276, tokenIndex276
+					goto l277
+				l278:
+					position, tokenIndex = position278, tokenIndex278
 				}
 				if !_rules[rule_]() {
-					goto l273
+					goto l275
 				}
 				if buffer[position] != rune(')') {
-					goto l273
+					goto l275
 				}
 				position++
-				add(ruleTupleType, position274)
+				add(ruleTupleType, position276)
 			}
 			return true
-		l273:
-			position, tokenIndex = position273, tokenIndex273
+		l275:
+			position, tokenIndex = position275, tokenIndex275
 			return false
 		},
 		/* 55 Types <- <((GenericType / TupleType / Type) (_ '|' _ Types)?)> */
 		func() bool {
-			position277, tokenIndex277 := position, tokenIndex
+			position279, tokenIndex279 := position, tokenIndex
 			{
-				position278 := position
+				position280 := position
 				{
-					position279, tokenIndex279 := position, tokenIndex
+					position281, tokenIndex281 := position, tokenIndex
 					if !_rules[ruleGenericType]() {
-						goto l280
+						goto l282
 					}
-					goto l279
-				l280:
-					position, tokenIndex = position279, tokenIndex279
+					goto l281
+				l282:
+					position, tokenIndex = position281, tokenIndex281
 					if !_rules[ruleTupleType]() {
-						goto l281
+						goto l283
 					}
-					goto l279
-				l281:
-					position, tokenIndex = position279, tokenIndex279
+					goto l281
+				l283:
+					position, tokenIndex = position281, tokenIndex281
 					if !_rules[ruleType]() {
-						goto l277
+						goto l279
 					}
 				}
-			l279:
+			l281:
 				{
-					position282, tokenIndex282 := position, tokenIndex
+					position284, tokenIndex284 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l282
+						goto l284
 					}
 					if buffer[position] != rune('|') {
-						goto l282
+						goto l284
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l282
+						goto l284
 					}
 					if !_rules[ruleTypes]() {
-						goto l282
+						goto l284
 					}
-					goto l283
-				l282:
-					position, tokenIndex = position282, tokenIndex282
+					goto l285
+				l284:
+					position, tokenIndex = position284, tokenIndex284
 				}
-			l283:
-				add(ruleTypes, position278)
+			l285:
+				add(ruleTypes, position280)
 			}
 			return true
-		l277:
-			position, tokenIndex = position277, tokenIndex277
+		l279:
+			position, tokenIndex = position279, tokenIndex279
 			return false
 		},
 		/* 56 Base16 <- <('b' 'a' 's' 'e' '1' '6' '\'' ([0-9] / 'A' / 'B' / 'C' / 'D' / 'E' / 'F' / 'a' / 'b' / 'c' / 'd' / 'e' / 'f')* '\'')> */
 		func() bool {
-			position284, tokenIndex284 := position, tokenIndex
+			position286, tokenIndex286 := position, tokenIndex
 			{
-				position285 := position
+				position287 := position
 				if buffer[position] != rune('b') {
-					goto l284
+					goto l286
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l284
+					goto l286
 				}
 				position++
 				if buffer[position] != rune('s') {
-					goto l284
+					goto l286
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l284
+					goto l286
 				}
 				position++
 				if buffer[position] != rune('1') {
-					goto l284
+					goto l286
 				}
 				position++
 				if buffer[position] != rune('6') {
-					goto l284
+					goto l286
 				}
 				position++
 				if buffer[position] != rune('\'') {
-					goto l284
+					goto l286
 				}
 				position++
-			l286:
+			l288:
 				{
-					position287, tokenIndex287 := position, tokenIndex
+					position289, tokenIndex289 := position, tokenIndex
 					{
-						position288, tokenIndex288 := position, tokenIndex
+						position290, tokenIndex290 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l289
-						}
-						position++
-						goto l288
-					l289:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('A') {
-							goto l290
-						}
-						position++
-						goto l288
-					l290:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('B') {
 							goto l291
 						}
 						position++
-						goto l288
+						goto l290
 					l291:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('C') {
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('A') {
 							goto l292
 						}
 						position++
-						goto l288
+						goto l290
 					l292:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('D') {
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('B') {
 							goto l293
 						}
 						position++
-						goto l288
+						goto l290
 					l293:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('E') {
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('C') {
 							goto l294
 						}
 						position++
-						goto l288
+						goto l290
 					l294:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('F') {
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('D') {
 							goto l295
 						}
 						position++
-						goto l288
+						goto l290
 					l295:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('a') {
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('E') {
 							goto l296
 						}
 						position++
-						goto l288
+						goto l290
 					l296:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('b') {
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('F') {
 							goto l297
 						}
 						position++
-						goto l288
+						goto l290
 					l297:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('c') {
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('a') {
 							goto l298
 						}
 						position++
-						goto l288
+						goto l290
 					l298:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('d') {
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('b') {
 							goto l299
 						}
 						position++
-						goto l288
+						goto l290
 					l299:
-						position, tokenIndex = position288, tokenIndex288
-						if buffer[position] != rune('e') {
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('c') {
 							goto l300
 						}
 						position++
-						goto l288
+						goto l290
 					l300:
-						position, tokenIndex = position288, tokenIndex288
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('d') {
+							goto l301
+						}
+						position++
+						goto l290
+					l301:
+						position, tokenIndex = position290, tokenIndex290
+						if buffer[position] != rune('e') {
+							goto l302
+						}
+						position++
+						goto l290
+					l302:
+						position, tokenIndex = position290, tokenIndex290
 						if buffer[position] != rune('f') {
-							goto l287
+							goto l289
 						}
 						position++
 					}
-				l288:
-					goto l286
-				l287:
-					position, tokenIndex = position287, tokenIndex287
+				l290:
+					goto l288
+				l289:
+					position, tokenIndex = position289, tokenIndex289
 				}
 				if buffer[position] != rune('\'') {
-					goto l284
+					goto l286
 				}
 				position++
-				add(ruleBase16, position285)
+				add(ruleBase16, position287)
 			}
 			return true
-		l284:
-			position, tokenIndex = position284, tokenIndex284
+		l286:
+			position, tokenIndex = position286, tokenIndex286
 			return false
 		},
 		/* 57 Base58 <- <('b' 'a' 's' 'e' '5' '8' '\'' ('1' / '2' / '3' / '4' / '5' / '6' / '7' / '8' / '9' / 'A' / 'B' / 'C' / 'D' / 'E' / 'F' / 'G' / 'H' / 'J' / 'K' / 'L' / 'M' / 'N' / 'P' / 'Q' / 'R' / 'S' / 'T' / 'U' / 'V' / 'W' / 'X' / 'Y' / 'Z' / 'a' / 'b' / 'c' / 'd' / 'e' / 'f' / 'g' / 'h' / 'i' / 'j' / 'k' / 'm' / 'n' / 'o' / 'p' / 'q' / 'r' / 's' / 't' / 'u' / 'v' / 'w' / 'x' / 'y' / 'z')* '\'')> */
 		func() bool {
-			position301, tokenIndex301 := position, tokenIndex
+			position303, tokenIndex303 := position, tokenIndex
 			{
-				position302 := position
+				position304 := position
 				if buffer[position] != rune('b') {
-					goto l301
+					goto l303
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l301
+					goto l303
 				}
 				position++
 				if buffer[position] != rune('s') {
-					goto l301
+					goto l303
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l301
+					goto l303
 				}
 				position++
 				if buffer[position] != rune('5') {
-					goto l301
+					goto l303
 				}
 				position++
 				if buffer[position] != rune('8') {
-					goto l301
+					goto l303
 				}
 				position++
 				if buffer[position] != rune('\'') {
-					goto l301
+					goto l303
 				}
 				position++
-			l303:
+			l305:
 				{
-					position304, tokenIndex304 := position, tokenIndex
+					position306, tokenIndex306 := position, tokenIndex
 					{
-						position305, tokenIndex305 := position, tokenIndex
+						position307, tokenIndex307 := position, tokenIndex
 						if buffer[position] != rune('1') {
-							goto l306
-						}
-						position++
-						goto l305
-					l306:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('2') {
-							goto l307
-						}
-						position++
-						goto l305
-					l307:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('3') {
 							goto l308
 						}
 						position++
-						goto l305
+						goto l307
 					l308:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('4') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('2') {
 							goto l309
 						}
 						position++
-						goto l305
+						goto l307
 					l309:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('5') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('3') {
 							goto l310
 						}
 						position++
-						goto l305
+						goto l307
 					l310:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('6') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('4') {
 							goto l311
 						}
 						position++
-						goto l305
+						goto l307
 					l311:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('7') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('5') {
 							goto l312
 						}
 						position++
-						goto l305
+						goto l307
 					l312:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('8') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('6') {
 							goto l313
 						}
 						position++
-						goto l305
+						goto l307
 					l313:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('9') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('7') {
 							goto l314
 						}
 						position++
-						goto l305
+						goto l307
 					l314:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('A') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('8') {
 							goto l315
 						}
 						position++
-						goto l305
+						goto l307
 					l315:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('B') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('9') {
 							goto l316
 						}
 						position++
-						goto l305
+						goto l307
 					l316:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('C') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('A') {
 							goto l317
 						}
 						position++
-						goto l305
+						goto l307
 					l317:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('D') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('B') {
 							goto l318
 						}
 						position++
-						goto l305
+						goto l307
 					l318:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('E') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('C') {
 							goto l319
 						}
 						position++
-						goto l305
+						goto l307
 					l319:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('F') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('D') {
 							goto l320
 						}
 						position++
-						goto l305
+						goto l307
 					l320:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('G') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('E') {
 							goto l321
 						}
 						position++
-						goto l305
+						goto l307
 					l321:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('H') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('F') {
 							goto l322
 						}
 						position++
-						goto l305
+						goto l307
 					l322:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('J') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('G') {
 							goto l323
 						}
 						position++
-						goto l305
+						goto l307
 					l323:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('K') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('H') {
 							goto l324
 						}
 						position++
-						goto l305
+						goto l307
 					l324:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('L') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('J') {
 							goto l325
 						}
 						position++
-						goto l305
+						goto l307
 					l325:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('M') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('K') {
 							goto l326
 						}
 						position++
-						goto l305
+						goto l307
 					l326:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('N') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('L') {
 							goto l327
 						}
 						position++
-						goto l305
+						goto l307
 					l327:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('P') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('M') {
 							goto l328
 						}
 						position++
-						goto l305
+						goto l307
 					l328:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('Q') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('N') {
 							goto l329
 						}
 						position++
-						goto l305
+						goto l307
 					l329:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('R') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('P') {
 							goto l330
 						}
 						position++
-						goto l305
+						goto l307
 					l330:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('S') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('Q') {
 							goto l331
 						}
 						position++
-						goto l305
+						goto l307
 					l331:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('T') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('R') {
 							goto l332
 						}
 						position++
-						goto l305
+						goto l307
 					l332:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('U') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('S') {
 							goto l333
 						}
 						position++
-						goto l305
+						goto l307
 					l333:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('V') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('T') {
 							goto l334
 						}
 						position++
-						goto l305
+						goto l307
 					l334:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('W') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('U') {
 							goto l335
 						}
 						position++
-						goto l305
+						goto l307
 					l335:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('X') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('V') {
 							goto l336
 						}
 						position++
-						goto l305
+						goto l307
 					l336:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('Y') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('W') {
 							goto l337
 						}
 						position++
-						goto l305
+						goto l307
 					l337:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('Z') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('X') {
 							goto l338
 						}
 						position++
-						goto l305
+						goto l307
 					l338:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('a') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('Y') {
 							goto l339
 						}
 						position++
-						goto l305
+						goto l307
 					l339:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('b') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('Z') {
 							goto l340
 						}
 						position++
-						goto l305
+						goto l307
 					l340:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('c') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('a') {
 							goto l341
 						}
 						position++
-						goto l305
+						goto l307
 					l341:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('d') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('b') {
 							goto l342
 						}
 						position++
-						goto l305
+						goto l307
 					l342:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('e') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('c') {
 							goto l343
 						}
 						position++
-						goto l305
+						goto l307
 					l343:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('f') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('d') {
 							goto l344
 						}
 						position++
-						goto l305
+						goto l307
 					l344:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('g') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('e') {
 							goto l345
 						}
 						position++
-						goto l305
+						goto l307
 					l345:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('h') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('f') {
 							goto l346
 						}
 						position++
-						goto l305
+						goto l307
 					l346:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('i') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('g') {
 							goto l347
 						}
 						position++
-						goto l305
+						goto l307
 					l347:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('j') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('h') {
 							goto l348
 						}
 						position++
-						goto l305
+						goto l307
 					l348:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('k') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('i') {
 							goto l349
 						}
 						position++
-						goto l305
+						goto l307
 					l349:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('m') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('j') {
 							goto l350
 						}
 						position++
-						goto l305
+						goto l307
 					l350:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('n') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('k') {
 							goto l351
 						}
 						position++
-						goto l305
+						goto l307
 					l351:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('o') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('m') {
 							goto l352
 						}
 						position++
-						goto l305
+						goto l307
 					l352:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('p') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('n') {
 							goto l353
 						}
 						position++
-						goto l305
+						goto l307
 					l353:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('q') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('o') {
 							goto l354
 						}
 						position++
-						goto l305
+						goto l307
 					l354:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('r') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('p') {
 							goto l355
 						}
 						position++
-						goto l305
+						goto l307
 					l355:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('s') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('q') {
 							goto l356
 						}
 						position++
-						goto l305
+						goto l307
 					l356:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('t') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('r') {
 							goto l357
 						}
 						position++
-						goto l305
+						goto l307
 					l357:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('u') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('s') {
 							goto l358
 						}
 						position++
-						goto l305
+						goto l307
 					l358:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('v') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('t') {
 							goto l359
 						}
 						position++
-						goto l305
+						goto l307
 					l359:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('w') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('u') {
 							goto l360
 						}
 						position++
-						goto l305
+						goto l307
 					l360:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('x') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('v') {
 							goto l361
 						}
 						position++
-						goto l305
+						goto l307
 					l361:
-						position, tokenIndex = position305, tokenIndex305
-						if buffer[position] != rune('y') {
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('w') {
 							goto l362
 						}
 						position++
-						goto l305
+						goto l307
 					l362:
-						position, tokenIndex = position305, tokenIndex305
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('x') {
+							goto l363
+						}
+						position++
+						goto l307
+					l363:
+						position, tokenIndex = position307, tokenIndex307
+						if buffer[position] != rune('y') {
+							goto l364
+						}
+						position++
+						goto l307
+					l364:
+						position, tokenIndex = position307, tokenIndex307
 						if buffer[position] != rune('z') {
-							goto l304
+							goto l306
 						}
 						position++
 					}
-				l305:
-					goto l303
-				l304:
-					position, tokenIndex = position304, tokenIndex304
+				l307:
+					goto l305
+				l306:
+					position, tokenIndex = position306, tokenIndex306
 				}
 				if buffer[position] != rune('\'') {
-					goto l301
+					goto l303
 				}
 				position++
-				add(ruleBase58, position302)
+				add(ruleBase58, position304)
 			}
 			return true
-		l301:
-			position, tokenIndex = position301, tokenIndex301
+		l303:
+			position, tokenIndex = position303, tokenIndex303
 			return false
 		},
 		/* 58 Base64 <- <('b' 'a' 's' 'e' '6' '4' '\'' ('A' / 'B' / 'C' / 'D' / 'E' / 'F' / 'G' / 'H' / 'I' / 'J' / 'K' / 'L' / 'M' / 'N' / 'O' / 'P' / 'Q' / 'R' / 'S' / 'T' / 'U' / 'V' / 'W' / 'X' / 'Y' / 'Z' / 'a' / 'b' / 'c' / 'd' / 'e' / 'f' / 'g' / 'h' / 'i' / 'j' / 'k' / 'l' / 'm' / 'n' / 'o' / 'p' / 'q' / 'r' / 's' / 't' / 'u' / 'v' / 'w' / 'x' / 'y' / 'z' / '0' / '1' / '2' / '3' / '4' / '5' / '6' / '7' / '8' / '9' / '+' / '/' / '=')* '\'')> */
 		func() bool {
-			position363, tokenIndex363 := position, tokenIndex
+			position365, tokenIndex365 := position, tokenIndex
 			{
-				position364 := position
+				position366 := position
 				if buffer[position] != rune('b') {
-					goto l363
+					goto l365
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l363
+					goto l365
 				}
 				position++
 				if buffer[position] != rune('s') {
-					goto l363
+					goto l365
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l363
+					goto l365
 				}
 				position++
 				if buffer[position] != rune('6') {
-					goto l363
+					goto l365
 				}
 				position++
 				if buffer[position] != rune('4') {
-					goto l363
+					goto l365
 				}
 				position++
 				if buffer[position] != rune('\'') {
-					goto l363
+					goto l365
 				}
 				position++
-			l365:
+			l367:
 				{
-					position366, tokenIndex366 := position, tokenIndex
+					position368, tokenIndex368 := position, tokenIndex
 					{
-						position367, tokenIndex367 := position, tokenIndex
+						position369, tokenIndex369 := position, tokenIndex
 						if buffer[position] != rune('A') {
-							goto l368
-						}
-						position++
-						goto l367
-					l368:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('B') {
-							goto l369
-						}
-						position++
-						goto l367
-					l369:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('C') {
 							goto l370
 						}
 						position++
-						goto l367
+						goto l369
 					l370:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('D') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('B') {
 							goto l371
 						}
 						position++
-						goto l367
+						goto l369
 					l371:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('E') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('C') {
 							goto l372
 						}
 						position++
-						goto l367
+						goto l369
 					l372:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('F') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('D') {
 							goto l373
 						}
 						position++
-						goto l367
+						goto l369
 					l373:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('G') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('E') {
 							goto l374
 						}
 						position++
-						goto l367
+						goto l369
 					l374:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('H') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('F') {
 							goto l375
 						}
 						position++
-						goto l367
+						goto l369
 					l375:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('I') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('G') {
 							goto l376
 						}
 						position++
-						goto l367
+						goto l369
 					l376:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('J') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('H') {
 							goto l377
 						}
 						position++
-						goto l367
+						goto l369
 					l377:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('K') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('I') {
 							goto l378
 						}
 						position++
-						goto l367
+						goto l369
 					l378:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('L') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('J') {
 							goto l379
 						}
 						position++
-						goto l367
+						goto l369
 					l379:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('M') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('K') {
 							goto l380
 						}
 						position++
-						goto l367
+						goto l369
 					l380:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('N') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('L') {
 							goto l381
 						}
 						position++
-						goto l367
+						goto l369
 					l381:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('O') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('M') {
 							goto l382
 						}
 						position++
-						goto l367
+						goto l369
 					l382:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('P') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('N') {
 							goto l383
 						}
 						position++
-						goto l367
+						goto l369
 					l383:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('Q') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('O') {
 							goto l384
 						}
 						position++
-						goto l367
+						goto l369
 					l384:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('R') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('P') {
 							goto l385
 						}
 						position++
-						goto l367
+						goto l369
 					l385:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('S') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('Q') {
 							goto l386
 						}
 						position++
-						goto l367
+						goto l369
 					l386:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('T') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('R') {
 							goto l387
 						}
 						position++
-						goto l367
+						goto l369
 					l387:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('U') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('S') {
 							goto l388
 						}
 						position++
-						goto l367
+						goto l369
 					l388:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('V') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('T') {
 							goto l389
 						}
 						position++
-						goto l367
+						goto l369
 					l389:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('W') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('U') {
 							goto l390
 						}
 						position++
-						goto l367
+						goto l369
 					l390:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('X') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('V') {
 							goto l391
 						}
 						position++
-						goto l367
+						goto l369
 					l391:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('Y') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('W') {
 							goto l392
 						}
 						position++
-						goto l367
+						goto l369
 					l392:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('Z') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('X') {
 							goto l393
 						}
 						position++
-						goto l367
+						goto l369
 					l393:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('a') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('Y') {
 							goto l394
 						}
 						position++
-						goto l367
+						goto l369
 					l394:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('b') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('Z') {
 							goto l395
 						}
 						position++
-						goto l367
+						goto l369
 					l395:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('c') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('a') {
 							goto l396
 						}
 						position++
-						goto l367
+						goto l369
 					l396:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('d') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('b') {
 							goto l397
 						}
 						position++
-						goto l367
+						goto l369
 					l397:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('e') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('c') {
 							goto l398
 						}
 						position++
-						goto l367
+						goto l369
 					l398:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('f') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('d') {
 							goto l399
 						}
 						position++
-						goto l367
+						goto l369
 					l399:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('g') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('e') {
 							goto l400
 						}
 						position++
-						goto l367
+						goto l369
 					l400:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('h') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('f') {
 							goto l401
 						}
 						position++
-						goto l367
+						goto l369
 					l401:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('i') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('g') {
 							goto l402
 						}
 						position++
-						goto l367
+						goto l369
 					l402:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('j') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('h') {
 							goto l403
 						}
 						position++
-						goto l367
+						goto l369
 					l403:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('k') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('i') {
 							goto l404
 						}
 						position++
-						goto l367
+						goto l369
 					l404:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('l') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('j') {
 							goto l405
 						}
 						position++
-						goto l367
+						goto l369
 					l405:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('m') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('k') {
 							goto l406
 						}
 						position++
-						goto l367
+						goto l369
 					l406:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('n') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('l') {
 							goto l407
 						}
 						position++
-						goto l367
+						goto l369
 					l407:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('o') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('m') {
 							goto l408
 						}
 						position++
-						goto l367
+						goto l369
 					l408:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('p') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('n') {
 							goto l409
 						}
 						position++
-						goto l367
+						goto l369
 					l409:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('q') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('o') {
 							goto l410
 						}
 						position++
-						goto l367
+						goto l369
 					l410:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('r') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('p') {
 							goto l411
 						}
 						position++
-						goto l367
+						goto l369
 					l411:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('s') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('q') {
 							goto l412
 						}
 						position++
-						goto l367
+						goto l369
 					l412:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('t') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('r') {
 							goto l413
 						}
 						position++
-						goto l367
+						goto l369
 					l413:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('u') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('s') {
 							goto l414
 						}
 						position++
-						goto l367
+						goto l369
 					l414:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('v') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('t') {
 							goto l415
 						}
 						position++
-						goto l367
+						goto l369
 					l415:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('w') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('u') {
 							goto l416
 						}
 						position++
-						goto l367
+						goto l369
 					l416:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('x') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('v') {
 							goto l417
 						}
 						position++
-						goto l367
+						goto l369
 					l417:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('y') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('w') {
 							goto l418
 						}
 						position++
-						goto l367
+						goto l369
 					l418:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('z') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('x') {
 							goto l419
 						}
 						position++
-						goto l367
+						goto l369
 					l419:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('0') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('y') {
 							goto l420
 						}
 						position++
-						goto l367
+						goto l369
 					l420:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('1') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('z') {
 							goto l421
 						}
 						position++
-						goto l367
+						goto l369
 					l421:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('2') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('0') {
 							goto l422
 						}
 						position++
-						goto l367
+						goto l369
 					l422:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('3') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('1') {
 							goto l423
 						}
 						position++
-						goto l367
+						goto l369
 					l423:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('4') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('2') {
 							goto l424
 						}
 						position++
-						goto l367
+						goto l369
 					l424:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('5') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('3') {
 							goto l425
 						}
 						position++
-						goto l367
+						goto l369
 					l425:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('6') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('4') {
 							goto l426
 						}
 						position++
-						goto l367
+						goto l369
 					l426:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('7') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('5') {
 							goto l427
 						}
 						position++
-						goto l367
+						goto l369
 					l427:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('8') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('6') {
 							goto l428
 						}
 						position++
-						goto l367
+						goto l369
 					l428:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('9') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('7') {
 							goto l429
 						}
 						position++
-						goto l367
+						goto l369
 					l429:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('+') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('8') {
 							goto l430
 						}
 						position++
-						goto l367
+						goto l369
 					l430:
-						position, tokenIndex = position367, tokenIndex367
-						if buffer[position] != rune('/') {
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('9') {
 							goto l431
 						}
 						position++
-						goto l367
+						goto l369
 					l431:
-						position, tokenIndex = position367, tokenIndex367
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('+') {
+							goto l432
+						}
+						position++
+						goto l369
+					l432:
+						position, tokenIndex = position369, tokenIndex369
+						if buffer[position] != rune('/') {
+							goto l433
+						}
+						position++
+						goto l369
+					l433:
+						position, tokenIndex = position369, tokenIndex369
 						if buffer[position] != rune('=') {
-							goto l366
+							goto l368
 						}
 						position++
 					}
-				l367:
-					goto l365
-				l366:
-					position, tokenIndex = position366, tokenIndex366
+				l369:
+					goto l367
+				l368:
+					position, tokenIndex = position368, tokenIndex368
 				}
 				if buffer[position] != rune('\'') {
-					goto l363
+					goto l365
 				}
 				position++
-				add(ruleBase64, position364)
+				add(ruleBase64, position366)
 			}
 			return true
-		l363:
-			position, tokenIndex = position363, tokenIndex363
+		l365:
+			position, tokenIndex = position365, tokenIndex365
 			return false
 		},
 		/* 59 ByteVector <- <(Base16 / Base58 / Base64)> */
 		func() bool {
-			position432, tokenIndex432 := position, tokenIndex
+			position434, tokenIndex434 := position, tokenIndex
 			{
-				position433 := position
+				position435 := position
 				{
-					position434, tokenIndex434 := position, tokenIndex
+					position436, tokenIndex436 := position, tokenIndex
 					if !_rules[ruleBase16]() {
-						goto l435
+						goto l437
 					}
-					goto l434
-				l435:
-					position, tokenIndex = position434, tokenIndex434
+					goto l436
+				l437:
+					position, tokenIndex = position436, tokenIndex436
 					if !_rules[ruleBase58]() {
-						goto l436
+						goto l438
 					}
-					goto l434
-				l436:
-					position, tokenIndex = position434, tokenIndex434
+					goto l436
+				l438:
+					position, tokenIndex = position436, tokenIndex436
 					if !_rules[ruleBase64]() {
-						goto l432
+						goto l434
 					}
 				}
-			l434:
-				add(ruleByteVector, position433)
+			l436:
+				add(ruleByteVector, position435)
 			}
 			return true
-		l432:
-			position, tokenIndex = position432, tokenIndex432
+		l434:
+			position, tokenIndex = position434, tokenIndex434
 			return false
 		},
 		/* 60 Boolean <- <(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> */
 		func() bool {
-			position437, tokenIndex437 := position, tokenIndex
+			position439, tokenIndex439 := position, tokenIndex
 			{
-				position438 := position
+				position440 := position
 				{
-					position439, tokenIndex439 := position, tokenIndex
+					position441, tokenIndex441 := position, tokenIndex
 					if buffer[position] != rune('t') {
-						goto l440
+						goto l442
 					}
 					position++
 					if buffer[position] != rune('r') {
-						goto l440
+						goto l442
 					}
 					position++
 					if buffer[position] != rune('u') {
-						goto l440
+						goto l442
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l440
+						goto l442
 					}
 					position++
-					goto l439
-				l440:
-					position, tokenIndex = position439, tokenIndex439
+					goto l441
+				l442:
+					position, tokenIndex = position441, tokenIndex441
 					if buffer[position] != rune('f') {
-						goto l437
+						goto l439
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l437
+						goto l439
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l437
+						goto l439
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l437
+						goto l439
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l437
+						goto l439
 					}
 					position++
 				}
-			l439:
-				add(ruleBoolean, position438)
+			l441:
+				add(ruleBoolean, position440)
 			}
 			return true
-		l437:
-			position, tokenIndex = position437, tokenIndex437
+		l439:
+			position, tokenIndex = position439, tokenIndex439
 			return false
 		},
 		/* 61 String <- <('"' (UnicodeChar / EscapedChar / Char)* '"')> */
 		func() bool {
-			position441, tokenIndex441 := position, tokenIndex
+			position443, tokenIndex443 := position, tokenIndex
 			{
-				position442 := position
+				position444 := position
 				if buffer[position] != rune('"') {
-					goto l441
+					goto l443
 				}
 				position++
-			l443:
+			l445:
 				{
-					position444, tokenIndex444 := position, tokenIndex
+					position446, tokenIndex446 := position, tokenIndex
 					{
-						position445, tokenIndex445 := position, tokenIndex
+						position447, tokenIndex447 := position, tokenIndex
 						if !_rules[ruleUnicodeChar]() {
-							goto l446
+							goto l448
 						}
-						goto l445
-					l446:
-						position, tokenIndex = position445, tokenIndex445
+						goto l447
+					l448:
+						position, tokenIndex = position447, tokenIndex447
 						if !_rules[ruleEscapedChar]() {
-							goto l447
+							goto l449
 						}
-						goto l445
-					l447:
-						position, tokenIndex = position445, tokenIndex445
+						goto l447
+					l449:
+						position, tokenIndex = position447, tokenIndex447
 						if !_rules[ruleChar]() {
-							goto l444
+							goto l446
 						}
 					}
-				l445:
-					goto l443
-				l444:
-					position, tokenIndex = position444, tokenIndex444
+				l447:
+					goto l445
+				l446:
+					position, tokenIndex = position446, tokenIndex446
 				}
 				if buffer[position] != rune('"') {
-					goto l441
+					goto l443
 				}
 				position++
-				add(ruleString, position442)
+				add(ruleString, position444)
 			}
 			return true
-		l441:
-			position, tokenIndex = position441, tokenIndex441
+		l443:
+			position, tokenIndex = position443, tokenIndex443
 			return false
 		},
 		/* 62 UnicodeChar <- <('\\' 'u' ([0-9] / 'A' / 'B' / 'C' / 'D' / 'E' / 'F' / 'a' / 'b' / 'c' / 'd' / 'e' / 'f') ([0-9] / 'A' / 'B' / 'C' / 'D' / 'E' / 'F' / 'a' / 'b' / 'c' / 'd' / 'e' / 'f') ([0-9] / 'A' / 'B' / 'C' / 'D' / 'E' / 'F' / 'a' / 'b' / 'c' / 'd' / 'e' / 'f') ([0-9] / 'A' / 'B' / 'C' / 'D' / 'E' / 'F' / 'a' / 'b' / 'c' / 'd' / 'e' / 'f'))> */
 		func() bool {
-			position448, tokenIndex448 := position, tokenIndex
+			position450, tokenIndex450 := position, tokenIndex
 			{
-				position449 := position
+				position451 := position
 				if buffer[position] != rune('\\') {
-					goto l448
+					goto l450
 				}
 				position++
 				if buffer[position] != rune('u') {
-					goto l448
+					goto l450
 				}
 				position++
 				{
-					position450, tokenIndex450 := position, tokenIndex
+					position452, tokenIndex452 := position, tokenIndex
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l451
-					}
-					position++
-					goto l450
-				l451:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('A') {
-						goto l452
-					}
-					position++
-					goto l450
-				l452:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('B') {
 						goto l453
 					}
 					position++
-					goto l450
+					goto l452
 				l453:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('C') {
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('A') {
 						goto l454
 					}
 					position++
-					goto l450
+					goto l452
 				l454:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('D') {
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('B') {
 						goto l455
 					}
 					position++
-					goto l450
+					goto l452
 				l455:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('E') {
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('C') {
 						goto l456
 					}
 					position++
-					goto l450
+					goto l452
 				l456:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('F') {
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('D') {
 						goto l457
 					}
 					position++
-					goto l450
+					goto l452
 				l457:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('a') {
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('E') {
 						goto l458
 					}
 					position++
-					goto l450
+					goto l452
 				l458:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('b') {
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('F') {
 						goto l459
 					}
 					position++
-					goto l450
+					goto l452
 				l459:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('c') {
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('a') {
 						goto l460
 					}
 					position++
-					goto l450
+					goto l452
 				l460:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('d') {
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('b') {
 						goto l461
 					}
 					position++
-					goto l450
+					goto l452
 				l461:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('e') {
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('c') {
 						goto l462
 					}
 					position++
-					goto l450
+					goto l452
 				l462:
-					position, tokenIndex = position450, tokenIndex450
-					if buffer[position] != rune('f') {
-						goto l448
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('d') {
+						goto l463
 					}
 					position++
-				}
-			l450:
-				{
-					position463, tokenIndex463 := position, tokenIndex
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l452
+				l463:
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('e') {
 						goto l464
 					}
 					position++
-					goto l463
+					goto l452
 				l464:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('A') {
-						goto l465
+					position, tokenIndex = position452, tokenIndex452
+					if buffer[position] != rune('f') {
+						goto l450
 					}
 					position++
-					goto l463
-				l465:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('B') {
+				}
+			l452:
+				{
+					position465, tokenIndex465 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
 						goto l466
 					}
 					position++
-					goto l463
+					goto l465
 				l466:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('C') {
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('A') {
 						goto l467
 					}
 					position++
-					goto l463
+					goto l465
 				l467:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('D') {
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('B') {
 						goto l468
 					}
 					position++
-					goto l463
+					goto l465
 				l468:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('E') {
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('C') {
 						goto l469
 					}
 					position++
-					goto l463
+					goto l465
 				l469:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('F') {
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('D') {
 						goto l470
 					}
 					position++
-					goto l463
+					goto l465
 				l470:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('a') {
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('E') {
 						goto l471
 					}
 					position++
-					goto l463
+					goto l465
 				l471:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('b') {
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('F') {
 						goto l472
 					}
 					position++
-					goto l463
+					goto l465
 				l472:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('c') {
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('a') {
 						goto l473
 					}
 					position++
-					goto l463
+					goto l465
 				l473:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('d') {
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('b') {
 						goto l474
 					}
 					position++
-					goto l463
+					goto l465
 				l474:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('e') {
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('c') {
 						goto l475
 					}
 					position++
-					goto l463
+					goto l465
 				l475:
-					position, tokenIndex = position463, tokenIndex463
-					if buffer[position] != rune('f') {
-						goto l448
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('d') {
+						goto l476
 					}
 					position++
-				}
-			l463:
-				{
-					position476, tokenIndex476 := position, tokenIndex
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l465
+				l476:
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('e') {
 						goto l477
 					}
 					position++
-					goto l476
+					goto l465
 				l477:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('A') {
-						goto l478
+					position, tokenIndex = position465, tokenIndex465
+					if buffer[position] != rune('f') {
+						goto l450
 					}
 					position++
-					goto l476
-				l478:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('B') {
+				}
+			l465:
+				{
+					position478, tokenIndex478 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
 						goto l479
 					}
 					position++
-					goto l476
+					goto l478
 				l479:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('C') {
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('A') {
 						goto l480
 					}
 					position++
-					goto l476
+					goto l478
 				l480:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('D') {
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('B') {
 						goto l481
 					}
 					position++
-					goto l476
+					goto l478
 				l481:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('E') {
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('C') {
 						goto l482
 					}
 					position++
-					goto l476
+					goto l478
 				l482:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('F') {
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('D') {
 						goto l483
 					}
 					position++
-					goto l476
+					goto l478
 				l483:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('a') {
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('E') {
 						goto l484
 					}
 					position++
-					goto l476
+					goto l478
 				l484:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('b') {
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('F') {
 						goto l485
 					}
 					position++
-					goto l476
+					goto l478
 				l485:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('c') {
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('a') {
 						goto l486
 					}
 					position++
-					goto l476
+					goto l478
 				l486:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('d') {
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('b') {
 						goto l487
 					}
 					position++
-					goto l476
+					goto l478
 				l487:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('e') {
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('c') {
 						goto l488
 					}
 					position++
-					goto l476
+					goto l478
 				l488:
-					position, tokenIndex = position476, tokenIndex476
-					if buffer[position] != rune('f') {
-						goto l448
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('d') {
+						goto l489
 					}
 					position++
-				}
-			l476:
-				{
-					position489, tokenIndex489 := position, tokenIndex
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l478
+				l489:
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('e') {
 						goto l490
 					}
 					position++
-					goto l489
+					goto l478
 				l490:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('A') {
-						goto l491
+					position, tokenIndex = position478, tokenIndex478
+					if buffer[position] != rune('f') {
+						goto l450
 					}
 					position++
-					goto l489
-				l491:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('B') {
+				}
+			l478:
+				{
+					position491, tokenIndex491 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
 						goto l492
 					}
 					position++
-					goto l489
+					goto l491
 				l492:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('C') {
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('A') {
 						goto l493
 					}
 					position++
-					goto l489
+					goto l491
 				l493:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('D') {
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('B') {
 						goto l494
 					}
 					position++
-					goto l489
+					goto l491
 				l494:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('E') {
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('C') {
 						goto l495
 					}
 					position++
-					goto l489
+					goto l491
 				l495:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('F') {
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('D') {
 						goto l496
 					}
 					position++
-					goto l489
+					goto l491
 				l496:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('a') {
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('E') {
 						goto l497
 					}
 					position++
-					goto l489
+					goto l491
 				l497:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('b') {
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('F') {
 						goto l498
 					}
 					position++
-					goto l489
+					goto l491
 				l498:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('c') {
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('a') {
 						goto l499
 					}
 					position++
-					goto l489
+					goto l491
 				l499:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('d') {
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('b') {
 						goto l500
 					}
 					position++
-					goto l489
+					goto l491
 				l500:
-					position, tokenIndex = position489, tokenIndex489
-					if buffer[position] != rune('e') {
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('c') {
 						goto l501
 					}
 					position++
-					goto l489
+					goto l491
 				l501:
-					position, tokenIndex = position489, tokenIndex489
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('d') {
+						goto l502
+					}
+					position++
+					goto l491
+				l502:
+					position, tokenIndex = position491, tokenIndex491
+					if buffer[position] != rune('e') {
+						goto l503
+					}
+					position++
+					goto l491
+				l503:
+					position, tokenIndex = position491, tokenIndex491
 					if buffer[position] != rune('f') {
-						goto l448
+						goto l450
 					}
 					position++
 				}
-			l489:
-				add(ruleUnicodeChar, position449)
+			l491:
+				add(ruleUnicodeChar, position451)
 			}
 			return true
-		l448:
-			position, tokenIndex = position448, tokenIndex448
+		l450:
+			position, tokenIndex = position450, tokenIndex450
 			return false
 		},
 		/* 63 EscapedChar <- <('\\' .)> */
 		func() bool {
-			position502, tokenIndex502 := position, tokenIndex
+			position504, tokenIndex504 := position, tokenIndex
 			{
-				position503 := position
+				position505 := position
 				if buffer[position] != rune('\\') {
-					goto l502
+					goto l504
 				}
 				position++
 				if !matchDot() {
-					goto l502
+					goto l504
 				}
-				add(ruleEscapedChar, position503)
+				add(ruleEscapedChar, position505)
 			}
 			return true
-		l502:
-			position, tokenIndex = position502, tokenIndex502
+		l504:
+			position, tokenIndex = position504, tokenIndex504
 			return false
 		},
 		/* 64 Char <- <(!'"' .)> */
 		func() bool {
-			position504, tokenIndex504 := position, tokenIndex
+			position506, tokenIndex506 := position, tokenIndex
 			{
-				position505 := position
+				position507 := position
 				{
-					position506, tokenIndex506 := position, tokenIndex
+					position508, tokenIndex508 := position, tokenIndex
 					if buffer[position] != rune('"') {
-						goto l506
+						goto l508
 					}
 					position++
-					goto l504
-				l506:
-					position, tokenIndex = position506, tokenIndex506
+					goto l506
+				l508:
+					position, tokenIndex = position508, tokenIndex508
 				}
 				if !matchDot() {
-					goto l504
+					goto l506
 				}
-				add(ruleChar, position505)
+				add(ruleChar, position507)
 			}
 			return true
-		l504:
-			position, tokenIndex = position504, tokenIndex504
+		l506:
+			position, tokenIndex = position506, tokenIndex506
 			return false
 		},
 		/* 65 Integer <- <([0-9] ('_' &[0-9])?)+> */
 		func() bool {
-			position507, tokenIndex507 := position, tokenIndex
+			position509, tokenIndex509 := position, tokenIndex
 			{
-				position508 := position
+				position510 := position
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l507
+					goto l509
 				}
 				position++
 				{
-					position511, tokenIndex511 := position, tokenIndex
+					position513, tokenIndex513 := position, tokenIndex
 					if buffer[position] != rune('_') {
-						goto l511
+						goto l513
 					}
 					position++
 					{
-						position513, tokenIndex513 := position, tokenIndex
+						position515, tokenIndex515 := position, tokenIndex
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l511
+							goto l513
 						}
 						position++
-						position, tokenIndex = position513, tokenIndex513
+						position, tokenIndex = position515, tokenIndex515
 					}
-					goto l512
-				l511:
-					position, tokenIndex = position511, tokenIndex511
+					goto l514
+				l513:
+					position, tokenIndex = position513, tokenIndex513
 				}
-			l512:
-			l509:
+			l514:
+			l511:
 				{
-					position510, tokenIndex510 := position, tokenIndex
+					position512, tokenIndex512 := position, tokenIndex
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l510
+						goto l512
 					}
 					position++
 					{
-						position514, tokenIndex514 := position, tokenIndex
+						position516, tokenIndex516 := position, tokenIndex
 						if buffer[position] != rune('_') {
-							goto l514
+							goto l516
 						}
 						position++
 						{
-							position516, tokenIndex516 := position, tokenIndex
+							position518, tokenIndex518 := position, tokenIndex
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l514
+								goto l516
 							}
 							position++
-							position, tokenIndex = position516, tokenIndex516
+							position, tokenIndex = position518, tokenIndex518
 						}
-						goto l515
-					l514:
-						position, tokenIndex = position514, tokenIndex514
+						goto l517
+					l516:
+						position, tokenIndex = position516, tokenIndex516
 					}
-				l515:
-					goto l509
-				l510:
-					position, tokenIndex = position510, tokenIndex510
+				l517:
+					goto l511
+				l512:
+					position, tokenIndex = position512, tokenIndex512
 				}
-				add(ruleInteger, position508)
+				add(ruleInteger, position510)
 			}
 			return true
-		l507:
-			position, tokenIndex = position507, tokenIndex507
+		l509:
+			position, tokenIndex = position509, tokenIndex509
 			return false
 		},
 		/* 66 List <- <('[' _ ExprSeq? _ ']')> */
 		func() bool {
-			position517, tokenIndex517 := position, tokenIndex
+			position519, tokenIndex519 := position, tokenIndex
 			{
-				position518 := position
+				position520 := position
 				if buffer[position] != rune('[') {
-					goto l517
+					goto l519
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l517
+					goto l519
 				}
 				{
-					position519, tokenIndex519 := position, tokenIndex
+					position521, tokenIndex521 := position, tokenIndex
 					if !_rules[ruleExprSeq]() {
-						goto l519
+						goto l521
 					}
-					goto l520
-				l519:
-					position, tokenIndex = position519, tokenIndex519
+					goto l522
+				l521:
+					position, tokenIndex = position521, tokenIndex521
 				}
-			l520:
+			l522:
 				if !_rules[rule_]() {
-					goto l517
+					goto l519
 				}
 				if buffer[position] != rune(']') {
-					goto l517
+					goto l519
 				}
 				position++
-				add(ruleList, position518)
+				add(ruleList, position520)
 			}
 			return true
-		l517:
-			position, tokenIndex = position517, tokenIndex517
+		l519:
+			position, tokenIndex = position519, tokenIndex519
 			return false
 		},
 		/* 67 ExprSeq <- <(Expr (_ ',' _ ExprSeq)?)> */
 		func() bool {
-			position521, tokenIndex521 := position, tokenIndex
+			position523, tokenIndex523 := position, tokenIndex
 			{
-				position522 := position
+				position524 := position
 				if !_rules[ruleExpr]() {
-					goto l521
+					goto l523
 				}
 				{
-					position523, tokenIndex523 := position, tokenIndex
+					position525, tokenIndex525 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l523
+						goto l525
 					}
 					if buffer[position] != rune(',') {
-						goto l523
+						goto l525
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l523
+						goto l525
 					}
 					if !_rules[ruleExprSeq]() {
-						goto l523
+						goto l525
 					}
-					goto l524
-				l523:
-					position, tokenIndex = position523, tokenIndex523
+					goto l526
+				l525:
+					position, tokenIndex = position525, tokenIndex525
 				}
-			l524:
-				add(ruleExprSeq, position522)
+			l526:
+				add(ruleExprSeq, position524)
 			}
 			return true
-		l521:
-			position, tokenIndex = position521, tokenIndex521
+		l523:
+			position, tokenIndex = position523, tokenIndex523
 			return false
 		},
 		/* 68 AtomExpr <- <(UnaryOp? _ (FoldMacro / GettableExpr / IfWithError / Match))> */
 		func() bool {
-			position525, tokenIndex525 := position, tokenIndex
+			position527, tokenIndex527 := position, tokenIndex
 			{
-				position526 := position
+				position528 := position
 				{
-					position527, tokenIndex527 := position, tokenIndex
+					position529, tokenIndex529 := position, tokenIndex
 					if !_rules[ruleUnaryOp]() {
-						goto l527
+						goto l529
 					}
-					goto l528
-				l527:
-					position, tokenIndex = position527, tokenIndex527
+					goto l530
+				l529:
+					position, tokenIndex = position529, tokenIndex529
 				}
-			l528:
+			l530:
 				if !_rules[rule_]() {
-					goto l525
+					goto l527
 				}
 				{
-					position529, tokenIndex529 := position, tokenIndex
+					position531, tokenIndex531 := position, tokenIndex
 					if !_rules[ruleFoldMacro]() {
-						goto l530
+						goto l532
 					}
-					goto l529
-				l530:
-					position, tokenIndex = position529, tokenIndex529
+					goto l531
+				l532:
+					position, tokenIndex = position531, tokenIndex531
 					if !_rules[ruleGettableExpr]() {
-						goto l531
+						goto l533
 					}
-					goto l529
-				l531:
-					position, tokenIndex = position529, tokenIndex529
+					goto l531
+				l533:
+					position, tokenIndex = position531, tokenIndex531
 					if !_rules[ruleIfWithError]() {
-						goto l532
+						goto l534
 					}
-					goto l529
-				l532:
-					position, tokenIndex = position529, tokenIndex529
+					goto l531
+				l534:
+					position, tokenIndex = position531, tokenIndex531
 					if !_rules[ruleMatch]() {
-						goto l525
+						goto l527
 					}
 				}
-			l529:
-				add(ruleAtomExpr, position526)
+			l531:
+				add(ruleAtomExpr, position528)
 			}
 			return true
-		l525:
-			position, tokenIndex = position525, tokenIndex525
+		l527:
+			position, tokenIndex = position527, tokenIndex527
 			return false
 		},
 		/* 69 MultGroupOpAtom <- <(AtomExpr (_ MultGroupOp _ AtomExpr)*)> */
 		func() bool {
-			position533, tokenIndex533 := position, tokenIndex
+			position535, tokenIndex535 := position, tokenIndex
 			{
-				position534 := position
+				position536 := position
 				if !_rules[ruleAtomExpr]() {
-					goto l533
+					goto l535
 				}
-			l535:
+			l537:
 				{
-					position536, tokenIndex536 := position, tokenIndex
+					position538, tokenIndex538 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l536
+						goto l538
 					}
 					if !_rules[ruleMultGroupOp]() {
-						goto l536
+						goto l538
 					}
 					if !_rules[rule_]() {
-						goto l536
+						goto l538
 					}
 					if !_rules[ruleAtomExpr]() {
-						goto l536
+						goto l538
 					}
-					goto l535
-				l536:
-					position, tokenIndex = position536, tokenIndex536
+					goto l537
+				l538:
+					position, tokenIndex = position538, tokenIndex538
 				}
-				add(ruleMultGroupOpAtom, position534)
+				add(ruleMultGroupOpAtom, position536)
 			}
 			return true
-		l533:
-			position, tokenIndex = position533, tokenIndex533
+		l535:
+			position, tokenIndex = position535, tokenIndex535
 			return false
 		},
 		/* 70 SumGroupOpAtom <- <(MultGroupOpAtom (_ SumGroupOp _ MultGroupOpAtom)*)> */
 		func() bool {
-			position537, tokenIndex537 := position, tokenIndex
+			position539, tokenIndex539 := position, tokenIndex
 			{
-				position538 := position
+				position540 := position
 				if !_rules[ruleMultGroupOpAtom]() {
-					goto l537
+					goto l539
 				}
-			l539:
+			l541:
 				{
-					position540, tokenIndex540 := position, tokenIndex
+					position542, tokenIndex542 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l540
+						goto l542
 					}
 					if !_rules[ruleSumGroupOp]() {
-						goto l540
+						goto l542
 					}
 					if !_rules[rule_]() {
-						goto l540
+						goto l542
 					}
 					if !_rules[ruleMultGroupOpAtom]() {
-						goto l540
+						goto l542
 					}
-					goto l539
-				l540:
-					position, tokenIndex = position540, tokenIndex540
+					goto l541
+				l542:
+					position, tokenIndex = position542, tokenIndex542
 				}
-				add(ruleSumGroupOpAtom, position538)
+				add(ruleSumGroupOpAtom, position540)
 			}
 			return true
-		l537:
-			position, tokenIndex = position537, tokenIndex537
+		l539:
+			position, tokenIndex = position539, tokenIndex539
 			return false
 		},
 		/* 71 ListGroupOpAtom <- <(SumGroupOpAtom (_ ListGroupOp _ SumGroupOpAtom)*)> */
 		func() bool {
-			position541, tokenIndex541 := position, tokenIndex
+			position543, tokenIndex543 := position, tokenIndex
 			{
-				position542 := position
+				position544 := position
 				if !_rules[ruleSumGroupOpAtom]() {
-					goto l541
+					goto l543
 				}
-			l543:
+			l545:
 				{
-					position544, tokenIndex544 := position, tokenIndex
+					position546, tokenIndex546 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l544
+						goto l546
 					}
 					if !_rules[ruleListGroupOp]() {
-						goto l544
+						goto l546
 					}
 					if !_rules[rule_]() {
-						goto l544
+						goto l546
 					}
 					if !_rules[ruleSumGroupOpAtom]() {
-						goto l544
+						goto l546
 					}
-					goto l543
-				l544:
-					position, tokenIndex = position544, tokenIndex544
+					goto l545
+				l546:
+					position, tokenIndex = position546, tokenIndex546
 				}
-				add(ruleListGroupOpAtom, position542)
+				add(ruleListGroupOpAtom, position544)
 			}
 			return true
-		l541:
-			position, tokenIndex = position541, tokenIndex541
+		l543:
+			position, tokenIndex = position543, tokenIndex543
 			return false
 		},
 		/* 72 CompareGroupOpAtom <- <(ListGroupOpAtom (_ CompareGroupOp _ ListGroupOpAtom)*)> */
 		func() bool {
-			position545, tokenIndex545 := position, tokenIndex
+			position547, tokenIndex547 := position, tokenIndex
 			{
-				position546 := position
+				position548 := position
 				if !_rules[ruleListGroupOpAtom]() {
-					goto l545
+					goto l547
 				}
-			l547:
+			l549:
 				{
-					position548, tokenIndex548 := position, tokenIndex
+					position550, tokenIndex550 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l548
+						goto l550
 					}
 					if !_rules[ruleCompareGroupOp]() {
-						goto l548
+						goto l550
 					}
 					if !_rules[rule_]() {
-						goto l548
+						goto l550
 					}
 					if !_rules[ruleListGroupOpAtom]() {
-						goto l548
+						goto l550
 					}
-					goto l547
-				l548:
-					position, tokenIndex = position548, tokenIndex548
+					goto l549
+				l550:
+					position, tokenIndex = position550, tokenIndex550
 				}
-				add(ruleCompareGroupOpAtom, position546)
+				add(ruleCompareGroupOpAtom, position548)
 			}
 			return true
-		l545:
-			position, tokenIndex = position545, tokenIndex545
+		l547:
+			position, tokenIndex = position547, tokenIndex547
 			return false
 		},
 		/* 73 EqualityGroupOpAtom <- <(CompareGroupOpAtom (_ EqGroupOp _ CompareGroupOpAtom)*)> */
 		func() bool {
-			position549, tokenIndex549 := position, tokenIndex
+			position551, tokenIndex551 := position, tokenIndex
 			{
-				position550 := position
+				position552 := position
 				if !_rules[ruleCompareGroupOpAtom]() {
-					goto l549
+					goto l551
 				}
-			l551:
+			l553:
 				{
-					position552, tokenIndex552 := position, tokenIndex
+					position554, tokenIndex554 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l552
+						goto l554
 					}
 					if !_rules[ruleEqGroupOp]() {
-						goto l552
+						goto l554
 					}
 					if !_rules[rule_]() {
-						goto l552
+						goto l554
 					}
 					if !_rules[ruleCompareGroupOpAtom]() {
-						goto l552
+						goto l554
 					}
-					goto l551
-				l552:
-					position, tokenIndex = position552, tokenIndex552
+					goto l553
+				l554:
+					position, tokenIndex = position554, tokenIndex554
 				}
-				add(ruleEqualityGroupOpAtom, position550)
+				add(ruleEqualityGroupOpAtom, position552)
 			}
 			return true
-		l549:
-			position, tokenIndex = position549, tokenIndex549
+		l551:
+			position, tokenIndex = position551, tokenIndex551
 			return false
 		},
 		/* 74 AndOpAtom <- <(EqualityGroupOpAtom (_ AndOp _ EqualityGroupOpAtom)*)> */
 		func() bool {
-			position553, tokenIndex553 := position, tokenIndex
+			position555, tokenIndex555 := position, tokenIndex
 			{
-				position554 := position
+				position556 := position
 				if !_rules[ruleEqualityGroupOpAtom]() {
-					goto l553
+					goto l555
 				}
-			l555:
+			l557:
 				{
-					position556, tokenIndex556 := position, tokenIndex
+					position558, tokenIndex558 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l556
+						goto l558
 					}
 					if !_rules[ruleAndOp]() {
-						goto l556
+						goto l558
 					}
 					if !_rules[rule_]() {
-						goto l556
+						goto l558
 					}
 					if !_rules[ruleEqualityGroupOpAtom]() {
-						goto l556
+						goto l558
 					}
-					goto l555
-				l556:
-					position, tokenIndex = position556, tokenIndex556
+					goto l557
+				l558:
+					position, tokenIndex = position558, tokenIndex558
 				}
-				add(ruleAndOpAtom, position554)
+				add(ruleAndOpAtom, position556)
 			}
 			return true
-		l553:
-			position, tokenIndex = position553, tokenIndex553
+		l555:
+			position, tokenIndex = position555, tokenIndex555
 			return false
 		},
 		/* 75 OrOpAtom <- <(AndOpAtom (_ OrOp _ AndOpAtom)*)> */
 		func() bool {
-			position557, tokenIndex557 := position, tokenIndex
+			position559, tokenIndex559 := position, tokenIndex
 			{
-				position558 := position
+				position560 := position
 				if !_rules[ruleAndOpAtom]() {
-					goto l557
+					goto l559
 				}
-			l559:
+			l561:
 				{
-					position560, tokenIndex560 := position, tokenIndex
+					position562, tokenIndex562 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l560
+						goto l562
 					}
 					if !_rules[ruleOrOp]() {
-						goto l560
+						goto l562
 					}
 					if !_rules[rule_]() {
-						goto l560
+						goto l562
 					}
 					if !_rules[ruleAndOpAtom]() {
-						goto l560
+						goto l562
 					}
-					goto l559
-				l560:
-					position, tokenIndex = position560, tokenIndex560
+					goto l561
+				l562:
+					position, tokenIndex = position562, tokenIndex562
 				}
-				add(ruleOrOpAtom, position558)
+				add(ruleOrOpAtom, position560)
 			}
 			return true
-		l557:
-			position, tokenIndex = position557, tokenIndex557
+		l559:
+			position, tokenIndex = position559, tokenIndex559
 			return false
 		},
 		/* 76 Expr <- <OrOpAtom> */
 		func() bool {
-			position561, tokenIndex561 := position, tokenIndex
+			position563, tokenIndex563 := position, tokenIndex
 			{
-				position562 := position
+				position564 := position
 				if !_rules[ruleOrOpAtom]() {
-					goto l561
+					goto l563
 				}
-				add(ruleExpr, position562)
+				add(ruleExpr, position564)
 			}
 			return true
-		l561:
-			position, tokenIndex = position561, tokenIndex561
+		l563:
+			position, tokenIndex = position563, tokenIndex563
 			return false
 		},
 		/* 77 ParExpr <- <('(' _ Expr _ ')')> */
 		func() bool {
-			position563, tokenIndex563 := position, tokenIndex
+			position565, tokenIndex565 := position, tokenIndex
 			{
-				position564 := position
+				position566 := position
 				if buffer[position] != rune('(') {
-					goto l563
+					goto l565
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l563
+					goto l565
 				}
 				if !_rules[ruleExpr]() {
-					goto l563
+					goto l565
 				}
 				if !_rules[rule_]() {
-					goto l563
+					goto l565
 				}
 				if buffer[position] != rune(')') {
-					goto l563
+					goto l565
 				}
 				position++
-				add(ruleParExpr, position564)
+				add(ruleParExpr, position566)
 			}
 			return true
-		l563:
-			position, tokenIndex = position563, tokenIndex563
+		l565:
+			position, tokenIndex = position565, tokenIndex565
 			return false
 		},
 		/* 78 GettableExpr <- <((ParExpr / Block / FunctionCall / Identifier / Const) (AsType / ListAccess / (_ '.' _ (FunctionCallAccess / IdentifierAccess / TupleAccess)))*)> */
 		func() bool {
-			position565, tokenIndex565 := position, tokenIndex
+			position567, tokenIndex567 := position, tokenIndex
 			{
-				position566 := position
+				position568 := position
 				{
-					position567, tokenIndex567 := position, tokenIndex
+					position569, tokenIndex569 := position, tokenIndex
 					if !_rules[ruleParExpr]() {
-						goto l568
+						goto l570
 					}
-					goto l567
-				l568:
-					position, tokenIndex = position567, tokenIndex567
+					goto l569
+				l570:
+					position, tokenIndex = position569, tokenIndex569
 					if !_rules[ruleBlock]() {
-						goto l569
+						goto l571
 					}
-					goto l567
-				l569:
-					position, tokenIndex = position567, tokenIndex567
+					goto l569
+				l571:
+					position, tokenIndex = position569, tokenIndex569
 					if !_rules[ruleFunctionCall]() {
-						goto l570
+						goto l572
 					}
-					goto l567
-				l570:
-					position, tokenIndex = position567, tokenIndex567
+					goto l569
+				l572:
+					position, tokenIndex = position569, tokenIndex569
 					if !_rules[ruleIdentifier]() {
-						goto l571
+						goto l573
 					}
-					goto l567
-				l571:
-					position, tokenIndex = position567, tokenIndex567
+					goto l569
+				l573:
+					position, tokenIndex = position569, tokenIndex569
 					if !_rules[ruleConst]() {
-						goto l565
+						goto l567
 					}
 				}
-			l567:
-			l572:
+			l569:
+			l574:
 				{
-					position573, tokenIndex573 := position, tokenIndex
+					position575, tokenIndex575 := position, tokenIndex
 					{
-						position574, tokenIndex574 := position, tokenIndex
+						position576, tokenIndex576 := position, tokenIndex
 						if !_rules[ruleAsType]() {
-							goto l575
+							goto l577
 						}
-						goto l574
-					l575:
-						position, tokenIndex = position574, tokenIndex574
+						goto l576
+					l577:
+						position, tokenIndex = position576, tokenIndex576
 						if !_rules[ruleListAccess]() {
-							goto l576
+							goto l578
 						}
-						goto l574
-					l576:
-						position, tokenIndex = position574, tokenIndex574
+						goto l576
+					l578:
+						position, tokenIndex = position576, tokenIndex576
 						if !_rules[rule_]() {
-							goto l573
+							goto l575
 						}
 						if buffer[position] != rune('.') {
-							goto l573
+							goto l575
 						}
 						position++
 						if !_rules[rule_]() {
-							goto l573
+							goto l575
 						}
 						{
-							position577, tokenIndex577 := position, tokenIndex
+							position579, tokenIndex579 := position, tokenIndex
 							if !_rules[ruleFunctionCallAccess]() {
-								goto l578
+								goto l580
 							}
-							goto l577
-						l578:
-							position, tokenIndex = position577, tokenIndex577
+							goto l579
+						l580:
+							position, tokenIndex = position579, tokenIndex579
 							if !_rules[ruleIdentifierAccess]() {
-								goto l579
+								goto l581
 							}
-							goto l577
-						l579:
-							position, tokenIndex = position577, tokenIndex577
+							goto l579
+						l581:
+							position, tokenIndex = position579, tokenIndex579
 							if !_rules[ruleTupleAccess]() {
-								goto l573
+								goto l575
 							}
 						}
-					l577:
+					l579:
 					}
-				l574:
-					goto l572
-				l573:
-					position, tokenIndex = position573, tokenIndex573
+				l576:
+					goto l574
+				l575:
+					position, tokenIndex = position575, tokenIndex575
 				}
-				add(ruleGettableExpr, position566)
+				add(ruleGettableExpr, position568)
 			}
 			return true
-		l565:
-			position, tokenIndex = position565, tokenIndex565
+		l567:
+			position, tokenIndex = position567, tokenIndex567
 			return false
 		},
 		/* 79 FunctionCallAccess <- <FunctionCall> */
 		func() bool {
-			position580, tokenIndex580 := position, tokenIndex
+			position582, tokenIndex582 := position, tokenIndex
 			{
-				position581 := position
+				position583 := position
 				if !_rules[ruleFunctionCall]() {
-					goto l580
+					goto l582
 				}
-				add(ruleFunctionCallAccess, position581)
+				add(ruleFunctionCallAccess, position583)
 			}
 			return true
-		l580:
-			position, tokenIndex = position580, tokenIndex580
+		l582:
+			position, tokenIndex = position582, tokenIndex582
 			return false
 		},
 		/* 80 IdentifierAccess <- <Identifier> */
 		func() bool {
-			position582, tokenIndex582 := position, tokenIndex
+			position584, tokenIndex584 := position, tokenIndex
 			{
-				position583 := position
+				position585 := position
 				if !_rules[ruleIdentifier]() {
-					goto l582
+					goto l584
 				}
-				add(ruleIdentifierAccess, position583)
+				add(ruleIdentifierAccess, position585)
 			}
 			return true
-		l582:
-			position, tokenIndex = position582, tokenIndex582
+		l584:
+			position, tokenIndex = position584, tokenIndex584
 			return false
 		},
 		/* 81 ListAccess <- <('[' _ (Expr / Identifier) _ ']')> */
 		func() bool {
-			position584, tokenIndex584 := position, tokenIndex
+			position586, tokenIndex586 := position, tokenIndex
 			{
-				position585 := position
+				position587 := position
 				if buffer[position] != rune('[') {
-					goto l584
+					goto l586
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l584
+					goto l586
 				}
 				{
-					position586, tokenIndex586 := position, tokenIndex
+					position588, tokenIndex588 := position, tokenIndex
 					if !_rules[ruleExpr]() {
-						goto l587
+						goto l589
 					}
-					goto l586
-				l587:
-					position, tokenIndex = position586, tokenIndex586
+					goto l588
+				l589:
+					position, tokenIndex = position588, tokenIndex588
 					if !_rules[ruleIdentifier]() {
-						goto l584
+						goto l586
 					}
 				}
-			l586:
+			l588:
 				if !_rules[rule_]() {
-					goto l584
+					goto l586
 				}
 				if buffer[position] != rune(']') {
-					goto l584
+					goto l586
 				}
 				position++
-				add(ruleListAccess, position585)
+				add(ruleListAccess, position587)
 			}
 			return true
-		l584:
-			position, tokenIndex = position584, tokenIndex584
+		l586:
+			position, tokenIndex = position586, tokenIndex586
 			return false
 		},
 		/* 82 AsType <- <(_ '.' _ (AsString / ExactAsString) '[' _ Types _ ']')> */
 		func() bool {
-			position588, tokenIndex588 := position, tokenIndex
+			position590, tokenIndex590 := position, tokenIndex
 			{
-				position589 := position
+				position591 := position
 				if !_rules[rule_]() {
-					goto l588
+					goto l590
 				}
 				if buffer[position] != rune('.') {
-					goto l588
+					goto l590
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l588
+					goto l590
 				}
 				{
-					position590, tokenIndex590 := position, tokenIndex
+					position592, tokenIndex592 := position, tokenIndex
 					if !_rules[ruleAsString]() {
-						goto l591
+						goto l593
 					}
-					goto l590
-				l591:
-					position, tokenIndex = position590, tokenIndex590
+					goto l592
+				l593:
+					position, tokenIndex = position592, tokenIndex592
 					if !_rules[ruleExactAsString]() {
-						goto l588
+						goto l590
 					}
 				}
-			l590:
+			l592:
 				if buffer[position] != rune('[') {
-					goto l588
+					goto l590
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l588
+					goto l590
 				}
 				if !_rules[ruleTypes]() {
-					goto l588
+					goto l590
 				}
 				if !_rules[rule_]() {
-					goto l588
+					goto l590
 				}
 				if buffer[position] != rune(']') {
-					goto l588
+					goto l590
 				}
 				position++
-				add(ruleAsType, position589)
+				add(ruleAsType, position591)
 			}
 			return true
-		l588:
-			position, tokenIndex = position588, tokenIndex588
+		l590:
+			position, tokenIndex = position590, tokenIndex590
 			return false
 		},
 		/* 83 AsString <- <('a' 's')> */
 		func() bool {
-			position592, tokenIndex592 := position, tokenIndex
+			position594, tokenIndex594 := position, tokenIndex
 			{
-				position593 := position
+				position595 := position
 				if buffer[position] != rune('a') {
-					goto l592
+					goto l594
 				}
 				position++
 				if buffer[position] != rune('s') {
-					goto l592
+					goto l594
 				}
 				position++
-				add(ruleAsString, position593)
+				add(ruleAsString, position595)
 			}
 			return true
-		l592:
-			position, tokenIndex = position592, tokenIndex592
+		l594:
+			position, tokenIndex = position594, tokenIndex594
 			return false
 		},
 		/* 84 ExactAsString <- <('e' 'x' 'a' 'c' 't' 'A' 's')> */
 		func() bool {
-			position594, tokenIndex594 := position, tokenIndex
+			position596, tokenIndex596 := position, tokenIndex
 			{
-				position595 := position
+				position597 := position
 				if buffer[position] != rune('e') {
-					goto l594
+					goto l596
 				}
 				position++
 				if buffer[position] != rune('x') {
-					goto l594
+					goto l596
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l594
+					goto l596
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l594
+					goto l596
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l594
+					goto l596
 				}
 				position++
 				if buffer[position] != rune('A') {
-					goto l594
+					goto l596
 				}
 				position++
 				if buffer[position] != rune('s') {
-					goto l594
+					goto l596
 				}
 				position++
-				add(ruleExactAsString, position595)
+				add(ruleExactAsString, position597)
 			}
 			return true
-		l594:
-			position, tokenIndex = position594, tokenIndex594
+		l596:
+			position, tokenIndex = position596, tokenIndex596
 			return false
 		},
 		/* 85 Block <- <('{' (_ Declaration)* _ Expr _ '}')> */
 		func() bool {
-			position596, tokenIndex596 := position, tokenIndex
+			position598, tokenIndex598 := position, tokenIndex
 			{
-				position597 := position
+				position599 := position
 				if buffer[position] != rune('{') {
-					goto l596
+					goto l598
 				}
 				position++
-			l598:
+			l600:
 				{
-					position599, tokenIndex599 := position, tokenIndex
+					position601, tokenIndex601 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l599
+						goto l601
 					}
 					if !_rules[ruleDeclaration]() {
-						goto l599
+						goto l601
 					}
-					goto l598
-				l599:
-					position, tokenIndex = position599, tokenIndex599
+					goto l600
+				l601:
+					position, tokenIndex = position601, tokenIndex601
 				}
 				if !_rules[rule_]() {
-					goto l596
+					goto l598
 				}
 				if !_rules[ruleExpr]() {
-					goto l596
+					goto l598
 				}
 				if !_rules[rule_]() {
-					goto l596
+					goto l598
 				}
 				if buffer[position] != rune('}') {
-					goto l596
+					goto l598
 				}
 				position++
-				add(ruleBlock, position597)
+				add(ruleBlock, position599)
 			}
 			return true
-		l596:
-			position, tokenIndex = position596, tokenIndex596
+		l598:
+			position, tokenIndex = position598, tokenIndex598
 			return false
 		},
 		/* 86 BlockWithoutPar <- <((_ Declaration)* _ Expr)> */
 		func() bool {
-			position600, tokenIndex600 := position, tokenIndex
+			position602, tokenIndex602 := position, tokenIndex
 			{
-				position601 := position
-			l602:
+				position603 := position
+			l604:
 				{
-					position603, tokenIndex603 := position, tokenIndex
+					position605, tokenIndex605 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l603
+						goto l605
 					}
 					if !_rules[ruleDeclaration]() {
-						goto l603
+						goto l605
 					}
-					goto l602
-				l603:
-					position, tokenIndex = position603, tokenIndex603
+					goto l604
+				l605:
+					position, tokenIndex = position605, tokenIndex605
 				}
 				if !_rules[rule_]() {
-					goto l600
+					goto l602
 				}
 				if !_rules[ruleExpr]() {
-					goto l600
+					goto l602
 				}
-				add(ruleBlockWithoutPar, position601)
+				add(ruleBlockWithoutPar, position603)
 			}
 			return true
-		l600:
-			position, tokenIndex = position600, tokenIndex600
+		l602:
+			position, tokenIndex = position602, tokenIndex602
 			return false
 		},
 		/* 87 FunctionCall <- <(Identifier '(' _ ExprSeq? _ ')')> */
 		func() bool {
-			position604, tokenIndex604 := position, tokenIndex
+			position606, tokenIndex606 := position, tokenIndex
 			{
-				position605 := position
+				position607 := position
 				if !_rules[ruleIdentifier]() {
-					goto l604
+					goto l606
 				}
 				if buffer[position] != rune('(') {
-					goto l604
+					goto l606
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l604
+					goto l606
 				}
 				{
-					position606, tokenIndex606 := position, tokenIndex
+					position608, tokenIndex608 := position, tokenIndex
 					if !_rules[ruleExprSeq]() {
-						goto l606
+						goto l608
 					}
-					goto l607
-				l606:
-					position, tokenIndex = position606, tokenIndex606
+					goto l609
+				l608:
+					position, tokenIndex = position608, tokenIndex608
 				}
-			l607:
+			l609:
 				if !_rules[rule_]() {
-					goto l604
+					goto l606
 				}
 				if buffer[position] != rune(')') {
-					goto l604
+					goto l606
 				}
 				position++
-				add(ruleFunctionCall, position605)
+				add(ruleFunctionCall, position607)
 			}
 			return true
-		l604:
-			position, tokenIndex = position604, tokenIndex604
+		l606:
+			position, tokenIndex = position606, tokenIndex606
 			return false
 		},
 		/* 88 FoldMacro <- <('F' 'O' 'L' 'D' _ '<' _ Integer _ '>' _ '(' _ Expr _ ',' _ Expr _ ',' _ Identifier _ ')')> */
 		func() bool {
-			position608, tokenIndex608 := position, tokenIndex
+			position610, tokenIndex610 := position, tokenIndex
 			{
-				position609 := position
+				position611 := position
 				if buffer[position] != rune('F') {
-					goto l608
+					goto l610
 				}
 				position++
 				if buffer[position] != rune('O') {
-					goto l608
+					goto l610
 				}
 				position++
 				if buffer[position] != rune('L') {
-					goto l608
+					goto l610
 				}
 				position++
 				if buffer[position] != rune('D') {
-					goto l608
+					goto l610
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if buffer[position] != rune('<') {
-					goto l608
+					goto l610
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if !_rules[ruleInteger]() {
-					goto l608
+					goto l610
 				}
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if buffer[position] != rune('>') {
-					goto l608
+					goto l610
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if buffer[position] != rune('(') {
-					goto l608
+					goto l610
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if !_rules[ruleExpr]() {
-					goto l608
+					goto l610
 				}
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if buffer[position] != rune(',') {
-					goto l608
+					goto l610
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if !_rules[ruleExpr]() {
-					goto l608
+					goto l610
 				}
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if buffer[position] != rune(',') {
-					goto l608
+					goto l610
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if !_rules[ruleIdentifier]() {
-					goto l608
+					goto l610
 				}
 				if !_rules[rule_]() {
-					goto l608
+					goto l610
 				}
 				if buffer[position] != rune(')') {
-					goto l608
+					goto l610
 				}
 				position++
-				add(ruleFoldMacro, position609)
+				add(ruleFoldMacro, position611)
 			}
 			return true
-		l608:
-			position, tokenIndex = position608, tokenIndex608
+		l610:
+			position, tokenIndex = position610, tokenIndex610
 			return false
 		},
 		/* 89 IfWithError <- <(If / FailedIfWithoutElse)> */
 		func() bool {
-			position610, tokenIndex610 := position, tokenIndex
+			position612, tokenIndex612 := position, tokenIndex
 			{
-				position611 := position
+				position613 := position
 				{
-					position612, tokenIndex612 := position, tokenIndex
+					position614, tokenIndex614 := position, tokenIndex
 					if !_rules[ruleIf]() {
-						goto l613
+						goto l615
 					}
-					goto l612
-				l613:
-					position, tokenIndex = position612, tokenIndex612
+					goto l614
+				l615:
+					position, tokenIndex = position614, tokenIndex614
 					if !_rules[ruleFailedIfWithoutElse]() {
-						goto l610
+						goto l612
 					}
 				}
-			l612:
-				add(ruleIfWithError, position611)
+			l614:
+				add(ruleIfWithError, position613)
 			}
 			return true
-		l610:
-			position, tokenIndex = position610, tokenIndex610
+		l612:
+			position, tokenIndex = position612, tokenIndex612
 			return false
 		},
 		/* 90 If <- <('i' 'f' _ Expr _ ('t' 'h' 'e' 'n') _ (Expr / BlockWithoutPar) _ ('e' 'l' 's' 'e') _ (Expr / BlockWithoutPar))> */
 		func() bool {
-			position614, tokenIndex614 := position, tokenIndex
+			position616, tokenIndex616 := position, tokenIndex
 			{
-				position615 := position
+				position617 := position
 				if buffer[position] != rune('i') {
-					goto l614
+					goto l616
 				}
 				position++
 				if buffer[position] != rune('f') {
-					goto l614
+					goto l616
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l614
+					goto l616
 				}
 				if !_rules[ruleExpr]() {
-					goto l614
+					goto l616
 				}
 				if !_rules[rule_]() {
-					goto l614
+					goto l616
 				}
 				if buffer[position] != rune('t') {
-					goto l614
+					goto l616
 				}
 				position++
 				if buffer[position] != rune('h') {
-					goto l614
+					goto l616
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l614
+					goto l616
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l614
+					goto l616
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l614
+					goto l616
 				}
 				{
-					position616, tokenIndex616 := position, tokenIndex
+					position618, tokenIndex618 := position, tokenIndex
 					if !_rules[ruleExpr]() {
-						goto l617
+						goto l619
 					}
-					goto l616
-				l617:
-					position, tokenIndex = position616, tokenIndex616
+					goto l618
+				l619:
+					position, tokenIndex = position618, tokenIndex618
 					if !_rules[ruleBlockWithoutPar]() {
-						goto l614
+						goto l616
 					}
 				}
-			l616:
+			l618:
 				if !_rules[rule_]() {
-					goto l614
+					goto l616
 				}
 				if buffer[position] != rune('e') {
-					goto l614
+					goto l616
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l614
+					goto l616
 				}
 				position++
 				if buffer[position] != rune('s') {
-					goto l614
+					goto l616
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l614
+					goto l616
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l614
+					goto l616
 				}
 				{
-					position618, tokenIndex618 := position, tokenIndex
+					position620, tokenIndex620 := position, tokenIndex
 					if !_rules[ruleExpr]() {
-						goto l619
+						goto l621
 					}
-					goto l618
-				l619:
-					position, tokenIndex = position618, tokenIndex618
+					goto l620
+				l621:
+					position, tokenIndex = position620, tokenIndex620
 					if !_rules[ruleBlockWithoutPar]() {
-						goto l614
+						goto l616
 					}
 				}
-			l618:
-				add(ruleIf, position615)
+			l620:
+				add(ruleIf, position617)
 			}
 			return true
-		l614:
-			position, tokenIndex = position614, tokenIndex614
+		l616:
+			position, tokenIndex = position616, tokenIndex616
 			return false
 		},
 		/* 91 FailedIfWithoutElse <- <('i' 'f' _ Expr _ ('t' 'h' 'e' 'n') _ (Expr / BlockWithoutPar))> */
 		func() bool {
-			position620, tokenIndex620 := position, tokenIndex
+			position622, tokenIndex622 := position, tokenIndex
 			{
-				position621 := position
+				position623 := position
 				if buffer[position] != rune('i') {
-					goto l620
+					goto l622
 				}
 				position++
 				if buffer[position] != rune('f') {
-					goto l620
+					goto l622
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l620
+					goto l622
 				}
 				if !_rules[ruleExpr]() {
-					goto l620
+					goto l622
 				}
 				if !_rules[rule_]() {
-					goto l620
+					goto l622
 				}
 				if buffer[position] != rune('t') {
-					goto l620
+					goto l622
 				}
 				position++
 				if buffer[position] != rune('h') {
-					goto l620
+					goto l622
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l620
+					goto l622
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l620
+					goto l622
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l620
+					goto l622
 				}
 				{
-					position622, tokenIndex622 := position, tokenIndex
+					position624, tokenIndex624 := position, tokenIndex
 					if !_rules[ruleExpr]() {
-						goto l623
+						goto l625
 					}
-					goto l622
-				l623:
-					position, tokenIndex = position622, tokenIndex622
+					goto l624
+				l625:
+					position, tokenIndex = position624, tokenIndex624
 					if !_rules[ruleBlockWithoutPar]() {
-						goto l620
+						goto l622
 					}
 				}
-			l622:
-				add(ruleFailedIfWithoutElse, position621)
+			l624:
+				add(ruleFailedIfWithoutElse, position623)
 			}
 			return true
-		l620:
-			position, tokenIndex = position620, tokenIndex620
+		l622:
+			position, tokenIndex = position622, tokenIndex622
 			return false
 		},
 		/* 92 Match <- <('m' 'a' 't' 'c' 'h' _ Expr _ '{' (_ Case)+ _ '}')> */
 		func() bool {
-			position624, tokenIndex624 := position, tokenIndex
+			position626, tokenIndex626 := position, tokenIndex
 			{
-				position625 := position
+				position627 := position
 				if buffer[position] != rune('m') {
-					goto l624
+					goto l626
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l624
+					goto l626
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l624
+					goto l626
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l624
+					goto l626
 				}
 				position++
 				if buffer[position] != rune('h') {
-					goto l624
+					goto l626
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l624
+					goto l626
 				}
 				if !_rules[ruleExpr]() {
-					goto l624
+					goto l626
 				}
 				if !_rules[rule_]() {
-					goto l624
+					goto l626
 				}
 				if buffer[position] != rune('{') {
-					goto l624
+					goto l626
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l624
+					goto l626
 				}
 				if !_rules[ruleCase]() {
-					goto l624
+					goto l626
 				}
-			l626:
+			l628:
 				{
-					position627, tokenIndex627 := position, tokenIndex
+					position629, tokenIndex629 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l627
+						goto l629
 					}
 					if !_rules[ruleCase]() {
-						goto l627
+						goto l629
 					}
-					goto l626
-				l627:
-					position, tokenIndex = position627, tokenIndex627
+					goto l628
+				l629:
+					position, tokenIndex = position629, tokenIndex629
 				}
 				if !_rules[rule_]() {
-					goto l624
+					goto l626
 				}
 				if buffer[position] != rune('}') {
-					goto l624
+					goto l626
 				}
 				position++
-				add(ruleMatch, position625)
+				add(ruleMatch, position627)
 			}
 			return true
-		l624:
-			position, tokenIndex = position624, tokenIndex624
+		l626:
+			position, tokenIndex = position626, tokenIndex626
 			return false
 		},
 		/* 93 Case <- <('c' 'a' 's' 'e' _ (ValuePattern / TuplePattern / ObjectPattern / Placeholder / Expr) _ ('=' '>') _ (Block / BlockWithoutPar))> */
 		func() bool {
-			position628, tokenIndex628 := position, tokenIndex
+			position630, tokenIndex630 := position, tokenIndex
 			{
-				position629 := position
+				position631 := position
 				if buffer[position] != rune('c') {
-					goto l628
+					goto l630
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l628
+					goto l630
 				}
 				position++
 				if buffer[position] != rune('s') {
-					goto l628
+					goto l630
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l628
+					goto l630
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l628
+					goto l630
 				}
 				{
-					position630, tokenIndex630 := position, tokenIndex
+					position632, tokenIndex632 := position, tokenIndex
 					if !_rules[ruleValuePattern]() {
-						goto l631
+						goto l633
 					}
-					goto l630
-				l631:
-					position, tokenIndex = position630, tokenIndex630
+					goto l632
+				l633:
+					position, tokenIndex = position632, tokenIndex632
 					if !_rules[ruleTuplePattern]() {
-						goto l632
+						goto l634
 					}
-					goto l630
-				l632:
-					position, tokenIndex = position630, tokenIndex630
+					goto l632
+				l634:
+					position, tokenIndex = position632, tokenIndex632
 					if !_rules[ruleObjectPattern]() {
-						goto l633
+						goto l635
 					}
-					goto l630
-				l633:
-					position, tokenIndex = position630, tokenIndex630
+					goto l632
+				l635:
+					position, tokenIndex = position632, tokenIndex632
 					if !_rules[rulePlaceholder]() {
-						goto l634
+						goto l636
 					}
-					goto l630
-				l634:
-					position, tokenIndex = position630, tokenIndex630
+					goto l632
+				l636:
+					position, tokenIndex = position632, tokenIndex632
 					if !_rules[ruleExpr]() {
-						goto l628
+						goto l630
 					}
 				}
-			l630:
+			l632:
 				if !_rules[rule_]() {
-					goto l628
+					goto l630
 				}
 				if buffer[position] != rune('=') {
-					goto l628
+					goto l630
 				}
 				position++
 				if buffer[position] != rune('>') {
-					goto l628
+					goto l630
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l628
+					goto l630
 				}
 				{
-					position635, tokenIndex635 := position, tokenIndex
+					position637, tokenIndex637 := position, tokenIndex
 					if !_rules[ruleBlock]() {
-						goto l636
+						goto l638
 					}
-					goto l635
-				l636:
-					position, tokenIndex = position635, tokenIndex635
+					goto l637
+				l638:
+					position, tokenIndex = position637, tokenIndex637
 					if !_rules[ruleBlockWithoutPar]() {
-						goto l628
+						goto l630
 					}
 				}
-			l635:
-				add(ruleCase, position629)
+			l637:
+				add(ruleCase, position631)
 			}
 			return true
-		l628:
-			position, tokenIndex = position628, tokenIndex628
+		l630:
+			position, tokenIndex = position630, tokenIndex630
 			return false
 		},
 		/* 94 Placeholder <- <'_'> */
 		func() bool {
-			position637, tokenIndex637 := position, tokenIndex
+			position639, tokenIndex639 := position, tokenIndex
 			{
-				position638 := position
+				position640 := position
 				if buffer[position] != rune('_') {
-					goto l637
+					goto l639
 				}
 				position++
-				add(rulePlaceholder, position638)
+				add(rulePlaceholder, position640)
 			}
 			return true
-		l637:
-			position, tokenIndex = position637, tokenIndex637
+		l639:
+			position, tokenIndex = position639, tokenIndex639
 			return false
 		},
 		/* 95 ValuePattern <- <((Placeholder / Identifier) _ ':' _ Types)> */
 		func() bool {
-			position639, tokenIndex639 := position, tokenIndex
+			position641, tokenIndex641 := position, tokenIndex
 			{
-				position640 := position
+				position642 := position
 				{
-					position641, tokenIndex641 := position, tokenIndex
+					position643, tokenIndex643 := position, tokenIndex
 					if !_rules[rulePlaceholder]() {
-						goto l642
+						goto l644
 					}
-					goto l641
-				l642:
-					position, tokenIndex = position641, tokenIndex641
+					goto l643
+				l644:
+					position, tokenIndex = position643, tokenIndex643
 					if !_rules[ruleIdentifier]() {
-						goto l639
+						goto l641
 					}
 				}
-			l641:
+			l643:
 				if !_rules[rule_]() {
-					goto l639
+					goto l641
 				}
 				if buffer[position] != rune(':') {
-					goto l639
+					goto l641
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l639
+					goto l641
 				}
 				if !_rules[ruleTypes]() {
-					goto l639
+					goto l641
 				}
-				add(ruleValuePattern, position640)
+				add(ruleValuePattern, position642)
 			}
 			return true
-		l639:
-			position, tokenIndex = position639, tokenIndex639
+		l641:
+			position, tokenIndex = position641, tokenIndex641
 			return false
 		},
 		/* 96 TupleValuesPattern <- <((ValuePattern / Placeholder / Identifier / Expr / GettableExpr) (_ ',' _ TupleValuesPattern)?)> */
 		func() bool {
-			position643, tokenIndex643 := position, tokenIndex
+			position645, tokenIndex645 := position, tokenIndex
 			{
-				position644 := position
+				position646 := position
 				{
-					position645, tokenIndex645 := position, tokenIndex
+					position647, tokenIndex647 := position, tokenIndex
 					if !_rules[ruleValuePattern]() {
-						goto l646
+						goto l648
 					}
-					goto l645
-				l646:
-					position, tokenIndex = position645, tokenIndex645
+					goto l647
+				l648:
+					position, tokenIndex = position647, tokenIndex647
 					if !_rules[rulePlaceholder]() {
-						goto l647
+						goto l649
 					}
-					goto l645
-				l647:
-					position, tokenIndex = position645, tokenIndex645
+					goto l647
+				l649:
+					position, tokenIndex = position647, tokenIndex647
 					if !_rules[ruleIdentifier]() {
-						goto l648
+						goto l650
 					}
-					goto l645
-				l648:
-					position, tokenIndex = position645, tokenIndex645
+					goto l647
+				l650:
+					position, tokenIndex = position647, tokenIndex647
 					if !_rules[ruleExpr]() {
-						goto l649
+						goto l651
 					}
-					goto l645
-				l649:
-					position, tokenIndex = position645, tokenIndex645
+					goto l647
+				l651:
+					position, tokenIndex = position647, tokenIndex647
 					if !_rules[ruleGettableExpr]() {
-						goto l643
+						goto l645
 					}
 				}
-			l645:
+			l647:
 				{
-					position650, tokenIndex650 := position, tokenIndex
+					position652, tokenIndex652 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l650
+						goto l652
 					}
 					if buffer[position] != rune(',') {
-						goto l650
+						goto l652
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l650
+						goto l652
 					}
 					if !_rules[ruleTupleValuesPattern]() {
-						goto l650
+						goto l652
 					}
-					goto l651
-				l650:
-					position, tokenIndex = position650, tokenIndex650
+					goto l653
+				l652:
+					position, tokenIndex = position652, tokenIndex652
 				}
-			l651:
-				add(ruleTupleValuesPattern, position644)
+			l653:
+				add(ruleTupleValuesPattern, position646)
 			}
 			return true
-		l643:
-			position, tokenIndex = position643, tokenIndex643
+		l645:
+			position, tokenIndex = position645, tokenIndex645
 			return false
 		},
 		/* 97 TuplePattern <- <('(' _ TupleValuesPattern _ ')')> */
 		func() bool {
-			position652, tokenIndex652 := position, tokenIndex
+			position654, tokenIndex654 := position, tokenIndex
 			{
-				position653 := position
+				position655 := position
 				if buffer[position] != rune('(') {
-					goto l652
+					goto l654
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l652
+					goto l654
 				}
 				if !_rules[ruleTupleValuesPattern]() {
-					goto l652
+					goto l654
 				}
 				if !_rules[rule_]() {
-					goto l652
+					goto l654
 				}
 				if buffer[position] != rune(')') {
-					goto l652
+					goto l654
 				}
 				position++
-				add(ruleTuplePattern, position653)
+				add(ruleTuplePattern, position655)
 			}
 			return true
-		l652:
-			position, tokenIndex = position652, tokenIndex652
+		l654:
+			position, tokenIndex = position654, tokenIndex654
 			return false
 		},
 		/* 98 ObjectFieldsPattern <- <(Identifier _ '=' _ (Identifier / Expr) (_ ',' _ ObjectFieldsPattern)?)> */
 		func() bool {
-			position654, tokenIndex654 := position, tokenIndex
+			position656, tokenIndex656 := position, tokenIndex
 			{
-				position655 := position
+				position657 := position
 				if !_rules[ruleIdentifier]() {
-					goto l654
+					goto l656
 				}
 				if !_rules[rule_]() {
-					goto l654
+					goto l656
 				}
 				if buffer[position] != rune('=') {
-					goto l654
+					goto l656
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l654
+					goto l656
 				}
 				{
-					position656, tokenIndex656 := position, tokenIndex
+					position658, tokenIndex658 := position, tokenIndex
 					if !_rules[ruleIdentifier]() {
-						goto l657
+						goto l659
 					}
-					goto l656
-				l657:
-					position, tokenIndex = position656, tokenIndex656
+					goto l658
+				l659:
+					position, tokenIndex = position658, tokenIndex658
 					if !_rules[ruleExpr]() {
-						goto l654
+						goto l656
 					}
 				}
-			l656:
+			l658:
 				{
-					position658, tokenIndex658 := position, tokenIndex
+					position660, tokenIndex660 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l658
+						goto l660
 					}
 					if buffer[position] != rune(',') {
-						goto l658
+						goto l660
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l658
+						goto l660
 					}
 					if !_rules[ruleObjectFieldsPattern]() {
-						goto l658
+						goto l660
 					}
-					goto l659
-				l658:
-					position, tokenIndex = position658, tokenIndex658
+					goto l661
+				l660:
+					position, tokenIndex = position660, tokenIndex660
 				}
-			l659:
-				add(ruleObjectFieldsPattern, position655)
+			l661:
+				add(ruleObjectFieldsPattern, position657)
 			}
 			return true
-		l654:
-			position, tokenIndex = position654, tokenIndex654
+		l656:
+			position, tokenIndex = position656, tokenIndex656
 			return false
 		},
 		/* 99 ObjectPattern <- <(Identifier _ '(' _ ObjectFieldsPattern? _ ')')> */
 		func() bool {
-			position660, tokenIndex660 := position, tokenIndex
+			position662, tokenIndex662 := position, tokenIndex
 			{
-				position661 := position
+				position663 := position
 				if !_rules[ruleIdentifier]() {
-					goto l660
+					goto l662
 				}
 				if !_rules[rule_]() {
-					goto l660
+					goto l662
 				}
 				if buffer[position] != rune('(') {
-					goto l660
+					goto l662
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l660
+					goto l662
 				}
 				{
-					position662, tokenIndex662 := position, tokenIndex
+					position664, tokenIndex664 := position, tokenIndex
 					if !_rules[ruleObjectFieldsPattern]() {
-						goto l662
+						goto l664
 					}
-					goto l663
-				l662:
-					position, tokenIndex = position662, tokenIndex662
+					goto l665
+				l664:
+					position, tokenIndex = position664, tokenIndex664
 				}
-			l663:
+			l665:
 				if !_rules[rule_]() {
-					goto l660
+					goto l662
 				}
 				if buffer[position] != rune(')') {
-					goto l660
+					goto l662
 				}
 				position++
-				add(ruleObjectPattern, position661)
+				add(ruleObjectPattern, position663)
 			}
 			return true
-		l660:
-			position, tokenIndex = position660, tokenIndex660
+		l662:
+			position, tokenIndex = position662, tokenIndex662
 			return false
 		},
 		/* 100 Tuple <- <('(' _ Expr (_ ',' _ Expr)+ _ ')')> */
 		func() bool {
-			position664, tokenIndex664 := position, tokenIndex
+			position666, tokenIndex666 := position, tokenIndex
 			{
-				position665 := position
+				position667 := position
 				if buffer[position] != rune('(') {
-					goto l664
+					goto l666
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l664
+					goto l666
 				}
 				if !_rules[ruleExpr]() {
-					goto l664
+					goto l666
 				}
 				if !_rules[rule_]() {
-					goto l664
+					goto l666
 				}
 				if buffer[position] != rune(',') {
-					goto l664
+					goto l666
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l664
+					goto l666
 				}
 				if !_rules[ruleExpr]() {
-					goto l664
+					goto l666
 				}
-			l666:
+			l668:
 				{
-					position667, tokenIndex667 := position, tokenIndex
+					position669, tokenIndex669 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l667
+						goto l669
 					}
 					if buffer[position] != rune(',') {
-						goto l667
+						goto l669
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l667
+						goto l669
 					}
 					if !_rules[ruleExpr]() {
-						goto l667
+						goto l669
 					}
-					goto l666
-				l667:
-					position, tokenIndex = position667, tokenIndex667
+					goto l668
+				l669:
+					position, tokenIndex = position669, tokenIndex669
 				}
 				if !_rules[rule_]() {
-					goto l664
+					goto l666
 				}
 				if buffer[position] != rune(')') {
-					goto l664
+					goto l666
 				}
 				position++
-				add(ruleTuple, position665)
+				add(ruleTuple, position667)
 			}
 			return true
-		l664:
-			position, tokenIndex = position664, tokenIndex664
+		l666:
+			position, tokenIndex = position666, tokenIndex666
 			return false
 		},
 		/* 101 TupleRef <- <('(' _ Identifier (_ ',' _ Identifier)+ _ ')')> */
 		func() bool {
-			position668, tokenIndex668 := position, tokenIndex
+			position670, tokenIndex670 := position, tokenIndex
 			{
-				position669 := position
+				position671 := position
 				if buffer[position] != rune('(') {
-					goto l668
+					goto l670
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l668
+					goto l670
 				}
 				if !_rules[ruleIdentifier]() {
-					goto l668
+					goto l670
 				}
 				if !_rules[rule_]() {
-					goto l668
+					goto l670
 				}
 				if buffer[position] != rune(',') {
-					goto l668
+					goto l670
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l668
+					goto l670
 				}
 				if !_rules[ruleIdentifier]() {
-					goto l668
+					goto l670
 				}
-			l670:
+			l672:
 				{
-					position671, tokenIndex671 := position, tokenIndex
+					position673, tokenIndex673 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l671
+						goto l673
 					}
 					if buffer[position] != rune(',') {
-						goto l671
+						goto l673
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l671
+						goto l673
 					}
 					if !_rules[ruleIdentifier]() {
-						goto l671
+						goto l673
 					}
-					goto l670
-				l671:
-					position, tokenIndex = position671, tokenIndex671
+					goto l672
+				l673:
+					position, tokenIndex = position673, tokenIndex673
 				}
 				if !_rules[rule_]() {
-					goto l668
+					goto l670
 				}
 				if buffer[position] != rune(')') {
-					goto l668
+					goto l670
 				}
 				position++
-				add(ruleTupleRef, position669)
+				add(ruleTupleRef, position671)
 			}
 			return true
-		l668:
-			position, tokenIndex = position668, tokenIndex668
+		l670:
+			position, tokenIndex = position670, tokenIndex670
 			return false
 		},
 		/* 102 TupleAccess <- <('_' [0-9]+)> */
 		func() bool {
-			position672, tokenIndex672 := position, tokenIndex
+			position674, tokenIndex674 := position, tokenIndex
 			{
-				position673 := position
+				position675 := position
 				if buffer[position] != rune('_') {
-					goto l672
+					goto l674
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l672
+					goto l674
 				}
 				position++
-			l674:
+			l676:
 				{
-					position675, tokenIndex675 := position, tokenIndex
+					position677, tokenIndex677 := position, tokenIndex
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l675
+						goto l677
 					}
 					position++
-					goto l674
-				l675:
-					position, tokenIndex = position675, tokenIndex675
+					goto l676
+				l677:
+					position, tokenIndex = position677, tokenIndex677
 				}
-				add(ruleTupleAccess, position673)
+				add(ruleTupleAccess, position675)
 			}
 			return true
-		l672:
-			position, tokenIndex = position672, tokenIndex672
+		l674:
+			position, tokenIndex = position674, tokenIndex674
 			return false
 		},
 	}
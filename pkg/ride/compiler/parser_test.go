@@ -97,6 +97,7 @@ func TestDirectives(t *testing.T) {
 		{`{-# IMPORT lib1 #-}`, false, "Directive<.>;DirectiveName<IMPORT>;PathString<lib1>"},
 		{`{-# IMPORT lib1,my_lib2 #-}`, false, "Directive<.>;DirectiveName<IMPORT>;PathString<lib1>;PathString<my_lib2>"},
 		{`{-# IMPORT lib3.ride,dir/lib4.ride #-}`, false, "Directive<.>;DirectiveName<IMPORT>;PathString<lib3.ride>;PathString<dir/lib4.ride>"},
+		{`{-# IMPORT https://example.com/lib.ride #-}`, false, "Directive<.>;DirectiveName<IMPORT>;PathString<https://example.com/lib.ride>"},
 		{`{-# STDLIB_version 123 #-}`, true, "\nparse error near DirectiveName (line 1 symbol 5 - line 1 symbol 12):\n\"STDLIB_\"\n"},
 		{`{-# NAME #-}`, true, "\nparse error near WS (line 1 symbol 9 - line 1 symbol 10):\n\" \"\n"},
 		{`{-# 123 #-}`, true, "\nparse error near WS (line 1 symbol 4 - line 1 symbol 5):\n\" \"\n"},
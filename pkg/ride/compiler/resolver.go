@@ -0,0 +1,172 @@
+package compiler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// ErrLibraryNotFound is returned by ImportResolver if it doesn't know where to find the library.
+var ErrLibraryNotFound = errors.New("library not found")
+
+// Library is the source code of the imported library.
+type Library struct {
+	// Path is the location of the library. It identifies the library and is used to resolve its own imports.
+	Path string
+	Code []byte
+}
+
+// ImportResolver resolves the paths of IMPORT directives to the libraries.
+// The importer is the location of the script or library that contains the directive, it may be empty.
+type ImportResolver interface {
+	Resolve(path, importer string) (Library, error)
+}
+
+func notFound(path string) error {
+	return errors.Wrapf(ErrLibraryNotFound, "failed to resolve '%s'", path)
+}
+
+// FileResolver resolves the imports to local files. The relative paths are looked up in the directory
+// of the importer and then in the search directories in the given order, so the vendored libraries directory
+// is usually the last one. The working directory is searched last if no search directories are set.
+type FileResolver struct {
+	dirs []string
+}
+
+func NewFileResolver(dirs ...string) *FileResolver {
+	return &FileResolver{dirs: dirs}
+}
+
+func (r *FileResolver) Resolve(path, importer string) (Library, error) {
+	if isRemote(path) || (isRemote(importer) && !filepath.IsAbs(path)) {
+		return Library{}, notFound(path)
+	}
+	var candidates []string
+	switch {
+	case filepath.IsAbs(path):
+		candidates = []string{path}
+	default:
+		if importer != "" {
+			candidates = append(candidates, filepath.Join(filepath.Dir(importer), path))
+		}
+		for _, dir := range r.dirs {
+			candidates = append(candidates, filepath.Join(dir, path))
+		}
+		if len(r.dirs) == 0 {
+			candidates = append(candidates, filepath.Clean(path))
+		}
+	}
+	for _, c := range candidates {
+		code, err := os.ReadFile(c)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return Library{}, errors.Wrapf(err, "failed to read library '%s'", path)
+		}
+		return Library{Path: c, Code: code}, nil
+	}
+	return Library{}, notFound(path)
+}
+
+// Manifest pins the remote libraries to the hex encoded SHA-256 checksums of their code.
+type Manifest map[string]string
+
+// LoadManifest reads the manifest in JSON format, an object with the URLs of libraries as keys and
+// their checksums as values.
+func LoadManifest(r io.Reader) (Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, errors.Wrap(err, "failed to decode manifest")
+	}
+	for u, sum := range m {
+		if !isRemote(u) {
+			return nil, errors.Errorf("invalid manifest: '%s' is not a remote library URL", u)
+		}
+		if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+			return nil, errors.Errorf("invalid manifest: invalid checksum '%s' of library '%s'", sum, u)
+		}
+	}
+	return m, nil
+}
+
+// CacheResolver resolves the remote libraries pinned in the manifest from the local cache directory.
+// The library is stored in the cache in the file named by its checksum, the code of the library is
+// verified against the checksum from the manifest. The relative imports of remote libraries are resolved
+// against their URLs.
+type CacheResolver struct {
+	manifest Manifest
+	dir      string
+}
+
+func NewCacheResolver(manifest Manifest, dir string) *CacheResolver {
+	return &CacheResolver{manifest: manifest, dir: dir}
+}
+
+func (r *CacheResolver) Resolve(path, importer string) (Library, error) {
+	u, ok := remoteURL(path, importer)
+	if !ok {
+		return Library{}, notFound(path)
+	}
+	sum, ok := r.manifest[u]
+	if !ok {
+		return Library{}, errors.Errorf("remote library '%s' is not pinned in the manifest", u)
+	}
+	code, err := os.ReadFile(filepath.Join(r.dir, sum))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Library{}, errors.Errorf("remote library '%s' is not found in the cache", u)
+		}
+		return Library{}, errors.Wrapf(err, "failed to read remote library '%s' from the cache", u)
+	}
+	if actual := sha256.Sum256(code); hex.EncodeToString(actual[:]) != sum {
+		return Library{}, errors.Errorf("checksum mismatch of remote library '%s': expected %s, actual %x",
+			u, sum, actual)
+	}
+	return Library{Path: u, Code: code}, nil
+}
+
+// ChainResolver tries the resolvers in order until one of them finds the library.
+type ChainResolver []ImportResolver
+
+func (r ChainResolver) Resolve(path, importer string) (Library, error) {
+	for _, resolver := range r {
+		lib, err := resolver.Resolve(path, importer)
+		if errors.Is(err, ErrLibraryNotFound) {
+			continue
+		}
+		return lib, err
+	}
+	return Library{}, notFound(path)
+}
+
+func isRemote(path string) bool {
+	u, err := url.Parse(path)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
+
+// remoteURL returns the URL of the remote library, the relative path is resolved against the remote importer.
+func remoteURL(path, importer string) (string, bool) {
+	if isRemote(path) {
+		return path, true
+	}
+	if !isRemote(importer) || filepath.IsAbs(path) {
+		return "", false
+	}
+	base, err := url.Parse(importer)
+	if err != nil {
+		return "", false
+	}
+	ref, err := url.Parse(path)
+	if err != nil {
+		return "", false
+	}
+	return base.ResolveReference(ref).String(), true
+}
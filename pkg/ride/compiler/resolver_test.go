@@ -0,0 +1,165 @@
+package compiler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, code := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(code), 0600))
+	}
+}
+
+func library(decl string, imports ...string) string {
+	code := "{-# STDLIB_VERSION 6 #-}\n{-# CONTENT_TYPE LIBRARY #-}\n"
+	if len(imports) > 0 {
+		code += "{-# IMPORT " + strings.Join(imports, ",") + " #-}\n"
+	}
+	return code + decl + "\n"
+}
+
+func TestFileResolver(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main/lib.ride":     "main",
+		"main/sub/lib.ride": "sub",
+		"search/lib.ride":   "search",
+		"search/only.ride":  "search only",
+		"vendor/only.ride":  "vendor only",
+		"vendor/ven.ride":   "vendor",
+	})
+	r := NewFileResolver(filepath.Join(dir, "search"), filepath.Join(dir, "vendor"))
+	for _, test := range []struct {
+		path     string
+		importer string
+		code     string
+		libPath  string
+	}{
+		{"lib.ride", filepath.Join(dir, "main", "main.ride"), "main", filepath.Join(dir, "main", "lib.ride")},
+		{"lib.ride", filepath.Join(dir, "main", "sub", "x.ride"), "sub", filepath.Join(dir, "main", "sub", "lib.ride")},
+		{"lib.ride", "", "search", filepath.Join(dir, "search", "lib.ride")},
+		{"only.ride", filepath.Join(dir, "main", "main.ride"), "search only", filepath.Join(dir, "search", "only.ride")},
+		{"ven.ride", filepath.Join(dir, "main", "main.ride"), "vendor", filepath.Join(dir, "vendor", "ven.ride")},
+		{filepath.Join(dir, "main", "lib.ride"), "", "main", filepath.Join(dir, "main", "lib.ride")},
+	} {
+		lib, err := r.Resolve(test.path, test.importer)
+		require.NoError(t, err, test.path)
+		assert.Equal(t, test.code, string(lib.Code), test.path)
+		assert.Equal(t, test.libPath, lib.Path, test.path)
+	}
+	for _, test := range []struct {
+		path     string
+		importer string
+	}{
+		{"missing.ride", filepath.Join(dir, "main", "main.ride")},
+		{"https://example.com/lib.ride", ""},
+		{"lib.ride", "https://example.com/main.ride"},
+	} {
+		_, err := r.Resolve(test.path, test.importer)
+		assert.ErrorIs(t, err, ErrLibraryNotFound, test.path)
+	}
+}
+
+func TestCacheResolver(t *testing.T) {
+	const (
+		mainURL = "https://example.com/libs/main.ride"
+		utilURL = "https://example.com/libs/util.ride"
+		badURL  = "https://example.com/libs/bad.ride"
+	)
+	checksum := func(code string) string {
+		sum := sha256.Sum256([]byte(code))
+		return hex.EncodeToString(sum[:])
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		checksum("main"):     "main",
+		checksum("util"):     "util",
+		checksum("original"): "tampered",
+	})
+	m, err := LoadManifest(strings.NewReader(`{
+		"` + mainURL + `": "` + checksum("main") + `",
+		"` + utilURL + `": "` + checksum("util") + `",
+		"` + badURL + `": "` + checksum("original") + `",
+		"https://example.com/libs/missing.ride": "` + checksum("missing") + `"
+	}`))
+	require.NoError(t, err)
+	r := NewCacheResolver(m, dir)
+
+	lib, err := r.Resolve(mainURL, "")
+	require.NoError(t, err)
+	assert.Equal(t, Library{Path: mainURL, Code: []byte("main")}, lib)
+	lib, err = r.Resolve("util.ride", mainURL)
+	require.NoError(t, err)
+	assert.Equal(t, Library{Path: utilURL, Code: []byte("util")}, lib)
+
+	_, err = r.Resolve("lib.ride", "")
+	assert.ErrorIs(t, err, ErrLibraryNotFound)
+	_, err = r.Resolve("https://example.com/other.ride", "")
+	assert.EqualError(t, err, "remote library 'https://example.com/other.ride' is not pinned in the manifest")
+	_, err = r.Resolve("missing.ride", mainURL)
+	assert.EqualError(t, err, "remote library 'https://example.com/libs/missing.ride' is not found in the cache")
+	_, err = r.Resolve(badURL, "")
+	assert.ErrorContains(t, err, "checksum mismatch of remote library '"+badURL+"'")
+
+	for _, manifest := range []string{
+		`{"lib.ride": "` + checksum("lib") + `"}`,
+		`{"https://example.com/lib.ride": "abc"}`,
+		`[]`,
+	} {
+		_, err = LoadManifest(strings.NewReader(manifest))
+		assert.Error(t, err, manifest)
+	}
+}
+
+func TestCompileWithImports(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"libs/a.ride":      library("func a() = b() + c()", "b.ride", "c.ride"),
+		"libs/b.ride":      library("func b() = d()", "d.ride"),
+		"libs/c.ride":      library("func c() = d()", "d.ride"),
+		"libs/d.ride":      library("func d() = 1"),
+		"libs/cycle1.ride": library("func c1() = 1", "cycle2.ride"),
+		"libs/cycle2.ride": library("func c2() = 2", "cycle1.ride"),
+		"vendor/v.ride":    library("func v() = 2"),
+	})
+	remote := library("func r() = 3")
+	sum := sha256.Sum256([]byte(remote))
+	writeFiles(t, dir, map[string]string{filepath.Join("cache", hex.EncodeToString(sum[:])): remote})
+	manifest := Manifest{"https://example.com/r.ride": hex.EncodeToString(sum[:])}
+	main := filepath.Join(dir, "main.ride")
+	resolver := ChainResolver{
+		NewFileResolver(filepath.Join(dir, "vendor")),
+		NewCacheResolver(manifest, filepath.Join(dir, "cache")),
+	}
+	const header = "{-# STDLIB_VERSION 6 #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n{-# SCRIPT_TYPE ACCOUNT #-}\n"
+
+	// The library "d.ride" is imported by two libraries, but it's loaded only once.
+	_, errs := CompileToTreeWithImports(header+"{-# IMPORT libs/a.ride, v.ride #-}\na() + v() == 4", main, resolver)
+	assert.Empty(t, errs)
+	_, errs = CompileToTreeWithImports(header+"{-# IMPORT https://example.com/r.ride #-}\nr() == 3", main, resolver)
+	assert.Empty(t, errs)
+
+	for _, test := range []struct {
+		imports string
+		err     string
+	}{
+		{"libs/d.ride, libs/../libs/d.ride", "Library 'libs/../libs/d.ride' is imported more than once"},
+		{"libs/cycle1.ride", "Cyclic import of library 'cycle1.ride': " + filepath.Join(dir, "libs", "cycle1.ride") +
+			" -> " + filepath.Join(dir, "libs", "cycle2.ride") + " -> " + filepath.Join(dir, "libs", "cycle1.ride")},
+		{"libs/missing.ride", "File 'libs/missing.ride' doesn't exist"},
+	} {
+		_, errs = CompileToTreeWithImports(header+"{-# IMPORT "+test.imports+" #-}\ntrue", main, resolver)
+		require.Len(t, errs, 1, test.imports)
+		assert.Contains(t, errs[0].Error(), test.err)
+	}
+}
@@ -0,0 +1,163 @@
+package compiler
+
+import (
+	"strings"
+)
+
+// SymbolKind is the kind of the declaration.
+type SymbolKind byte
+
+const (
+	VariableSymbol SymbolKind = iota + 1
+	FunctionSymbol
+	ArgumentSymbol
+)
+
+// Symbol is the declaration of a variable, function or function argument in the source code.
+type Symbol struct {
+	Name      string
+	Kind      SymbolKind
+	Begin     Position // the position of the first character of the name
+	End       Position // the position right after the name
+	Signature string   // the header of the function, e.g. "func f(a: Int, b: String)", or the argument with its type
+}
+
+// Symbols parses the code and returns the declarations in the order of their appearance in the code.
+// The code is only parsed, the declarations of the code that fails the type checks are returned too.
+func Symbols(code string) ([]Symbol, error) {
+	pp := Parser{Buffer: code}
+	if err := pp.Init(); err != nil {
+		return nil, err
+	}
+	if err := pp.Parse(); err != nil {
+		return nil, err
+	}
+	c := symbolsCollector{buffer: pp.buffer}
+	c.walk(pp.AST())
+	positions := make([]int, 0, 2*len(c.nodes))
+	for _, n := range c.nodes {
+		positions = append(positions, int(n.begin), int(n.end))
+	}
+	translations := translatePositions(pp.buffer, positions)
+	for i, n := range c.nodes {
+		begin, end := translations[int(n.begin)], translations[int(n.end)]
+		c.symbols[i].Begin = Position{Line: begin.line, Column: begin.symbol}
+		c.symbols[i].End = Position{Line: end.line, Column: end.symbol}
+	}
+	return c.symbols, nil
+}
+
+// Imports returns the paths of libraries listed in the IMPORT directive of the code.
+func Imports(code string) ([]string, error) {
+	pp := Parser{Buffer: code}
+	if err := pp.Init(); err != nil {
+		return nil, err
+	}
+	if err := pp.Parse(); err != nil {
+		return nil, err
+	}
+	var r []string
+	var walk func(n *node32)
+	walk = func(n *node32) {
+		for ; n != nil; n = n.next {
+			switch n.pegRule {
+			case rulePathString:
+				r = append(r, string(pp.buffer[n.begin:n.end]))
+			case ruleDirective, ruleCode, ruleDAppRoot, ruleScriptRoot, rulePaths:
+				walk(n.up)
+			}
+		}
+	}
+	walk(pp.AST())
+	return r, nil
+}
+
+type symbolsCollector struct {
+	buffer  []rune
+	symbols []Symbol
+	nodes   []*node32
+}
+
+func (c *symbolsCollector) text(n *node32) string {
+	return string(c.buffer[n.begin:n.end])
+}
+
+func (c *symbolsCollector) add(n *node32, kind SymbolKind, signature string) {
+	c.symbols = append(c.symbols, Symbol{Name: c.text(n), Kind: kind, Signature: signature})
+	c.nodes = append(c.nodes, n)
+}
+
+func (c *symbolsCollector) walk(n *node32) {
+	for ; n != nil; n = n.next {
+		switch n.pegRule {
+		case ruleVariable, ruleStrictVariable:
+			c.variable(n)
+		case ruleFunc:
+			c.function(n)
+		case ruleValuePattern:
+			if id := child(n, ruleIdentifier); id != nil {
+				c.add(id, VariableSymbol, "")
+			}
+		case ruleAnnotation:
+			if seq := child(n, ruleIdentifierSeq); seq != nil {
+				c.identifiers(seq, ArgumentSymbol)
+			}
+			continue
+		}
+		c.walk(n.up)
+	}
+}
+
+func (c *symbolsCollector) variable(n *node32) {
+	if id := child(n, ruleIdentifier); id != nil {
+		c.add(id, VariableSymbol, "")
+		return
+	}
+	if ref := child(n, ruleTupleRef); ref != nil {
+		c.identifiers(ref, VariableSymbol)
+	}
+}
+
+func (c *symbolsCollector) identifiers(n *node32, kind SymbolKind) {
+	for id := n.up; id != nil; id = id.next {
+		switch id.pegRule {
+		case ruleIdentifier:
+			c.add(id, kind, "")
+		case ruleIdentifierSeq:
+			c.identifiers(id, kind)
+		}
+	}
+}
+
+func (c *symbolsCollector) function(n *node32) {
+	id := child(n, ruleIdentifier)
+	if id == nil {
+		return
+	}
+	var args []*node32
+	for seq := child(n, ruleFuncArgSeq); seq != nil; seq = child(seq, ruleFuncArgSeq) {
+		if arg := child(seq, ruleFuncArg); arg != nil {
+			args = append(args, arg)
+		}
+	}
+	signatures := make([]string, len(args))
+	for i, arg := range args {
+		signatures[i] = strings.Join(strings.Fields(c.text(arg)), " ")
+	}
+	c.add(id, FunctionSymbol, "func "+c.text(id)+"("+strings.Join(signatures, ", ")+")")
+	for i, arg := range args {
+		if aid := child(arg, ruleIdentifier); aid != nil {
+			c.add(aid, ArgumentSymbol, signatures[i])
+		}
+	}
+}
+
+// child returns the first direct child of the node with the given rule.
+func child(n *node32, rule pegRule) *node32 {
+	for c := n.up; c != nil; c = c.next {
+		if c.pegRule == rule {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package compiler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnose(t *testing.T) {
+	dir := t.TempDir()
+	lib := "{-# SCRIPT_TYPE ACCOUNT #-}\n{-# CONTENT_TYPE LIBRARY #-}\nfunc inc(a: Int) = a + 1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib.ride"), []byte(lib), 0600))
+
+	for _, test := range []struct {
+		code string
+		diag []Diagnostic
+	}{
+		{"{-# STDLIB_VERSION 6 #-}\n{-# IMPORT lib.ride #-}\ninc(1) == 2", nil},
+		{
+			"{-# STDLIB_VERSION 6 #-}\nlet x = 1\nx == \"a\"",
+			[]Diagnostic{{
+				Begin:   Position{Line: 3, Column: 6},
+				End:     Position{Line: 3, Column: 9},
+				Message: "Unexpected type, required 'Int', but 'String' found",
+			}},
+		},
+		{
+			"{-# STDLIB_VERSION 6 #-}\nlet x = \n",
+			[]Diagnostic{{
+				Begin:   Position{Line: 3, Column: 1},
+				End:     Position{Line: 3, Column: 1},
+				Message: "Parse error near EOL",
+			}},
+		},
+	} {
+		diag := Diagnose(test.code, filepath.Join(dir, "main.ride"), NewFileResolver())
+		assert.Equal(t, test.diag, nilIfEmpty(diag), test.code)
+	}
+}
+
+func nilIfEmpty(d []Diagnostic) []Diagnostic {
+	if len(d) == 0 {
+		return nil
+	}
+	return d
+}
+
+func TestSymbols(t *testing.T) {
+	const code = `{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# IMPORT lib1.ride, dir/lib2.ride #-}
+let (a, b) = (1, "x")
+func f(x: Int, y: (Int, String)) = {
+  strict z = x
+  z
+}
+@Callable(i)
+func call() = []
+`
+	ss, err := Symbols(code)
+	require.NoError(t, err)
+	expected := []Symbol{
+		{Name: "a", Kind: VariableSymbol, Begin: Position{4, 6}, End: Position{4, 7}},
+		{Name: "b", Kind: VariableSymbol, Begin: Position{4, 9}, End: Position{4, 10}},
+		{Name: "f", Kind: FunctionSymbol, Begin: Position{5, 6}, End: Position{5, 7},
+			Signature: "func f(x: Int, y: (Int, String))"},
+		{Name: "x", Kind: ArgumentSymbol, Begin: Position{5, 8}, End: Position{5, 9}, Signature: "x: Int"},
+		{Name: "y", Kind: ArgumentSymbol, Begin: Position{5, 16}, End: Position{5, 17},
+			Signature: "y: (Int, String)"},
+		{Name: "z", Kind: VariableSymbol, Begin: Position{6, 10}, End: Position{6, 11}},
+		{Name: "i", Kind: ArgumentSymbol, Begin: Position{9, 11}, End: Position{9, 12}},
+		{Name: "call", Kind: FunctionSymbol, Begin: Position{10, 6}, End: Position{10, 10},
+			Signature: "func call()"},
+	}
+	assert.Equal(t, expected, ss)
+
+	imports, err := Imports(code)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lib1.ride", "dir/lib2.ride"}, imports)
+
+	_, err = Symbols("let x = ")
+	assert.Error(t, err)
+}
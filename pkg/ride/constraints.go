@@ -18,6 +18,15 @@ const (
 	unlimitedVerifierComplexity    = math.MaxInt16
 )
 
+const (
+	MaxVerifierScriptComplexityReduced = 2000
+	MaxVerifierScriptComplexity        = 4000
+	MaxCallableScriptComplexityV12     = 2000
+	MaxCallableScriptComplexityV34     = 4000
+	MaxCallableScriptComplexityV5      = 10000
+	MaxCallableScriptComplexityV6      = 52000
+)
+
 func MaxChainInvokeComplexityByVersion(version ast.LibraryVersion) (uint32, error) {
 	// libV1 and libV2 don't have callables
 	switch version {
@@ -45,3 +54,29 @@ func MaxVerifierComplexity(rideV5Activated bool) uint32 {
 	}
 	return unlimitedVerifierComplexity
 }
+
+// MaxScriptComplexity returns the maximum allowed complexities of callable functions and verifier of the script
+// of the given library version. The reduced verifier complexity is applied to account scripts after BlockV5.
+func MaxScriptComplexity(libVersion ast.LibraryVersion, reducedVerifierComplexity bool) (int, int, error) {
+	var maxCallableComplexity, maxVerifierComplexity int
+	switch version := libVersion; version {
+	case ast.LibV1, ast.LibV2:
+		maxCallableComplexity = MaxCallableScriptComplexityV12
+		maxVerifierComplexity = MaxVerifierScriptComplexityReduced
+	case ast.LibV3, ast.LibV4:
+		maxCallableComplexity = MaxCallableScriptComplexityV34
+		maxVerifierComplexity = MaxVerifierScriptComplexity
+	case ast.LibV5:
+		maxCallableComplexity = MaxCallableScriptComplexityV5
+		maxVerifierComplexity = MaxVerifierScriptComplexity
+	case ast.LibV6, ast.LibV7, ast.LibV8:
+		maxCallableComplexity = MaxCallableScriptComplexityV6
+		maxVerifierComplexity = MaxVerifierScriptComplexity
+	default:
+		return 0, 0, errors.Errorf("unknown script LibVersion=%d", version)
+	}
+	if reducedVerifierComplexity {
+		maxVerifierComplexity = MaxVerifierScriptComplexityReduced
+	}
+	return maxCallableComplexity, maxVerifierComplexity, nil
+}
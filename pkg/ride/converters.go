@@ -1245,6 +1245,27 @@ func invocationToObject(rideVersion ast.LibraryVersion, scheme byte, tx proto.Tr
 	}
 }
 
+// readOnlyInvocationToObject creates invocation object for read-only function calls without transaction.
+// The caller public key and transaction ID are empty, the fee is zero and there are no payments.
+func readOnlyInvocationToObject(rideVersion ast.LibraryVersion, caller proto.WavesAddress) rideType {
+	var (
+		callerPK = rideByteVector{}
+		id       = rideByteVector{}
+		feeAsset = optionalAsset(proto.NewOptionalAssetWaves())
+		fee      = rideInt(0)
+	)
+	switch rideVersion {
+	case ast.LibV1, ast.LibV2, ast.LibV3:
+		return newRideInvocationV3(rideUnit{}, callerPK, feeAsset, id, rideAddress(caller), fee)
+	case ast.LibV4:
+		return newRideInvocationV4(rideList{}, callerPK, feeAsset, id, rideAddress(caller), fee)
+	default:
+		return newRideInvocationV5(rideAddress(caller), rideList{}, callerPK, feeAsset, callerPK, id,
+			rideAddress(caller), fee,
+		)
+	}
+}
+
 func ethereumInvocationToObject(rideVersion ast.LibraryVersion, scheme proto.Scheme, tx *proto.EthereumTransaction, scriptPayments []proto.ScriptPayment) (rideType, error) {
 	sender, err := tx.WavesAddressFrom(scheme)
 	if err != nil {
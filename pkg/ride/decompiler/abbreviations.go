@@ -0,0 +1,202 @@
+package decompiler
+
+import (
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+)
+
+const compactNameChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// compactName returns the n-th name generated by the compaction of the script.
+func compactName(n int, suffix string) string {
+	l := len(compactNameChars)
+	if n < l {
+		return string(compactNameChars[n]) + suffix
+	}
+	return compactName(n/l-1, string(compactNameChars[n%l])+suffix)
+}
+
+// originalNames returns the original names of declarations of the compacted DApp by their compact names.
+// The compact names are generated in the order of the original names skipping the names of callable functions.
+// It returns nil if the script is not compacted or the original names are ambiguous.
+func originalNames(tree *ast.Tree) map[string]string {
+	if !tree.IsDApp() {
+		return nil
+	}
+	callables := make(map[string]struct{}, len(tree.Functions))
+	for _, n := range tree.Functions {
+		if f, ok := n.(*ast.FunctionDeclarationNode); ok {
+			callables[f.Name] = struct{}{}
+		}
+	}
+	names := tree.Meta.Abbreviations.OriginalNames()
+	r := make(map[string]string, len(names))
+	originals := make(map[string]struct{}, len(names))
+	counter := 0
+	for _, original := range names {
+		name := compactName(counter, "")
+		for _, ok := callables[name]; ok; _, ok = callables[name] {
+			counter++
+			name = compactName(counter, "")
+		}
+		counter++
+		if _, ok := originals[original]; ok {
+			return nil
+		}
+		originals[original] = struct{}{}
+		r[name] = original
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
+
+// restorer replaces the compact names of the declarations with the original names. Only the names declared
+// in the scope are replaced, so the references to the global variables and functions are left intact.
+type restorer struct {
+	names map[string]string
+	vars  map[string]int
+	funcs map[string]int
+}
+
+// restoreNames returns the copy of the tree with the original names of declarations of the compacted DApp.
+func restoreNames(tree *ast.Tree) *ast.Tree {
+	names := originalNames(tree)
+	if names == nil {
+		return tree
+	}
+	r := &restorer{names: names, vars: make(map[string]int), funcs: make(map[string]int)}
+	t := *tree
+	t.Declarations = make([]ast.Node, len(tree.Declarations))
+	for i, n := range tree.Declarations {
+		t.Declarations[i] = r.declare(n)
+	}
+	t.Functions = make([]ast.Node, len(tree.Functions))
+	for i, n := range tree.Functions {
+		t.Functions[i] = r.callable(n)
+	}
+	if tree.Verifier != nil {
+		t.Verifier = r.callable(tree.Verifier)
+	}
+	return &t
+}
+
+func (r *restorer) name(compact string) string {
+	if n, ok := r.names[compact]; ok {
+		return n
+	}
+	return compact
+}
+
+func (r *restorer) scoped(scope map[string]int, compact string) string {
+	if scope[compact] > 0 {
+		return r.name(compact)
+	}
+	return compact
+}
+
+// declare restores the names of the declaration and its expression, the declared name stays in scope.
+func (r *restorer) declare(n ast.Node) ast.Node {
+	switch nn := n.(type) {
+	case *ast.AssignmentNode:
+		a := *nn
+		a.Name = r.name(nn.Name)
+		a.Expression = r.restore(nn.Expression)
+		r.vars[nn.Name]++
+		a.Block = nil
+		return &a
+	case *ast.FunctionDeclarationNode:
+		f := *nn
+		f.Name = r.name(nn.Name)
+		f.Body = r.function(nn.Arguments, nn.Body)
+		f.Arguments = r.arguments(nn.Arguments)
+		r.funcs[nn.Name]++
+		f.Block = nil
+		return &f
+	default:
+		return n
+	}
+}
+
+func (r *restorer) undeclare(n ast.Node) {
+	switch nn := n.(type) {
+	case *ast.AssignmentNode:
+		r.vars[nn.Name]--
+	case *ast.FunctionDeclarationNode:
+		r.funcs[nn.Name]--
+	}
+}
+
+func (r *restorer) arguments(args []string) []string {
+	res := make([]string, len(args))
+	for i, a := range args {
+		res[i] = r.name(a)
+	}
+	return res
+}
+
+func (r *restorer) function(args []string, body ast.Node) ast.Node {
+	for _, a := range args {
+		r.vars[a]++
+	}
+	res := r.restore(body)
+	for _, a := range args {
+		r.vars[a]--
+	}
+	return res
+}
+
+func (r *restorer) callable(n ast.Node) ast.Node {
+	f, ok := n.(*ast.FunctionDeclarationNode)
+	if !ok {
+		return n
+	}
+	c := *f
+	c.InvocationParameter = r.name(f.InvocationParameter)
+	r.vars[f.InvocationParameter]++
+	c.Body = r.function(f.Arguments, f.Body)
+	r.vars[f.InvocationParameter]--
+	c.Arguments = r.arguments(f.Arguments)
+	return &c
+}
+
+func (r *restorer) restore(n ast.Node) ast.Node {
+	switch nn := n.(type) {
+	case *ast.AssignmentNode, *ast.FunctionDeclarationNode:
+		d := r.declare(n)
+		block := r.restore(blockOf(n))
+		r.undeclare(n)
+		d.SetBlock(block)
+		return d
+	case *ast.ConditionalNode:
+		return ast.NewConditionalNode(r.restore(nn.Condition), r.restore(nn.TrueExpression),
+			r.restore(nn.FalseExpression))
+	case *ast.ReferenceNode:
+		return ast.NewReferenceNode(r.scoped(r.vars, nn.Name))
+	case *ast.FunctionCallNode:
+		args := make([]ast.Node, len(nn.Arguments))
+		for i, a := range nn.Arguments {
+			args[i] = r.restore(a)
+		}
+		fn := nn.Function
+		if uf, ok := fn.(ast.UserFunction); ok {
+			fn = ast.UserFunction(r.scoped(r.funcs, string(uf)))
+		}
+		return ast.NewFunctionCallNode(fn, args)
+	case *ast.PropertyNode:
+		return ast.NewPropertyNode(nn.Name, r.restore(nn.Object))
+	default:
+		return n
+	}
+}
+
+func blockOf(n ast.Node) ast.Node {
+	switch nn := n.(type) {
+	case *ast.AssignmentNode:
+		return nn.Block
+	case *ast.FunctionDeclarationNode:
+		return nn.Block
+	default:
+		return nil
+	}
+}
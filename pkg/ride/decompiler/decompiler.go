@@ -0,0 +1,734 @@
+// Package decompiler renders the tree of Ride script back to the source code.
+package decompiler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler/stdlib"
+)
+
+const (
+	indentation = "    "
+	// maxLineLength is the length of the conditional expression that is split into several lines.
+	maxLineLength = 100
+	// maxBase58Length is the maximum length of the byte vector that is rendered in Base58.
+	maxBase58Length = 64
+)
+
+// ScriptType is the type of the expression script. It can't be derived from the tree and has to be given
+// to render the SCRIPT_TYPE directive.
+type ScriptType byte
+
+const (
+	AccountScript ScriptType = iota
+	AssetScript
+)
+
+func (t ScriptType) String() string {
+	switch t {
+	case AccountScript:
+		return "ACCOUNT"
+	case AssetScript:
+		return "ASSET"
+	default:
+		return fmt.Sprintf("ScriptType(%d)", byte(t))
+	}
+}
+
+// Directives of the decompiled script.
+type Directives struct {
+	LibVersion  ast.LibraryVersion
+	ContentType string
+	ScriptType  ScriptType
+}
+
+// Decompile renders the tree as the source code with directives. The compilation of the source code produces
+// the equivalent tree. Types of arguments of functions are not stored in the tree, for the callable functions
+// they are taken from the meta, for other functions they are inferred from the usage of arguments and fall back
+// to `Any`. The original names of declarations of the compacted DApp are restored from the meta.
+func Decompile(tree *ast.Tree, scriptType ScriptType) (string, error) {
+	_, src, err := DecompileWithDirectives(tree, scriptType)
+	return src, err
+}
+
+// DecompileWithDirectives is like Decompile but returns the directives of the script separately.
+func DecompileWithDirectives(tree *ast.Tree, scriptType ScriptType) (Directives, string, error) {
+	if tree == nil {
+		return Directives{}, "", errors.New("empty script tree")
+	}
+	if scriptType != AccountScript && scriptType != AssetScript {
+		return Directives{}, "", errors.Errorf("unsupported script type %d", byte(scriptType))
+	}
+	if _, err := ast.NewLibraryVersion(byte(tree.LibVersion)); err != nil {
+		return Directives{}, "", err
+	}
+	dir := Directives{LibVersion: tree.LibVersion, ScriptType: scriptType}
+	switch tree.ContentType {
+	case ast.ContentTypeExpression:
+		dir.ContentType = "EXPRESSION"
+	case ast.ContentTypeApplication:
+		if scriptType == AssetScript {
+			return Directives{}, "", errors.New("DApp can't be an asset script")
+		}
+		dir.ContentType = "DAPP"
+	default:
+		return Directives{}, "", errors.Errorf("unsupported content type %d", tree.ContentType)
+	}
+	if err := checkFunctionCalls(tree); err != nil {
+		return Directives{}, "", err
+	}
+	d := newDecompiler(restoreNames(tree))
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "{-# STDLIB_VERSION %d #-}\n", dir.LibVersion)
+	fmt.Fprintf(&sb, "{-# CONTENT_TYPE %s #-}\n", dir.ContentType)
+	fmt.Fprintf(&sb, "{-# SCRIPT_TYPE %s #-}\n", dir.ScriptType)
+	if tree.IsDApp() {
+		d.dApp(&sb)
+	} else {
+		d.expression(&sb)
+	}
+	if d.err != nil {
+		return Directives{}, "", d.err
+	}
+	return dir, sb.String(), nil
+}
+
+type kind byte
+
+const (
+	gettable kind = iota // can be followed by field access or list access
+	operand              // can be an operand of binary operator
+	compound             // has to be parenthesized to be an operand
+)
+
+type decompiler struct {
+	tree    *ast.Tree
+	lib     library
+	decls   ast.Node                                       // chained declarations of DApp
+	types   map[*ast.FunctionDeclarationNode][]string      // inferred argument types of functions
+	returns map[*ast.FunctionDeclarationNode]stdlib.Type   // result types of functions
+	calls   map[*ast.FunctionDeclarationNode][]stdlib.Type // types of values the functions are called with
+	hints   map[*ast.FunctionDeclarationNode][]string      // argument types from the previous analysis pass
+	scope   map[string]*ast.FunctionDeclarationNode        // functions declared in the scope during analysis
+	vars    map[string]stdlib.Type                         // types of variables by name
+	used    map[string]struct{}                            // all names of the tree
+	renamed map[string]string                              // replacements of names not allowed in the source code
+	err     error
+}
+
+func newDecompiler(tree *ast.Tree) *decompiler {
+	d := &decompiler{
+		tree:    tree,
+		lib:     newLibrary(tree.LibVersion),
+		decls:   chain(tree.Declarations),
+		used:    make(map[string]struct{}),
+		renamed: make(map[string]string),
+	}
+	collect := func(n ast.Node) {
+		switch nn := n.(type) {
+		case *ast.AssignmentNode:
+			d.used[nn.Name] = struct{}{}
+		case *ast.FunctionDeclarationNode:
+			d.used[nn.Name] = struct{}{}
+			for _, a := range nn.Arguments {
+				d.used[a] = struct{}{}
+			}
+		case *ast.ReferenceNode:
+			d.used[nn.Name] = struct{}{}
+		}
+	}
+	for _, n := range tree.Declarations {
+		walk(n, collect)
+	}
+	for _, n := range tree.Functions {
+		walk(n, collect)
+	}
+	walk(tree.Verifier, collect)
+	d.analyze()
+	return d
+}
+
+func (d *decompiler) fail(format string, args ...any) {
+	if d.err == nil {
+		d.err = errors.Errorf(format, args...)
+	}
+}
+
+func indent(ind int) string {
+	return strings.Repeat(indentation, ind)
+}
+
+func (d *decompiler) dApp(sb *strings.Builder) {
+	decls := d.decls
+	for decls != nil {
+		decl, rest, ok := d.declaration(decls, 0)
+		if !ok {
+			d.fail("unexpected DApp declaration %T", decls)
+			return
+		}
+		sb.WriteString("\n" + decl + "\n")
+		decls = rest
+	}
+	for i, n := range d.tree.Functions {
+		f, ok := n.(*ast.FunctionDeclarationNode)
+		if !ok {
+			d.fail("unexpected callable function %T", n)
+			return
+		}
+		fmt.Fprintf(sb, "\n@Callable(%s)\n%s\n", d.ident(f.InvocationParameter), d.function(f, d.callableTypes(i, f), 0))
+	}
+	if d.tree.Verifier != nil {
+		f, ok := d.tree.Verifier.(*ast.FunctionDeclarationNode)
+		if !ok {
+			d.fail("unexpected verifier function %T", d.tree.Verifier)
+			return
+		}
+		fmt.Fprintf(sb, "\n@Verifier(%s)\n%s\n", d.ident(f.InvocationParameter), d.function(f, nil, 0))
+	}
+}
+
+func (d *decompiler) expression(sb *strings.Builder) {
+	n := d.tree.Verifier
+	sb.WriteString("\n")
+	for {
+		decl, rest, ok := d.declaration(n, 0)
+		if !ok {
+			break
+		}
+		sb.WriteString(decl + "\n\n")
+		n = rest
+	}
+	s, _ := d.expr(n, 0)
+	sb.WriteString(s + "\n")
+}
+
+// chain links the top level declarations of DApp into a block to render them the same way as in expressions.
+func chain(decls []ast.Node) ast.Node {
+	var r ast.Node
+	for i := len(decls) - 1; i >= 0; i-- {
+		switch n := decls[i].(type) {
+		case *ast.AssignmentNode:
+			r = &ast.AssignmentNode{Name: n.Name, Expression: n.Expression, Block: r}
+		case *ast.FunctionDeclarationNode:
+			r = &ast.FunctionDeclarationNode{Name: n.Name, Arguments: n.Arguments, Body: n.Body, Block: r}
+		default:
+			return decls[i]
+		}
+	}
+	return r
+}
+
+// callableTypes returns the types of callable function arguments from the meta.
+func (d *decompiler) callableTypes(i int, f *ast.FunctionDeclarationNode) []string {
+	if i >= len(d.tree.Meta.Functions) || len(d.tree.Meta.Functions[i].Arguments) != len(f.Arguments) {
+		return nil
+	}
+	types := make([]string, len(f.Arguments))
+	for j, t := range d.tree.Meta.Functions[i].Arguments {
+		types[j] = metaTypeString(t)
+	}
+	return types
+}
+
+// declaration renders the leading declaration of the block and returns the rest of the block.
+func (d *decompiler) declaration(n ast.Node, ind int) (string, ast.Node, bool) {
+	switch nn := n.(type) {
+	case *ast.AssignmentNode:
+		if isExpression(nn) {
+			return "", nil, false
+		}
+		keyword, rest := "let", nn.Block
+		if r, ok := strictOf(nn); ok {
+			keyword, rest = "strict", r
+		}
+		value, _ := d.expr(nn.Expression, ind)
+		if names, r, ok := tupleDeclaration(nn.Name, rest); ok {
+			for i := range names {
+				names[i] = d.ident(names[i])
+			}
+			return fmt.Sprintf("%s (%s) = %s", keyword, strings.Join(names, ", "), value), r, true
+		}
+		return fmt.Sprintf("%s %s = %s", keyword, d.ident(nn.Name), value), rest, true
+	case *ast.FunctionDeclarationNode:
+		return d.function(nn, nil, ind), nn.Block, true
+	default:
+		return "", nil, false
+	}
+}
+
+// isExpression reports whether the declaration is a part of the expression expanded by the compiler.
+func isExpression(n *ast.AssignmentNode) bool {
+	if _, ok := matchOf(n); ok {
+		return true
+	}
+	if _, ok := foldOf(n); ok {
+		return true
+	}
+	_, ok := castOf(n)
+	return ok
+}
+
+func (d *decompiler) function(f *ast.FunctionDeclarationNode, types []string, ind int) string {
+	if types == nil {
+		types = d.functionTypes(f)
+	}
+	args := make([]string, len(f.Arguments))
+	for i, a := range f.Arguments {
+		args[i] = d.ident(a) + ": " + types[i]
+	}
+	body, _ := d.expr(f.Body, ind)
+	return fmt.Sprintf("func %s(%s) = %s", d.ident(f.Name), strings.Join(args, ", "), body)
+}
+
+func (d *decompiler) expr(n ast.Node, ind int) (string, kind) {
+	switch nn := n.(type) {
+	case nil:
+		d.fail("empty expression")
+		return "", compound
+	case *ast.LongNode:
+		return long(nn.Value)
+	case *ast.StringNode:
+		return quote(nn.Value), gettable
+	case *ast.BytesNode:
+		return byteVector(nn.Value), gettable
+	case *ast.BooleanNode:
+		return strconv.FormatBool(nn.Value), gettable
+	case *ast.ReferenceNode:
+		if nn.Name == "nil" {
+			return "[]", gettable
+		}
+		return d.ident(nn.Name), gettable
+	case *ast.PropertyNode:
+		return d.gettable(nn.Object, ind) + "." + nn.Name, gettable
+	case *ast.FunctionCallNode:
+		return d.call(nn, ind)
+	case *ast.ConditionalNode:
+		return d.conditional(nn, ind)
+	case *ast.AssignmentNode:
+		if m, ok := matchOf(nn); ok {
+			return d.match(m, ind), compound
+		}
+		if f, ok := foldOf(nn); ok {
+			return fmt.Sprintf("FOLD<%d>(%s, %s, %s)",
+				f.limit, d.plain(f.list, ind), d.plain(f.acc, ind), d.ident(f.fn)), operand
+		}
+		if c, ok := castOf(nn); ok {
+			as := "as"
+			if c.exact {
+				as = "exactAs"
+			}
+			return fmt.Sprintf("%s.%s[%s]", d.gettable(c.expr, ind), as, c.typ), gettable
+		}
+		return d.block(n, ind), gettable
+	case *ast.FunctionDeclarationNode:
+		return d.block(n, ind), gettable
+	default:
+		d.fail("unexpected node %T", n)
+		return "", compound
+	}
+}
+
+func (d *decompiler) plain(n ast.Node, ind int) string {
+	s, _ := d.expr(n, ind)
+	return s
+}
+
+// operand renders the expression that is used as an operand of binary or unary operator.
+func (d *decompiler) operand(n ast.Node, ind int) string {
+	s, k := d.expr(n, ind)
+	if k == compound {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// gettable renders the expression that is followed by the field or the list access.
+func (d *decompiler) gettable(n ast.Node, ind int) string {
+	s, k := d.expr(n, ind)
+	if k != gettable {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+func (d *decompiler) block(n ast.Node, ind int) string {
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	for {
+		decl, rest, ok := d.declaration(n, ind+1)
+		if !ok {
+			break
+		}
+		sb.WriteString(indent(ind+1) + decl + "\n")
+		n = rest
+	}
+	sb.WriteString(indent(ind+1) + d.plain(n, ind+1) + "\n")
+	sb.WriteString(indent(ind) + "}")
+	return sb.String()
+}
+
+func (d *decompiler) conditional(c *ast.ConditionalNode, ind int) (string, kind) {
+	if isBoolean(c.FalseExpression, false) && d.isBoolean(c.TrueExpression) {
+		return fmt.Sprintf("%s && %s", d.operand(c.Condition, ind), d.operand(c.TrueExpression, ind)), compound
+	}
+	if isBoolean(c.TrueExpression, true) && d.isBoolean(c.FalseExpression) {
+		return fmt.Sprintf("%s || %s", d.operand(c.Condition, ind), d.operand(c.FalseExpression, ind)), compound
+	}
+	cond := d.plain(c.Condition, ind)
+	t := d.plain(c.TrueExpression, ind+1)
+	f := d.plain(c.FalseExpression, ind+1)
+	s := fmt.Sprintf("if (%s) then %s else %s", cond, t, f)
+	if strings.Contains(s, "\n") || len(s) > maxLineLength {
+		s = fmt.Sprintf("if (%s)\n%sthen %s\n%selse %s", cond, indent(ind+1), t, indent(ind+1), f)
+	}
+	return s, compound
+}
+
+// isBoolean reports whether the expression is known to be of Boolean type.
+func (d *decompiler) isBoolean(n ast.Node) bool {
+	switch nn := n.(type) {
+	case *ast.BooleanNode:
+		return true
+	case *ast.ConditionalNode:
+		return d.isBoolean(nn.TrueExpression) && d.isBoolean(nn.FalseExpression)
+	case *ast.FunctionCallNode:
+		switch nn.Function.(type) {
+		case ast.NativeFunction:
+			switch nn.Function.Name() {
+			case "0", "1", "102", "103", "319", "320":
+				return true
+			}
+		case ast.UserFunction:
+			switch nn.Function.Name() {
+			case "!=", "!":
+				return true
+			}
+		}
+		return d.lib.returnsBoolean(nn.Function)
+	default:
+		return false
+	}
+}
+
+var binaryOperators = map[string]string{
+	"0": "==", "100": "+", "101": "-", "102": ">", "103": ">=", "104": "*", "105": "/", "106": "%",
+	"203": "+", "300": "+", "311": "+", "312": "-", "313": "*", "314": "/", "315": "%", "319": ">", "320": ">=",
+	"1100": "::", "1101": ":+", "1102": "++",
+}
+
+const (
+	firstTupleConstructor = 1300
+	lastTupleConstructor  = 1320
+)
+
+func (d *decompiler) call(c *ast.FunctionCallNode, ind int) (string, kind) {
+	id := c.Function.Name()
+	switch c.Function.(type) {
+	case ast.NativeFunction:
+		if items, ok := listLiteral(c); ok {
+			return d.list("[", items, "]", ind), gettable
+		}
+		if op, ok := binaryOperators[id]; ok && len(c.Arguments) == 2 {
+			return d.binary(op, c.Arguments, ind), compound
+		}
+		switch id {
+		case "318":
+			if len(c.Arguments) == 1 {
+				return "-" + d.gettable(c.Arguments[0], ind), operand
+			}
+		case "401":
+			if len(c.Arguments) == 2 {
+				return fmt.Sprintf("%s[%s]", d.gettable(c.Arguments[0], ind), d.plain(c.Arguments[1], ind)), gettable
+			}
+		case "1":
+			if t, ok := instanceOf(c, func(ast.Node) bool { return true }); ok {
+				// There is no type check function in the language, so the check is rendered as a match.
+				return fmt.Sprintf("match %s {\n%scase _: %s => true\n%scase _ => false\n%s}",
+					d.plain(c.Arguments[0], ind), indent(ind+1), t, indent(ind+1), indent(ind)), compound
+			}
+		}
+		if n, err := strconv.Atoi(id); err == nil && n >= firstTupleConstructor && n <= lastTupleConstructor &&
+			len(c.Arguments) == n-firstTupleConstructor+2 {
+			return d.list("(", c.Arguments, ")", ind), gettable
+		}
+	case ast.UserFunction:
+		switch {
+		case id == "!=" && len(c.Arguments) == 2:
+			return d.binary(id, c.Arguments, ind), compound
+		case (id == "!" || id == "-") && len(c.Arguments) == 1:
+			return id + d.gettable(c.Arguments[0], ind), operand
+		}
+		if _, ok := d.lib.function(c.Function); !ok {
+			return d.ident(id) + d.list("(", c.Arguments, ")", ind), gettable
+		}
+	}
+	f, ok := d.lib.function(c.Function)
+	if !ok {
+		d.fail("unknown %s '%s'", c.Function.Type(), id)
+		return "", compound
+	}
+	return f.name + d.list("(", c.Arguments, ")", ind), gettable
+}
+
+func (d *decompiler) binary(op string, args []ast.Node, ind int) string {
+	return fmt.Sprintf("%s %s %s", d.operand(args[0], ind), op, d.operand(args[1], ind))
+}
+
+func (d *decompiler) list(open string, items []ast.Node, closing string, ind int) string {
+	r := make([]string, len(items))
+	for i, item := range items {
+		r[i] = d.plain(item, ind)
+	}
+	return open + strings.Join(r, ", ") + closing
+}
+
+// listLiteral returns the items of the list built with the cons chain that ends with `nil`.
+func listLiteral(c *ast.FunctionCallNode) ([]ast.Node, bool) {
+	var items []ast.Node
+	var n ast.Node = c
+	for {
+		if isReference(n, "nil") {
+			return items, true
+		}
+		cons, ok := isNative(n, "1100", 2)
+		if !ok {
+			return nil, false
+		}
+		items = append(items, cons.Arguments[0])
+		n = cons.Arguments[1]
+	}
+}
+
+func (d *decompiler) match(m matchExpr, ind int) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "match %s {\n", d.plain(m.expr, ind))
+	for _, c := range m.cases {
+		fmt.Fprintf(&sb, "%scase %s =>", indent(ind+1), d.pattern(c.pattern, true, ind+1))
+		var decls []string
+		n := c.body
+		for {
+			decl, rest, ok := d.declaration(n, ind+2)
+			if !ok {
+				break
+			}
+			decls = append(decls, decl)
+			n = rest
+		}
+		if len(decls) == 0 {
+			sb.WriteString(" " + d.plain(n, ind+1) + "\n")
+			continue
+		}
+		sb.WriteString("\n")
+		for _, decl := range decls {
+			sb.WriteString(indent(ind+2) + decl + "\n")
+		}
+		sb.WriteString(indent(ind+2) + d.plain(n, ind+2) + "\n")
+	}
+	sb.WriteString(indent(ind) + "}")
+	return sb.String()
+}
+
+// pattern renders the pattern of the match case. The values of nested patterns that are references
+// are parenthesized to distinguish them from the bindings.
+func (d *decompiler) pattern(p pattern, top bool, ind int) string {
+	switch p.kind {
+	case typedPattern:
+		return d.binding(p.name) + ": " + strings.Join(p.types, "|")
+	case valuePattern:
+		if _, isRef := p.value.(*ast.ReferenceNode); isRef && !top {
+			return "(" + d.plain(p.value, ind) + ")"
+		}
+		return d.plain(p.value, ind)
+	case objectPattern:
+		fields := make([]string, len(p.elements))
+		for i, f := range p.elements {
+			fields[i] = f.field + " = " + d.pattern(f, false, ind)
+		}
+		return p.object + "(" + strings.Join(fields, ", ") + ")"
+	case tuplePattern:
+		elements := make([]string, len(p.elements))
+		for i, e := range p.elements {
+			elements[i] = d.pattern(e, false, ind)
+		}
+		return "(" + strings.Join(elements, ", ") + ")"
+	default:
+		return d.binding(p.name)
+	}
+}
+
+func (d *decompiler) binding(name string) string {
+	if name == "" {
+		return "_"
+	}
+	return d.ident(name)
+}
+
+// ident returns the name that is allowed in the source code, the names of variables and functions introduced
+// by the compiler are replaced with the unique names.
+func (d *decompiler) ident(name string) string {
+	if validIdentifier(name) {
+		return name
+	}
+	if r, ok := d.renamed[name]; ok {
+		return r
+	}
+	var sb strings.Builder
+	for _, c := range name {
+		if c < 128 && (isLetter(byte(c)) || isDigit(byte(c))) {
+			sb.WriteRune(c)
+		}
+	}
+	base := sb.String()
+	if base == "" || isDigit(base[0]) {
+		base = "v" + base
+	}
+	candidate := base
+	for i := 1; ; i++ {
+		_, used := d.used[candidate]
+		if !used && validIdentifier(candidate) {
+			break
+		}
+		candidate = base + "_" + strconv.Itoa(i)
+	}
+	d.used[candidate] = struct{}{}
+	d.renamed[name] = candidate
+	return candidate
+}
+
+var reservedWords = map[string]struct{}{
+	"let": {}, "strict": {}, "base16": {}, "base58": {}, "base64": {}, "true": {}, "false": {},
+	"if": {}, "then": {}, "else": {}, "match": {}, "case": {}, "func": {}, "FOLD": {},
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// validIdentifier reports whether the name can be used as identifier in the source code.
+func validIdentifier(name string) bool {
+	if name == "" || name == "_" {
+		return false
+	}
+	if _, ok := reservedWords[name]; ok {
+		return false
+	}
+	if !isLetter(name[0]) && (name[0] != '_' || len(name) < 2 || !isLetter(name[1])) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case isLetter(c) || isDigit(c):
+		case c == '_' && name[i-1] != '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func long(v int64) (string, kind) {
+	switch {
+	case v == math.MinInt64:
+		// The absolute value of the minimal integer doesn't fit into the integer literal.
+		return fmt.Sprintf("(%d - 1)", v+1), gettable
+	case v < 0:
+		return strconv.FormatInt(v, 10), operand
+	default:
+		return strconv.FormatInt(v, 10), gettable
+	}
+}
+
+func quote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04x`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+func byteVector(b []byte) string {
+	if len(b) <= maxBase58Length {
+		return "base58'" + base58.Encode(b) + "'"
+	}
+	return "base64'" + base64.StdEncoding.EncodeToString(b) + "'"
+}
+
+// checkFunctionCalls checks that the functions of all calls of the tree are set.
+func checkFunctionCalls(tree *ast.Tree) error {
+	var err error
+	check := func(n ast.Node) {
+		if c, ok := n.(*ast.FunctionCallNode); ok && c.Function == nil && err == nil {
+			err = errors.New("function call without function")
+		}
+	}
+	for _, n := range tree.Declarations {
+		walk(n, check)
+	}
+	for _, n := range tree.Functions {
+		walk(n, check)
+	}
+	walk(tree.Verifier, check)
+	return err
+}
+
+// walk calls the visit function for every node of the tree.
+func walk(n ast.Node, visit func(ast.Node)) {
+	if n == nil {
+		return
+	}
+	visit(n)
+	switch nn := n.(type) {
+	case *ast.ConditionalNode:
+		walk(nn.Condition, visit)
+		walk(nn.TrueExpression, visit)
+		walk(nn.FalseExpression, visit)
+	case *ast.AssignmentNode:
+		walk(nn.Expression, visit)
+		walk(nn.Block, visit)
+	case *ast.FunctionDeclarationNode:
+		walk(nn.Body, visit)
+		walk(nn.Block, visit)
+	case *ast.FunctionCallNode:
+		for _, a := range nn.Arguments {
+			walk(a, visit)
+		}
+	case *ast.PropertyNode:
+		walk(nn.Object, visit)
+	}
+}
@@ -0,0 +1,372 @@
+package decompiler
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+)
+
+func compile(t *testing.T, src string, compaction bool) *ast.Tree {
+	b, errs := compiler.Compile(src, compaction, false)
+	require.Empty(t, errs, src)
+	tree, err := serialization.Parse(b)
+	require.NoError(t, err)
+	return tree
+}
+
+// checkRoundTrip decompiles the tree, compiles the result and checks that the compiled tree is equivalent
+// to the original one and the second decompilation produces the same source code.
+func checkRoundTrip(t *testing.T, tree *ast.Tree, scriptType ScriptType) string {
+	src, err := Decompile(tree, scriptType)
+	require.NoError(t, err)
+	recompiled := compile(t, src, false)
+	assertEquivalent(t, tree, recompiled)
+	again, err := Decompile(recompiled, scriptType)
+	require.NoError(t, err)
+	assert.Equal(t, src, again)
+	return src
+}
+
+func TestDecompileTestScripts(t *testing.T) {
+	dir := filepath.Join("..", "compiler", "testdata")
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, f := range files {
+		code, rErr := os.ReadFile(filepath.Join(dir, f.Name()))
+		require.NoError(t, rErr)
+		t.Run(f.Name(), func(t *testing.T) {
+			checkRoundTrip(t, compile(t, string(code), false), AccountScript)
+		})
+		t.Run(f.Name()+"/compacted", func(t *testing.T) {
+			checkRoundTrip(t, compile(t, string(code), true), AccountScript)
+		})
+	}
+}
+
+// equivalent reports whether the trees are the same up to the names of declarations.
+type equivalence struct {
+	names map[string]string
+	funcs map[string]string
+}
+
+func (e equivalence) clone() equivalence {
+	return equivalence{names: maps.Clone(e.names), funcs: maps.Clone(e.funcs)}
+}
+
+func (e equivalence) ref(m map[string]string, a, b string) bool {
+	if n, ok := m[a]; ok {
+		return n == b
+	}
+	return a == b
+}
+
+func (e equivalence) equal(a, b ast.Node) bool {
+	switch an := a.(type) {
+	case nil:
+		return b == nil
+	case *ast.LongNode:
+		bn, ok := b.(*ast.LongNode)
+		return ok && an.Value == bn.Value
+	case *ast.BooleanNode:
+		bn, ok := b.(*ast.BooleanNode)
+		return ok && an.Value == bn.Value
+	case *ast.StringNode:
+		bn, ok := b.(*ast.StringNode)
+		return ok && an.Value == bn.Value
+	case *ast.BytesNode:
+		bn, ok := b.(*ast.BytesNode)
+		return ok && bytes.Equal(an.Value, bn.Value)
+	case *ast.ReferenceNode:
+		bn, ok := b.(*ast.ReferenceNode)
+		return ok && e.ref(e.names, an.Name, bn.Name)
+	case *ast.PropertyNode:
+		bn, ok := b.(*ast.PropertyNode)
+		return ok && an.Name == bn.Name && e.equal(an.Object, bn.Object)
+	case *ast.ConditionalNode:
+		bn, ok := b.(*ast.ConditionalNode)
+		return ok && e.equal(an.Condition, bn.Condition) && e.equal(an.TrueExpression, bn.TrueExpression) &&
+			e.equal(an.FalseExpression, bn.FalseExpression)
+	case *ast.FunctionCallNode:
+		bn, ok := b.(*ast.FunctionCallNode)
+		if !ok || len(an.Arguments) != len(bn.Arguments) || an.Function.Type() != bn.Function.Type() {
+			return false
+		}
+		if _, user := an.Function.(ast.UserFunction); user {
+			if !e.ref(e.funcs, an.Function.Name(), bn.Function.Name()) {
+				return false
+			}
+		} else if an.Function.Name() != bn.Function.Name() {
+			return false
+		}
+		for i := range an.Arguments {
+			if !e.equal(an.Arguments[i], bn.Arguments[i]) {
+				return false
+			}
+		}
+		return true
+	case *ast.AssignmentNode:
+		bn, ok := b.(*ast.AssignmentNode)
+		if !ok || !e.equal(an.Expression, bn.Expression) {
+			return false
+		}
+		inner := e.clone()
+		inner.names[an.Name] = bn.Name
+		return inner.equal(an.Block, bn.Block)
+	case *ast.FunctionDeclarationNode:
+		bn, ok := b.(*ast.FunctionDeclarationNode)
+		if !ok || len(an.Arguments) != len(bn.Arguments) {
+			return false
+		}
+		body := e.clone()
+		for i := range an.Arguments {
+			body.names[an.Arguments[i]] = bn.Arguments[i]
+		}
+		body.names[an.InvocationParameter] = bn.InvocationParameter
+		if !body.equal(an.Body, bn.Body) {
+			return false
+		}
+		inner := e.clone()
+		inner.funcs[an.Name] = bn.Name
+		return inner.equal(an.Block, bn.Block)
+	default:
+		return false
+	}
+}
+
+func assertEquivalent(t *testing.T, a, b *ast.Tree) {
+	require.Equal(t, a.LibVersion, b.LibVersion)
+	require.Equal(t, a.ContentType, b.ContentType)
+	require.Equal(t, len(a.Declarations), len(b.Declarations))
+	require.Equal(t, len(a.Functions), len(b.Functions))
+	e := equivalence{names: make(map[string]string), funcs: make(map[string]string)}
+	for i := range a.Declarations {
+		require.True(t, e.equal(a.Declarations[i], b.Declarations[i]), "declaration %d", i)
+		switch d := a.Declarations[i].(type) {
+		case *ast.AssignmentNode:
+			e.names[d.Name] = b.Declarations[i].(*ast.AssignmentNode).Name
+		case *ast.FunctionDeclarationNode:
+			e.funcs[d.Name] = b.Declarations[i].(*ast.FunctionDeclarationNode).Name
+		}
+	}
+	for i := range a.Functions {
+		fa, fb := a.Functions[i].(*ast.FunctionDeclarationNode), b.Functions[i].(*ast.FunctionDeclarationNode)
+		require.Equal(t, fa.Name, fb.Name)
+		require.True(t, e.equal(fa, fb), "callable function %s", fa.Name)
+	}
+	assert.True(t, e.equal(a.Verifier, b.Verifier), "verifier")
+}
+
+func TestDecompileScripts(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		scriptType ScriptType
+		src        string
+	}{
+		{"V1 expression", AccountScript, `{-# STDLIB_VERSION 1 #-}
+{-# CONTENT_TYPE EXPRESSION #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+let a = base58'3P3336rNSSU8bDAqDb6S5jNs'
+let b = base64'AQa3b8tH'
+let c = -9223372036854775807 - 1
+sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey) && size(a) > size(b) && c < 0 && "\"\\é" != ""`},
+		{"V2 asset script", AssetScript, `{-# STDLIB_VERSION 2 #-}
+{-# CONTENT_TYPE EXPRESSION #-}
+{-# SCRIPT_TYPE ASSET #-}
+match tx {
+  case t: TransferTransaction => t.amount > 100 && !isDefined(t.feeAssetId)
+  case _: BurnTransaction | ReissueTransaction => false
+  case _ => true
+}`},
+		{"V3 DApp", AccountScript, `{-# STDLIB_VERSION 3 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+let owner = base58'3P3336rNSSU8bDAqDb6S5jNs'
+func key(i: Invocation) = toBase58String(i.caller.bytes)
+@Callable(i)
+func deposit() = {
+  let pmt = extract(i.payment)
+  if (isDefined(pmt.assetId)) then throw("only WAVES")
+  else WriteSet([DataEntry(key(i), pmt.amount)])
+}
+@Callable(i)
+func withdraw(amount: Int) = ScriptResult(WriteSet([DataEntry(key(i), amount)]),
+  TransferSet([ScriptTransfer(i.caller, amount, unit)]))
+@Verifier(tx)
+func verify() = sigVerify(tx.bodyBytes, tx.proofs[0], owner)`},
+		{"V5 DApp", AccountScript, `{-# STDLIB_VERSION 5 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+func pair(a: Int, s: String) = (a, s, [a, a * 2])
+@Callable(i)
+func call(amounts: List[Int], name: String|Int) = {
+  let (x, y, z) = pair(amounts[0], "k")
+  strict (p, q) = (x + 1, y + "v")
+  strict r = invoke(this, "other", [p], [])
+  let n = match name {
+    case s: String => s
+    case v: Int => toString(v)
+  }
+  ([IntegerEntry(n + q, p), BooleanEntry("b", r == unit)], z.size())
+}
+@Callable(i)
+func other(v: Int) = ([], v)`},
+		{"V6 expression", AccountScript, `{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE EXPRESSION #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+let (a, b, c) = (1, "x", [1, 2])
+strict s = a * 2
+func f(acc: Int, e: Int) = acc + e
+let sum = FOLD<5>(c, 0, f)
+let m = match tx {
+  case t: TransferTransaction => t.amount
+  case d: DataTransaction | SetScriptTransaction => 1
+  case _ => -1
+}
+let y = getString("k").exactAs[String]
+let w = getInteger("i").as[Int]
+let z = if (a > 0 && b != "y" || !(s < 3)) then -a else a % 3
+let big = -toBigInt(a) * parseBigIntValue("10") / toBigInt(3)
+sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey) && sum + m + z > y.size() && isDefined(w) &&
+  big >= toBigInt(0) && (c :+ 3) ++ (4 :: c) != []`},
+		{"V7 match patterns", AccountScript, `{-# STDLIB_VERSION 7 #-}
+{-# CONTENT_TYPE EXPRESSION #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+let t = (1, "a", unit)
+let a = match t {
+  case (x: Int, "b", _) => x
+  case (_, s: String, u: Unit) => size(s)
+  case _ => 0
+}
+let b = match tx {
+  case TransferTransaction(amount = 1) => 2
+  case d: DataTransaction => d.fee + 1
+  case _ => 0
+}
+let c = match a {
+  case 1 => true
+  case _ => false
+}
+a + b > 0 && c`},
+		{"V8 DApp", AccountScript, `{-# STDLIB_VERSION 8 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+@Callable(i)
+func replace(v: List[Int], n: Int) = {
+  let r = replaceByIndex(v, 0, n)
+  ([IntegerEntry("r", r[0]), IntegerEntry("d", calculateDelay(i.caller, n))], unit)
+}`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			checkRoundTrip(t, compile(t, test.src, false), test.scriptType)
+		})
+	}
+}
+
+func TestDecompileSource(t *testing.T) {
+	tree := compile(t, `{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+func sum(a: Int, b: Int) = a + b
+@Callable(i)
+func call(x: Int) = ([IntegerEntry("x", sum(x, 1) * 2)], unit)`, false)
+	dir, src, err := DecompileWithDirectives(tree, AccountScript)
+	require.NoError(t, err)
+	assert.Equal(t, Directives{LibVersion: ast.LibV6, ContentType: "DAPP", ScriptType: AccountScript}, dir)
+	assert.Equal(t, `{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+
+func sum(a: Int, b: Int) = a + b
+
+@Callable(i)
+func call(x: Int) = ([IntegerEntry("x", sum(x, 1) * 2)], unit)
+`, src)
+}
+
+func TestDecompileCompactedNames(t *testing.T) {
+	src := `{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+let prefix = "balance_"
+func balanceKey(addr: Address) = prefix + toString(addr)
+@Callable(i)
+func deposit() = [IntegerEntry(balanceKey(i.caller), i.payments[0].amount)]`
+	compacted := checkRoundTrip(t, compile(t, src, true), AccountScript)
+	plain := checkRoundTrip(t, compile(t, src, false), AccountScript)
+	assert.Equal(t, plain, compacted)
+	assert.Contains(t, compacted, "func balanceKey(addr: Address) = prefix + toString(addr)")
+}
+
+func TestDecompileErrors(t *testing.T) {
+	_, err := Decompile(nil, AccountScript)
+	assert.EqualError(t, err, "empty script tree")
+
+	dApp := compile(t, `{-# STDLIB_VERSION 5 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+@Callable(i)
+func call() = []`, false)
+	_, err = Decompile(dApp, AssetScript)
+	assert.EqualError(t, err, "DApp can't be an asset script")
+	_, err = Decompile(dApp, ScriptType(5))
+	assert.EqualError(t, err, "unsupported script type 5")
+
+	unknown := &ast.Tree{
+		LibVersion:  ast.LibV5,
+		ContentType: ast.ContentTypeExpression,
+		Verifier:    ast.NewFunctionCallNode(ast.NativeFunction("99999"), nil),
+	}
+	_, err = Decompile(unknown, AccountScript)
+	assert.Error(t, err)
+}
+
+func TestDecompileInvalidTrees(t *testing.T) {
+	match := compile(t, `{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE EXPRESSION #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+let v = if (height > 10) then 1 else "a"
+match v {
+    case i: Int => i > 0
+    case _ => false
+}`, false)
+	for _, name := range []string{"", "Int ", "( Int , String )", "Foo[Int]", "Int|", "(Int)", "List[Int"} {
+		t.Run(name, func(t *testing.T) {
+			walk(match.Verifier, func(n ast.Node) {
+				if s, ok := n.(*ast.StringNode); ok && s.Value == "Int" {
+					s.Value = name
+				}
+			})
+			assert.NotPanics(t, func() { _, _ = Decompile(match, AccountScript) })
+			walk(match.Verifier, func(n ast.Node) {
+				if s, ok := n.(*ast.StringNode); ok && s.Value == name {
+					s.Value = "Int"
+				}
+			})
+		})
+	}
+
+	tree := func(n ast.Node) *ast.Tree {
+		return &ast.Tree{LibVersion: ast.LibV5, ContentType: ast.ContentTypeExpression, Verifier: n}
+	}
+	equality := &ast.FunctionDeclarationNode{
+		Name:      "f",
+		Arguments: []string{"a"},
+		Body:      ast.NewFunctionCallNode(ast.NativeFunction("0"), []ast.Node{ast.NewReferenceNode("a")}),
+		Block:     ast.NewFunctionCallNode(ast.UserFunction("f"), []ast.Node{ast.NewLongNode(1)}),
+	}
+	assert.NotPanics(t, func() { _, _ = Decompile(tree(equality), AccountScript) })
+	assert.NotPanics(t, func() {
+		_, _ = Decompile(tree(ast.NewFunctionCallNode(ast.NativeFunction("401"), nil)), AccountScript)
+	})
+	_, err := Decompile(tree(ast.NewFunctionCallNode(nil, nil)), AccountScript)
+	assert.EqualError(t, err, "function call without function")
+}
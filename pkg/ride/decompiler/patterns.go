@@ -0,0 +1,541 @@
+package decompiler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler/stdlib"
+)
+
+// The compiler expands some constructions of the language into the combinations of basic expressions
+// using the variables with names that are not allowed in the source code. The functions of this file
+// recognize such combinations to render them back into the original constructions.
+
+const (
+	matchErrorMessage  = "Match error"
+	strictErrorMessage = "Strict value is not equal to itself."
+	foldErrorPrefix    = "List size exceeds "
+)
+
+type patternKind byte
+
+const (
+	placeholderPattern patternKind = iota // `_` or an identifier in tuple pattern
+	typedPattern                          // `x: Int|String` or `_: Int`
+	valuePattern                          // an expression to compare with
+	objectPattern                         // `Struct(field = x, other = 1)`
+	tuplePattern                          // `(x: Int, _, 1)`
+)
+
+type pattern struct {
+	kind     patternKind
+	name     string // binding name, empty for placeholder
+	types    []string
+	value    ast.Node
+	object   string
+	field    string    // field name of object pattern item
+	elements []pattern // items of tuple and object patterns
+}
+
+type matchCase struct {
+	pattern pattern
+	body    ast.Node
+}
+
+type matchExpr struct {
+	expr  ast.Node
+	cases []matchCase
+}
+
+type foldExpr struct {
+	limit int64
+	list  ast.Node
+	acc   ast.Node
+	fn    string
+}
+
+type castExpr struct {
+	expr  ast.Node
+	typ   string
+	exact bool
+}
+
+func isBoolean(n ast.Node, value bool) bool {
+	b, ok := n.(*ast.BooleanNode)
+	return ok && b.Value == value
+}
+
+func isReference(n ast.Node, name string) bool {
+	r, ok := n.(*ast.ReferenceNode)
+	return ok && r.Name == name
+}
+
+func isNative(n ast.Node, id string, args int) (*ast.FunctionCallNode, bool) {
+	c, ok := n.(*ast.FunctionCallNode)
+	if !ok || len(c.Arguments) != args {
+		return nil, false
+	}
+	f, ok := c.Function.(ast.NativeFunction)
+	return c, ok && string(f) == id
+}
+
+func isUser(n ast.Node, name string, args int) (*ast.FunctionCallNode, bool) {
+	c, ok := n.(*ast.FunctionCallNode)
+	if !ok || len(c.Arguments) != args {
+		return nil, false
+	}
+	f, ok := c.Function.(ast.UserFunction)
+	return c, ok && string(f) == name
+}
+
+// isThrow reports whether the node is a call of `throw` with the message that satisfies the check.
+func isThrow(n ast.Node, check func(string) bool) bool {
+	c, ok := isNative(n, "2", 1)
+	if !ok {
+		return false
+	}
+	s, ok := c.Arguments[0].(*ast.StringNode)
+	return ok && check(s.Value)
+}
+
+func isMatchError(n ast.Node) bool {
+	if _, ok := isUser(n, "throw", 0); ok {
+		return true
+	}
+	return isThrow(n, func(s string) bool { return s == matchErrorMessage })
+}
+
+// instanceOf returns the type name if the node is a check of the object type.
+func instanceOf(n ast.Node, object func(ast.Node) bool) (string, bool) {
+	c, ok := isNative(n, "1", 2)
+	if !ok || !object(c.Arguments[0]) {
+		return "", false
+	}
+	s, ok := c.Arguments[1].(*ast.StringNode)
+	if !ok || !validTypeName(s.Value) {
+		return "", false
+	}
+	return s.Value, true
+}
+
+func equalTo(n ast.Node, object func(ast.Node) bool) (ast.Node, bool) {
+	c, ok := isNative(n, "0", 2)
+	if !ok || !object(c.Arguments[1]) {
+		return nil, false
+	}
+	return c.Arguments[0], true
+}
+
+func reference(name string) func(ast.Node) bool {
+	return func(n ast.Node) bool { return isReference(n, name) }
+}
+
+// property returns the name of the field if the node is a field access of the variable.
+func property(n ast.Node, name string) (string, bool) {
+	p, ok := n.(*ast.PropertyNode)
+	if !ok || !isReference(p.Object, name) {
+		return "", false
+	}
+	return p.Name, true
+}
+
+func tupleIndex(field string) (int, bool) {
+	if !isTupleField(field) {
+		return 0, false
+	}
+	i, err := strconv.Atoi(field[1:])
+	return i, err == nil && i > 0
+}
+
+// bindings splits the leading declarations of fields of the variable from the rest of the block.
+func bindings(n ast.Node, name string) ([]pattern, ast.Node) {
+	var r []pattern
+	for {
+		a, ok := n.(*ast.AssignmentNode)
+		if !ok {
+			return r, n
+		}
+		f, ok := property(a.Expression, name)
+		if !ok {
+			return r, n
+		}
+		r = append(r, pattern{kind: placeholderPattern, name: a.Name, field: f})
+		n = a.Block
+	}
+}
+
+// conjunction returns the operands of `a && b && c` chain.
+func conjunction(n ast.Node) []ast.Node {
+	var r []ast.Node
+	for n != nil && !isBoolean(n, true) {
+		c, ok := n.(*ast.ConditionalNode)
+		if !ok || !isBoolean(c.FalseExpression, false) {
+			return append(r, n)
+		}
+		r = append(r, c.Condition)
+		n = c.TrueExpression
+	}
+	return r
+}
+
+// typeCheck returns the types of a value pattern: a type check or a union of checks.
+func typeCheck(n ast.Node, name string) ([]string, bool) {
+	if t, ok := instanceOf(n, reference(name)); ok {
+		return []string{t}, true
+	}
+	c, ok := n.(*ast.ConditionalNode)
+	if !ok || !isBoolean(c.TrueExpression, true) {
+		return nil, false
+	}
+	last, ok := instanceOf(c.Condition, reference(name))
+	if !ok {
+		return nil, false
+	}
+	prev, ok := typeCheck(c.FalseExpression, name)
+	if !ok {
+		return nil, false
+	}
+	return append(prev, last), true
+}
+
+func matchOf(n *ast.AssignmentNode) (matchExpr, bool) {
+	if validIdentifier(n.Name) {
+		return matchExpr{}, false
+	}
+	var cases []matchCase
+	cur := n.Block
+	for {
+		c, ok := cur.(*ast.ConditionalNode)
+		if !ok {
+			break
+		}
+		mc, ok := casePattern(n.Name, c.Condition, c.TrueExpression)
+		if !ok {
+			break
+		}
+		cases = append(cases, mc)
+		cur = c.FalseExpression
+	}
+	if len(cases) == 0 {
+		return matchExpr{}, false
+	}
+	if !isMatchError(cur) {
+		// The default case of the match with the binding of the value is restored if the matched value is a variable.
+		if a, ok := cur.(*ast.AssignmentNode); ok && isReference(a.Expression, n.Name) {
+			if r, isRef := n.Expression.(*ast.ReferenceNode); isRef && validIdentifier(r.Name) {
+				cur = &ast.AssignmentNode{Name: a.Name, Expression: r, Block: a.Block}
+			}
+		}
+		cases = append(cases, matchCase{pattern: pattern{kind: placeholderPattern}, body: cur})
+	}
+	for _, c := range cases {
+		if references(c.body, n.Name) || references(c.pattern.value, n.Name) {
+			return matchExpr{}, false
+		}
+		for _, p := range c.pattern.elements {
+			if references(p.value, n.Name) {
+				return matchExpr{}, false
+			}
+		}
+	}
+	return matchExpr{expr: n.Expression, cases: cases}, true
+}
+
+func casePattern(name string, cond, body ast.Node) (matchCase, bool) {
+	if types, ok := typeCheck(cond, name); ok {
+		p := pattern{kind: typedPattern, types: types}
+		if a, isLet := body.(*ast.AssignmentNode); isLet && isReference(a.Expression, name) {
+			p.name = a.Name
+			body = a.Block
+		}
+		return matchCase{pattern: p, body: body}, true
+	}
+	if v, ok := equalTo(cond, reference(name)); ok {
+		return matchCase{pattern: pattern{kind: valuePattern, value: v}, body: body}, true
+	}
+	if p, b, ok := objectCase(name, cond, body); ok {
+		return matchCase{pattern: p, body: b}, true
+	}
+	if p, b, ok := tupleCase(name, cond, body); ok {
+		return matchCase{pattern: p, body: b}, true
+	}
+	return matchCase{}, false
+}
+
+// objectCase recognizes the pattern `Struct(field = value, other = x)`.
+func objectCase(name string, cond, body ast.Node) (pattern, ast.Node, bool) {
+	c, ok := cond.(*ast.ConditionalNode)
+	if !ok || !isBoolean(c.FalseExpression, false) {
+		return pattern{}, nil, false
+	}
+	object, ok := instanceOf(c.Condition, reference(name))
+	if !ok {
+		return pattern{}, nil, false
+	}
+	shadow, ok := c.TrueExpression.(*ast.AssignmentNode)
+	if !ok || shadow.Name != name || !isReference(shadow.Expression, name) {
+		return pattern{}, nil, false
+	}
+	p := pattern{kind: objectPattern, object: object}
+	for _, e := range conjunction(shadow.Block) {
+		v, isEq := equalTo(e, func(n ast.Node) bool {
+			_, isProp := property(n, name)
+			return isProp
+		})
+		if !isEq {
+			return pattern{}, nil, false
+		}
+		f, _ := property(e.(*ast.FunctionCallNode).Arguments[1], name)
+		p.elements = append(p.elements, pattern{kind: valuePattern, value: v, field: f})
+	}
+	bodyShadow, ok := body.(*ast.AssignmentNode)
+	if !ok || bodyShadow.Name != name || !isReference(bodyShadow.Expression, name) {
+		return pattern{}, nil, false
+	}
+	binds, rest := bindings(bodyShadow.Block, name)
+	p.elements = append(p.elements, binds...)
+	return p, rest, true
+}
+
+// tupleCase recognizes the pattern `(x: Int, _, 1, y)`.
+func tupleCase(name string, cond, body ast.Node) (pattern, ast.Node, bool) {
+	c, ok := cond.(*ast.ConditionalNode)
+	if !ok || !isBoolean(c.FalseExpression, false) {
+		return pattern{}, nil, false
+	}
+	var size int
+	if t, isTuple := instanceOf(c.TrueExpression, reference(name)); isTuple {
+		size = tupleSize(t)
+	} else if v, isEq := equalTo(c.TrueExpression, func(n ast.Node) bool {
+		l, isLong := n.(*ast.LongNode)
+		return isLong && l.Value > 1 && l.Value <= stdlib.MaxTupleLength
+	}); isEq {
+		if _, isSize := isNative(v, "1350", 1); !isSize || !isReference(v.(*ast.FunctionCallNode).Arguments[0], name) {
+			return pattern{}, nil, false
+		}
+		size = int(c.TrueExpression.(*ast.FunctionCallNode).Arguments[1].(*ast.LongNode).Value)
+	}
+	if size == 0 {
+		return pattern{}, nil, false
+	}
+	elements := make([]pattern, size)
+	isElement := func(n ast.Node) bool {
+		f, isProp := property(n, name)
+		i, isIndex := tupleIndex(f)
+		return isProp && isIndex && i <= size
+	}
+	elementIndex := func(n ast.Node) int {
+		f, _ := property(n, name)
+		i, _ := tupleIndex(f)
+		return i - 1
+	}
+	for _, e := range conjunction(c.Condition) {
+		if t, isType := instanceOf(e, isElement); isType {
+			elements[elementIndex(e.(*ast.FunctionCallNode).Arguments[0])] = pattern{kind: typedPattern, types: []string{t}}
+			continue
+		}
+		if v, isEq := equalTo(e, isElement); isEq {
+			elements[elementIndex(e.(*ast.FunctionCallNode).Arguments[1])] = pattern{kind: valuePattern, value: v}
+			continue
+		}
+		return pattern{}, nil, false
+	}
+	binds, rest := bindings(body, name)
+	for _, b := range binds {
+		i, isIndex := tupleIndex(b.field)
+		if !isIndex || i > size || elements[i-1].kind == valuePattern || elements[i-1].name != "" {
+			return pattern{}, nil, false
+		}
+		elements[i-1].name = b.name
+	}
+	return pattern{kind: tuplePattern, elements: elements}, rest, true
+}
+
+// foldOf recognizes the expansion of `FOLD<N>(list, acc, func)` macro.
+func foldOf(n *ast.AssignmentNode) (foldExpr, bool) {
+	if validIdentifier(n.Name) {
+		return foldExpr{}, false
+	}
+	size, ok := n.Block.(*ast.AssignmentNode)
+	if !ok {
+		return foldExpr{}, false
+	}
+	if c, isSize := isNative(size.Expression, "400", 1); !isSize || !isReference(c.Arguments[0], n.Name) {
+		return foldExpr{}, false
+	}
+	acc, ok := size.Block.(*ast.AssignmentNode)
+	if !ok {
+		return foldExpr{}, false
+	}
+	step, ok := acc.Block.(*ast.FunctionDeclarationNode)
+	if !ok || len(step.Arguments) != 2 {
+		return foldExpr{}, false
+	}
+	a, i := step.Arguments[0], step.Arguments[1]
+	fn, ok := foldStep(step.Body, a, i, size.Name, func(next ast.Node) (string, bool) {
+		c, isCall := next.(*ast.FunctionCallNode)
+		if !isCall || len(c.Arguments) != 2 || !isReference(c.Arguments[0], a) {
+			return "", false
+		}
+		if _, isUserFunc := c.Function.(ast.UserFunction); !isUserFunc {
+			return "", false
+		}
+		get, isGet := isNative(c.Arguments[1], "401", 2)
+		if !isGet || !isReference(get.Arguments[0], n.Name) || !isReference(get.Arguments[1], i) {
+			return "", false
+		}
+		return c.Function.Name(), true
+	})
+	if !ok {
+		return foldExpr{}, false
+	}
+	last, ok := step.Block.(*ast.FunctionDeclarationNode)
+	if !ok || len(last.Arguments) != 2 {
+		return foldExpr{}, false
+	}
+	var limit int64
+	_, ok = foldStep(last.Body, last.Arguments[0], last.Arguments[1], size.Name, func(next ast.Node) (string, bool) {
+		var err error
+		isLimit := isThrow(next, func(s string) bool {
+			if !strings.HasPrefix(s, foldErrorPrefix) {
+				return false
+			}
+			limit, err = strconv.ParseInt(strings.TrimPrefix(s, foldErrorPrefix), 10, 64)
+			return err == nil
+		})
+		return "", isLimit
+	})
+	if !ok || limit < 1 {
+		return foldExpr{}, false
+	}
+	call, ok := isUser(last.Block, last.Name, 2)
+	if !ok || !isLong(call.Arguments[1], limit) {
+		return foldExpr{}, false
+	}
+	cur := call.Arguments[0]
+	for k := limit - 1; k >= 0; k-- {
+		c, isStep := isUser(cur, step.Name, 2)
+		if !isStep || !isLong(c.Arguments[1], k) {
+			return foldExpr{}, false
+		}
+		cur = c.Arguments[0]
+	}
+	if !isReference(cur, acc.Name) {
+		return foldExpr{}, false
+	}
+	return foldExpr{limit: limit, list: n.Expression, acc: acc.Expression, fn: fn}, true
+}
+
+// foldStep checks the body of FOLD step function `if (i >= size) then a else <next>`.
+func foldStep(body ast.Node, a, i, size string, next func(ast.Node) (string, bool)) (string, bool) {
+	c, ok := body.(*ast.ConditionalNode)
+	if !ok || !isReference(c.TrueExpression, a) {
+		return "", false
+	}
+	ge, ok := isNative(c.Condition, "103", 2)
+	if !ok || !isReference(ge.Arguments[0], i) || !isReference(ge.Arguments[1], size) {
+		return "", false
+	}
+	return next(c.FalseExpression)
+}
+
+func isLong(n ast.Node, v int64) bool {
+	l, ok := n.(*ast.LongNode)
+	return ok && l.Value == v
+}
+
+// castOf recognizes the expansion of `x.as[T]` and `x.exactAs[T]`.
+func castOf(n *ast.AssignmentNode) (castExpr, bool) {
+	if validIdentifier(n.Name) {
+		return castExpr{}, false
+	}
+	c, ok := n.Block.(*ast.ConditionalNode)
+	if !ok || !isReference(c.TrueExpression, n.Name) {
+		return castExpr{}, false
+	}
+	t, ok := instanceOf(c.Condition, reference(n.Name))
+	if !ok {
+		return castExpr{}, false
+	}
+	switch {
+	case isReference(c.FalseExpression, "unit"):
+		return castExpr{expr: n.Expression, typ: t}, true
+	case isThrowOfType(c.FalseExpression, n.Name):
+		return castExpr{expr: n.Expression, typ: t, exact: true}, true
+	case isThrowCall(c.FalseExpression) && !references(c.FalseExpression, n.Name):
+		return castExpr{expr: n.Expression, typ: t, exact: true}, true
+	default:
+		return castExpr{}, false
+	}
+}
+
+func isThrowCall(n ast.Node) bool {
+	_, ok := isNative(n, "2", 1)
+	return ok
+}
+
+// isThrowOfType checks the error of `exactAs` that includes the type of the value.
+func isThrowOfType(n ast.Node, name string) bool {
+	c, ok := isNative(n, "2", 1)
+	if !ok {
+		return false
+	}
+	concat, ok := isNative(c.Arguments[0], "300", 2)
+	if !ok {
+		return false
+	}
+	getType, ok := isNative(concat.Arguments[0], "3", 1)
+	return ok && isReference(getType.Arguments[0], name) && !references(concat.Arguments[1], name)
+}
+
+// strictOf returns the rest of the block if the declaration is `strict`.
+func strictOf(n *ast.AssignmentNode) (ast.Node, bool) {
+	c, ok := n.Block.(*ast.ConditionalNode)
+	if !ok {
+		return nil, false
+	}
+	eq, ok := isNative(c.Condition, "0", 2)
+	if !ok || !isReference(eq.Arguments[0], n.Name) || !isReference(eq.Arguments[1], n.Name) {
+		return nil, false
+	}
+	if !isThrow(c.FalseExpression, func(s string) bool { return s == strictErrorMessage }) {
+		return nil, false
+	}
+	return c.TrueExpression, true
+}
+
+// tupleDeclaration recognizes the declaration `let (a, b) = t`, it returns the names of variables and the rest
+// of the block.
+func tupleDeclaration(name string, n ast.Node) ([]string, ast.Node, bool) {
+	if validIdentifier(name) {
+		return nil, nil, false
+	}
+	binds, rest := bindings(n, name)
+	if len(binds) == 0 || references(rest, name) {
+		return nil, nil, false
+	}
+	names := make([]string, len(binds))
+	for _, b := range binds {
+		i, ok := tupleIndex(b.field)
+		if !ok || i > len(binds) || names[i-1] != "" {
+			return nil, nil, false
+		}
+		names[i-1] = b.name
+	}
+	return names, rest, true
+}
+
+// references reports whether the variable is used in the expression.
+func references(n ast.Node, name string) bool {
+	found := false
+	walk(n, func(n ast.Node) {
+		if found {
+			return
+		}
+		if r, ok := n.(*ast.ReferenceNode); ok && r.Name == name {
+			found = true
+		}
+	})
+	return found
+}
@@ -0,0 +1,374 @@
+package decompiler
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler/stdlib"
+)
+
+var unitType = stdlib.SimpleType{Type: "Unit"}
+
+// operatorResults are the types of results of the functions that are compiled from operators.
+var operatorResults = map[string]stdlib.Type{
+	"0": stdlib.BooleanType, "1": stdlib.BooleanType, "3": stdlib.StringType,
+	"100": stdlib.IntType, "101": stdlib.IntType, "102": stdlib.BooleanType, "103": stdlib.BooleanType,
+	"104": stdlib.IntType, "105": stdlib.IntType, "106": stdlib.IntType,
+	"203": stdlib.ByteVectorType,
+	"300": stdlib.StringType,
+	"311": stdlib.BigIntType, "312": stdlib.BigIntType, "313": stdlib.BigIntType, "314": stdlib.BigIntType,
+	"315": stdlib.BigIntType, "318": stdlib.BigIntType, "319": stdlib.BooleanType, "320": stdlib.BooleanType,
+	"1350": stdlib.IntType,
+}
+
+// maxPasses limits the number of passes of the analysis, every pass refines the types of function arguments
+// with the types of values the functions are called with on the previous pass.
+const maxPasses = 4
+
+// analyze collects the types of variables and functions of the script in the order of their declarations.
+// The types are approximate, they are used only to infer the types of function arguments.
+func (d *decompiler) analyze() {
+	for range maxPasses {
+		d.types = make(map[*ast.FunctionDeclarationNode][]string)
+		d.returns = make(map[*ast.FunctionDeclarationNode]stdlib.Type)
+		d.calls = make(map[*ast.FunctionDeclarationNode][]stdlib.Type)
+		d.vars = make(map[string]stdlib.Type)
+		d.scope = make(map[string]*ast.FunctionDeclarationNode)
+		d.pass()
+		hints := make(map[*ast.FunctionDeclarationNode][]string, len(d.calls))
+		for f, args := range d.calls {
+			types := make([]string, len(args))
+			for i, a := range args {
+				types[i] = typeString(a)
+			}
+			hints[f] = types
+		}
+		if maps.EqualFunc(hints, d.hints, slices.Equal) {
+			return
+		}
+		d.hints = hints
+	}
+}
+
+func (d *decompiler) pass() {
+	if !d.tree.IsDApp() {
+		d.typeOf(d.tree.Verifier)
+		return
+	}
+	// The global declarations stay in the scope of the callable functions and the verifier.
+	for n := d.decls; n != nil; n = blockOf(n) {
+		switch nn := n.(type) {
+		case *ast.AssignmentNode:
+			d.declareVariable(nn)
+		case *ast.FunctionDeclarationNode:
+			d.scope[nn.Name] = nn
+			d.declareFunction(nn)
+		}
+	}
+	for i, n := range d.tree.Functions {
+		f, ok := n.(*ast.FunctionDeclarationNode)
+		if !ok {
+			continue
+		}
+		d.vars[f.InvocationParameter] = stdlib.SimpleType{Type: "Invocation"}
+		if types := d.callableTypes(i, f); types != nil {
+			for j, a := range f.Arguments {
+				d.vars[a] = stdlib.ParseType(types[j])
+			}
+		}
+		d.typeOf(f.Body)
+	}
+	if f, ok := d.tree.Verifier.(*ast.FunctionDeclarationNode); ok {
+		d.vars[f.InvocationParameter] = nil
+		d.typeOf(f.Body)
+	}
+}
+
+// called records the types of values the function is called with. The unknown types are skipped.
+func (d *decompiler) called(f *ast.FunctionDeclarationNode, args []stdlib.Type) {
+	calls, ok := d.calls[f]
+	if !ok {
+		calls = make([]stdlib.Type, len(args))
+		d.calls[f] = calls
+	}
+	for i, a := range args {
+		if i >= len(calls) || !isKnown(typeString(a)) {
+			continue
+		}
+		if calls[i] == nil {
+			calls[i] = a
+		} else {
+			calls[i] = join(calls[i], a)
+		}
+	}
+}
+
+// functionTypes returns the inferred types of the function arguments.
+func (d *decompiler) functionTypes(f *ast.FunctionDeclarationNode) []string {
+	if types, ok := d.types[f]; ok {
+		return types
+	}
+	for _, a := range f.Arguments {
+		d.vars[a] = nil
+	}
+	types := d.inferArgTypes(f)
+	d.types[f] = types
+	return types
+}
+
+// declareFunction infers the types of the function arguments and the type of its result.
+func (d *decompiler) declareFunction(f *ast.FunctionDeclarationNode) {
+	types := d.functionTypes(f)
+	for i, a := range f.Arguments {
+		d.vars[a] = stdlib.ParseType(types[i])
+	}
+	d.returns[f] = d.typeOf(f.Body)
+}
+
+func (d *decompiler) declareVariable(a *ast.AssignmentNode) {
+	d.vars[a.Name] = d.typeOf(a.Expression)
+	if f, ok := foldOf(a); ok {
+		// The folding function is called with the accumulator and the elements of the list.
+		if fn, declared := d.scope[f.fn]; declared {
+			var elem stdlib.Type
+			if l, isList := d.typeOf(f.list).(stdlib.ListType); isList {
+				elem = l.Type
+			}
+			d.called(fn, []stdlib.Type{d.typeOf(f.acc), elem})
+		}
+	}
+}
+
+// typeOf returns the type of the expression or nil if the type is unknown.
+func (d *decompiler) typeOf(n ast.Node) stdlib.Type {
+	switch nn := n.(type) {
+	case *ast.LongNode:
+		return stdlib.IntType
+	case *ast.StringNode:
+		return stdlib.StringType
+	case *ast.BytesNode:
+		return stdlib.ByteVectorType
+	case *ast.BooleanNode:
+		return stdlib.BooleanType
+	case *ast.ReferenceNode:
+		if t, ok := d.vars[nn.Name]; ok {
+			return t
+		}
+		return d.lib.vars[nn.Name]
+	case *ast.ConditionalNode:
+		d.typeOf(nn.Condition)
+		return join(d.typeOf(nn.TrueExpression), d.typeOf(nn.FalseExpression))
+	case *ast.AssignmentNode:
+		if m, ok := matchOf(nn); ok {
+			return d.matchType(m)
+		}
+		if c, ok := castOf(nn); ok {
+			d.typeOf(c.expr)
+			if c.exact {
+				return stdlib.ParseType(c.typ)
+			}
+			return stdlib.JoinTypes(stdlib.ParseType(c.typ), unitType)
+		}
+		d.declareVariable(nn)
+		return d.typeOf(nn.Block)
+	case *ast.FunctionDeclarationNode:
+		prev, shadows := d.scope[nn.Name]
+		d.scope[nn.Name] = nn
+		d.declareFunction(nn)
+		t := d.typeOf(nn.Block)
+		if shadows {
+			d.scope[nn.Name] = prev
+		} else {
+			delete(d.scope, nn.Name)
+		}
+		return t
+	case *ast.PropertyNode:
+		return d.propertyType(d.typeOf(nn.Object), nn.Name)
+	case *ast.FunctionCallNode:
+		args := make([]stdlib.Type, len(nn.Arguments))
+		for i, a := range nn.Arguments {
+			args[i] = d.typeOf(a)
+		}
+		return d.callType(nn, args)
+	default:
+		return nil
+	}
+}
+
+// matchType returns the type of the match expression, the bindings of typed patterns get the types of patterns.
+func (d *decompiler) matchType(m matchExpr) stdlib.Type {
+	d.typeOf(m.expr)
+	var r stdlib.Type = stdlib.ThrowType
+	for _, c := range m.cases {
+		d.bind(c.pattern)
+		r = join(r, d.typeOf(c.body))
+	}
+	return r
+}
+
+func (d *decompiler) bind(p pattern) {
+	if p.name != "" {
+		var t stdlib.Type
+		if p.kind == typedPattern {
+			t = stdlib.ParseType(strings.Join(p.types, "|"))
+		}
+		d.vars[p.name] = t
+	}
+	for _, e := range p.elements {
+		d.bind(e)
+	}
+}
+
+func join(a, b stdlib.Type) stdlib.Type {
+	switch {
+	case a == nil || b == nil:
+		return nil
+	case a.Equal(stdlib.ThrowType):
+		return b
+	case b.Equal(stdlib.ThrowType):
+		return a
+	default:
+		return stdlib.JoinTypes(a, b)
+	}
+}
+
+func (d *decompiler) propertyType(t stdlib.Type, field string) stdlib.Type {
+	switch tt := t.(type) {
+	case stdlib.TupleType:
+		if !isTupleField(field) {
+			return nil
+		}
+		k, err := strconv.Atoi(field[1:])
+		if err != nil || k < 1 || k > len(tt.Types) {
+			return nil
+		}
+		return tt.Types[k-1]
+	case stdlib.SimpleType:
+		info, ok := d.lib.objects.Obj[tt.Type]
+		if !ok {
+			return nil
+		}
+		for _, f := range info.Fields {
+			if f.Name == field {
+				return f.Type
+			}
+		}
+	case stdlib.UnionType:
+		var r stdlib.Type = stdlib.ThrowType
+		for _, m := range tt.Types {
+			r = join(r, d.propertyType(m, field))
+		}
+		return r
+	}
+	return nil
+}
+
+func (d *decompiler) callType(c *ast.FunctionCallNode, args []stdlib.Type) stdlib.Type {
+	id := c.Function.Name()
+	switch c.Function.(type) {
+	case ast.NativeFunction:
+		if t, ok := operatorResults[id]; ok {
+			return t
+		}
+		switch id {
+		case "2":
+			return stdlib.ThrowType
+		case "401":
+			if len(args) != 2 {
+				return nil
+			}
+			if l, ok := args[0].(stdlib.ListType); ok {
+				return l.Type
+			}
+			return nil
+		case "1100", "1101", "1102":
+			if len(args) != 2 || args[0] == nil || args[1] == nil {
+				return nil
+			}
+			l := stdlib.ListType{}
+			for i, a := range args {
+				if al, ok := a.(stdlib.ListType); ok && (id == "1102" || (id == "1100") == (i == 1)) {
+					if al.Type != nil {
+						l.AppendType(al.Type)
+					}
+				} else {
+					l.AppendType(a)
+				}
+			}
+			return l
+		}
+		if n, err := strconv.Atoi(id); err == nil && n >= firstTupleConstructor &&
+			n < firstTupleConstructor+stdlib.MaxTupleLength {
+			return stdlib.TupleType{Types: args}
+		}
+	case ast.UserFunction:
+		switch id {
+		case "!", "!=":
+			return stdlib.BooleanType
+		case "-":
+			return stdlib.IntType
+		}
+		if f, ok := d.scope[id]; ok {
+			d.called(f, args)
+			return d.returns[f]
+		}
+		if info, ok := d.lib.objects.Obj[id]; ok && !info.NotConstruct {
+			return stdlib.SimpleType{Type: id}
+		}
+	}
+	f, ok := d.lib.function(c.Function)
+	if !ok {
+		return nil
+	}
+	for _, a := range args {
+		if a == nil {
+			return f.params.ReturnType
+		}
+	}
+	// The result types of generic functions depend on the types of their arguments.
+	if sig, found := d.lib.sigs.Get(f.name, args); found && sig.ID.Name() == id {
+		return sig.ReturnType
+	}
+	return f.params.ReturnType
+}
+
+// withoutUnit returns the type without Unit.
+func withoutUnit(t stdlib.Type) stdlib.Type {
+	u, ok := t.(stdlib.UnionType)
+	if !ok {
+		if t != nil && t.Equal(unitType) {
+			return nil
+		}
+		return t
+	}
+	r := stdlib.UnionType{Types: []stdlib.Type{}}
+	for _, m := range u.Types {
+		if !m.Equal(unitType) {
+			r.AppendType(m)
+		}
+	}
+	if len(r.Types) == 0 {
+		return nil
+	}
+	return r.Simplify()
+}
+
+// typeString returns the source representation of the type or an empty string if the type is unknown.
+func typeString(t stdlib.Type) string {
+	switch tt := t.(type) {
+	case nil:
+		return ""
+	case stdlib.UnionType:
+		if len(tt.Types) == 0 {
+			return ""
+		}
+	}
+	if t.Equal(stdlib.ThrowType) {
+		return ""
+	}
+	// The type of elements of the empty list is unknown.
+	return strings.ReplaceAll(t.String(), "List[]", anyListType)
+}
@@ -0,0 +1,553 @@
+package decompiler
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler/stdlib"
+	"github.com/wavesplatform/gowaves/pkg/ride/meta"
+)
+
+const (
+	anyType     = "Any"
+	anyListType = "List[Any]"
+	booleanType = "Boolean"
+)
+
+// operandTypes are the types of arguments of the functions that are compiled from operators.
+var operandTypes = map[string]string{
+	"100": "Int", "101": "Int", "102": "Int", "103": "Int", "104": "Int", "105": "Int", "106": "Int",
+	"203": "ByteVector",
+	"300": "String",
+	"311": "BigInt", "312": "BigInt", "313": "BigInt", "314": "BigInt", "315": "BigInt", "318": "BigInt",
+	"319": "BigInt", "320": "BigInt",
+	"1102": anyListType,
+}
+
+// library is the standard library of the version of the script.
+type library struct {
+	funcs   map[string]namedFunction // functions by ID
+	sigs    stdlib.FunctionsSignatures
+	objects stdlib.ObjectsSignatures
+	vars    map[string]stdlib.Type
+}
+
+type namedFunction struct {
+	name   string
+	params stdlib.FunctionParams
+}
+
+func newLibrary(v ast.LibraryVersion) library {
+	sigs := stdlib.FuncsByVersion()[v]
+	funcs := make(map[string]namedFunction)
+	for name, overloads := range sigs.Funcs {
+		for _, o := range overloads {
+			funcs[o.ID.Name()] = namedFunction{name: name, params: o}
+		}
+	}
+	vars := make(map[string]stdlib.Type)
+	for i := range int(v) {
+		for _, vr := range stdlib.Vars().Vars[i].Append {
+			vars[vr.Name] = vr.Type
+		}
+		for _, name := range stdlib.Vars().Vars[i].Remove {
+			delete(vars, name)
+		}
+	}
+	return library{funcs: funcs, sigs: sigs, objects: stdlib.ObjectsByVersion()[v], vars: vars}
+}
+
+func (l library) function(id ast.Function) (namedFunction, bool) {
+	f, ok := l.funcs[id.Name()]
+	return f, ok
+}
+
+// returnsBoolean reports whether the function from the library returns Boolean.
+func (l library) returnsBoolean(id ast.Function) bool {
+	f, ok := l.function(id)
+	return ok && f.params.ReturnType != nil && f.params.ReturnType.String() == booleanType
+}
+
+// objectsWithFields returns the names of the objects that have all the given fields.
+func (l library) objectsWithFields(fields []string) []string {
+	var r []string
+	for name, info := range l.objects.Obj {
+		ok := true
+		for _, f := range fields {
+			if !slices.ContainsFunc(info.Fields, func(of stdlib.ObjectField) bool { return of.Name == f }) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			r = append(r, name)
+		}
+	}
+	slices.Sort(r)
+	return r
+}
+
+// metaTypeString returns the source representation of the callable argument type from the script meta.
+func metaTypeString(t meta.Type) string {
+	switch tt := t.(type) {
+	case meta.SimpleType:
+		switch tt {
+		case meta.Int:
+			return "Int"
+		case meta.Bytes:
+			return "ByteVector"
+		case meta.Boolean:
+			return booleanType
+		case meta.String:
+			return "String"
+		}
+	case meta.UnionType:
+		types := make([]string, len(tt))
+		for i, st := range tt {
+			types[i] = metaTypeString(st)
+		}
+		return strings.Join(types, "|")
+	case meta.ListType:
+		return "List[" + metaTypeString(tt.Inner) + "]"
+	}
+	return anyType
+}
+
+// argTypesInferrer collects the types of the function arguments from their usages in the function body.
+// The types of arguments are lost during compilation, so the types are taken from the signatures of
+// the functions the arguments are passed to, from the usage in conditions and from the accessed fields.
+// The variables that are declared as the arguments or their tuple elements are tracked as well.
+type argTypesInferrer struct {
+	d       *decompiler
+	aliases map[string]*usage
+	narrow  map[string]struct{} // bindings of typed patterns that narrow the type of the matched value
+}
+
+// usage is the collected usage of the argument or the element of the tuple argument.
+type usage struct {
+	types    []string
+	weak     []string // types from comparisons and list operations
+	matched  []string // types of patterns the value is matched against
+	fields   []string
+	elements map[int]*usage
+}
+
+func (u *usage) element(k int) *usage {
+	if u.elements == nil {
+		u.elements = make(map[int]*usage)
+	}
+	e, ok := u.elements[k]
+	if !ok {
+		e = &usage{}
+		u.elements[k] = e
+	}
+	return e
+}
+
+func (d *decompiler) inferArgTypes(f *ast.FunctionDeclarationNode) []string {
+	inf := &argTypesInferrer{
+		d:       d,
+		aliases: make(map[string]*usage, len(f.Arguments)),
+		narrow:  make(map[string]struct{}),
+	}
+	args := make([]*usage, len(f.Arguments))
+	for i, a := range f.Arguments {
+		args[i] = &usage{}
+		inf.aliases[a] = args[i]
+	}
+	scope := maps.Clone(d.scope)
+	walk(f.Body, inf.visit)
+	d.scope = scope
+	hints := d.hints[f]
+	r := make([]string, len(f.Arguments))
+	for i, u := range args {
+		r[i] = inf.result(u)
+		// The type of the values the function is called with is taken if the usage gives no precise type.
+		if !isKnown(r[i]) && i < len(hints) && isKnown(hints[i]) {
+			r[i] = hints[i]
+		}
+		// The comparisons and list operations with the values of known types are the least reliable source.
+		if w := narrowest(u.weak); !isKnown(r[i]) && w != "" {
+			r[i] = w
+		}
+	}
+	return r
+}
+
+func hasDefault(m matchExpr) bool {
+	for _, c := range m.cases {
+		if c.pattern.kind == placeholderPattern {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnown reports whether the type is known and has no `Any` in it.
+func isKnown(t string) bool {
+	return t != "" && !strings.Contains(t, anyType)
+}
+
+// usageOf returns the usage of the argument or its tuple element the node refers to.
+func (inf *argTypesInferrer) usageOf(n ast.Node) (*usage, bool) {
+	switch nn := n.(type) {
+	case *ast.ReferenceNode:
+		u, ok := inf.aliases[nn.Name]
+		return u, ok
+	case *ast.PropertyNode:
+		if !isTupleField(nn.Name) {
+			return nil, false
+		}
+		u, ok := inf.usageOf(nn.Object)
+		if !ok {
+			return nil, false
+		}
+		k, err := strconv.Atoi(nn.Name[1:])
+		if err != nil {
+			return nil, false
+		}
+		return u.element(k), true
+	default:
+		return nil, false
+	}
+}
+
+func (inf *argTypesInferrer) add(n ast.Node, t string) {
+	if u, ok := inf.usageOf(n); ok && t != "" {
+		u.types = append(u.types, t)
+	}
+}
+
+func (inf *argTypesInferrer) visit(n ast.Node) {
+	switch nn := n.(type) {
+	case *ast.FunctionDeclarationNode:
+		// The types of arguments of the nested function are required to infer the types of the values passed to it.
+		inf.d.scope[nn.Name] = nn
+		inf.d.declareFunction(nn)
+	case *ast.AssignmentNode:
+		u, ok := inf.usageOf(nn.Expression)
+		if _, narrowed := inf.narrow[nn.Name]; !ok || narrowed {
+			delete(inf.aliases, nn.Name)
+		} else {
+			inf.aliases[nn.Name] = u
+		}
+		// The value of the match without the default case has the union of types of typed patterns.
+		if m, isMatch := matchOf(nn); isMatch && ok && !hasDefault(m) {
+			for _, c := range m.cases {
+				if c.pattern.kind == typedPattern {
+					u.matched = append(u.matched, c.pattern.types...)
+					if c.pattern.name != "" {
+						inf.narrow[c.pattern.name] = struct{}{}
+					}
+				}
+			}
+		}
+		inf.d.declareVariable(nn)
+	case *ast.ConditionalNode:
+		inf.add(nn.Condition, booleanType)
+	case *ast.PropertyNode:
+		if u, ok := inf.usageOf(nn.Object); ok && !isTupleField(nn.Name) {
+			u.fields = append(u.fields, nn.Name)
+		}
+	case *ast.FunctionCallNode:
+		for k, a := range nn.Arguments {
+			if u, ok := inf.usageOf(a); ok {
+				if isEquality(nn) {
+					if t := inf.d.argType(nn, k); t != "" {
+						u.weak = append(u.weak, t)
+					}
+					continue
+				}
+				if t := inf.d.listOperandType(nn, k); isKnown(t) {
+					u.weak = append(u.weak, t)
+				}
+				inf.add(a, inf.d.argType(nn, k))
+				continue
+			}
+			c, ok := a.(*ast.FunctionCallNode)
+			if !ok {
+				continue
+			}
+			t := inf.d.argType(nn, k)
+			// The elements of the list literal get the type of the list elements.
+			if items, isList := listLiteral(c); isList {
+				if strings.HasPrefix(t, "List[") && strings.HasSuffix(t, "]") {
+					for _, item := range items {
+						inf.add(item, t[len("List["):len(t)-1])
+					}
+				}
+				continue
+			}
+			// The list which element is passed to the function is the list of elements of the argument type.
+			if _, isGet := isNative(c, "401", 2); isGet && t != "" && t != anyType {
+				inf.add(c.Arguments[0], "List["+t+"]")
+			}
+		}
+	}
+}
+
+func (inf *argTypesInferrer) result(u *usage) string {
+	// The narrowest of the types is taken, so the argument can be passed to all functions it's used with.
+	r := narrowest(u.types)
+	// The matched value has the union of types of patterns unless it's used as the value of wider type.
+	if len(u.matched) > 0 {
+		m := stdlib.JoinTypes(stdlib.ParseType(strings.Join(u.matched, "|"))).String()
+		if r == "" || !subtype(m, r) {
+			return m
+		}
+	}
+	if r != "" {
+		return r
+	}
+	if len(u.elements) > 0 {
+		size := 0
+		for k := range u.elements {
+			size = max(size, k)
+		}
+		if size >= 2 && size <= stdlib.MaxTupleLength {
+			elements := make([]string, size)
+			for k := range elements {
+				elements[k] = anyType
+				if e, ok := u.elements[k+1]; ok {
+					elements[k] = inf.result(e)
+				}
+			}
+			return "(" + strings.Join(elements, ", ") + ")"
+		}
+	}
+	if len(u.fields) > 0 {
+		if objects := inf.d.lib.objectsWithFields(u.fields); len(objects) > 0 {
+			return strings.Join(objects, "|")
+		}
+	}
+	if slices.Contains(u.types, anyListType) {
+		return anyListType
+	}
+	return anyType
+}
+
+// argType returns the type of the k-th argument of the function call, it returns an empty string if
+// the type is unknown.
+func (d *decompiler) argType(n *ast.FunctionCallNode, k int) string {
+	id := n.Function.Name()
+	switch n.Function.(type) {
+	case ast.NativeFunction:
+		if t, ok := operandTypes[id]; ok {
+			return t
+		}
+		switch id {
+		case "0":
+			if len(n.Arguments) == 2 {
+				return typeString(d.typeOf(n.Arguments[1-k]))
+			}
+			return ""
+		case "401":
+			if k == 0 {
+				return anyListType
+			}
+			return "Int"
+		case "1100":
+			if k == 1 {
+				return anyListType
+			}
+			return ""
+		case "1101":
+			if k == 0 {
+				return anyListType
+			}
+			return ""
+		}
+	case ast.UserFunction:
+		switch id {
+		case "!":
+			return booleanType
+		case "-":
+			return "Int"
+		case "!=":
+			if len(n.Arguments) == 2 {
+				return typeString(d.typeOf(n.Arguments[1-k]))
+			}
+			return ""
+		case "valueOrElse":
+			// The default value has the type of the optional value.
+			if k == 1 && len(n.Arguments) == 2 {
+				return typeString(withoutUnit(d.typeOf(n.Arguments[0])))
+			}
+		}
+		if f, ok := d.scope[id]; ok {
+			if types := d.types[f]; k < len(types) {
+				return types[k]
+			}
+			return ""
+		}
+		if info, ok := d.lib.objects.Obj[id]; ok && !info.NotConstruct && k < len(info.Fields) {
+			return info.Fields[k].Type.String()
+		}
+	}
+	if f, ok := d.lib.function(n.Function); ok && k < len(f.params.Arguments) {
+		return f.params.Arguments[k].String()
+	}
+	return ""
+}
+
+// listOperandType returns the type of the list operand of the list operation that is the list of the same
+// elements as the other operand.
+func (d *decompiler) listOperandType(c *ast.FunctionCallNode, k int) string {
+	if _, ok := c.Function.(ast.NativeFunction); !ok || len(c.Arguments) != 2 {
+		return ""
+	}
+	switch id := c.Function.Name(); {
+	case id == "1102":
+		if _, ok := d.typeOf(c.Arguments[1-k]).(stdlib.ListType); ok {
+			return typeString(d.typeOf(c.Arguments[1-k]))
+		}
+	case id == "1100" && k == 1, id == "1101" && k == 0:
+		if t := typeString(d.typeOf(c.Arguments[1-k])); t != "" {
+			return "List[" + t + "]"
+		}
+	}
+	return ""
+}
+
+// narrowest returns the narrowest of the given types skipping `Any` and `List[Any]`.
+func narrowest(types []string) string {
+	r := ""
+	for _, t := range types {
+		if t == anyType || t == anyListType {
+			continue
+		}
+		if r == "" || subtype(t, r) {
+			r = t
+		}
+	}
+	return r
+}
+
+// isEquality reports whether the function call is the comparison of values.
+func isEquality(c *ast.FunctionCallNode) bool {
+	switch c.Function.(type) {
+	case ast.NativeFunction:
+		return c.Function.Name() == "0"
+	case ast.UserFunction:
+		return c.Function.Name() == "!="
+	default:
+		return false
+	}
+}
+
+// subtype reports whether all types of the union a are the members of the union b.
+func subtype(a, b string) bool {
+	members := unionMembers(b)
+	for _, m := range unionMembers(a) {
+		if !slices.Contains(members, m) {
+			return false
+		}
+	}
+	return true
+}
+
+// unionMembers splits the union type given as string into its members.
+func unionMembers(t string) []string {
+	var r []string
+	depth, start := 0, 0
+	for i, c := range t {
+		switch c {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case '|':
+			if depth == 0 {
+				r = append(r, t[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(r, t[start:])
+}
+
+func isTupleField(name string) bool {
+	return len(name) > 1 && name[0] == '_' && name[1] >= '0' && name[1] <= '9'
+}
+
+// tupleSize returns the number of elements of the tuple type given as string or zero if the type is not a tuple.
+func tupleSize(t string) int {
+	if !strings.HasPrefix(t, "(") || !strings.HasSuffix(t, ")") {
+		return 0
+	}
+	n, depth := 1, 0
+	for _, c := range t[1 : len(t)-1] {
+		switch c {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// validTypeName reports whether the type name of the type check is in the form the compiler produces it:
+// the union of simple types, lists and tuples, like `Int|List[String]|(Int, Unit)`. The type names of
+// the scripts are not validated on parsing, other names can't be rendered as the types of the source code.
+func validTypeName(t string) bool {
+	rest, ok := unionTypeName(t)
+	return ok && rest == ""
+}
+
+// unionTypeName skips the union of types at the beginning of the string and returns the rest of it.
+func unionTypeName(s string) (string, bool) {
+	for {
+		rest, ok := memberTypeName(s)
+		if !ok {
+			return "", false
+		}
+		if !strings.HasPrefix(rest, "|") {
+			return rest, true
+		}
+		s = rest[1:]
+	}
+}
+
+// memberTypeName skips the member of the union at the beginning of the string and returns the rest of it.
+func memberTypeName(s string) (string, bool) {
+	switch {
+	case strings.HasPrefix(s, "List["):
+		rest := s[len("List["):]
+		if !strings.HasPrefix(rest, "]") {
+			var ok bool
+			if rest, ok = unionTypeName(rest); !ok || !strings.HasPrefix(rest, "]") {
+				return "", false
+			}
+		}
+		return rest[1:], true
+	case strings.HasPrefix(s, "("):
+		rest := s[1:]
+		for n := 1; ; n++ {
+			var ok bool
+			if rest, ok = unionTypeName(rest); !ok {
+				return "", false
+			}
+			if strings.HasPrefix(rest, ")") {
+				return rest[1:], n >= 2
+			}
+			if !strings.HasPrefix(rest, ", ") {
+				return "", false
+			}
+			rest = rest[len(", "):]
+		}
+	default:
+		i := 0
+		for i < len(s) && (isLetter(s[i]) || i > 0 && isDigit(s[i])) {
+			i++
+		}
+		return s[i:], i > 0
+	}
+}
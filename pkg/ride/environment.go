@@ -1295,6 +1295,7 @@ type EvaluationEnvironment struct {
 	isProtobufTransaction              bool
 	mds                                int
 	cc                                 complexityCalculator
+	trace                              *Trace // trace of the evaluation, nil if the evaluation is not traced
 }
 
 func bytesSizeCheckV1V2(l int) bool {
@@ -1525,6 +1526,11 @@ func (e *EvaluationEnvironment) SetEthereumInvoke(tx *proto.EthereumTransaction,
 	return nil
 }
 
+// SetReadOnlyInvocation sets invocation for read-only function calls which are not backed by any transaction.
+func (e *EvaluationEnvironment) SetReadOnlyInvocation(caller proto.WavesAddress, v ast.LibraryVersion) {
+	e.inv = readOnlyInvocationToObject(v, caller)
+}
+
 func (e *EvaluationEnvironment) SetLimit(limit uint32) {
 	e.cc.setLimit(limit)
 }
@@ -1630,3 +1636,11 @@ func (e *EvaluationEnvironment) complexityCalculator() complexityCalculator {
 func (e *EvaluationEnvironment) setComplexityCalculator(cc complexityCalculator) {
 	e.cc = cc
 }
+
+func (e *EvaluationEnvironment) evaluationTrace() *Trace {
+	return e.trace
+}
+
+func (e *EvaluationEnvironment) setEvaluationTrace(t *Trace) {
+	e.trace = t
+}
@@ -13,6 +13,10 @@ func invokeFunctionFromDApp(env environment, tree *ast.Tree, fnName rideString,
 	if err != nil {
 		return nil, EvaluationFailure.Wrapf(err, "failed to call function '%s'", fnName)
 	}
+	if te, ok := env.(tracingEnvironment); ok && te.evaluationTrace() != nil {
+		e.trace = te.evaluationTrace()
+		e.traceArguments()
+	}
 	res, err := e.evaluate()
 	if err != nil {
 		// Evaluation failed we have to add spent execution complexity to an error
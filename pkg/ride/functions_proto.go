@@ -20,6 +20,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	c2 "github.com/wavesplatform/gowaves/pkg/ride/crypto"
+	"github.com/wavesplatform/gowaves/pkg/types"
 	"github.com/wavesplatform/gowaves/pkg/util/common"
 )
 
@@ -954,6 +955,10 @@ func transferByID(env environment, args ...rideType) (rideType, error) {
 		if env.state().IsNotFound(err) {
 			return rideUnit{}, nil
 		}
+		// Pruning keeps bodies of transfers, so the pruned transaction is not a transfer.
+		if errors.Is(err, types.ErrTransactionPruned) {
+			return rideUnit{}, nil
+		}
 		return nil, errors.Wrap(err, "transferByID")
 	}
 	switch t := tx.GetTypeInfo().Type; t {
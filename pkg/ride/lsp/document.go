@@ -0,0 +1,120 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+)
+
+const (
+	fileScheme = "file"
+	// defaultLibVersion is the library version the compiler uses for scripts without STDLIB_VERSION directive.
+	defaultLibVersion = ast.LibV6
+)
+
+var libVersionDirective = regexp.MustCompile(`\{-#\s*STDLIB_VERSION\s+(\d+)\s*#-}`)
+
+type document struct {
+	uri   string
+	text  string
+	lines []string
+}
+
+func newDocument(uri, text string) *document {
+	return &document{uri: uri, text: text, lines: strings.Split(text, "\n")}
+}
+
+// libVersion returns the library version of the document set by the STDLIB_VERSION directive.
+func (d *document) libVersion() ast.LibraryVersion {
+	m := libVersionDirective.FindStringSubmatch(d.text)
+	if m == nil {
+		return defaultLibVersion
+	}
+	v, err := strconv.Atoi(m[1])
+	if err != nil {
+		return defaultLibVersion
+	}
+	lv, err := ast.NewLibraryVersion(byte(v))
+	if err != nil {
+		return defaultLibVersion
+	}
+	return lv
+}
+
+// path returns the local path of the document or an empty string if the document is not a local file.
+func (d *document) path() string {
+	return uriToPath(d.uri)
+}
+
+func isIdentifierChar(c rune) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// word is the identifier at the position in the document.
+type word struct {
+	text     string
+	line     int
+	start    int  // the first character of the identifier
+	end      int  // the character right after the identifier
+	selector bool // the identifier is preceded by a dot
+	receiver string
+}
+
+// wordAt returns the identifier under or right before the position, the identifier is empty
+// if there is none.
+func (d *document) wordAt(p position) word {
+	if p.Line < 0 || p.Line >= len(d.lines) {
+		return word{}
+	}
+	line := []rune(d.lines[p.Line])
+	ch := min(max(p.Character, 0), len(line))
+	start, end := ch, ch
+	for start > 0 && isIdentifierChar(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isIdentifierChar(line[end]) {
+		end++
+	}
+	w := word{text: string(line[start:end]), line: p.Line, start: start, end: end}
+	i := start - 1
+	for i >= 0 && line[i] == ' ' {
+		i--
+	}
+	if i >= 0 && line[i] == '.' {
+		w.selector = true
+		j := i - 1
+		for j >= 0 && line[j] == ' ' {
+			j--
+		}
+		k := j + 1
+		for j >= 0 && isIdentifierChar(line[j]) {
+			j--
+		}
+		w.receiver = string(line[j+1 : k])
+	}
+	return w
+}
+
+func (w word) textRange() textRange {
+	return textRange{
+		Start: position{Line: w.line, Character: w.start},
+		End:   position{Line: w.line, Character: w.end},
+	}
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != fileScheme {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathToURI(path string) string {
+	u := url.URL{Scheme: fileScheme, Path: filepath.ToSlash(path)}
+	return u.String()
+}
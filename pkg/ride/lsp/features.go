@@ -0,0 +1,246 @@
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+)
+
+const markdown = "markdown"
+
+// declaration is a fallback pattern to find the declarations in the code that can't be parsed.
+var declaration = regexp.MustCompile(`\b(let|strict|func)\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+// symbols returns the declarations of the code. The code being edited is often incomplete and can't be parsed,
+// in this case the declarations of variables and functions are searched in the text.
+func symbols(text string) []compiler.Symbol {
+	if s, err := compiler.Symbols(text); err == nil {
+		return s
+	}
+	var r []compiler.Symbol
+	for i, line := range strings.Split(text, "\n") {
+		for _, m := range declaration.FindAllStringSubmatchIndex(line, -1) {
+			kind := compiler.VariableSymbol
+			if line[m[2]:m[3]] == "func" {
+				kind = compiler.FunctionSymbol
+			}
+			begin := len([]rune(line[:m[4]]))
+			end := len([]rune(line[:m[5]]))
+			r = append(r, compiler.Symbol{
+				Name:  line[m[4]:m[5]],
+				Kind:  kind,
+				Begin: compiler.Position{Line: i + 1, Column: begin + 1},
+				End:   compiler.Position{Line: i + 1, Column: end + 1},
+			})
+		}
+	}
+	return r
+}
+
+// newRange converts the positions of the compiler to the range in the document. The end of the range
+// is never before its start.
+func newRange(begin, end compiler.Position) textRange {
+	r := textRange{
+		Start: position{Line: max(begin.Line-1, 0), Character: max(begin.Column-1, 0)},
+		End:   position{Line: max(end.Line-1, 0), Character: max(end.Column-1, 0)},
+	}
+	if r.End.Line < r.Start.Line || (r.End.Line == r.Start.Line && r.End.Character < r.Start.Character) {
+		r.End = r.Start
+	}
+	return r
+}
+
+func symbolRange(s compiler.Symbol) textRange {
+	return newRange(s.Begin, s.End)
+}
+
+// lookup returns the nearest declaration of the name before the position or the first declaration after it.
+func lookup(ss []compiler.Symbol, name string, p position) (compiler.Symbol, bool) {
+	var (
+		found bool
+		r     compiler.Symbol
+	)
+	for _, s := range ss {
+		if s.Name != name {
+			continue
+		}
+		start := symbolRange(s).Start
+		before := start.Line < p.Line || (start.Line == p.Line && start.Character <= p.Character)
+		if !found || before {
+			r, found = s, true
+		}
+		if !before {
+			break
+		}
+	}
+	return r, found
+}
+
+func (s *Server) completion(d *document, p position) []completionItem {
+	w := d.wordAt(p)
+	prefix := w.text
+	if p.Character >= w.start && p.Character-w.start <= len([]rune(w.text)) {
+		prefix = string([]rune(w.text)[:p.Character-w.start])
+	}
+	lib := newLibrary(d.libVersion())
+	var items []completionItem
+	add := func(label string, kind completionItemKind, detail string) {
+		if strings.HasPrefix(label, prefix) {
+			items = append(items, completionItem{Label: label, Kind: kind, Detail: detail})
+		}
+	}
+	if w.selector {
+		for _, f := range lib.fields(lib.vars[w.receiver]) {
+			add(f.Name, kindField, typeString(f.Type))
+		}
+	} else {
+		for _, k := range keywords {
+			add(k, kindKeyword, "")
+		}
+		for name, t := range lib.vars {
+			add(name, kindVariable, typeString(t))
+		}
+		for name, info := range lib.objects {
+			if !info.NotConstruct {
+				add(name, kindStruct, objectSignature(name, info))
+			}
+		}
+		seen := make(map[string]bool)
+		for _, sym := range symbols(d.text) {
+			if seen[sym.Name] {
+				continue
+			}
+			seen[sym.Name] = true
+			kind := kindVariable
+			if sym.Kind == compiler.FunctionSymbol {
+				kind = kindFunction
+			}
+			add(sym.Name, kind, sym.Signature)
+		}
+	}
+	// Functions can be called as methods of their first argument.
+	for name, overloads := range lib.funcs {
+		add(name, kindFunction, signature(name, overloads[0]))
+	}
+	slices.SortFunc(items, func(a, b completionItem) int {
+		return strings.Compare(a.Label, b.Label)
+	})
+	return items
+}
+
+func (s *Server) hover(d *document, p position) *hover {
+	w := d.wordAt(p)
+	if w.text == "" {
+		return nil
+	}
+	lib := newLibrary(d.libVersion())
+	var lines []string
+	if w.selector {
+		for _, f := range lib.fields(lib.vars[w.receiver]) {
+			if f.Name == w.text {
+				lines = append(lines, code(f.Name+": "+typeString(f.Type)))
+				break
+			}
+		}
+	}
+	if sym, ok := lookup(symbols(d.text), w.text, p); ok && !w.selector {
+		switch sym.Kind {
+		case compiler.FunctionSymbol, compiler.ArgumentSymbol:
+			lines = append(lines, code(sym.Signature))
+		default:
+			lines = append(lines, code("let "+sym.Name))
+		}
+	} else if lines == nil {
+		lines = lib.describe(w.text)
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	r := w.textRange()
+	return &hover{Contents: markupContent{Kind: markdown, Value: strings.Join(lines, "\n")}, Range: &r}
+}
+
+// describe returns the description of the library function, variable or object with the given name.
+func (l *library) describe(name string) []string {
+	var lines []string
+	for _, f := range l.funcs[name] {
+		lines = append(lines, code(signature(name, f)))
+		if c, ok := l.costs[f.ID.Name()]; ok {
+			lines = append(lines, fmt.Sprintf("Complexity: %d", c))
+		}
+	}
+	if t, ok := l.vars[name]; ok {
+		lines = append(lines, code(name+": "+typeString(t)))
+	}
+	if info, ok := l.objects[name]; ok {
+		lines = append(lines, code(objectSignature(name, info)))
+		if c, ok := l.costs[name]; ok && !info.NotConstruct {
+			lines = append(lines, fmt.Sprintf("Complexity: %d", c))
+		}
+	}
+	return lines
+}
+
+func code(s string) string {
+	return "```ride\n" + s + "\n```"
+}
+
+func (s *Server) definition(d *document, p position) []location {
+	w := d.wordAt(p)
+	if w.text == "" || w.selector {
+		return nil
+	}
+	if sym, ok := lookup(symbols(d.text), w.text, p); ok {
+		return []location{{URI: d.uri, Range: symbolRange(sym)}}
+	}
+	imports, err := compiler.Imports(d.text)
+	if err != nil {
+		return nil
+	}
+	for _, path := range imports {
+		lib, rErr := s.resolver.Resolve(path, d.path())
+		if rErr != nil {
+			continue
+		}
+		uri, text := s.library(lib)
+		for _, sym := range symbols(text) {
+			if sym.Name == w.text && sym.Kind != compiler.ArgumentSymbol {
+				return []location{{URI: uri, Range: symbolRange(sym)}}
+			}
+		}
+	}
+	return nil
+}
+
+// library returns the URI and the text of the imported library, the opened documents take precedence over
+// the resolved code.
+func (s *Server) library(lib compiler.Library) (string, string) {
+	uri := lib.Path
+	if u, err := url.Parse(lib.Path); err != nil || u.Scheme == "" {
+		uri = pathToURI(lib.Path)
+	}
+	if d, ok := s.docs[uri]; ok {
+		return uri, d.text
+	}
+	return uri, string(lib.Code)
+}
+
+func (s *Server) diagnostics(d *document) []diagnostic {
+	ds := compiler.Diagnose(d.text, d.path(), s.resolver)
+	r := make([]diagnostic, len(ds))
+	for i, cd := range ds {
+		rng := newRange(cd.Begin, cd.End)
+		msg := cd.Message
+		if cd.File != "" {
+			// The errors in imported libraries are shown at the beginning of the document.
+			rng = textRange{}
+			msg = cd.File + ": " + msg
+		}
+		r[i] = diagnostic{Range: rng, Severity: severityError, Source: "ride", Message: msg}
+	}
+	return r
+}
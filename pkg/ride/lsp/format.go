@@ -0,0 +1,94 @@
+package lsp
+
+import (
+	"strings"
+)
+
+const (
+	indentation      = "  "
+	directivePrefix  = "{-#"
+	maxBlankLines    = 1
+	commentCharacter = '#'
+)
+
+// format re-indents the code by the nesting of brackets, removes trailing spaces and repeated blank lines.
+// The lines inside multiline strings are left intact.
+func format(text string) string {
+	var (
+		b        strings.Builder
+		depth    int
+		blanks   int
+		inString bool
+	)
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for _, line := range lines {
+		if inString {
+			b.WriteString(line)
+			b.WriteByte('\n')
+			depth, inString = scan(line, depth, inString)
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			blanks++
+			if blanks <= maxBlankLines && b.Len() > 0 {
+				b.WriteByte('\n')
+			}
+			continue
+		}
+		blanks = 0
+		if strings.HasPrefix(trimmed, directivePrefix) {
+			b.WriteString(trimmed)
+			b.WriteByte('\n')
+			continue
+		}
+		indent := depth - leadingClosers(trimmed)
+		b.WriteString(strings.Repeat(indentation, max(indent, 0)))
+		b.WriteString(trimmed)
+		b.WriteByte('\n')
+		depth, inString = scan(trimmed, depth, false)
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func leadingClosers(line string) int {
+	n := 0
+	for _, c := range line {
+		switch c {
+		case '}', ')', ']':
+			n++
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// scan updates the nesting depth by the brackets of the line, the brackets in strings and comments are skipped.
+func scan(line string, depth int, inString bool) (int, bool) {
+	escaped := false
+	for _, c := range line {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case commentCharacter:
+			return depth, false
+		case '{', '(', '[':
+			depth++
+		case '}', ')', ']':
+			depth = max(depth-1, 0)
+		}
+	}
+	return depth, inString
+}
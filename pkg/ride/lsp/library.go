@@ -0,0 +1,117 @@
+package lsp
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler/stdlib"
+)
+
+var keywords = []string{"let", "strict", "func", "if", "then", "else", "match", "case", "FOLD", "true", "false"}
+
+// library is the standard library of the particular version.
+type library struct {
+	version ast.LibraryVersion
+	funcs   map[string][]stdlib.FunctionParams
+	vars    map[string]stdlib.Type
+	objects map[string]stdlib.ObjectInfo
+	costs   map[string]int
+}
+
+func newLibrary(v ast.LibraryVersion) *library {
+	vars := make(map[string]stdlib.Type)
+	for i := range int(v) {
+		for _, vr := range stdlib.Vars().Vars[i].Append {
+			vars[vr.Name] = vr.Type
+		}
+		for _, name := range stdlib.Vars().Vars[i].Remove {
+			delete(vars, name)
+		}
+	}
+	return &library{
+		version: v,
+		funcs:   stdlib.FuncsByVersion()[v].Funcs,
+		vars:    vars,
+		objects: stdlib.ObjectsByVersion()[v].Obj,
+		costs:   catalogue(v),
+	}
+}
+
+// catalogue returns the complexities of functions that are used by the estimator of the newest version.
+func catalogue(v ast.LibraryVersion) map[string]int {
+	switch v {
+	case ast.LibV1, ast.LibV2:
+		return ride.CatalogueV2
+	case ast.LibV3:
+		return ride.CatalogueV3
+	case ast.LibV4:
+		return ride.CatalogueV4
+	case ast.LibV5:
+		return ride.CatalogueV5
+	case ast.LibV6:
+		return ride.CatalogueV6
+	case ast.LibV7:
+		return ride.CatalogueV7
+	default:
+		return ride.CatalogueV8
+	}
+}
+
+func typeString(t stdlib.Type) string {
+	if t == nil {
+		return "Any"
+	}
+	return t.String()
+}
+
+// signature returns the signature of the function overload, e.g. "blake2b256(ByteVector): ByteVector".
+func signature(name string, f stdlib.FunctionParams) string {
+	args := make([]string, len(f.Arguments))
+	for i, a := range f.Arguments {
+		args[i] = typeString(a)
+	}
+	return fmt.Sprintf("%s(%s): %s", name, strings.Join(args, ", "), typeString(f.ReturnType))
+}
+
+// objectSignature returns the signature of the object constructor, e.g. "Address(bytes: ByteVector)".
+func objectSignature(name string, info stdlib.ObjectInfo) string {
+	fields := make([]string, len(info.Fields))
+	for i, f := range info.Fields {
+		fields[i] = f.Name + ": " + typeString(f.Type)
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(fields, ", "))
+}
+
+// fields returns the fields of objects of the type, the fields of all objects are returned if the type is unknown.
+func (l *library) fields(t stdlib.Type) []stdlib.ObjectField {
+	var names []string
+	switch tt := t.(type) {
+	case stdlib.SimpleType:
+		names = []string{tt.Type}
+	case stdlib.UnionType:
+		for _, m := range tt.Types {
+			if st, ok := m.(stdlib.SimpleType); ok {
+				names = append(names, st.Type)
+			}
+		}
+	default:
+		for name := range l.objects {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	seen := make(map[string]bool)
+	var r []stdlib.ObjectField
+	for _, name := range names {
+		for _, f := range l.objects[name].Fields {
+			if !seen[f.Name] {
+				seen[f.Name] = true
+				r = append(r, f)
+			}
+		}
+	}
+	return r
+}
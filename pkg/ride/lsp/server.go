@@ -0,0 +1,209 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+)
+
+const (
+	serverName       = "ride-lsp"
+	textDocumentSync = 1 // the documents are synced by sending the full content
+)
+
+// Server is the Ride language server. The server handles the requests one by one in the order of arrival.
+type Server struct {
+	logger   *slog.Logger
+	w        io.Writer
+	docs     map[string]*document
+	resolver compiler.ImportResolver
+	shutdown bool
+}
+
+// NewServer creates the language server that writes the responses and notifications to the writer.
+// The libraries imported by the documents are resolved by the given resolver.
+func NewServer(w io.Writer, logger *slog.Logger, resolver compiler.ImportResolver) *Server {
+	return &Server{logger: logger, w: w, docs: make(map[string]*document), resolver: resolver}
+}
+
+// Serve reads the messages from the reader until the exit notification or the end of the input.
+func (s *Server) Serve(r io.Reader) error {
+	br := bufio.NewReader(r)
+	for {
+		b, err := readMessage(br)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Wrap(err, "failed to read message")
+		}
+		var m message
+		if uErr := json.Unmarshal(b, &m); uErr != nil {
+			if wErr := s.reply(nil, nil, newResponseError(codeParseError, "invalid message: %v", uErr)); wErr != nil {
+				return wErr
+			}
+			continue
+		}
+		if m.Method == "exit" {
+			return nil
+		}
+		if hErr := s.handle(m); hErr != nil {
+			return hErr
+		}
+	}
+}
+
+func (s *Server) handle(m message) error {
+	result, err := s.dispatch(m)
+	if m.ID == nil { // notifications are never replied
+		if err != nil {
+			s.logger.Debug("Failed to handle notification", slog.String("method", m.Method), logging.Error(err))
+		}
+		return nil
+	}
+	var re *responseError
+	if err != nil && !errors.As(err, &re) {
+		re = newResponseError(codeInvalidParams, "%v", err)
+	}
+	return s.reply(m.ID, result, re)
+}
+
+func (s *Server) reply(id *json.RawMessage, result any, err *responseError) error {
+	resp := response{JSONRPC: jsonRPCVersion, ID: id, Result: result}
+	if err != nil {
+		resp.Result, resp.Error = nil, err
+	}
+	if wErr := writeMessage(s.w, resp); wErr != nil {
+		return errors.Wrap(wErr, "failed to write response")
+	}
+	return nil
+}
+
+func (s *Server) notify(method string, params any) error {
+	return writeMessage(s.w, notification{JSONRPC: jsonRPCVersion, Method: method, Params: params})
+}
+
+func (s *Server) dispatch(m message) (any, error) {
+	if s.shutdown && m.ID != nil {
+		return nil, newResponseError(codeInvalidRequest, "server is shut down")
+	}
+	switch m.Method {
+	case "initialize":
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:           textDocumentSync,
+				CompletionProvider:         completionOptions{TriggerCharacters: []string{"."}},
+				HoverProvider:              true,
+				DefinitionProvider:         true,
+				DocumentFormattingProvider: true,
+			},
+			ServerInfo: serverInfo{Name: serverName},
+		}, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var p didOpenTextDocumentParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, err
+		}
+		return nil, s.open(p.TextDocument.URI, p.TextDocument.Text)
+	case "textDocument/didChange":
+		var p didChangeTextDocumentParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, err
+		}
+		if len(p.ContentChanges) == 0 {
+			return nil, nil
+		}
+		return nil, s.open(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+	case "textDocument/didClose":
+		var p didCloseTextDocumentParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, err
+		}
+		delete(s.docs, p.TextDocument.URI)
+		return nil, s.notify("textDocument/publishDiagnostics",
+			publishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []diagnostic{}})
+	case "textDocument/completion":
+		d, p, err := s.position(m.Params)
+		if err != nil {
+			return nil, err
+		}
+		return s.completion(d, p), nil
+	case "textDocument/hover":
+		d, p, err := s.position(m.Params)
+		if err != nil {
+			return nil, err
+		}
+		return s.hover(d, p), nil
+	case "textDocument/definition":
+		d, p, err := s.position(m.Params)
+		if err != nil {
+			return nil, err
+		}
+		return s.definition(d, p), nil
+	case "textDocument/formatting":
+		var p documentFormattingParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, err
+		}
+		d, err := s.document(p.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		return s.formatting(d), nil
+	default:
+		if strings.HasPrefix(m.Method, "$/") {
+			return nil, nil // optional notifications and requests may be ignored
+		}
+		return nil, newResponseError(codeMethodNotFound, "method '%s' is not supported", m.Method)
+	}
+}
+
+// open stores the text of the document and publishes its diagnostics.
+func (s *Server) open(uri, text string) error {
+	d := newDocument(uri, text)
+	s.docs[uri] = d
+	return s.notify("textDocument/publishDiagnostics",
+		publishDiagnosticsParams{URI: uri, Diagnostics: s.diagnostics(d)})
+}
+
+func (s *Server) document(uri string) (*document, error) {
+	d, ok := s.docs[uri]
+	if !ok {
+		return nil, errors.Errorf("document '%s' is not opened", uri)
+	}
+	return d, nil
+}
+
+func (s *Server) position(params json.RawMessage) (*document, position, error) {
+	var p textDocumentPositionParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, position{}, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, position{}, err
+	}
+	return d, p.Position, nil
+}
+
+// formatting returns the edit that replaces the whole document with the formatted text.
+func (s *Server) formatting(d *document) []textEdit {
+	formatted := format(d.text)
+	if formatted == d.text {
+		return []textEdit{}
+	}
+	end := position{Line: len(d.lines)}
+	return []textEdit{{Range: textRange{End: end}, NewText: formatted}}
+}
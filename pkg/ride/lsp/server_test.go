@@ -0,0 +1,184 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+)
+
+type session struct {
+	t   *testing.T
+	buf bytes.Buffer
+	id  int
+}
+
+func (s *session) request(method string, params any) {
+	s.id++
+	s.send(map[string]any{"jsonrpc": jsonRPCVersion, "id": s.id, "method": method, "params": params})
+}
+
+func (s *session) notify(method string, params any) {
+	s.send(map[string]any{"jsonrpc": jsonRPCVersion, "method": method, "params": params})
+}
+
+func (s *session) send(v any) {
+	require.NoError(s.t, writeMessage(&s.buf, v))
+}
+
+type received struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+func (s *session) run() []received {
+	var out bytes.Buffer
+	srv := NewServer(&out, slog.New(slog.NewTextHandler(io.Discard, nil)),
+		compiler.NewFileResolver())
+	require.NoError(s.t, srv.Serve(&s.buf))
+	var r []received
+	br := bufio.NewReader(&out)
+	for {
+		b, err := readMessage(br)
+		if errors.Is(err, io.EOF) {
+			return r
+		}
+		require.NoError(s.t, err)
+		var m received
+		require.NoError(s.t, json.Unmarshal(b, &m))
+		r = append(r, m)
+	}
+}
+
+func at(uri string, line, character int) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     position{Line: line, Character: character},
+	}
+}
+
+func decode[T any](t *testing.T, b json.RawMessage) T {
+	var v T
+	require.NoError(t, json.Unmarshal(b, &v))
+	return v
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	const lib = `{-# SCRIPT_TYPE ACCOUNT #-}
+{-# CONTENT_TYPE LIBRARY #-}
+func inc(a: Int) = a + 1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib.ride"), []byte(lib), 0600))
+	const code = `{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# IMPORT lib.ride #-}
+let total = inc(1)
+@Callable(i)
+func call() = {
+let h = blake2b256(i.caller.bytes)
+[IntegerEntry("total", total)]
+}
+`
+	uri := pathToURI(filepath.Join(dir, "main.ride"))
+	s := &session{t: t}
+	s.request("initialize", map[string]any{})
+	s.notify("initialized", map[string]any{})
+	s.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "languageId": "ride", "version": 1, "text": code},
+	})
+	s.request("textDocument/hover", at(uri, 6, 10))      // blake2b256
+	s.request("textDocument/completion", at(uri, 6, 30)) // i.caller.by
+	s.request("textDocument/definition", at(uri, 3, 13)) // inc
+	s.request("textDocument/definition", at(uri, 7, 23)) // total
+	s.request("textDocument/formatting", map[string]any{"textDocument": map[string]any{"uri": uri}})
+	s.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []map[string]any{{"text": "{-# STDLIB_VERSION 6 #-}\nlet x = 1\nx == \"a\""}},
+	})
+	s.request("textDocument/unknown", map[string]any{})
+	s.request("shutdown", nil)
+	s.notify("exit", nil)
+	s.notify("textDocument/didClose", map[string]any{"textDocument": map[string]any{"uri": uri}})
+
+	msgs := s.run()
+	require.Len(t, msgs, 10)
+
+	caps := decode[initializeResult](t, msgs[0].Result)
+	assert.True(t, caps.Capabilities.HoverProvider)
+	assert.Equal(t, serverName, caps.ServerInfo.Name)
+
+	assert.Equal(t, "textDocument/publishDiagnostics", msgs[1].Method)
+	assert.Empty(t, decode[publishDiagnosticsParams](t, msgs[1].Params).Diagnostics)
+
+	h := decode[hover](t, msgs[2].Result)
+	assert.Contains(t, h.Contents.Value, "blake2b256(ByteVector): ByteVector")
+	assert.Contains(t, h.Contents.Value, "Complexity: 136")
+
+	var labels []string
+	for _, item := range decode[[]completionItem](t, msgs[3].Result) {
+		labels = append(labels, item.Label)
+	}
+	assert.Contains(t, labels, "bytes")
+	assert.NotContains(t, labels, "caller")
+
+	locs := decode[[]location](t, msgs[4].Result)
+	require.Len(t, locs, 1)
+	assert.Equal(t, pathToURI(filepath.Join(dir, "lib.ride")), locs[0].URI)
+	assert.Equal(t, textRange{Start: position{Line: 2, Character: 5}, End: position{Line: 2, Character: 8}},
+		locs[0].Range)
+
+	locs = decode[[]location](t, msgs[5].Result)
+	require.Len(t, locs, 1)
+	assert.Equal(t, uri, locs[0].URI)
+	assert.Equal(t, 3, locs[0].Range.Start.Line)
+
+	edits := decode[[]textEdit](t, msgs[6].Result)
+	require.Len(t, edits, 1)
+	assert.Contains(t, edits[0].NewText, "\n  let h = blake2b256(i.caller.bytes)\n")
+
+	ds := decode[publishDiagnosticsParams](t, msgs[7].Params).Diagnostics
+	require.Len(t, ds, 1)
+	assert.Equal(t, "Unexpected type, required 'Int', but 'String' found", ds[0].Message)
+	assert.Equal(t, 2, ds[0].Range.Start.Line)
+
+	require.NotNil(t, msgs[8].Error)
+	assert.Equal(t, codeMethodNotFound, msgs[8].Error.Code)
+
+	assert.Nil(t, msgs[9].Error)
+	assert.Equal(t, "null", string(msgs[9].Result))
+}
+
+func TestFormat(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		expected string
+	}{
+		{"", "\n"},
+		{"  {-# STDLIB_VERSION 6 #-}  \nlet a = 1", "{-# STDLIB_VERSION 6 #-}\nlet a = 1\n"},
+		{
+			"func f() = {\nlet a = [\n1,\n2\n]\n\n\n\na\n}\n",
+			"func f() = {\n  let a = [\n    1,\n    2\n  ]\n\n  a\n}\n",
+		},
+		{
+			"func f() = {\nlet s = \"{(\" # {\n   s\n}",
+			"func f() = {\n  let s = \"{(\" # {\n  s\n}\n",
+		},
+	} {
+		assert.Equal(t, test.expected, format(test.text), strings.ReplaceAll(test.text, "\n", "\\n"))
+	}
+}
@@ -80,6 +80,11 @@ func (a *Abbreviations) CompactToOriginal(compact string) (string, error) {
 	return "", errors.Errorf("short name '%s' not found", compact)
 }
 
+// OriginalNames returns the original names of the compacted script in the order of generation of compact names.
+func (a *Abbreviations) OriginalNames() []string {
+	return a.names
+}
+
 func Convert(meta *g.DAppMeta) (DApp, error) {
 	v := int(meta.GetVersion())
 	abbreviations := convertAbbreviations(meta.GetCompactNameAndOriginalNamePairList(), meta.GetOriginalNames())
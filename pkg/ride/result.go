@@ -1,6 +1,13 @@
 package ride
 
-import "github.com/wavesplatform/gowaves/pkg/proto"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
 
 type Result interface {
 	Result() bool
@@ -69,3 +76,61 @@ func (r DAppResult) withComplexity(c int) Result {
 		complexity: c,
 	}
 }
+
+// EvaluatedValue is a representation of the value returned by script in the format of the node API.
+type EvaluatedValue struct {
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// ResultValue returns the value returned by the script or by the callable function.
+// For callable functions without returned value (before V5 or without tuple) the Unit value is returned.
+func ResultValue(r Result) EvaluatedValue {
+	v := r.userResult()
+	if v == nil {
+		return newEvaluatedValue(rideUnit{})
+	}
+	return newEvaluatedValue(v)
+}
+
+func newEvaluatedValue(v rideType) EvaluatedValue {
+	switch tv := v.(type) {
+	case rideInt:
+		return EvaluatedValue{Type: intTypeName, Value: int64(tv)}
+	case rideBigInt:
+		return EvaluatedValue{Type: bigIntTypeName, Value: json.Number(tv.v.String())}
+	case rideBoolean:
+		return EvaluatedValue{Type: booleanTypeName, Value: bool(tv)}
+	case rideString:
+		return EvaluatedValue{Type: stringTypeName, Value: string(tv)}
+	case rideByteVector:
+		return EvaluatedValue{Type: byteVectorTypeName, Value: base58.Encode(tv)}
+	case rideUnit:
+		return EvaluatedValue{Type: unitTypeName, Value: struct{}{}}
+	case rideAddress:
+		bytes := newEvaluatedValue(rideByteVector(proto.WavesAddress(tv).Bytes()))
+		return EvaluatedValue{Type: addressTypeName, Value: map[string]EvaluatedValue{bytesField: bytes}}
+	case rideAlias:
+		alias := newEvaluatedValue(rideString(proto.Alias(tv).Alias))
+		return EvaluatedValue{Type: aliasTypeName, Value: map[string]EvaluatedValue{aliasField: alias}}
+	case rideList:
+		items := make([]EvaluatedValue, len(tv))
+		for i, item := range tv {
+			items[i] = newEvaluatedValue(item)
+		}
+		return EvaluatedValue{Type: "Array", Value: items}
+	case rideTuple:
+		items := make(map[string]EvaluatedValue, tv.size())
+		for i := 1; i <= tv.size(); i++ {
+			name := fmt.Sprintf("_%d", i)
+			item, err := tv.get(name)
+			if err != nil {
+				continue
+			}
+			items[name] = newEvaluatedValue(item)
+		}
+		return EvaluatedValue{Type: "Tuple", Value: items}
+	default: // Other objects are represented by their string form
+		return EvaluatedValue{Type: v.instanceOf(), Value: v.String()}
+	}
+}
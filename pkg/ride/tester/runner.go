@@ -0,0 +1,255 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+)
+
+const (
+	invokeVersion = 1
+	invokeFee     = 500000
+)
+
+// Result is the outcome of a single test. The test has failed if the failure is not empty.
+type Result struct {
+	Name       string
+	Failure    string
+	Complexity int
+	Duration   time.Duration
+}
+
+// Failed reports whether the test has failed.
+func (r Result) Failed() bool {
+	return r.Failure != ""
+}
+
+// Report is the list of results of the suite tests.
+type Report struct {
+	Name    string
+	Results []Result
+}
+
+// Failures returns the number of failed tests.
+func (r *Report) Failures() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Failed() {
+			n++
+		}
+	}
+	return n
+}
+
+// Run runs the tests of the suite against the compiled dApp script. Every test starts from the initial
+// state of the suite, the changes made by the previous tests are not applied to the state.
+func Run(script []byte, s *Suite) (*Report, error) {
+	tree, err := serialization.Parse(script)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse script")
+	}
+	if !tree.IsDApp() {
+		return nil, errors.New("script is not a dApp")
+	}
+	st, env, err := s.newState(tree, script)
+	if err != nil {
+		return nil, err
+	}
+	r := &Report{Name: s.Name, Results: make([]Result, 0, len(s.Tests))}
+	for _, t := range s.Tests {
+		start := time.Now()
+		res := Result{Name: t.Name}
+		res.Complexity, err = run(st, env, t)
+		if err != nil {
+			res.Failure = err.Error()
+		}
+		res.Duration = time.Since(start)
+		r.Results = append(r.Results, res)
+	}
+	return r, nil
+}
+
+// run invokes the callable and checks the result, the error describes the failed expectation.
+func run(st *state, env *environment, t Test) (int, error) {
+	caller, ok := env.accounts[t.Caller]
+	if !ok {
+		return 0, errors.Errorf("unknown caller '%s'", t.Caller)
+	}
+	args, err := env.arguments(t.Args)
+	if err != nil {
+		return 0, err
+	}
+	payments := make(proto.ScriptPayments, len(t.Payments))
+	for i, p := range t.Payments {
+		asset, aErr := env.asset(p.Asset)
+		if aErr != nil {
+			return 0, aErr
+		}
+		payments[i] = proto.ScriptPayment{Amount: p.Amount, Asset: asset}
+	}
+	fc := proto.NewFunctionCall(t.Function, args)
+	tx := proto.NewUnsignedInvokeScriptWithProofs(invokeVersion, caller.pk,
+		proto.NewRecipientFromAddress(st.dApp.address), fc, payments, proto.NewOptionalAssetWaves(),
+		invokeFee, st.timestamp,
+	)
+	if sErr := tx.Sign(env.scheme, caller.sk); sErr != nil {
+		return 0, errors.Wrap(sErr, "failed to sign invoke transaction")
+	}
+	e, err := newEnvironment(st, env.scheme, tx, caller.address)
+	if err != nil {
+		return 0, err
+	}
+	res, err := ride.CallFunction(e, st.tree, fc)
+	if err != nil {
+		return ride.EvaluationErrorSpentComplexity(err), checkError(t.Expect, err)
+	}
+	return res.Complexity(), check(env, t.Expect, res.ScriptActions())
+}
+
+// newEnvironment creates the environment of evaluation with all features activated.
+func newEnvironment(
+	st *state, scheme proto.Scheme, tx *proto.InvokeScriptWithProofs, caller proto.WavesAddress,
+) (*ride.EvaluationEnvironment, error) {
+	const activated = true
+	v := st.tree.LibVersion
+	env, err := ride.NewEnvironment(scheme, st, 0, 0, activated, activated, activated, activated, activated)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create RIDE environment")
+	}
+	blockInfo, err := st.NewestBlockInfoByHeight(st.height)
+	if err != nil {
+		return nil, err
+	}
+	env.SetThisFromAddress(st.dApp.address)
+	env.ChooseSizeCheck(v)
+	if err := env.SetLastBlockFromBlockInfo(blockInfo); err != nil {
+		return nil, err
+	}
+	env.SetTimestamp(tx.Timestamp)
+	env.ChooseTakeString(activated)
+	env.ChooseMaxDataEntriesSize(activated)
+	limit, err := ride.MaxChainInvokeComplexityByVersion(v)
+	if err != nil {
+		return nil, err
+	}
+	env.SetLimit(limit)
+	if err := env.SetTransaction(tx); err != nil {
+		return nil, err
+	}
+	if err := env.SetInvoke(tx, v); err != nil {
+		return nil, err
+	}
+	if v < ast.LibV5 {
+		return env, nil
+	}
+	const checkSenderBalance = true
+	return ride.NewEnvironmentWithWrappedState(env, st, tx.Payments, caller, false, v, checkSenderBalance)
+}
+
+func checkError(exp Expectation, err error) error {
+	if exp.Error == "" {
+		return errors.Errorf("unexpected error: %v", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, exp.Error) {
+		return errors.Errorf("expected error containing '%s', got '%s'", exp.Error, msg)
+	}
+	return nil
+}
+
+func check(env *environment, exp Expectation, actions []proto.ScriptAction) error {
+	if exp.Error != "" {
+		return errors.Errorf("expected error containing '%s', but invocation succeeded", exp.Error)
+	}
+	if exp.Actions != nil && *exp.Actions != len(actions) {
+		return errors.Errorf("expected %d actions, got %d", *exp.Actions, len(actions))
+	}
+	var (
+		entries   proto.DataEntries
+		transfers []*proto.TransferScriptAction
+	)
+	for _, a := range actions {
+		switch ta := a.(type) {
+		case *proto.DataEntryScriptAction:
+			entries = append(entries, ta.Entry)
+		case *proto.TransferScriptAction:
+			transfers = append(transfers, ta)
+		}
+	}
+	if exp.Data != nil {
+		expected, err := env.entries(*exp.Data)
+		if err != nil {
+			return err
+		}
+		if err := checkData(expected, entries); err != nil {
+			return err
+		}
+	}
+	if exp.Transfers != nil {
+		if err := checkTransfers(env, *exp.Transfers, transfers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkData(expected, actual proto.DataEntries) error {
+	if len(expected) == 0 && len(actual) == 0 {
+		return nil
+	}
+	e, err := json.Marshal(expected)
+	if err != nil {
+		return err
+	}
+	a, err := json.Marshal(actual)
+	if err != nil {
+		return err
+	}
+	if string(e) != string(a) {
+		return errors.Errorf("expected data entries %s, got %s", e, a)
+	}
+	return nil
+}
+
+func checkTransfers(env *environment, expected []Transfer, actual []*proto.TransferScriptAction) error {
+	if len(expected) != len(actual) {
+		return errors.Errorf("expected %d transfers, got %d", len(expected), len(actual))
+	}
+	for i, exp := range expected {
+		addr, err := env.address(exp.Recipient)
+		if err != nil {
+			return err
+		}
+		asset, err := env.asset(exp.Asset)
+		if err != nil {
+			return err
+		}
+		act := actual[i]
+		ok, err := act.Recipient.EqAddr(addr)
+		if err != nil {
+			return err
+		}
+		if !ok || act.Amount != exp.Amount || act.Asset != asset {
+			return errors.Errorf("transfer #%d: expected %d of %s to %s, got %s",
+				i+1, exp.Amount, asset.String(), addr.String(), transferString(act))
+		}
+	}
+	return nil
+}
+
+func transferString(t *proto.TransferScriptAction) string {
+	recipient := "<unknown>"
+	if addr := t.Recipient.Address(); addr != nil {
+		recipient = addr.String()
+	} else if alias := t.Recipient.Alias(); alias != nil {
+		recipient = alias.String()
+	}
+	return fmt.Sprintf("%d of %s to %s", t.Amount, t.Asset.String(), recipient)
+}
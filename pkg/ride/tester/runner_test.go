@@ -0,0 +1,132 @@
+package tester
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+)
+
+const dApp = `
+{-# STDLIB_VERSION 6 #-}
+{-# CONTENT_TYPE DAPP #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+
+@Callable(i)
+func deposit() = {
+  let pmt = i.payments[0]
+  if (isDefined(pmt.assetId)) then throw("only Waves") else
+  let key = toString(i.caller)
+  let balance = getInteger(this, key).valueOrElse(0)
+  [IntegerEntry(key, balance + pmt.amount)]
+}
+
+@Callable(i)
+func withdraw(amount: Int) = {
+  let key = toString(i.caller)
+  let balance = getInteger(this, key).valueOrElse(0)
+  if (amount > balance) then throw("not enough funds") else
+  [IntegerEntry(key, balance - amount), ScriptTransfer(i.caller, amount, unit)]
+}
+
+@Callable(i)
+func send(to: String, amount: Int) = [ScriptTransfer(addressFromStringValue(to), amount, unit)]
+`
+
+const suite = `{
+  "name": "wallet",
+  "dApp": "wallet",
+  "accounts": {
+    "wallet": {"balance": 1000, "data": [{"key": "$alice", "type": "integer", "value": 1}]},
+    "alice": {"balance": 500, "assets": {"token": 10}}
+  },
+  "assets": {"token": {"issuer": "alice", "decimals": 2, "quantity": 100}},
+  "tests": [
+    {
+      "name": "deposit",
+      "caller": "alice",
+      "function": "deposit",
+      "payments": [{"amount": 100}],
+      "expect": {"actions": 1, "data": [{"key": "$alice", "type": "integer", "value": 101}]}
+    },
+    {
+      "name": "deposit asset",
+      "caller": "alice",
+      "function": "deposit",
+      "payments": [{"amount": 1, "asset": "token"}],
+      "expect": {"error": "only Waves"}
+    },
+    {
+      "name": "withdraw",
+      "caller": "alice",
+      "function": "withdraw",
+      "args": [{"type": "integer", "value": 1}],
+      "expect": {"transfers": [{"recipient": "alice", "amount": 1}]}
+    },
+    {
+      "name": "withdraw too much",
+      "caller": "alice",
+      "function": "withdraw",
+      "args": [{"type": "integer", "value": 2}],
+      "expect": {"error": "not enough funds"}
+    },
+    {
+      "name": "send",
+      "caller": "alice",
+      "function": "send",
+      "args": [{"type": "string", "value": "$wallet"}, {"type": "integer", "value": 5}],
+      "expect": {"transfers": [{"recipient": "wallet", "amount": 5, "asset": "WAVES"}]}
+    },
+    {
+      "name": "wrong expectation",
+      "caller": "alice",
+      "function": "withdraw",
+      "args": [{"type": "integer", "value": 1}],
+      "expect": {"data": []}
+    }
+  ]
+}`
+
+func TestRun(t *testing.T) {
+	script, errs := compiler.Compile(dApp, false, false)
+	require.Empty(t, errs)
+	s, err := LoadSuite(strings.NewReader(suite))
+	require.NoError(t, err)
+
+	r, err := Run(script, s)
+	require.NoError(t, err)
+	require.Len(t, r.Results, len(s.Tests))
+	for _, res := range r.Results[:len(r.Results)-1] {
+		assert.False(t, res.Failed(), "%s: %s", res.Name, res.Failure)
+		assert.Positive(t, res.Complexity, res.Name)
+	}
+	last := r.Results[len(r.Results)-1]
+	assert.True(t, last.Failed())
+	assert.Contains(t, last.Failure, "expected data entries []")
+	assert.Equal(t, 1, r.Failures())
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteJUnit(&buf))
+	out := buf.String()
+	assert.Contains(t, out, `<testsuite name="wallet" tests="6" failures="1"`)
+	assert.Contains(t, out, `<testcase name="deposit" classname="wallet"`)
+	assert.Contains(t, out, `<failure message="expected data entries [], got`)
+}
+
+func TestLoadSuite(t *testing.T) {
+	for _, test := range []struct {
+		src string
+		err string
+	}{
+		{`{"tests": []}`, "dApp account is not specified"},
+		{`{"dApp": "a", "unknown": 1}`, "unknown field"},
+		{`{"dApp": "a", "tests": [{"args": [{"type": "foo", "value": 1}]}]}`, "failed to load test suite"},
+	} {
+		_, err := LoadSuite(strings.NewReader(test.src))
+		assert.ErrorContains(t, err, test.err)
+	}
+}
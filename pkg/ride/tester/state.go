@@ -0,0 +1,293 @@
+package tester
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
+const estimatorVersion = 4
+
+type account struct {
+	sk      crypto.SecretKey
+	pk      crypto.PublicKey
+	address proto.WavesAddress
+	balance uint64
+	assets  map[crypto.Digest]uint64
+	data    map[string]proto.DataEntry
+}
+
+// state is an in-memory blockchain state that holds only the accounts and assets of the test suite.
+// The script of the dApp is the only script in the state, all other accounts are not scripted.
+type state struct {
+	height    proto.Height
+	timestamp uint64
+	dApp      *account
+	tree      *ast.Tree
+	script    proto.Script
+	accounts  map[proto.WavesAddress]*account
+	assets    map[crypto.Digest]*proto.AssetInfo
+}
+
+var _ types.EnrichedSmartState = (*state)(nil)
+
+func notFound(format string, args ...any) error {
+	return errors.Wrapf(proto.ErrNotFound, format, args...)
+}
+
+func (s *state) account(r proto.Recipient) (*account, error) {
+	addr, err := s.NewestRecipientToAddress(r)
+	if err != nil {
+		return nil, err
+	}
+	if acc, ok := s.accounts[addr]; ok {
+		return acc, nil
+	}
+	return &account{address: addr}, nil // unknown accounts are empty
+}
+
+func (s *state) accountByID(id proto.AddressID) *account {
+	for addr, acc := range s.accounts {
+		if addr.ID() == id {
+			return acc
+		}
+	}
+	return &account{}
+}
+
+func (s *state) entry(r proto.Recipient, key string) (proto.DataEntry, error) {
+	acc, err := s.account(r)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := acc.data[key]
+	if !ok {
+		return nil, notFound("entry '%s'", key)
+	}
+	return e, nil
+}
+
+func (s *state) NewestScriptPKByAddr(addr proto.WavesAddress) (crypto.PublicKey, error) {
+	if addr != s.dApp.address {
+		return crypto.PublicKey{}, notFound("script of %s", addr.String())
+	}
+	return s.dApp.pk, nil
+}
+
+func (s *state) AddingBlockHeight() (uint64, error) {
+	return s.height, nil
+}
+
+func (s *state) NewestTransactionByID(id []byte) (proto.Transaction, error) {
+	return nil, notFound("transaction %s", crypto.Digest(id).String())
+}
+
+func (s *state) NewestTransactionHeightByID(id []byte) (uint64, error) {
+	return 0, notFound("transaction %s", crypto.Digest(id).String())
+}
+
+func (s *state) NewestScriptByAccount(account proto.Recipient) (*ast.Tree, error) {
+	acc, err := s.account(account)
+	if err != nil {
+		return nil, err
+	}
+	if acc != s.dApp {
+		return nil, notFound("script of %s", acc.address.String())
+	}
+	return s.tree, nil
+}
+
+func (s *state) NewestScriptBytesByAccount(account proto.Recipient) (proto.Script, error) {
+	acc, err := s.account(account)
+	if err != nil {
+		return nil, err
+	}
+	if acc != s.dApp {
+		return nil, notFound("script of %s", acc.address.String())
+	}
+	return s.script, nil
+}
+
+func (s *state) NewestRecipientToAddress(recipient proto.Recipient) (proto.WavesAddress, error) {
+	if addr := recipient.Address(); addr != nil {
+		return *addr, nil
+	}
+	if alias := recipient.Alias(); alias != nil {
+		return s.NewestAddrByAlias(*alias)
+	}
+	return proto.WavesAddress{}, errors.New("empty recipient")
+}
+
+func (s *state) NewestAddrByAlias(alias proto.Alias) (proto.WavesAddress, error) {
+	return proto.WavesAddress{}, notFound("alias %s", alias.String())
+}
+
+func (s *state) NewestLeasingInfo(id crypto.Digest) (*proto.LeaseInfo, error) {
+	return nil, notFound("lease %s", id.String())
+}
+
+func (s *state) IsStateUntouched(account proto.Recipient) (bool, error) {
+	acc, err := s.account(account)
+	if err != nil {
+		return false, err
+	}
+	return len(acc.data) == 0, nil
+}
+
+func (s *state) NewestAssetBalance(account proto.Recipient, assetID crypto.Digest) (uint64, error) {
+	acc, err := s.account(account)
+	if err != nil {
+		return 0, err
+	}
+	return acc.assets[assetID], nil
+}
+
+func (s *state) NewestAssetBalanceByAddressID(id proto.AddressID, asset crypto.Digest) (uint64, error) {
+	return s.accountByID(id).assets[asset], nil
+}
+
+func (s *state) NewestWavesBalance(account proto.Recipient) (uint64, error) {
+	acc, err := s.account(account)
+	if err != nil {
+		return 0, err
+	}
+	return acc.balance, nil
+}
+
+func (s *state) NewestFullWavesBalance(account proto.Recipient) (*proto.FullWavesBalance, error) {
+	acc, err := s.account(account)
+	if err != nil {
+		return nil, err
+	}
+	b := acc.balance
+	return &proto.FullWavesBalance{Regular: b, Generating: b, Available: b, Effective: b}, nil
+}
+
+func (s *state) WavesBalanceProfile(id proto.AddressID) (*types.WavesBalanceProfile, error) {
+	b := s.accountByID(id).balance
+	return &types.WavesBalanceProfile{Balance: b, Generating: b}, nil
+}
+
+func (s *state) RetrieveNewestIntegerEntry(account proto.Recipient, key string) (*proto.IntegerDataEntry, error) {
+	e, err := s.entry(account, key)
+	if err != nil {
+		return nil, err
+	}
+	ie, ok := e.(*proto.IntegerDataEntry)
+	if !ok {
+		return nil, notFound("integer entry '%s'", key)
+	}
+	return ie, nil
+}
+
+func (s *state) RetrieveNewestBooleanEntry(account proto.Recipient, key string) (*proto.BooleanDataEntry, error) {
+	e, err := s.entry(account, key)
+	if err != nil {
+		return nil, err
+	}
+	be, ok := e.(*proto.BooleanDataEntry)
+	if !ok {
+		return nil, notFound("boolean entry '%s'", key)
+	}
+	return be, nil
+}
+
+func (s *state) RetrieveNewestStringEntry(account proto.Recipient, key string) (*proto.StringDataEntry, error) {
+	e, err := s.entry(account, key)
+	if err != nil {
+		return nil, err
+	}
+	se, ok := e.(*proto.StringDataEntry)
+	if !ok {
+		return nil, notFound("string entry '%s'", key)
+	}
+	return se, nil
+}
+
+func (s *state) RetrieveNewestBinaryEntry(account proto.Recipient, key string) (*proto.BinaryDataEntry, error) {
+	e, err := s.entry(account, key)
+	if err != nil {
+		return nil, err
+	}
+	be, ok := e.(*proto.BinaryDataEntry)
+	if !ok {
+		return nil, notFound("binary entry '%s'", key)
+	}
+	return be, nil
+}
+
+func (s *state) RetrieveEntries(account proto.Recipient) ([]proto.DataEntry, error) {
+	acc, err := s.account(account)
+	if err != nil {
+		return nil, err
+	}
+	r := make([]proto.DataEntry, 0, len(acc.data))
+	for _, e := range acc.data {
+		r = append(r, e)
+	}
+	return r, nil
+}
+
+func (s *state) NewestAssetIsSponsored(assetID crypto.Digest) (bool, error) {
+	info, err := s.NewestAssetInfo(assetID)
+	if err != nil {
+		return false, err
+	}
+	return info.Sponsored, nil
+}
+
+func (s *state) NewestAssetConstInfo(assetID proto.AssetID) (*proto.AssetConstInfo, error) {
+	for id, info := range s.assets {
+		if proto.AssetIDFromDigest(id) == assetID {
+			return &info.AssetConstInfo, nil
+		}
+	}
+	return nil, notFound("asset")
+}
+
+func (s *state) NewestAssetInfo(assetID crypto.Digest) (*proto.AssetInfo, error) {
+	info, ok := s.assets[assetID]
+	if !ok {
+		return nil, notFound("asset %s", assetID.String())
+	}
+	return info, nil
+}
+
+func (s *state) NewestFullAssetInfo(assetID crypto.Digest) (*proto.FullAssetInfo, error) {
+	info, err := s.NewestAssetInfo(assetID)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.FullAssetInfo{AssetInfo: *info}, nil
+}
+
+func (s *state) NewestScriptByAsset(assetID crypto.Digest) (*ast.Tree, error) {
+	return nil, notFound("script of asset %s", assetID.String())
+}
+
+func (s *state) NewestBlockInfoByHeight(height proto.Height) (*proto.BlockInfo, error) {
+	if height == 0 || height > s.height {
+		return nil, notFound("block at height %d", height)
+	}
+	return &proto.BlockInfo{
+		Version:             proto.ProtobufBlockVersion,
+		Timestamp:           s.timestamp,
+		Height:              height,
+		BaseTarget:          1,
+		Generator:           s.dApp.address,
+		GeneratorPublicKey:  s.dApp.pk,
+		GenerationSignature: make([]byte, crypto.DigestSize),
+		VRF:                 make([]byte, crypto.DigestSize),
+	}, nil
+}
+
+func (s *state) EstimatorVersion() (int, error) {
+	return estimatorVersion, nil
+}
+
+func (s *state) IsNotFound(err error) bool {
+	return errors.Is(err, proto.ErrNotFound)
+}
@@ -0,0 +1,316 @@
+package tester
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+)
+
+const (
+	defaultHeight = 1
+	wavesAsset    = "WAVES"
+	// accountPrefix marks the string argument, the data entry key or the string data entry value
+	// that is replaced with the address of the account, e.g. "$alice" is replaced with the address
+	// of the account "alice".
+	accountPrefix = "$"
+)
+
+// Suite is a set of tests of the dApp script. The accounts and assets are referenced by their names
+// all over the suite.
+type Suite struct {
+	Name      string             `json:"name"`
+	Scheme    string             `json:"scheme"`    // the address scheme, "T" by default
+	Height    proto.Height       `json:"height"`    // the height of the blockchain, 1 by default
+	Timestamp uint64             `json:"timestamp"` // the timestamp of the last block and transactions
+	DApp      string             `json:"dApp"`      // the name of the account the script is set to
+	Accounts  map[string]Account `json:"accounts"`
+	Assets    map[string]Asset   `json:"assets"`
+	Tests     []Test             `json:"tests"`
+}
+
+// Account is the initial state of the account.
+type Account struct {
+	Seed    string            `json:"seed"` // the name of the account is used as seed if empty
+	Balance uint64            `json:"balance"`
+	Assets  map[string]uint64 `json:"assets"`
+	Data    proto.DataEntries `json:"data"`
+}
+
+// Asset describes the issued asset. The identifier of the asset is the hash of its name if not set.
+type Asset struct {
+	ID         string `json:"id"`
+	Issuer     string `json:"issuer"`
+	Decimals   uint8  `json:"decimals"`
+	Quantity   uint64 `json:"quantity"`
+	Reissuable bool   `json:"reissuable"`
+}
+
+// Test is a single invocation of the callable function of the dApp with the expected outcome.
+type Test struct {
+	Name     string          `json:"name"`
+	Caller   string          `json:"caller"`
+	Function string          `json:"function"`
+	Args     proto.Arguments `json:"args"`
+	Payments []Payment       `json:"payments"`
+	Expect   Expectation     `json:"expect"`
+}
+
+// Payment is the payment attached to the invocation, the empty asset stands for Waves.
+type Payment struct {
+	Amount uint64 `json:"amount"`
+	Asset  string `json:"asset"`
+}
+
+// Expectation lists the checks of the invocation result. The checks that are not set are skipped.
+// The data entries and transfers are compared with the actions of the result in the order of their production.
+type Expectation struct {
+	Error     string             `json:"error"` // the expected substring of the error message
+	Actions   *int               `json:"actions"`
+	Data      *proto.DataEntries `json:"data"`
+	Transfers *[]Transfer        `json:"transfers"`
+}
+
+// Transfer is the expected transfer action, the recipient is either a name of account or an address.
+type Transfer struct {
+	Recipient string `json:"recipient"`
+	Amount    int64  `json:"amount"`
+	Asset     string `json:"asset"`
+}
+
+// LoadSuite reads the suite in JSON format.
+func LoadSuite(r io.Reader) (*Suite, error) {
+	s := new(Suite)
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	if err := d.Decode(s); err != nil {
+		return nil, errors.Wrap(err, "failed to load test suite")
+	}
+	if s.DApp == "" {
+		return nil, errors.New("dApp account is not specified")
+	}
+	return s, nil
+}
+
+func (s *Suite) scheme() (proto.Scheme, error) {
+	switch len(s.Scheme) {
+	case 0:
+		return proto.TestNetScheme, nil
+	case 1:
+		return s.Scheme[0], nil
+	default:
+		return 0, errors.Errorf("invalid scheme '%s'", s.Scheme)
+	}
+}
+
+// environment resolves the names of the suite.
+type environment struct {
+	scheme   proto.Scheme
+	accounts map[string]*account
+	assets   map[string]crypto.Digest
+}
+
+func (e *environment) address(name string) (proto.WavesAddress, error) {
+	if acc, ok := e.accounts[name]; ok {
+		return acc.address, nil
+	}
+	addr, err := proto.NewAddressFromString(name)
+	if err != nil {
+		return proto.WavesAddress{}, errors.Errorf("unknown account '%s'", name)
+	}
+	return addr, nil
+}
+
+func (e *environment) asset(name string) (proto.OptionalAsset, error) {
+	if name == "" || strings.EqualFold(name, wavesAsset) {
+		return proto.NewOptionalAssetWaves(), nil
+	}
+	if id, ok := e.assets[name]; ok {
+		return *proto.NewOptionalAssetFromDigest(id), nil
+	}
+	id, err := crypto.NewDigestFromBase58(name)
+	if err != nil {
+		return proto.OptionalAsset{}, errors.Errorf("unknown asset '%s'", name)
+	}
+	return *proto.NewOptionalAssetFromDigest(id), nil
+}
+
+// expand replaces the reference to the account with its address.
+func (e *environment) expand(s string) (string, error) {
+	name, ok := strings.CutPrefix(s, accountPrefix)
+	if !ok {
+		return s, nil
+	}
+	acc, ok := e.accounts[name]
+	if !ok {
+		return "", errors.Errorf("unknown account '%s'", name)
+	}
+	return acc.address.String(), nil
+}
+
+func (e *environment) entries(entries proto.DataEntries) (proto.DataEntries, error) {
+	r := make(proto.DataEntries, len(entries))
+	for i, entry := range entries {
+		key, err := e.expand(entry.GetKey())
+		if err != nil {
+			return nil, err
+		}
+		switch te := entry.(type) {
+		case *proto.IntegerDataEntry:
+			r[i] = &proto.IntegerDataEntry{Key: key, Value: te.Value}
+		case *proto.BooleanDataEntry:
+			r[i] = &proto.BooleanDataEntry{Key: key, Value: te.Value}
+		case *proto.BinaryDataEntry:
+			r[i] = &proto.BinaryDataEntry{Key: key, Value: te.Value}
+		case *proto.StringDataEntry:
+			v, vErr := e.expand(te.Value)
+			if vErr != nil {
+				return nil, vErr
+			}
+			r[i] = &proto.StringDataEntry{Key: key, Value: v}
+		case *proto.DeleteDataEntry:
+			r[i] = &proto.DeleteDataEntry{Key: key}
+		default:
+			return nil, errors.Errorf("unsupported data entry type %T", entry)
+		}
+	}
+	return r, nil
+}
+
+func (e *environment) arguments(args proto.Arguments) (proto.Arguments, error) {
+	r := make(proto.Arguments, len(args))
+	for i, a := range args {
+		switch ta := a.(type) {
+		case *proto.StringArgument:
+			v, err := e.expand(ta.Value)
+			if err != nil {
+				return nil, err
+			}
+			a = &proto.StringArgument{Value: v}
+		case *proto.ListArgument:
+			items, err := e.arguments(ta.Items)
+			if err != nil {
+				return nil, err
+			}
+			a = &proto.ListArgument{Items: items}
+		}
+		r[i] = a
+	}
+	return r, nil
+}
+
+func newAccount(scheme proto.Scheme, name string, a Account) (*account, error) {
+	seed := a.Seed
+	if seed == "" {
+		seed = name
+	}
+	sk, pk, err := crypto.GenerateKeyPair([]byte(seed))
+	if err != nil {
+		return nil, err
+	}
+	addr, err := proto.NewAddressFromPublicKey(scheme, pk)
+	if err != nil {
+		return nil, err
+	}
+	return &account{sk: sk, pk: pk, address: addr, balance: a.Balance}, nil
+}
+
+// newState builds the initial state of the suite with the given script set to the dApp account.
+func (s *Suite) newState(tree *ast.Tree, script proto.Script) (*state, *environment, error) {
+	scheme, err := s.scheme()
+	if err != nil {
+		return nil, nil, err
+	}
+	env := &environment{
+		scheme:   scheme,
+		accounts: make(map[string]*account, len(s.Accounts)),
+		assets:   make(map[string]crypto.Digest, len(s.Assets)),
+	}
+	st := &state{
+		height:    s.Height,
+		timestamp: s.Timestamp,
+		tree:      tree,
+		script:    script,
+		accounts:  make(map[proto.WavesAddress]*account, len(s.Accounts)),
+		assets:    make(map[crypto.Digest]*proto.AssetInfo, len(s.Assets)),
+	}
+	if st.height == 0 {
+		st.height = defaultHeight
+	}
+	for name, a := range s.Accounts {
+		acc, aErr := newAccount(scheme, name, a)
+		if aErr != nil {
+			return nil, nil, errors.Wrapf(aErr, "failed to create account '%s'", name)
+		}
+		env.accounts[name] = acc
+		st.accounts[acc.address] = acc
+	}
+	if _, ok := env.accounts[s.DApp]; !ok { // the dApp account may have no initial state
+		acc, aErr := newAccount(scheme, s.DApp, Account{})
+		if aErr != nil {
+			return nil, nil, errors.Wrapf(aErr, "failed to create account '%s'", s.DApp)
+		}
+		env.accounts[s.DApp] = acc
+		st.accounts[acc.address] = acc
+	}
+	st.dApp = env.accounts[s.DApp]
+	for name, a := range s.Assets {
+		info, aErr := s.newAsset(env, name, a)
+		if aErr != nil {
+			return nil, nil, errors.Wrapf(aErr, "failed to create asset '%s'", name)
+		}
+		env.assets[name] = info.ID
+		st.assets[info.ID] = info
+	}
+	for name, a := range s.Accounts {
+		acc := env.accounts[name]
+		entries, eErr := env.entries(a.Data)
+		if eErr != nil {
+			return nil, nil, errors.Wrapf(eErr, "invalid data of account '%s'", name)
+		}
+		acc.data = make(map[string]proto.DataEntry, len(entries))
+		for _, e := range entries {
+			acc.data[e.GetKey()] = e
+		}
+		acc.assets = make(map[crypto.Digest]uint64, len(a.Assets))
+		for an, amount := range a.Assets {
+			asset, aErr := env.asset(an)
+			if aErr != nil || !asset.Present {
+				return nil, nil, errors.Errorf("invalid asset '%s' of account '%s'", an, name)
+			}
+			acc.assets[asset.ID] = amount
+		}
+	}
+	return st, env, nil
+}
+
+func (s *Suite) newAsset(env *environment, name string, a Asset) (*proto.AssetInfo, error) {
+	id := crypto.MustFastHash([]byte(name))
+	if a.ID != "" {
+		d, err := crypto.NewDigestFromBase58(a.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid asset ID")
+		}
+		id = d
+	}
+	issuer, ok := env.accounts[a.Issuer]
+	if !ok {
+		issuer = env.accounts[s.DApp]
+	}
+	return &proto.AssetInfo{
+		AssetConstInfo: proto.AssetConstInfo{
+			ID:          id,
+			IssueHeight: 1,
+			Issuer:      issuer.address,
+			Decimals:    a.Decimals,
+		},
+		Quantity:        a.Quantity,
+		IssuerPublicKey: issuer.pk,
+		Reissuable:      a.Reissuable,
+	}, nil
+}
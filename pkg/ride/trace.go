@@ -0,0 +1,110 @@
+package ride
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler/stdlib"
+)
+
+const (
+	traceArgsSuffix       = ".@args"
+	traceComplexitySuffix = ".@complexity"
+	traceComplexityLimit  = "@complexityLimit"
+)
+
+// operatorNames are the names of native functions that are called by operators of the language.
+var operatorNames = map[string]string{
+	"0": "==", "100": "+", "101": "-", "102": ">", "103": ">=", "104": "*", "105": "/", "106": "%",
+	"203": "+", "300": "+", "1100": "::", "1101": ":+", "1102": "++",
+}
+
+// nativeNames returns the names of native functions by their identifiers.
+var nativeNames = sync.OnceValue(func() map[string]string {
+	r := make(map[string]string)
+	for _, sigs := range stdlib.FuncsByVersion() {
+		for name, overloads := range sigs.Funcs {
+			for _, o := range overloads {
+				if _, ok := o.ID.(ast.NativeFunction); ok {
+					r[o.ID.Name()] = name
+				}
+			}
+		}
+	}
+	for id, name := range operatorNames {
+		r[id] = name
+	}
+	return r
+})
+
+// TraceEntry is a record of the evaluation log in the format of the Scala node trace. The entry holds
+// either the evaluated value or the error of evaluation.
+type TraceEntry struct {
+	Name  string `json:"name"`
+	Type  string `json:"type,omitempty"`
+	Value any    `json:"value,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Trace collects the log of script evaluation: the values of evaluated variables, the arguments of called
+// functions, the complexity spent by every call and the complexity left after it.
+// Trace is not safe for concurrent use.
+type Trace struct {
+	entries []TraceEntry
+}
+
+func NewTrace() *Trace {
+	return &Trace{}
+}
+
+// Entries returns the collected log entries in the order of evaluation.
+func (t *Trace) Entries() []TraceEntry {
+	return t.entries
+}
+
+func (t *Trace) value(name string, v rideType) {
+	ev := newEvaluatedValue(v)
+	t.entries = append(t.entries, TraceEntry{Name: name, Type: ev.Type, Value: ev.Value})
+}
+
+func (t *Trace) error(name string, err error) {
+	t.entries = append(t.entries, TraceEntry{Name: name, Error: err.Error()})
+}
+
+// call records the arguments of the function call. The arguments are copied, otherwise the argument
+// slices of the evaluator escape to the heap even if the evaluation is not traced.
+func (t *Trace) call(name string, args []rideType) {
+	t.value(name+traceArgsSuffix, rideList(slices.Clone(args)))
+}
+
+func (t *Trace) complexity(name string, spent, left int) {
+	t.value(name+traceComplexitySuffix, rideInt(spent))
+	t.value(traceComplexityLimit, rideInt(left))
+}
+
+// tracingEnvironment is implemented by environments that pass the trace of the callable function to the
+// functions of dApps invoked by it, so the evaluation of nested invocations is recorded into the same trace.
+type tracingEnvironment interface {
+	evaluationTrace() *Trace
+	setEvaluationTrace(t *Trace)
+}
+
+// EvaluationOption configures the evaluation of the script.
+type EvaluationOption func(*treeEvaluator)
+
+// WithTrace makes the evaluator to record the evaluation log into the given trace.
+func WithTrace(t *Trace) EvaluationOption {
+	return func(e *treeEvaluator) {
+		e.trace = t
+	}
+}
+
+// traceFunctionName returns the name of the function in the library, the identifiers of native functions
+// are replaced with their names.
+func traceFunctionName(id string) string {
+	if name, ok := nativeNames()[id]; ok {
+		return name
+	}
+	return id
+}
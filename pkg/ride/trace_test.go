@@ -0,0 +1,176 @@
+package ride
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	ridec "github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/util/byte_helpers"
+)
+
+func TestTraceCallFunction(t *testing.T) {
+	/*
+	   {-# STDLIB_VERSION 3 #-}
+	   {-# CONTENT_TYPE DAPP #-}
+	   {-# SCRIPT_TYPE ACCOUNT #-}
+
+	   func getPreviousAnswer(address: String) = {
+	     address
+	   }
+
+	   @Callable(i)
+	   func tellme(question: String) = {
+	       let answer = getPreviousAnswer(question)
+
+	       WriteSet([
+	           DataEntry(answer + "_q", question),
+	           DataEntry(answer + "_a", answer)
+	           ])
+	   }
+	   ...
+	*/
+	tx := byte_helpers.InvokeScriptWithProofs.Transaction.Clone()
+	env := newTestEnv(t).withLibVersion(ast.LibV3).withComplexityLimit(200).withInvokeTransaction(tx).toEnv()
+	const script = "AAIDAAAAAAAAAAAAAAABAQAAABFnZXRQcmV2aW91c0Fuc3dlcgAAAAEAAAAHYWRkcmVzcwUAAAAHYWRkcmVzcwAAAAIAAAABaQEA" +
+		"AAAGdGVsbG1lAAAAAQAAAAhxdWVzdGlvbgQAAAAGYW5zd2VyCQEAAAARZ2V0UHJldmlvdXNBbnN3ZXIAAAABBQAAAAhxdWVzdGlv" +
+		"bgkBAAAACFdyaXRlU2V0AAAAAQkABEwAAAACCQEAAAAJRGF0YUVudHJ5AAAAAgkAASwAAAACBQAAAAZhbnN3ZXICAAAAAl9xBQAA" +
+		"AAhxdWVzdGlvbgkABEwAAAACCQEAAAAJRGF0YUVudHJ5AAAAAgkAASwAAAACBQAAAAZhbnN3ZXICAAAAAl9hBQAAAAZhbnN3ZXIF" +
+		"AAAAA25pbAAAAAppbnZvY2F0aW9uAQAAAAdkZWZhdWx0AAAAAAQAAAAHc2VuZGVyMAgIBQAAAAppbnZvY2F0aW9uAAAABmNhbGxl" +
+		"cgAAAAVieXRlcwkBAAAACFdyaXRlU2V0AAAAAQkABEwAAAACCQEAAAAJRGF0YUVudHJ5AAAAAgIAAAABYQIAAAABYgkABEwAAAAC" +
+		"CQEAAAAJRGF0YUVudHJ5AAAAAgIAAAAGc2VuZGVyBQAAAAdzZW5kZXIwBQAAAANuaWwAAAABAAAAAnR4AQAAAAZ2ZXJpZnkAAAAA" +
+		"CQAAAAAAAAIJAQAAABFnZXRQcmV2aW91c0Fuc3dlcgAAAAEJAAQlAAAAAQgFAAAAAnR4AAAABnNlbmRlcgIAAAABMcP91gY="
+	_, tree := parseBase64Script(t, script)
+
+	trace := NewTrace()
+	fc := proto.NewFunctionCall("tellme", proto.Arguments{proto.NewStringArgument("q")})
+	res, err := CallFunction(env, tree, fc, WithTrace(trace))
+	require.NoError(t, err)
+	entries := trace.Entries()
+	require.Greater(t, len(entries), 8)
+	assert.Equal(t, []TraceEntry{
+		{Name: "question", Type: "String", Value: "q"},
+		{Name: "getPreviousAnswer.@args", Type: "Array", Value: []EvaluatedValue{{Type: "String", Value: "q"}}},
+		{Name: "getPreviousAnswer.@complexity", Type: "Int", Value: int64(1)},
+		{Name: "@complexityLimit", Type: "Int", Value: int64(198)},
+		{Name: "answer", Type: "String", Value: "q"},
+		{Name: "+.@args", Type: "Array", Value: []EvaluatedValue{
+			{Type: "String", Value: "q"}, {Type: "String", Value: "_q"},
+		}},
+		{Name: "+.@complexity", Type: "Int", Value: int64(10)},
+		{Name: "@complexityLimit", Type: "Int", Value: int64(187)},
+	}, entries[:8])
+	last := entries[len(entries)-1]
+	assert.Equal(t, TraceEntry{Name: "@complexityLimit", Type: "Int", Value: int64(200 - res.Complexity())}, last)
+}
+
+func TestTraceCallVerifier(t *testing.T) {
+	src := "{-# STDLIB_VERSION 6 #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n{-# SCRIPT_TYPE ACCOUNT #-}\n" +
+		`let x = if (1 > 0) then throw("boom") else 1
+x == 1`
+	tree, errs := ridec.CompileExpressionToTree(src)
+	require.Empty(t, errs)
+	env := newTestEnv(t).withLibVersion(tree.LibVersion).withComplexityLimit(2000).toEnv()
+
+	trace := NewTrace()
+	_, err := CallVerifier(env, tree, WithTrace(trace))
+	require.Error(t, err)
+	entries := trace.Entries()
+	require.Len(t, entries, 7)
+	assert.Equal(t, ">.@args", entries[0].Name)
+	assert.Equal(t, TraceEntry{
+		Name: "throw.@args", Type: "Array", Value: []EvaluatedValue{{Type: "String", Value: "boom"}},
+	}, entries[3])
+	assert.Equal(t, "throw.@complexity", entries[4].Name)
+	assert.Equal(t, "x", entries[6].Name)
+	assert.Empty(t, entries[6].Type)
+	assert.Contains(t, entries[6].Error, "boom")
+
+	// Without the option the evaluation is not traced.
+	res, err := CallVerifier(env, tree)
+	assert.Nil(t, res)
+	assert.Error(t, err)
+}
+
+// tracingTestEnvironment is the mock of the environment that passes the trace to nested invocations.
+type tracingTestEnvironment struct {
+	*MockEnvironment
+	trace *Trace
+}
+
+func (e *tracingTestEnvironment) evaluationTrace() *Trace {
+	return e.trace
+}
+
+func (e *tracingTestEnvironment) setEvaluationTrace(t *Trace) {
+	e.trace = t
+}
+
+func TestTraceNestedInvocation(t *testing.T) {
+	sender := newTestAccount(t, "SENDER")
+	dApp1 := newTestAccount(t, "DAPP1")
+	/*
+		{-# STDLIB_VERSION 5#-}
+		{-# CONTENT_TYPE DAPP #-}
+		{-#SCRIPT_TYPE ACCOUNT#-}
+
+		 @Callable(i)
+		 func bar() = {
+		   ([IntegerEntry("bar", 1)], "return")
+		 }
+
+		 @Callable(i)
+		 func foo() = {
+		  let r = invoke(this, "bar", [], [])
+		  if r == "return"
+		  then
+		   let data = getIntegerValue(this, "bar")
+		   if data == 1
+		   then
+		    [
+		     IntegerEntry("key", 1)
+		    ]
+		   else
+		    throw("Bad state")
+		  else
+		   throw("Bad returned value")
+		 }
+	*/
+	_, tree := parseBase64Script(t, "AAIFAAAAAAAAAAYIAhIAEgAAAAAAAAAAAgAAAAFpAQAAAANiYXIAAAAACQAFFAAAAAIJAARMAAAAAgkBAAAA"+
+		"DEludGVnZXJFbnRyeQAAAAICAAAAA2JhcgAAAAAAAAAAAQUAAAADbmlsAgAAAAZyZXR1cm4AAAABaQEAAAADZm9vAAAAAAQAAAABcgkAA/wA"+
+		"AAAEBQAAAAR0aGlzAgAAAANiYXIFAAAAA25pbAUAAAADbmlsAwkAAAAAAAACBQAAAAFyAgAAAAZyZXR1cm4EAAAABGRhdGEJAQAAABFAZXh0"+
+		"ck5hdGl2ZSgxMDUwKQAAAAIFAAAABHRoaXMCAAAAA2JhcgMJAAAAAAAAAgUAAAAEZGF0YQAAAAAAAAAAAQkABEwAAAACCQEAAAAMSW50ZWdl"+
+		"ckVudHJ5AAAAAgIAAAADa2V5AAAAAAAAAAABBQAAAANuaWwJAAACAAAAAQIAAAAJQmFkIHN0YXRlCQAAAgAAAAECAAAAEkJhZCByZXR1cm5l"+
+		"ZCB2YWx1ZQAAAADz23Fz")
+	env := newTestEnv(t).withLibVersion(ast.LibV5).withComplexityLimit(2000).
+		withSender(sender).withThis(dApp1).withDApp(dApp1).withTree(dApp1, tree).
+		withInvocation("test").
+		withWavesBalance(sender, 10000).withWavesBalance(dApp1, 10000).
+		withWrappedState()
+
+	trace := NewTrace()
+	te := &tracingTestEnvironment{MockEnvironment: env.toEnv()}
+	_, err := CallFunction(te, tree, proto.NewFunctionCall("foo", proto.Arguments{}), WithTrace(trace))
+	require.NoError(t, err)
+	entries := trace.Entries()
+	index := func(name string) int {
+		for i, e := range entries {
+			if e.Name == name {
+				return i
+			}
+		}
+		return -1
+	}
+	// The evaluation of the invoked function is recorded before the complexity of the invoke call.
+	nested, invoke, r := index("IntegerEntry.@args"), index("invoke.@complexity"), index("r")
+	require.NotEqual(t, -1, nested)
+	assert.Less(t, nested, invoke)
+	assert.Less(t, invoke, r)
+	assert.Equal(t, TraceEntry{Name: "IntegerEntry.@args", Type: "Array", Value: []EvaluatedValue{
+		{Type: "String", Value: "bar"}, {Type: "Int", Value: int64(1)},
+	}}, entries[nested])
+	assert.Equal(t, TraceEntry{Name: "r", Type: "String", Value: "return"}, entries[r])
+}
@@ -6,11 +6,14 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/types"
 )
 
-func CallVerifier(env environment, tree *ast.Tree) (Result, error) {
+func CallVerifier(env environment, tree *ast.Tree, opts ...EvaluationOption) (Result, error) {
 	e, err := treeVerifierEvaluator(env, tree)
 	if err != nil {
 		return nil, RuntimeError.Wrap(err, "failed to call verifier")
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
 	res, err := e.evaluate()
 	if err != nil {
 		return nil, handleEvaluationError(err, e.fName, e.complexity())
@@ -18,7 +21,7 @@ func CallVerifier(env environment, tree *ast.Tree) (Result, error) {
 	return res, nil
 }
 
-func CallFunction(env environment, tree *ast.Tree, fc proto.FunctionCall) (Result, error) {
+func CallFunction(env environment, tree *ast.Tree, fc proto.FunctionCall, opts ...EvaluationOption) (Result, error) {
 	var (
 		name = fc.Name()
 		args = fc.Arguments()
@@ -31,6 +34,15 @@ func CallFunction(env environment, tree *ast.Tree, fc proto.FunctionCall) (Resul
 	if err != nil {
 		return nil, EvaluationFailure.Wrapf(err, "failed to call function '%s'", name)
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.trace != nil {
+		if te, ok := env.(tracingEnvironment); ok {
+			te.setEvaluationTrace(e.trace)
+		}
+		e.traceArguments()
+	}
 	// After that instruction script/function is executed,
 	// so result of the execution and spent complexity should be considered outside.
 	rideResult, err := e.evaluate()
@@ -54,6 +66,24 @@ func CallFunction(env environment, tree *ast.Tree, fc proto.FunctionCall) (Resul
 	return dAppResult, nil
 }
 
+// EvaluateExpression evaluates the expression script and returns its result, which can be of any type.
+// Unlike CallVerifier, the result is not required to be boolean. Use ResultValue to get the evaluated value.
+func EvaluateExpression(env environment, tree *ast.Tree) (Result, error) {
+	if tree.IsDApp() {
+		return nil, EvaluationFailure.New("unable to evaluate dApp script as expression")
+	}
+	e, err := treeVerifierEvaluator(env, tree)
+	if err != nil {
+		return nil, RuntimeError.Wrap(err, "failed to evaluate expression")
+	}
+	r, err := e.walk(e.f)
+	if err != nil {
+		return nil, handleEvaluationError(err, e.fName, e.complexity())
+	}
+	b, _ := r.(rideBoolean)
+	return ScriptResult{res: bool(b), param: r, complexity: e.complexity()}, nil
+}
+
 func wrappedStateActions(state types.SmartState) []proto.ScriptAction {
 	ws, ok := state.(*WrappedState)
 	if !ok {
@@ -325,6 +325,72 @@ func TestUserFunctionsInExpression(t *testing.T) {
 	assert.True(t, r.Result())
 }
 
+func TestEvaluateExpression(t *testing.T) {
+	for _, test := range []struct {
+		expr string
+		exp  string
+	}{
+		{`1 + 2`, `{"type":"Int","value":3}`},
+		{`"a" + "b"`, `{"type":"String","value":"ab"}`},
+		{`1 == 1`, `{"type":"Boolean","value":true}`},
+		{`base58'3xL'`, `{"type":"ByteVector","value":"3xL"}`},
+		{`[1, 2]`, `{"type":"Array","value":[{"type":"Int","value":1},{"type":"Int","value":2}]}`},
+		{`(1, "x")`, `{"type":"Tuple","value":{"_1":{"type":"Int","value":1},"_2":{"type":"String","value":"x"}}}`},
+		{`unit`, `{"type":"Unit","value":{}}`},
+	} {
+		src := "{-# STDLIB_VERSION 6 #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n{-# SCRIPT_TYPE ACCOUNT #-}\n" + test.expr
+		tree, errs := ridec.CompileExpressionToTree(src)
+		require.Empty(t, errs, test.expr)
+		env := newTestEnv(t).withLibVersion(tree.LibVersion).withComplexityLimit(2000).toEnv()
+		res, err := EvaluateExpression(env, tree)
+		require.NoError(t, err, test.expr)
+		js, err := json.Marshal(ResultValue(res))
+		require.NoError(t, err)
+		assert.JSONEq(t, test.exp, string(js), test.expr)
+	}
+}
+
+func TestEvaluateExpressionWithEnvironment(t *testing.T) {
+	const src = "{-# STDLIB_VERSION 6 #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n{-# SCRIPT_TYPE ACCOUNT #-}\n" +
+		"height * 2"
+	tree, errs := ridec.CompileExpressionToTree(src)
+	require.Empty(t, errs)
+	env := newTestEnv(t).withLibVersion(tree.LibVersion).withComplexityLimit(2000).withHeight(123).toEnv()
+	res, err := EvaluateExpression(env, tree)
+	require.NoError(t, err)
+	assert.Equal(t, EvaluatedValue{Type: intTypeName, Value: int64(246)}, ResultValue(res))
+	assert.Positive(t, res.Complexity())
+}
+
+func TestEvaluateExpressionFailures(t *testing.T) {
+	const header = "{-# STDLIB_VERSION 6 #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n{-# SCRIPT_TYPE ACCOUNT #-}\n"
+	tree, errs := ridec.CompileExpressionToTree(header + `throw("failure")`)
+	require.Empty(t, errs)
+	env := newTestEnv(t).withLibVersion(tree.LibVersion).withComplexityLimit(2000).toEnv()
+	_, err := EvaluateExpression(env, tree)
+	require.Error(t, err)
+	assert.Equal(t, UserError, GetEvaluationErrorType(err))
+
+	tree, errs = ridec.CompileExpressionToTree(header + `sigVerify(base58'', base58'', base58'')`)
+	require.Empty(t, errs)
+	env = newTestEnv(t).withLibVersion(tree.LibVersion).withComplexityLimit(10).toEnv()
+	_, err = EvaluateExpression(env, tree)
+	require.Error(t, err)
+	assert.Equal(t, ComplexityLimitExceed, GetEvaluationErrorType(err))
+
+	dApp, errs := ridec.CompileToTree(`
+		{-# STDLIB_VERSION 6 #-}
+		{-# CONTENT_TYPE DAPP #-}
+		{-# SCRIPT_TYPE ACCOUNT #-}
+		@Callable(i)
+		func call() = []
+	`)
+	require.Empty(t, errs)
+	_, err = EvaluateExpression(newTestEnv(t).withLibVersion(dApp.LibVersion).toEnv(), dApp)
+	require.Error(t, err)
+	assert.Equal(t, EvaluationFailure, GetEvaluationErrorType(err))
+}
+
 func TestDataFunctions(t *testing.T) {
 	acc := newTestAccount(t, "TEST")
 	tx := newTestDataTransaction(t, acc)
@@ -196,12 +196,54 @@ type treeEvaluator struct {
 	fName string
 	s     evaluationScope
 	env   environment
+	trace *Trace // optional log of evaluation
 }
 
 func (e *treeEvaluator) complexity() int {
 	return e.env.complexityCalculator().complexity()
 }
 
+// The trace helpers below are kept out of line, so the recursive evaluation functions stay small
+// and the untraced evaluation pays only for the nil check of the trace.
+
+// traceCall records the arguments of the function call.
+//
+//go:noinline
+func (e *treeEvaluator) traceCall(name string, args []rideType) {
+	e.trace.call(traceFunctionName(name), args)
+}
+
+// traceComplexity records the complexity spent by the function call and the complexity left after it.
+//
+//go:noinline
+func (e *treeEvaluator) traceComplexity(name string, spent int) {
+	cc := e.env.complexityCalculator()
+	e.trace.complexity(traceFunctionName(name), spent, cc.limit()-cc.complexity())
+}
+
+// traceValue records the evaluated value of the scope variable.
+//
+//go:noinline
+func (e *treeEvaluator) traceValue(id string, v rideType) {
+	e.trace.value(id, v)
+}
+
+// traceError records the error of evaluation of the scope variable.
+//
+//go:noinline
+func (e *treeEvaluator) traceError(id string, err error) {
+	e.trace.error(id, err)
+}
+
+// traceArguments records the values of the arguments of the callable function.
+func (e *treeEvaluator) traceArguments() {
+	for _, v := range e.s.cs[len(e.s.cs)-1] {
+		if v.value != nil {
+			e.trace.value(v.id, v.value)
+		}
+	}
+}
+
 func errTypeFromComplexityCalcErr(err error) EvaluationError {
 	eet := Undefined
 	if ccErr := complexityCalculatorError(nil); errors.As(err, &ccErr) {
@@ -316,8 +358,14 @@ func (e *treeEvaluator) evaluateNativeFunction(name string, arguments []ast.Node
 		eet := errTypeFromComplexityCalcErr(tErr)
 		return nil, eet.Wrap(tErr, "failed to test complexity of system function")
 	}
+	if e.trace != nil {
+		e.traceCall(name, args)
+	}
 	defer func() {
 		e.env.complexityCalculator().addNativeFunctionComplexity(name, cost)
+		if e.trace != nil {
+			e.traceComplexity(name, cost)
+		}
 	}()
 	r, err := f(e.env, args...)
 	if err != nil {
@@ -328,8 +376,14 @@ func (e *treeEvaluator) evaluateNativeFunction(name string, arguments []ast.Node
 
 func (e *treeEvaluator) evaluateUserFunction(name string, args []rideType) (rideType, error) {
 	initialComplexity := e.env.complexityCalculator().complexity()
+	if e.trace != nil {
+		e.traceCall(name, args)
+	}
 	defer func() {
 		e.env.complexityCalculator().addAdditionalUserFunctionComplexity(name, initialComplexity)
+		if e.trace != nil {
+			e.traceComplexity(name, e.complexity()-initialComplexity)
+		}
 	}()
 	uf, cl, found := e.s.userFunction(name)
 	if !found {
@@ -433,8 +487,14 @@ func (e *treeEvaluator) walk(node ast.Node) (rideType, error) {
 			}
 			r, err := e.walk(v.expression)
 			if err != nil {
+				if e.trace != nil {
+					e.traceError(id, err)
+				}
 				return nil, EvaluationErrorPushf(err, "failed to evaluate expression of scope value '%s'", id)
 			}
+			if e.trace != nil {
+				e.traceValue(id, r)
+			}
 			e.s.updateValue(f, p, id, r)
 			return r, nil
 		}
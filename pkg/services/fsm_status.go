@@ -0,0 +1,29 @@
+package services
+
+import "sync/atomic"
+
+// FSMStatus keeps the name of the current state of the node's FSM. It's updated by the FSM and can be read
+// concurrently, for example by APIs.
+type FSMStatus struct {
+	name atomic.Pointer[string]
+}
+
+func NewFSMStatus() *FSMStatus {
+	return &FSMStatus{}
+}
+
+// Set stores the name of the current FSM state.
+func (s *FSMStatus) Set(name string) {
+	s.name.Store(&name)
+}
+
+// Get returns the name of the current FSM state or an empty string if the FSM is not started yet.
+func (s *FSMStatus) Get() string {
+	if s == nil {
+		return ""
+	}
+	if n := s.name.Load(); n != nil {
+		return *n
+	}
+	return ""
+}
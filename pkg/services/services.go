@@ -57,4 +57,5 @@ type Services struct {
 	InternalChannel chan messages.InternalMessage
 	MinPeersMining  int
 	SkipMessageList *messages.SkipMessageList
+	FSMStatus       *FSMStatus
 }
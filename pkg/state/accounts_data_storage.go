@@ -286,6 +286,62 @@ func (s *accountsDataStorage) retrieveEntries(addr proto.Address) ([]proto.DataE
 	return entries, nil
 }
 
+// retrieveEntriesAtHeight returns all data entries of the account as they were at the given height.
+// The height must be within the rollback window, older history entries are cut.
+func (s *accountsDataStorage) retrieveEntriesAtHeight(
+	addr proto.Address, height proto.Height,
+) ([]proto.DataEntry, error) {
+	addrNum, err := s.addrToNum(addr)
+	if err != nil {
+		if errors.Is(err, keyvalue.ErrNotFound) { // no data was ever saved for the address
+			return nil, nil
+		}
+		return nil, err
+	}
+	key := accountsDataStorKey{addrNum: addrNum}
+	iter, err := s.hs.newTopEntryIteratorByPrefix(key.accountPrefix())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		iter.Release()
+		if itErr := iter.Error(); itErr != nil {
+			slog.Error("Iterator error", logging.Error(itErr))
+			panic(itErr)
+		}
+	}()
+
+	var entries []proto.DataEntry
+	for iter.Next() {
+		entryKeyBytes := keyvalue.SafeKey(iter)
+		recordBytes, err := s.hs.entryDataAtHeight(entryKeyBytes, height)
+		if err != nil {
+			return nil, err
+		}
+		if recordBytes == nil { // the entry was created after the height
+			continue
+		}
+		var record dataEntryRecord
+		if err := record.unmarshalBinary(recordBytes); err != nil {
+			return nil, err
+		}
+		var entryKey accountsDataStorKey
+		if err := entryKey.unmarshal(entryKeyBytes); err != nil {
+			return nil, err
+		}
+		entry, err := proto.NewDataEntryFromValueBytes(record.value)
+		if err != nil {
+			return nil, err
+		}
+		if entry.GetValueType() == proto.DataDelete {
+			continue
+		}
+		entry.SetKey(entryKey.entryKey)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
 func (s *accountsDataStorage) newestEntryExists(addr proto.Address) (bool, error) {
 	addrNum, newest, err := s.newestAddrToNum(addr)
 	if err != nil {
@@ -352,6 +408,36 @@ func (s *accountsDataStorage) retrieveEntry(addr proto.Address, key string) (pro
 	return entry, nil
 }
 
+// retrieveEntryAtHeight returns data entry by the given key as it was at the given height.
+// The height must be within the rollback window, older history entries are cut.
+func (s *accountsDataStorage) retrieveEntryAtHeight(addr proto.Address, key string, height proto.Height) (proto.DataEntry, error) {
+	addrNum, err := s.addrToNum(addr)
+	if err != nil {
+		return nil, err
+	}
+	storKey := accountsDataStorKey{addrNum, key}
+	recordBytes, err := s.hs.entryDataAtHeight(storKey.bytes(), height)
+	if err != nil {
+		return nil, err
+	}
+	if recordBytes == nil {
+		return nil, errors.Wrapf(keyvalue.ErrNotFound, "entry '%s' not found at height %d", key, height)
+	}
+	var record dataEntryRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return nil, err
+	}
+	entry, err := proto.NewDataEntryFromValueBytes(record.value)
+	if err != nil {
+		return nil, err
+	}
+	if entry.GetValueType() == proto.DataDelete {
+		return nil, errors.Wrapf(keyvalue.ErrNotFound, "entry '%s' was removed at height %d", key, height)
+	}
+	entry.SetKey(key)
+	return entry, nil
+}
+
 func (s *accountsDataStorage) retrieveNewestIntegerEntry(addr proto.Address, key string) (*proto.IntegerDataEntry, error) {
 	id := entryId{addr.ID(), key}
 	if entry, ok := s.uncertainEntries[id]; ok {
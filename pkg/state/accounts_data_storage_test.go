@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -44,6 +45,38 @@ func TestAppendEntry(t *testing.T) {
 	assert.Equal(t, entry1, newEntry)
 }
 
+func TestRetrieveEntryAtHeight(t *testing.T) {
+	to := createAccountsDataStorage(t, true)
+
+	to.stor.addBlock(t, blockID0)
+	addr0 := testGlobal.senderInfo.addr
+	entry0 := &proto.IntegerDataEntry{Key: "Whatever", Value: int64(100500)}
+	err := to.accountsDataStor.appendEntry(addr0, entry0, blockID0)
+	assert.NoError(t, err)
+	to.stor.flush(t)
+	to.stor.addBlock(t, blockID1)
+	entry1 := &proto.BooleanDataEntry{Key: "Whatever", Value: true}
+	err = to.accountsDataStor.appendEntry(addr0, entry1, blockID1)
+	assert.NoError(t, err)
+	to.stor.flush(t)
+	to.stor.addBlock(t, blockID2)
+	err = to.accountsDataStor.appendEntry(addr0, &proto.DeleteDataEntry{Key: "Whatever"}, blockID2)
+	assert.NoError(t, err)
+	to.stor.flush(t)
+
+	height := to.stor.rw.recentHeight()
+	entry, err := to.accountsDataStor.retrieveEntryAtHeight(addr0, entry0.Key, height-2)
+	assert.NoError(t, err)
+	assert.Equal(t, entry0, entry)
+	entry, err = to.accountsDataStor.retrieveEntryAtHeight(addr0, entry0.Key, height-1)
+	assert.NoError(t, err)
+	assert.Equal(t, entry1, entry)
+	_, err = to.accountsDataStor.retrieveEntryAtHeight(addr0, entry0.Key, height)
+	assert.ErrorIs(t, err, keyvalue.ErrNotFound)
+	_, err = to.accountsDataStor.retrieveEntryAtHeight(addr0, "unknown", height)
+	assert.ErrorIs(t, err, keyvalue.ErrNotFound)
+}
+
 func TestRetrieveEntries(t *testing.T) {
 	to := createAccountsDataStorage(t, true)
 
@@ -294,6 +294,31 @@ func (a *aliases) addrByAlias(aliasStr string) (proto.WavesAddress, error) {
 	return record.info.addressID.ToWavesAddress(a.scheme)
 }
 
+// addrByAliasAtHeight returns the address of the alias as it was at the given height.
+// The height must be within the rollback window, older history entries are cut.
+func (a *aliases) addrByAliasAtHeight(aliasStr string, height proto.Height) (proto.WavesAddress, error) {
+	disabled, err := a.isDisabled(aliasStr)
+	if err != nil {
+		return proto.WavesAddress{}, err
+	}
+	if disabled {
+		return proto.WavesAddress{}, errAliasDisabled
+	}
+	key := aliasKey{alias: aliasStr}
+	recordBytes, err := a.hs.entryDataAtHeight(key.bytes(), height)
+	if err != nil {
+		return proto.WavesAddress{}, err
+	}
+	if recordBytes == nil {
+		return proto.WavesAddress{}, errors.Wrapf(keyvalue.ErrNotFound, "alias %q not found at height %d", aliasStr, height)
+	}
+	var record aliasRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return proto.WavesAddress{}, errors.Wrap(err, "failed to unmarshal record")
+	}
+	return record.info.addressID.ToWavesAddress(a.scheme)
+}
+
 func (a *aliases) disableStolenAliases(blockID proto.BlockID) error {
 	// TODO: this action can not be rolled back now, do we need it?
 	iter, err := a.hs.newNewestTopEntryIterator(alias)
@@ -11,6 +11,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/libs/ntptime"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/types"
@@ -35,6 +36,9 @@ type TransactionIterator interface {
 type StateInfo interface {
 	// Block getters.
 	TopBlock() *proto.Block
+	// BlockchainChanged returns the channel that is closed on the next change of the top block,
+	// on appending of blocks or microblocks and on rollback.
+	BlockchainChanged() <-chan struct{}
 	Block(blockID proto.BlockID) (*proto.Block, error)
 	BlockByHeight(height proto.Height) (*proto.Block, error)
 	NewestBlockInfoByHeight(height proto.Height) (*proto.BlockInfo, error)
@@ -48,11 +52,22 @@ type StateInfo interface {
 	BlockIDToHeight(blockID proto.BlockID) (proto.Height, error)
 	HeightToBlockID(height proto.Height) (proto.BlockID, error)
 	WavesBalance(account proto.Recipient) (uint64, error)
+	// WavesBalanceAtHeight returns regular Waves balance of account at the given height.
+	// The height must be within the rollback window.
+	WavesBalanceAtHeight(account proto.Recipient, height proto.Height) (uint64, error)
+	// LeaseBalanceAtHeight returns incoming and outgoing leasing of account at the given height.
+	// The height must be within the rollback window.
+	LeaseBalanceAtHeight(account proto.Recipient, height proto.Height) (int64, int64, error)
 	// FullWavesBalance returns complete Waves balance record.
 	FullWavesBalance(account proto.Recipient) (*proto.FullWavesBalance, error)
 	GeneratingBalance(account proto.Recipient, height proto.Height) (uint64, error)
 	// AssetBalance retrieves balance of account in specific currency, asset is asset's ID.
 	AssetBalance(account proto.Recipient, assetID proto.AssetID) (uint64, error)
+	// AssetBalanceAtHeight retrieves balance of account in specific asset at the given height.
+	// The height must be within the rollback window.
+	AssetBalanceAtHeight(account proto.Recipient, assetID proto.AssetID, height proto.Height) (uint64, error)
+	// AssetBalances retrieves all non-zero asset balances of account.
+	AssetBalances(account proto.Recipient) ([]proto.AssetBalance, error)
 	// WavesAddressesNumber returns total number of Waves addresses in state.
 	// It is extremely slow, so it is recommended to only use for testing purposes.
 	WavesAddressesNumber() (uint64, error)
@@ -85,6 +100,9 @@ type StateInfo interface {
 	// Accounts data storage.
 	RetrieveEntries(account proto.Recipient) ([]proto.DataEntry, error)
 	RetrieveEntry(account proto.Recipient, key string) (proto.DataEntry, error)
+	// RetrieveEntryAtHeight retrieves data entry as it was at the given height.
+	// The height must be within the rollback window.
+	RetrieveEntryAtHeight(account proto.Recipient, key string, height proto.Height) (proto.DataEntry, error)
 	RetrieveIntegerEntry(account proto.Recipient, key string) (*proto.IntegerDataEntry, error)
 	RetrieveBooleanEntry(account proto.Recipient, key string) (*proto.BooleanDataEntry, error)
 	RetrieveStringEntry(account proto.Recipient, key string) (*proto.StringDataEntry, error)
@@ -101,17 +119,36 @@ type StateInfo interface {
 
 	// Asset fee sponsorship.
 	AssetIsSponsored(assetID proto.AssetID) (bool, error)
+	// MinFee returns the minimal fee of the transaction and the asset the fee is paid in.
+	// The fee is calculated by the same rules as on transaction validation, including the extra fees
+	// for smart accounts and smart assets and the conversion into the sponsored asset.
+	MinFee(tx proto.Transaction) (proto.OptionalAsset, uint64, error)
 	IsAssetExist(assetID proto.AssetID) (bool, error)
 	AssetInfo(assetID proto.AssetID) (*proto.AssetInfo, error)
 	FullAssetInfo(assetID proto.AssetID) (*proto.FullAssetInfo, error)
 	EnrichedFullAssetInfo(assetID proto.AssetID) (*proto.EnrichedFullAssetInfo, error)
+	// EnrichedFullAssetInfoAtHeight returns asset information as it was at the given height.
+	// The height must be within the rollback window.
+	EnrichedFullAssetInfoAtHeight(assetID proto.AssetID, height proto.Height) (*proto.EnrichedFullAssetInfo, error)
 	NFTList(account proto.Recipient, limit uint64, afterAssetID *proto.AssetID) ([]*proto.FullAssetInfo, error)
 	// Script information.
 	ScriptBasicInfoByAccount(account proto.Recipient) (*proto.ScriptBasicInfo, error)
 	ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error)
 	ScriptInfoByAsset(assetID proto.AssetID) (*proto.ScriptInfo, error)
+	// ScriptInfoByAssetAtHeight returns asset script information as it was at the given height.
+	// The height must be within the rollback window.
+	ScriptInfoByAssetAtHeight(assetID proto.AssetID, height proto.Height) (*proto.ScriptInfo, error)
 	NewestScriptByAccount(account proto.Recipient) (*ast.Tree, error)
 	NewestScriptBytesByAccount(account proto.Recipient) (proto.Script, error)
+	// ScriptBytesByAccountAtHeight returns account script as it was at the given height.
+	// The height must be within the rollback window.
+	ScriptBytesByAccountAtHeight(account proto.Recipient, height proto.Height) (proto.Script, error)
+	// Read-only script evaluation, changes made by scripts are never applied to the state.
+	EvaluateExpression(addr proto.WavesAddress, expr *ast.Tree) (ride.Result, error)
+	EvaluateFunctionCall(addr proto.WavesAddress, fc proto.FunctionCall) (ride.Result, error)
+	// TraceInvoke re-executes the confirmed invoke script transaction against the state right before it
+	// and returns the log of evaluation. The state before the transaction must be within the rollback window.
+	TraceInvoke(txID crypto.Digest) (*InvokeTrace, error)
 
 	// Leases.
 	IsActiveLeasing(leaseID crypto.Digest) (bool, error)
@@ -122,6 +159,12 @@ type StateInfo interface {
 	ProvidesExtendedApi() (bool, error)
 	// True if state stores and calculates state hashes for each block height.
 	ProvidesStateHashes() (bool, error)
+	// PrunedHeight returns the height of the last block with transactions removed by pruning,
+	// zero is returned if nothing is pruned.
+	PrunedHeight() proto.Height
+	// RollbackMinHeight returns the lowest height the state can be rolled back to.
+	// The queries at height are available only for the heights starting from it.
+	RollbackMinHeight() (proto.Height, error)
 
 	// State hashes.
 	LegacyStateHashAtHeight(height proto.Height) (*proto.StateHash, error)
@@ -197,6 +240,11 @@ type StateModifier interface {
 
 	// Func internally calls ResetValidationList.
 	TxValidation(func(validation TxValidation) error) error
+	// DryRunTx validates the transaction against the current state like ValidateNextTx does,
+	// but the validation list is reset after the call, so the state remains untouched.
+	// Signature of the transaction is not checked if verifySignature is false.
+	// Returns the transaction snapshot and the complexity spent by scripts.
+	DryRunTx(tx proto.Transaction, currentTimestamp uint64, verifySignature bool) ([]proto.AtomicSnapshot, uint64, error)
 
 	// Way to call multiple operations under same lock.
 	Map(func(state NonThreadSafeState) error) error
@@ -297,6 +345,10 @@ type StateParams struct {
 	ProvideExtendedApi bool
 	// BuildStateHashes enables building and storing state hashes by height.
 	BuildStateHashes bool
+	// Checkpoints configures periodic creation of state checkpoints.
+	Checkpoints CheckpointParams
+	// Pruning configures removal of old transactions and snapshots from the state.
+	Pruning PruningParams
 }
 
 func DefaultStateParams() StateParams {
@@ -128,8 +128,8 @@ func (a *txAppender) checkDuplicateTxIdsImpl(id []byte, recentIds map[string]str
 	if _, ok := recentIds[string(id)]; ok {
 		return proto.NewInfoMsg(errors.Errorf("transaction with ID %s already in state", base58.Encode(id)))
 	}
-	// Check DB.
-	if _, _, err := a.rw.readTransaction(id); err == nil {
+	// Check DB, bodies of old transactions may be pruned, so only the information about transaction is checked.
+	if _, err := a.rw.transactionInfoByID(id); err == nil {
 		return proto.NewInfoMsg(errors.Errorf("transaction with ID %s already in state", base58.Encode(id)))
 	}
 	return nil
@@ -389,7 +389,7 @@ func (a *txAppender) verifyWavesTxSigAndData(
 ) error {
 	// Detect what signatures must be checked for this transaction.
 	// For transaction with SmartAccount we don't check signature.
-	checkTxSig := !accountHasVerifierScript
+	checkTxSig := !accountHasVerifierScript && !params.skipTxSig
 	checkOrder1, checkOrder2, err := a.needToCheckOrdersSignatures(tx)
 	if err != nil {
 		return err
@@ -431,6 +431,7 @@ type appendTxParams struct {
 	blockRewardDistributionActivated bool
 	lightNodeActivated               bool
 	validatingUtx                    bool // if validatingUtx == false then chans MUST be initialized with non nil value
+	skipTxSig                        bool // signature is not checked, used for dry-run of unsigned transactions
 	currentMinerPK                   crypto.PublicKey
 }
 
@@ -1291,6 +1292,7 @@ func (a *txAppender) validateNextTx(
 	parentTimestamp uint64,
 	version proto.BlockVersion,
 	acceptFailed bool,
+	skipTxSig bool,
 ) ([]proto.AtomicSnapshot, error) {
 	// TODO: Doesn't work correctly if miner doesn't work in NG mode.
 	// In this case it returns the last block instead of what is being mined.
@@ -1360,6 +1362,7 @@ func (a *txAppender) validateNextTx(
 		blockRewardDistributionActivated: blockRewardDistributionActivated,
 		lightNodeActivated:               lightNodeActivated,
 		validatingUtx:                    true,
+		skipTxSig:                        skipTxSig,
 	}
 	snapshot, err := a.appendTx(tx, appendTxArgs)
 	if err != nil {
@@ -1368,6 +1371,69 @@ func (a *txAppender) validateNextTx(
 	return snapshot.regular, nil
 }
 
+// minFee calculates the minimal fee of the transaction by the same rules that are used on transaction validation.
+// It returns the fee amount and the asset in which the fee has to be paid.
+func (a *txAppender) minFee(tx proto.Transaction, version proto.BlockVersion) (proto.OptionalAsset, uint64, error) {
+	block, err := a.currentBlock()
+	if err != nil {
+		return proto.OptionalAsset{}, 0, errs.Extend(err, "failed get currentBlock")
+	}
+	blockInfo, err := a.currentBlockInfo()
+	if err != nil {
+		return proto.OptionalAsset{}, 0, errs.Extend(err, "failed get currentBlockInfo")
+	}
+	rideV5Activated, err := a.stor.features.newestIsActivated(int16(settings.RideV5))
+	if err != nil {
+		return proto.OptionalAsset{}, 0, errs.Extend(err, "failed to check 'RideV5' is activated")
+	}
+	rideV6Activated, err := a.stor.features.newestIsActivated(int16(settings.RideV6))
+	if err != nil {
+		return proto.OptionalAsset{}, 0, errs.Extend(err, "failed to check 'RideV6' is activated")
+	}
+	blockRewardDistribution, err := a.stor.features.newestIsActivated(int16(settings.BlockRewardDistribution))
+	if err != nil {
+		return proto.OptionalAsset{}, 0, errs.Extend(err, "failed to check 'BlockRewardDistribution' is activated")
+	}
+	if ethTx, ok := tx.(*proto.EthereumTransaction); ok {
+		kind, kErr := a.ethTxKindResolver.ResolveTxKind(ethTx, blockRewardDistribution)
+		if kErr != nil {
+			return proto.OptionalAsset{}, 0, errors.Wrap(kErr, "failed to guess ethereum transaction kind")
+		}
+		withKind := *ethTx // the kind is set on the copy to leave the given transaction untouched
+		withKind.TxKind = kind
+		tx = &withKind
+	}
+	// Transaction's timestamp is used as the current one and there is no parent timestamp,
+	// so the timestamp checks never fail, only the transaction fee matters here.
+	info := &checkerInfo{
+		currentTimestamp:        tx.GetTimestamp(),
+		blockID:                 block.BlockID(),
+		blockVersion:            version,
+		blockchainHeight:        blockInfo.Height - 1,
+		rideV5Activated:         rideV5Activated,
+		rideV6Activated:         rideV6Activated,
+		blockRewardDistribution: blockRewardDistribution,
+		estimatingFee:           true,
+	}
+	if _, err = a.txHandler.checkTx(tx, info); err != nil {
+		return proto.OptionalAsset{}, 0, err
+	}
+	if info.feeAssets == nil {
+		return proto.OptionalAsset{}, 0, errors.Errorf("fee is not validated for transaction of type %T", tx)
+	}
+	params := &feeValidationParams{
+		stor:            a.stor,
+		settings:        a.settings,
+		txAssets:        info.feeAssets,
+		rideV5Activated: rideV5Activated,
+	}
+	fee, err := minFeeInFeeAsset(tx, params)
+	if err != nil {
+		return proto.OptionalAsset{}, 0, err
+	}
+	return info.feeAssets.feeAsset, fee, nil
+}
+
 func (a *txAppender) createNextSnapshotHash(
 	block *proto.Block,
 	blockHeight proto.Height,
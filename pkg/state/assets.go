@@ -388,6 +388,28 @@ func (a *assets) assetInfo(assetID proto.AssetID) (*assetInfo, error) {
 	return &assetInfo{assetConstInfo: *constInfo, assetChangeableInfo: record.assetChangeableInfo}, nil
 }
 
+// assetInfoAtHeight returns the asset info as it was at the given height.
+// The height must be within the rollback window. If the asset wasn't issued by the height,
+// error of type `errs.UnknownAsset` is returned.
+func (a *assets) assetInfoAtHeight(assetID proto.AssetID, height proto.Height) (*assetInfo, error) {
+	constInfo, err := a.constInfo(assetID) // `errs.UnknownAsset` error here
+	if err != nil {
+		return nil, err
+	}
+	histKey := assetHistKey{assetID: assetID}
+	recordBytes, err := a.hs.entryDataAtHeight(histKey.bytes(), height)
+	if isNotFoundInHistoryOrDBErr(err) || (err == nil && recordBytes == nil) {
+		return nil, errs.NewUnknownAsset(fmt.Sprintf("asset %q is not issued at height %d", assetID.String(), height))
+	} else if err != nil {
+		return nil, err
+	}
+	var record assetHistoryRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal record")
+	}
+	return &assetInfo{assetConstInfo: *constInfo, assetChangeableInfo: record.assetChangeableInfo}, nil
+}
+
 // commitUncertain() moves all uncertain changes to historyStorage.
 func (a *assets) commitUncertain(blockID proto.BlockID) error {
 	for assetID, info := range a.uncertainAssetInfo {
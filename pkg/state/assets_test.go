@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/errs"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -67,6 +68,35 @@ func TestReissueAsset(t *testing.T) {
 	}
 }
 
+func TestAssetInfoAtHeight(t *testing.T) {
+	to := createAssets(t)
+
+	to.stor.addBlock(t, blockID0)
+	to.stor.addBlock(t, blockID1)
+	assetID, err := crypto.NewDigestFromBytes(bytes.Repeat([]byte{0xff}, crypto.DigestSize))
+	assert.NoError(t, err, "failed to create digest from bytes")
+	asset := defaultAssetInfo(proto.DigestTail(assetID), true)
+	id := proto.AssetIDFromDigest(assetID)
+	err = to.assets.issueAsset(id, asset, blockID1)
+	assert.NoError(t, err, "failed to issue asset")
+	to.stor.flush(t)
+	to.stor.addBlock(t, blockID2)
+	err = to.assets.reissueAsset(id, &assetReissueChange{false, 1}, blockID2)
+	assert.NoError(t, err, "failed to reissue asset")
+	to.stor.flush(t)
+
+	height := to.stor.rw.recentHeight()
+	_, err = to.assets.assetInfoAtHeight(id, height-2)
+	assert.ErrorIs(t, err, errs.UnknownAsset{})
+	info, err := to.assets.assetInfoAtHeight(id, height-1)
+	assert.NoError(t, err)
+	assert.True(t, info.equal(asset))
+	info, err = to.assets.assetInfoAtHeight(id, height)
+	assert.NoError(t, err)
+	assert.False(t, info.reissuable)
+	assert.Equal(t, big.NewInt(0).Add(&asset.quantity, big.NewInt(1)), &info.quantity)
+}
+
 func TestBurnAsset(t *testing.T) {
 	to := createAssets(t)
 
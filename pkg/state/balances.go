@@ -558,6 +558,49 @@ func collectNFTs(
 	return res, nil
 }
 
+// assetBalances returns all non-zero asset balances of the given address.
+// Asset balance keys are prefixed with the address ID, so the address prefix works as a portfolio index.
+func (s *balances) assetBalances(addr proto.AddressID) ([]proto.AssetBalance, error) {
+	key := assetBalanceKey{address: addr}
+	iter, err := s.hs.newTopEntryIteratorByPrefix(key.addressPrefix())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		iter.Release()
+		if itErr := iter.Error(); itErr != nil {
+			slog.Error("Iterator error", logging.Error(itErr))
+			panic(itErr)
+		}
+	}()
+
+	var (
+		k   assetBalanceKey
+		r   assetBalanceRecord
+		res []proto.AssetBalance
+	)
+	for iter.Next() {
+		if err := r.unmarshalBinary(keyvalue.SafeValue(iter)); err != nil {
+			return nil, err
+		}
+		if r.balance == 0 {
+			continue
+		}
+		if err := k.unmarshal(keyvalue.SafeKey(iter)); err != nil {
+			return nil, err
+		}
+		ai, err := s.assets.assetInfo(k.asset)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, proto.AssetBalance{
+			AssetID: proto.ReconstructDigest(k.asset, ai.Tail),
+			Balance: r.balance,
+		})
+	}
+	return res, nil
+}
+
 func (s *balances) wavesAddressesNumber() (uint64, error) {
 	iter, err := s.hs.newTopEntryIterator(wavesBalance)
 	if err != nil {
@@ -801,6 +844,20 @@ func (s *balances) assetBalance(addr proto.AddressID, assetID proto.AssetID) (ui
 	return s.assetBalanceFromRecordBytes(recordBytes)
 }
 
+// assetBalanceAtHeight returns asset balance of the address at the given height.
+// The height must be within the rollback window, older history entries are cut.
+func (s *balances) assetBalanceAtHeight(addr proto.AddressID, assetID proto.AssetID, height proto.Height) (uint64, error) {
+	key := assetBalanceKey{address: addr, asset: assetID}
+	recordBytes, err := s.hs.entryDataAtHeight(key.bytes(), height)
+	if errors.Is(err, keyvalue.ErrNotFound) || errors.Is(err, errEmptyHist) || (err == nil && recordBytes == nil) {
+		// Unknown address or no balance at the given height, return 0 and no errors in this case.
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return s.assetBalanceFromRecordBytes(recordBytes)
+}
+
 func (s *balances) newestAssetBalance(addr proto.AddressID, asset proto.AssetID) (uint64, error) {
 	key := assetBalanceKey{address: addr, asset: asset}
 	recordBytes, err := s.hs.newestTopEntryData(key.bytes())
@@ -864,6 +921,24 @@ func (s *balances) wavesBalance(addr proto.AddressID) (balanceProfile, error) {
 	return r.balanceProfile, nil
 }
 
+// wavesBalanceAtHeight returns waves balanceProfile of the address at the given height.
+// The height must be within the rollback window, older history entries are cut.
+func (s *balances) wavesBalanceAtHeight(addr proto.AddressID, height proto.Height) (balanceProfile, error) {
+	key := wavesBalanceKey{address: addr}
+	recordBytes, err := s.hs.entryDataAtHeight(key.bytes(), height)
+	if errors.Is(err, keyvalue.ErrNotFound) || errors.Is(err, errEmptyHist) || (err == nil && recordBytes == nil) {
+		// Unknown address or no balance at the given height, return empty profile and no errors in this case.
+		return balanceProfile{}, nil
+	} else if err != nil {
+		return balanceProfile{}, err
+	}
+	var record wavesBalanceRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return balanceProfile{}, errors.Wrapf(err, "failed to unmarshal data to %T", record)
+	}
+	return record.balanceProfile, nil
+}
+
 func (s *balances) calculateStateHashesAssetBalance(addr proto.AddressID, assetID proto.AssetID,
 	balance uint64, blockID proto.BlockID, keyStr string) error {
 	info, err := s.assets.newestConstInfo(assetID)
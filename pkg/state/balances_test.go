@@ -490,3 +490,75 @@ func TestNftList(t *testing.T) {
 	assert.Equal(t, []crypto.Digest(nil), nfts)
 
 }
+
+func TestAssetBalances(t *testing.T) {
+	to := createBalances(t)
+
+	// see to.balances.setAssetBalance function details for more info
+	asset0 := testGlobal.asset0.assetID
+	asset1 := testGlobal.asset1.assetID
+	addTailInfoToAssetsState(to.stor.entities.assets, asset0)
+	addTailInfoToAssetsState(to.stor.entities.assets, asset1)
+
+	to.stor.addBlock(t, blockID0)
+	addr := testGlobal.senderInfo.addr
+	err := to.balances.setAssetBalance(addr.ID(), proto.AssetIDFromDigest(asset0), 100, blockID0)
+	require.NoError(t, err)
+	err = to.balances.setAssetBalance(addr.ID(), proto.AssetIDFromDigest(asset1), 0, blockID0)
+	require.NoError(t, err)
+	info := defaultAssetInfo(proto.DigestTail(asset0), true)
+	err = to.stor.entities.assets.issueAsset(proto.AssetIDFromDigest(asset0), info, blockID0)
+	require.NoError(t, err)
+	to.stor.flush(t)
+
+	balances, err := to.balances.assetBalances(addr.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []proto.AssetBalance{{AssetID: asset0, Balance: 100}}, balances)
+
+	other := testGlobal.recipientInfo.addr
+	balances, err = to.balances.assetBalances(other.ID())
+	require.NoError(t, err)
+	assert.Empty(t, balances)
+}
+
+func TestBalancesAtHeight(t *testing.T) {
+	to := createBalances(t)
+
+	// see to.balances.setAssetBalance function details for more info
+	assetID := testGlobal.asset0.assetID
+	addTailInfoToAssetsState(to.stor.entities.assets, assetID)
+	addr := testGlobal.senderInfo.addr
+
+	to.stor.addBlock(t, blockID0)
+	to.stor.addBlockAndDo(t, blockID1, func(blockID proto.BlockID) {
+		err := to.balances.setWavesBalance(addr.ID(), newWavesValueFromProfile(balanceProfile{balance: 100}), blockID)
+		require.NoError(t, err)
+		err = to.balances.setAssetBalance(addr.ID(), proto.AssetIDFromDigest(assetID), 10, blockID)
+		require.NoError(t, err)
+	})
+	to.stor.addBlockAndDo(t, blockID2, func(blockID proto.BlockID) {
+		err := to.balances.setWavesBalance(addr.ID(), newWavesValueFromProfile(balanceProfile{balance: 200}), blockID)
+		require.NoError(t, err)
+		err = to.balances.setAssetBalance(addr.ID(), proto.AssetIDFromDigest(assetID), 20, blockID)
+		require.NoError(t, err)
+	})
+	to.stor.flush(t)
+
+	height := to.stor.rw.recentHeight()
+	for i, tc := range []struct {
+		height       proto.Height
+		wavesBalance uint64
+		assetBalance uint64
+	}{
+		{height - 2, 0, 0},
+		{height - 1, 100, 10},
+		{height, 200, 20},
+	} {
+		profile, err := to.balances.wavesBalanceAtHeight(addr.ID(), tc.height)
+		require.NoError(t, err, i)
+		assert.Equal(t, tc.wavesBalance, profile.balance, i)
+		balance, err := to.balances.assetBalanceAtHeight(addr.ID(), proto.AssetIDFromDigest(assetID), tc.height)
+		require.NoError(t, err, i)
+		assert.Equal(t, tc.assetBalance, balance, i)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/ccoveille/go-safecast/v2"
 	"github.com/minio/minlz"
+	"github.com/mr-tron/base58"
 	"github.com/pkg/errors"
 	"github.com/valyala/bytebufferpool"
 
@@ -173,6 +174,9 @@ type blockReadWriter struct {
 	// Protobuf-related stuff.
 	protobufInfoWithActivation
 
+	// Range of transactions removed by pruning.
+	pruned prunedInfo
+
 	mtx sync.RWMutex
 }
 
@@ -257,6 +261,10 @@ func newBlockReadWriter(
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load protobuf info")
 	}
+	pruned, err := loadPrunedInfo(stateDB.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load pruned info")
+	}
 	rw := &blockReadWriter{
 		db:                         stateDB.db,
 		dbBatch:                    stateDB.dbBatch,
@@ -279,6 +287,7 @@ func newBlockReadWriter(
 		headerOffsetLen:            headerOffsetLen,
 		height:                     height,
 		protobufInfoWithActivation: pbInfo,
+		pruned:                     pruned,
 	}
 	if sErr := rw.syncWithDb(); sErr != nil { // no need to close rw because all resources will be closed above
 		return nil, errors.Wrap(sErr, "failed to sync with db")
@@ -692,10 +701,34 @@ func (rw *blockReadWriter) readTransactionImpl(txID []byte) (proto.Transaction,
 	if err != nil {
 		return nil, 0, err
 	}
+	if rw.pruned.containsTxOffset(info.offset) {
+		tx, rErr := rw.readRetainedTransaction(txID, info.offset)
+		return tx, info.txStatus, rErr
+	}
 	tx, err := rw.readTransactionByOffsetImpl(info.offset)
 	return tx, info.txStatus, err
 }
 
+// readRetainedTransaction reads the transaction which body is kept in DB after pruning of the blockchain file.
+func (rw *blockReadWriter) readRetainedTransaction(txID []byte, offset uint64) (proto.Transaction, error) {
+	key := prunedTxKey{txID: txID}
+	data, err := rw.db.Get(key.bytes())
+	if err != nil {
+		if errors.Is(err, keyvalue.ErrNotFound) {
+			return nil, prunedError("transaction %s is removed by pruning", base58.Encode(txID))
+		}
+		return nil, err
+	}
+	if len(data) < uint32Size {
+		return nil, errInvalidDataSize
+	}
+	size, compressed := decodeSize(binary.BigEndian.Uint32(data[:uint32Size]))
+	if uint64(len(data)) != uint32Size+uint64(size) {
+		return nil, errInvalidDataSize
+	}
+	return rw.txFromRecordBytes(data[uint32Size:], compressed, rw.isProtobufTxOffset(offset))
+}
+
 func (rw *blockReadWriter) readTransactionByOffset(offset uint64) (proto.Transaction, error) {
 	rw.mtx.RLock()
 	defer rw.mtx.RUnlock()
@@ -703,6 +736,9 @@ func (rw *blockReadWriter) readTransactionByOffset(offset uint64) (proto.Transac
 }
 
 func (rw *blockReadWriter) readTransactionByOffsetImpl(offset uint64) (proto.Transaction, error) {
+	if rw.pruned.containsTxOffset(offset) {
+		return nil, prunedError("transaction at offset %d is removed by pruning", offset)
+	}
 	txSize, compressed, err := rw.readTransactionSize(offset)
 	if err != nil {
 		return nil, err
@@ -809,6 +845,9 @@ func (rw *blockReadWriter) readBlock(blockID proto.BlockID) (*proto.Block, error
 	if err != nil {
 		return nil, err
 	}
+	if rw.pruned.containsHeight(blockMeta.height) {
+		return nil, prunedError("transactions of block at height %d are removed by pruning", blockMeta.height)
+	}
 	blockStart := blockMeta.txStartOffset
 	blockEnd := blockMeta.txEndOffset
 	blockBytes := make([]byte, blockEnd-blockStart)
@@ -1094,12 +1133,17 @@ func (rw *blockReadWriter) txByBounds(start, end uint64, compressed bool) (proto
 	} else if n != len(txBytes) {
 		return nil, errors.New("did not read the whole tx")
 	}
-	protobuf := rw.isProtobufTxOffset(start)
+	return rw.txFromRecordBytes(txBytes, compressed, rw.isProtobufTxOffset(start))
+}
+
+// txFromRecordBytes unmarshals the transaction from bytes as they are stored in the blockchain file.
+func (rw *blockReadWriter) txFromRecordBytes(txBytes []byte, compressed, protobuf bool) (proto.Transaction, error) {
 	if !compressed {
 		return rw.txFromBytes(txBytes, protobuf)
 	}
 	bb := bytebufferpool.Get()
 	defer bytebufferpool.Put(bb)
+	var err error
 	bb.B = bb.B[:cap(bb.B)] // expand length to capacity
 	bb.B, err = minlz.Decode(bb.B, txBytes)
 	if err != nil {
@@ -1108,6 +1152,73 @@ func (rw *blockReadWriter) txByBounds(start, end uint64, compressed bool) (proto
 	return rw.txFromBytes(bb.B, protobuf)
 }
 
+func (rw *blockReadWriter) prunedRange() prunedInfo {
+	rw.mtx.RLock()
+	defer rw.mtx.RUnlock()
+	return rw.pruned
+}
+
+// setPruned makes the transactions in the given range unavailable for reading,
+// the range must be stored in DB before the transactions are removed from the blockchain file.
+func (rw *blockReadWriter) setPruned(info prunedInfo) {
+	rw.mtx.Lock()
+	defer rw.mtx.Unlock()
+	rw.pruned = info
+}
+
+// pruneTransactions extends the pruned range up to the transactions of the block at the given height.
+// The bodies of transactions accepted by retain are put to the batch along with the new range,
+// the new range has to be set after the batch is written to DB.
+func (rw *blockReadWriter) pruneTransactions(
+	height uint64,
+	batch keyvalue.Batch,
+	retain func(proto.Transaction) bool,
+) (prunedInfo, error) {
+	info := rw.prunedRange()
+	if height <= info.height {
+		return info, nil
+	}
+	if info.height == 0 { // transactions of genesis block are never pruned
+		genesis, err := rw.blockMetaByHeight(genesisHeight)
+		if err != nil {
+			return prunedInfo{}, errors.Wrap(err, "failed to find genesis block meta")
+		}
+		info = prunedInfo{height: genesisHeight, txStartOffset: genesis.txEndOffset, txEndOffset: genesis.txEndOffset}
+	}
+	last, err := rw.blockMetaByHeight(height)
+	if err != nil {
+		return prunedInfo{}, errors.Wrapf(err, "failed to find block meta by height %d", height)
+	}
+	sizeBytes := make([]byte, uint32Size)
+	for pos := info.txEndOffset; pos < last.txEndOffset; {
+		if _, rErr := rw.blockchain.ReadAt(sizeBytes, int64(pos)); rErr != nil {
+			return prunedInfo{}, rErr
+		}
+		txSize, compressed := decodeSize(binary.BigEndian.Uint32(sizeBytes))
+		record := make([]byte, uint32Size+uint64(txSize))
+		if _, rErr := rw.blockchain.ReadAt(record, int64(pos)); rErr != nil {
+			return prunedInfo{}, rErr
+		}
+		tx, txErr := rw.txFromRecordBytes(record[uint32Size:], compressed, rw.isProtobufTxOffset(pos))
+		if txErr != nil {
+			return prunedInfo{}, errors.Wrapf(txErr, "failed to read transaction at offset %d", pos)
+		}
+		if retain(tx) {
+			txID, idErr := tx.GetID(rw.scheme)
+			if idErr != nil {
+				return prunedInfo{}, idErr
+			}
+			key := prunedTxKey{txID: txID}
+			batch.Put(key.bytes(), record)
+		}
+		pos += uint64(len(record))
+	}
+	info.height = height
+	info.txEndOffset = last.txEndOffset
+	batch.Put(prunedInfoKeyBytes, info.marshalBinary())
+	return info, nil
+}
+
 func (rw *blockReadWriter) syncWithDb() error {
 	dbHeight, err := rw.stateDB.getHeight()
 	if err != nil {
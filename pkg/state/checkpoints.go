@@ -0,0 +1,552 @@
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrs "errors"
+	"io"
+	"io/fs"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+const (
+	// CheckpointVersion is the current version of the checkpoint layout and manifest format.
+	CheckpointVersion = 1
+
+	checkpointDirPrefix    = "checkpoint-"
+	checkpointTmpSuffix    = ".tmp"
+	checkpointManifestName = "checkpoint.json"
+)
+
+// blockStorageFiles are the append-only files of block storage that are copied to the checkpoint.
+var blockStorageFiles = []string{"blockchain", "headers", "block_height_to_id"}
+
+// CheckpointParams configures periodic creation of state checkpoints.
+type CheckpointParams struct {
+	// Dir is the directory where checkpoints are stored.
+	Dir string
+	// Interval is the number of blocks between checkpoints, checkpoints are disabled if it is zero.
+	Interval uint64
+	// Keep is the number of the most recent checkpoints to keep, all checkpoints are kept if it is zero.
+	Keep int
+}
+
+func (p CheckpointParams) enabled() bool {
+	return p.Interval != 0
+}
+
+func validateCheckpointParams(params StateParams) error {
+	if !params.Checkpoints.enabled() {
+		return nil
+	}
+	if !params.BuildStateHashes {
+		return errors.New("state checkpoints require building of state hashes")
+	}
+	if params.StoreExtendedApiData {
+		return errors.New("state checkpoints are not supported with extended API data")
+	}
+	return nil
+}
+
+// CheckpointFile describes the file of the checkpoint.
+type CheckpointFile struct {
+	Path     string `json:"path"` // relative to the checkpoint directory, slash separated
+	Size     int64  `json:"size"`
+	Checksum string `json:"sha256"`
+}
+
+// CheckpointManifest describes the checkpoint, it's written to the checkpoint directory after all other files.
+type CheckpointManifest struct {
+	Version      int              `json:"version"`
+	StateVersion uint16           `json:"stateVersion"`
+	Scheme       proto.Scheme     `json:"scheme"`
+	Height       proto.Height     `json:"height"`
+	BlockID      proto.BlockID    `json:"blockID"`
+	StateHash    crypto.Digest    `json:"stateHash"` // legacy state hash at the height of checkpoint
+	CreatedAt    time.Time        `json:"createdAt"`
+	Files        []CheckpointFile `json:"files"`
+}
+
+// ReadCheckpointManifest reads and checks the manifest of the checkpoint in the given directory.
+func ReadCheckpointManifest(dir string) (*CheckpointManifest, error) {
+	data, err := os.ReadFile(filepath.Join(filepath.Clean(dir), checkpointManifestName))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read checkpoint manifest")
+	}
+	m := new(CheckpointManifest)
+	if uErr := json.Unmarshal(data, m); uErr != nil {
+		return nil, errors.Wrap(uErr, "failed to unmarshal checkpoint manifest")
+	}
+	if m.Version != CheckpointVersion {
+		return nil, errors.Errorf("unsupported checkpoint version %d, expected %d", m.Version, CheckpointVersion)
+	}
+	if m.StateVersion != StateVersion {
+		return nil, errors.Errorf("checkpoint state version %d is incompatible with current state version %d",
+			m.StateVersion, StateVersion)
+	}
+	for _, f := range m.Files {
+		if !filepath.IsLocal(filepath.FromSlash(f.Path)) {
+			return nil, errors.Errorf("invalid checkpoint file path '%s'", f.Path)
+		}
+	}
+	return m, nil
+}
+
+// checkpointer creates consistent checkpoints of the state without stopping the blocks application.
+// The snapshot of the database and the sizes of block storage files are taken after the blocks are flushed,
+// the checkpoint is written from them in background. Block storage files are only appended between rollbacks,
+// so their prefixes of the recorded sizes are copied without blocking the state. Only the rollback below
+// the height of the checkpoint rewrites these prefixes, such checkpoint is discarded.
+type checkpointer struct {
+	params  CheckpointParams
+	dataDir string
+	scheme  proto.Scheme
+	algo    keyvalue.CompressionAlgo
+	db      *keyvalue.KeyVal
+
+	rollbackHeight atomic.Uint64 // the lowest height of rollback during the creation of the checkpoint
+	running        atomic.Bool
+	ctx            context.Context
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+func newCheckpointer(
+	params CheckpointParams,
+	dataDir string,
+	scheme proto.Scheme,
+	algo keyvalue.CompressionAlgo,
+	db *keyvalue.KeyVal,
+) (*checkpointer, error) {
+	if params.enabled() {
+		if params.Dir == "" {
+			return nil, errors.New("checkpoints directory is not set")
+		}
+		if err := os.MkdirAll(params.Dir, 0750); err != nil {
+			return nil, errors.Wrap(err, "failed to create checkpoints directory")
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &checkpointer{
+		params:  params,
+		dataDir: dataDir,
+		scheme:  scheme,
+		algo:    algo,
+		db:      db,
+		ctx:     ctx,
+		cancel:  cancel,
+	}, nil
+}
+
+// due reports whether the checkpoint must be created after the blocks from prevHeight to height are applied.
+func (c *checkpointer) due(prevHeight, height proto.Height) bool {
+	return c.params.enabled() && height/c.params.Interval > prevHeight/c.params.Interval
+}
+
+// start begins the creation of the checkpoint of the flushed state at the given height.
+func (c *checkpointer) start(height proto.Height, blockID proto.BlockID, stateHash crypto.Digest) error {
+	if !c.running.CompareAndSwap(false, true) {
+		slog.Warn("Previous state checkpoint is still being created, skipping checkpoint", "height", height)
+		return nil
+	}
+	sizes := make([]int64, len(blockStorageFiles))
+	for i, name := range blockStorageFiles {
+		fi, err := os.Stat(filepath.Join(c.dataDir, blocksStorDir, name))
+		if err != nil {
+			c.running.Store(false)
+			return errors.Wrapf(err, "failed to stat block storage file '%s'", name)
+		}
+		sizes[i] = fi.Size()
+	}
+	snap, err := c.db.NewSnapshot()
+	if err != nil {
+		c.running.Store(false)
+		return err
+	}
+	m := &CheckpointManifest{
+		Version:      CheckpointVersion,
+		StateVersion: StateVersion,
+		Scheme:       c.scheme,
+		Height:       height,
+		BlockID:      blockID,
+		StateHash:    stateHash,
+		CreatedAt:    time.Now().UTC(),
+	}
+	c.rollbackHeight.Store(math.MaxUint64)
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer c.running.Store(false)
+		start := time.Now()
+		slog.Info("Creating state checkpoint", "height", height)
+		if cErr := c.create(m, snap, sizes); cErr != nil {
+			slog.Error("Failed to create state checkpoint", "height", height, logging.Error(cErr))
+			return
+		}
+		slog.Info("State checkpoint created", "height", height, "duration", time.Since(start))
+		if pErr := c.prune(); pErr != nil {
+			slog.Error("Failed to remove old state checkpoints", logging.Error(pErr))
+		}
+	}()
+	return nil
+}
+
+//nolint:nonamedreturns // needs in defer
+func (c *checkpointer) create(m *CheckpointManifest, snap *keyvalue.Snapshot, sizes []int64) (retErr error) {
+	defer snap.Release()
+	final := filepath.Join(c.params.Dir, checkpointDirPrefix+strconv.FormatUint(m.Height, 10))
+	tmp := final + checkpointTmpSuffix
+	if err := os.RemoveAll(tmp); err != nil {
+		return errors.Wrap(err, "failed to remove incomplete checkpoint")
+	}
+	defer func() {
+		if retErr != nil {
+			if rmErr := os.RemoveAll(tmp); rmErr != nil {
+				retErr = stderrs.Join(retErr, errors.Wrap(rmErr, "failed to remove incomplete checkpoint"))
+			}
+		}
+	}()
+	if err := os.MkdirAll(filepath.Join(tmp, blocksStorDir), 0750); err != nil {
+		return errors.Wrap(err, "failed to create checkpoint directory")
+	}
+	for i, name := range blockStorageFiles {
+		src := filepath.Join(c.dataDir, blocksStorDir, name)
+		if err := copyFile(src, filepath.Join(tmp, blocksStorDir, name), sizes[i]); err != nil {
+			return err
+		}
+	}
+	if h := c.rollbackHeight.Load(); h < m.Height {
+		return errors.Errorf("state was rolled back to height %d while block storage files were copied", h)
+	}
+	if err := copyFile(filepath.Join(c.dataDir, dbMetaFileName), filepath.Join(tmp, dbMetaFileName), -1); err != nil {
+		return err
+	}
+	if err := snap.SaveTo(c.ctx, filepath.Join(tmp, keyvalueDir), c.algo); err != nil {
+		return errors.Wrap(err, "failed to save database snapshot")
+	}
+	files, err := checkpointFiles(tmp)
+	if err != nil {
+		return err
+	}
+	m.Files = files
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal checkpoint manifest")
+	}
+	if wErr := writeFileSync(filepath.Join(tmp, checkpointManifestName), data); wErr != nil {
+		return wErr
+	}
+	if rmErr := os.RemoveAll(final); rmErr != nil {
+		return errors.Wrap(rmErr, "failed to remove previous checkpoint at the same height")
+	}
+	if rnErr := os.Rename(tmp, final); rnErr != nil {
+		return errors.Wrap(rnErr, "failed to rename checkpoint directory")
+	}
+	return nil
+}
+
+// prune removes the oldest complete checkpoints exceeding the number of checkpoints to keep.
+func (c *checkpointer) prune() error {
+	if c.params.Keep <= 0 {
+		return nil
+	}
+	heights, err := listCheckpoints(c.params.Dir)
+	if err != nil {
+		return err
+	}
+	for len(heights) > c.params.Keep {
+		dir := filepath.Join(c.params.Dir, checkpointDirPrefix+strconv.FormatUint(heights[0], 10))
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			return errors.Wrapf(rmErr, "failed to remove checkpoint '%s'", dir)
+		}
+		heights = heights[1:]
+	}
+	return nil
+}
+
+// rolledBack registers the rollback of the state to the given height before the block storage files are
+// truncated. Rollbacks are not concurrent, so the lowest height is updated without compare-and-swap.
+func (c *checkpointer) rolledBack(height proto.Height) {
+	if height < c.rollbackHeight.Load() {
+		c.rollbackHeight.Store(height)
+	}
+}
+
+// close stops the creation of the checkpoint in progress and waits for it to finish.
+func (c *checkpointer) close() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+// listCheckpoints returns the sorted heights of complete checkpoints in the directory.
+func listCheckpoints(dir string) ([]proto.Height, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read checkpoints directory")
+	}
+	var heights []proto.Height
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() || !strings.HasPrefix(name, checkpointDirPrefix) || strings.HasSuffix(name, checkpointTmpSuffix) {
+			continue
+		}
+		h, pErr := strconv.ParseUint(strings.TrimPrefix(name, checkpointDirPrefix), 10, 64)
+		if pErr != nil {
+			continue
+		}
+		if _, sErr := os.Stat(filepath.Join(dir, name, checkpointManifestName)); sErr != nil {
+			continue
+		}
+		heights = append(heights, h)
+	}
+	slices.Sort(heights)
+	return heights, nil
+}
+
+// checkpointFiles lists the files of the checkpoint with their sizes and checksums.
+func checkpointFiles(dir string) ([]CheckpointFile, error) {
+	var files []CheckpointFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, rErr := filepath.Rel(dir, path)
+		if rErr != nil {
+			return rErr
+		}
+		size, sum, hErr := fileChecksum(path)
+		if hErr != nil {
+			return hErr
+		}
+		files = append(files, CheckpointFile{Path: filepath.ToSlash(rel), Size: size, Checksum: sum})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list checkpoint files")
+	}
+	return files, nil
+}
+
+func fileChecksum(path string) (int64, string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// copyFile copies the first size bytes of the file, the whole file is copied if size is negative.
+func copyFile(src, dst string, size int64) (retErr error) { //nolint:nonamedreturns // needs in defer
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file '%s'", src)
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(filepath.Clean(dst), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file '%s'", dst)
+	}
+	defer func() {
+		if clErr := out.Close(); clErr != nil {
+			retErr = stderrs.Join(retErr, errors.Wrapf(clErr, "failed to close file '%s'", dst))
+		}
+	}()
+	var r io.Reader = in
+	if size >= 0 {
+		r = io.LimitReader(in, size)
+	}
+	n, err := io.Copy(out, r)
+	if err != nil {
+		return errors.Wrapf(err, "failed to copy file '%s'", src)
+	}
+	if size >= 0 && n != size {
+		return errors.Errorf("file '%s' is truncated: copied %d bytes of %d", src, n, size)
+	}
+	return out.Sync()
+}
+
+func writeFileSync(path string, data []byte) (retErr error) { //nolint:nonamedreturns // needs in defer
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file '%s'", path)
+	}
+	defer func() {
+		if clErr := f.Close(); clErr != nil {
+			retErr = stderrs.Join(retErr, errors.Wrapf(clErr, "failed to close file '%s'", path))
+		}
+	}()
+	if _, wErr := f.Write(data); wErr != nil {
+		return errors.Wrapf(wErr, "failed to write file '%s'", path)
+	}
+	return f.Sync()
+}
+
+// RestoreCheckpoint copies the checkpoint to the empty data directory of the state. The stateHash is the trusted
+// state hash at the height of the checkpoint, e.g. taken from another node, the manifest must have the same hash.
+// The checksums of the files are verified during the copying, then the restored state is checked by
+// validateRestoredState. The restored files are removed if the validation fails.
+func RestoreCheckpoint(
+	ctx context.Context,
+	checkpointDir, dataDir string,
+	amend bool,
+	params StateParams,
+	settings *settings.BlockchainSettings,
+	stateHash crypto.Digest,
+) (_ *CheckpointManifest, retErr error) { //nolint:nonamedreturns // needs in defer
+	m, err := ReadCheckpointManifest(checkpointDir)
+	if err != nil {
+		return nil, err
+	}
+	if m.Scheme != settings.AddressSchemeCharacter {
+		return nil, errors.Errorf("checkpoint scheme '%c' differs from blockchain scheme '%c'",
+			m.Scheme, settings.AddressSchemeCharacter)
+	}
+	if stateHash != m.StateHash {
+		return nil, errors.Errorf("checkpoint state hash %s differs from expected %s",
+			m.StateHash.Hex(), stateHash.Hex())
+	}
+	if eErr := checkEmptyDir(dataDir); eErr != nil {
+		return nil, eErr
+	}
+	defer func() {
+		if retErr != nil {
+			for _, name := range []string{dbMetaFileName, keyvalueDir, blocksStorDir} {
+				if rmErr := os.RemoveAll(filepath.Join(dataDir, name)); rmErr != nil {
+					retErr = stderrs.Join(retErr, errors.Wrap(rmErr, "failed to remove restored files"))
+				}
+			}
+		}
+	}()
+	for _, f := range m.Files {
+		if cErr := restoreCheckpointFile(checkpointDir, dataDir, f); cErr != nil {
+			return nil, cErr
+		}
+	}
+	params.BuildStateHashes = true
+	params.Checkpoints = CheckpointParams{}
+	s, err := newStateManager(ctx, dataDir, amend, params, settings, false, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open restored state")
+	}
+	vErr := validateRestoredState(s, m)
+	if clErr := s.Close(); clErr != nil {
+		vErr = stderrs.Join(vErr, errors.Wrap(clErr, "failed to close restored state"))
+	}
+	if vErr != nil {
+		return nil, errors.Wrap(vErr, "invalid checkpoint")
+	}
+	return m, nil
+}
+
+// validateRestoredState checks the height and the last block of the restored state against the manifest.
+// The last block of the checkpoint is rolled back and applied again, so the state hash at the height of
+// the checkpoint is recomputed instead of being read from the checkpoint database. Note that the state hash
+// covers only the entries changed by the last block, so it's a consistency check of the checkpoint with
+// the trusted hash rather than a validation of the whole state.
+func validateRestoredState(s *stateManager, m *CheckpointManifest) error {
+	height, err := s.Height()
+	if err != nil {
+		return err
+	}
+	if height != m.Height {
+		return errors.Errorf("restored state height %d differs from checkpoint height %d", height, m.Height)
+	}
+	blockID, err := s.HeightToBlockID(height)
+	if err != nil {
+		return err
+	}
+	if blockID != m.BlockID {
+		return errors.Errorf("restored state last block %s differs from checkpoint block %s",
+			blockID.String(), m.BlockID.String())
+	}
+	block, err := s.BlockByHeight(height)
+	if err != nil {
+		return errors.Wrap(err, "failed to read last block of restored state")
+	}
+	if rbErr := s.RollbackToHeight(height - 1); rbErr != nil {
+		return errors.Wrap(rbErr, "failed to roll back last block of restored state")
+	}
+	if _, aErr := s.AddDeserializedBlocks([]*proto.Block{block}); aErr != nil {
+		return errors.Wrap(aErr, "failed to apply last block of restored state again")
+	}
+	sh, err := s.LegacyStateHashAtHeight(height)
+	if err != nil {
+		return errors.Wrap(err, "failed to get state hash of restored state")
+	}
+	if sh.SumHash != m.StateHash {
+		return errors.Errorf("restored state hash %s differs from checkpoint state hash %s",
+			sh.SumHash.Hex(), m.StateHash.Hex())
+	}
+	return nil
+}
+
+func checkEmptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to read state directory")
+	}
+	if len(entries) != 0 {
+		return errors.Errorf("state directory '%s' is not empty", dir)
+	}
+	return nil
+}
+
+//nolint:nonamedreturns // needs in defer
+func restoreCheckpointFile(checkpointDir, dataDir string, f CheckpointFile) (retErr error) {
+	src := filepath.Join(checkpointDir, filepath.FromSlash(f.Path))
+	dst := filepath.Join(dataDir, filepath.FromSlash(f.Path))
+	if err := os.MkdirAll(filepath.Dir(dst), 0750); err != nil {
+		return errors.Wrap(err, "failed to create state directory")
+	}
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open checkpoint file '%s'", f.Path)
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(filepath.Clean(dst), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file '%s'", dst)
+	}
+	defer func() {
+		if clErr := out.Close(); clErr != nil {
+			retErr = stderrs.Join(retErr, errors.Wrapf(clErr, "failed to close file '%s'", dst))
+		}
+	}()
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(out, h), in)
+	if err != nil {
+		return errors.Wrapf(err, "failed to copy checkpoint file '%s'", f.Path)
+	}
+	if n != f.Size || hex.EncodeToString(h.Sum(nil)) != f.Checksum {
+		return errors.Errorf("checksum mismatch of checkpoint file '%s'", f.Path)
+	}
+	return out.Sync()
+}
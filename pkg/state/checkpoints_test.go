@@ -0,0 +1,111 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/importer"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+func TestCheckpoints(t *testing.T) {
+	bs := settings.MustMainNetSettings()
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	importParams := importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath}
+
+	params := DefaultTestingStateParams()
+	params.BuildStateHashes = true
+	params.Checkpoints = CheckpointParams{Dir: t.TempDir(), Interval: 400, Keep: 1}
+	manager := newTestStateManager(t, true, params, bs)
+	for _, height := range []uint64{1000, 1500} {
+		current, hErr := manager.Height()
+		require.NoError(t, hErr)
+		require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, manager, height-1, current))
+		manager.checkpoints.wg.Wait()
+	}
+	heights, err := listCheckpoints(params.Checkpoints.Dir)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1500}, heights, "old checkpoints must be removed")
+	checkpointDir := filepath.Join(params.Checkpoints.Dir, "checkpoint-1500")
+	sh, err := manager.LegacyStateHashAtHeight(1500)
+	require.NoError(t, err)
+
+	restoreParams := DefaultTestingStateParams()
+	restoreParams.BuildStateHashes = true
+	wrong := crypto.Digest{1}
+	_, err = RestoreCheckpoint(t.Context(), checkpointDir, t.TempDir(), true, restoreParams, bs, wrong)
+	assert.ErrorContains(t, err, "differs from expected")
+
+	dataDir := t.TempDir()
+	m, err := RestoreCheckpoint(t.Context(), checkpointDir, dataDir, true, restoreParams, bs, sh.SumHash)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1500), m.Height)
+	assert.Equal(t, sh.BlockID, m.BlockID)
+	_, err = RestoreCheckpoint(t.Context(), checkpointDir, dataDir, true, restoreParams, bs, sh.SumHash)
+	assert.ErrorContains(t, err, "is not empty")
+
+	// The restored state continues to apply blocks from the height of checkpoint.
+	restored, err := newStateManager(t.Context(), dataDir, true, restoreParams, bs, false, nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, restored.Close()) }()
+	require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, restored, 1999, 1500))
+	restoredHash, err := restored.LegacyStateHashAtHeight(2000)
+	require.NoError(t, err)
+	current, err := manager.Height()
+	require.NoError(t, err)
+	require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, manager, 1999, current))
+	expectedHash, err := manager.LegacyStateHashAtHeight(2000)
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, restoredHash)
+
+	// Corrupted checkpoint files are detected and nothing is left in the state directory.
+	corrupted := filepath.Join(checkpointDir, blocksStorDir, "headers")
+	require.NoError(t, os.WriteFile(corrupted, []byte("corrupted"), 0600))
+	dataDir = t.TempDir()
+	_, err = RestoreCheckpoint(t.Context(), checkpointDir, dataDir, true, restoreParams, bs, sh.SumHash)
+	assert.ErrorContains(t, err, "checksum mismatch of checkpoint file 'blocks_storage/headers'")
+	entries, err := os.ReadDir(dataDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestValidateRestoredStateRecomputesStateHash(t *testing.T) {
+	bs := settings.MustMainNetSettings()
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	importParams := importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath}
+
+	params := DefaultTestingStateParams()
+	params.BuildStateHashes = true
+	manager := newTestStateManager(t, true, params, bs)
+	require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, manager, 99, 1))
+	sh, err := manager.LegacyStateHashAtHeight(100)
+	require.NoError(t, err)
+	m := &CheckpointManifest{Height: 100, BlockID: sh.BlockID, StateHash: sh.SumHash}
+	require.NoError(t, validateRestoredState(manager, m))
+
+	// The state hash stored in the checkpoint database is not trusted.
+	forged := *sh
+	forged.SumHash = crypto.Digest{1}
+	require.NoError(t, manager.stor.stateHashes.saveLegacyStateHash(&forged, 100))
+	require.NoError(t, manager.flush())
+	manager.reset()
+	stored, err := manager.LegacyStateHashAtHeight(100)
+	require.NoError(t, err)
+	require.Equal(t, forged.SumHash, stored.SumHash)
+	m.StateHash = forged.SumHash
+	assert.ErrorContains(t, validateRestoredState(manager, m), "differs from checkpoint state hash")
+}
+
+func TestCheckpointParamsValidation(t *testing.T) {
+	params := DefaultTestingStateParams()
+	params.Checkpoints = CheckpointParams{Dir: t.TempDir(), Interval: 100}
+	_, err := newStateManager(t.Context(), t.TempDir(), true, params, settings.MustMainNetSettings(), false, nil)
+	assert.ErrorContains(t, err, "state checkpoints require building of state hashes")
+}
@@ -1,14 +1,8 @@
 package state
 
 const (
-	FailFreeInvokeComplexity           = 1000
-	FreeVerifierComplexity             = 200
-	MaxVerifierScriptComplexityReduced = 2000
-	MaxVerifierScriptComplexity        = 4000
-	MaxCallableScriptComplexityV12     = 2000
-	MaxCallableScriptComplexityV34     = 4000
-	MaxCallableScriptComplexityV5      = 10000
-	MaxCallableScriptComplexityV6      = 52000
+	FailFreeInvokeComplexity = 1000
+	FreeVerifierComplexity   = 200
 )
 
 type MaxScriptsComplexityInBlock struct {
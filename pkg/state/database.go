@@ -26,6 +26,9 @@ type stateInfo struct {
 	HasExtendedAPIData bool                     `cbor:"2,keyasint,omitempty"`
 	HasStateHashes     bool                     `cbor:"3,keyasint,omitempty"`
 	DBCompressionAlgo  keyvalue.CompressionAlgo `cbor:"4,keyasint,omitempty"`
+	Pruned             bool                     `cbor:"5,keyasint,omitempty"`
+	// DBBackend is tracked only by DB meta file, the copy of stateInfo stored in DB is the same for all backends.
+	DBBackend keyvalue.Backend `cbor:"6,keyasint,omitempty"`
 }
 
 func (inf *stateInfo) marshalBinary() ([]byte, error) {
@@ -50,6 +53,7 @@ func saveStateInfo(db keyvalue.KeyValue, params StateParams, amend bool) error {
 		HasStateHashes:     params.BuildStateHashes,
 		Amend:              amend,
 		DBCompressionAlgo:  params.DbParams.CompressionAlgo,
+		Pruned:             params.Pruning.enabled(),
 	}
 	return putStateInfoToDB(db, info)
 }
@@ -317,6 +317,34 @@ func minFeeInWaves(tx proto.Transaction, params *feeValidationParams) (*txCosts,
 	return cost, nil
 }
 
+// minFeeInFeeAsset calculates the minimal fee of the transaction in the fee asset given in params.
+// For the sponsored assets the fee in Waves is converted using the current sponsorship rate.
+func minFeeInFeeAsset(tx proto.Transaction, params *feeValidationParams) (uint64, error) {
+	minWaves, err := minFeeInWaves(tx, params)
+	if err != nil {
+		return 0, errors.Errorf("failed to calculate min fee in Waves: %v", err)
+	}
+	feeAsset := params.txAssets.feeAsset
+	if !feeAsset.Present {
+		return minWaves.total, nil
+	}
+	shortFeeAssetID := proto.AssetIDFromDigest(feeAsset.ID)
+	isSponsored, err := params.stor.sponsoredAssets.newestIsSponsored(shortFeeAssetID)
+	if err != nil {
+		return 0, errors.Errorf("newestIsSponsored: %v", err)
+	}
+	if !isSponsored {
+		return 0, errs.NewTxValidationError(fmt.Sprintf("Asset %s is not sponsored, cannot be used to pay fees",
+			feeAsset.ID.String(),
+		))
+	}
+	minAsset, err := params.stor.sponsoredAssets.wavesToSponsoredAsset(shortFeeAssetID, minWaves.total)
+	if err != nil {
+		return 0, errors.Errorf("wavesToSponsoredAsset() failed: %v", err)
+	}
+	return minAsset, nil
+}
+
 func checkMinFeeWaves(tx proto.Transaction, params *feeValidationParams) error {
 	minWaves, err := minFeeInWaves(tx, params)
 	if err != nil {
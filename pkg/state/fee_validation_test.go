@@ -217,6 +217,35 @@ func TestCheckMinFeeAsset(t *testing.T) {
 	assert.Error(t, err, "checkMinFeeAsset() did not fail with invalid Transfer transaction fee in asset")
 }
 
+func TestMinFeeInFeeAsset(t *testing.T) {
+	to := createSponsoredAssets(t, true)
+
+	tx := createTransferWithSig(t)
+	params := &feeValidationParams{
+		stor:            to.stor.entities,
+		settings:        settings.MustMainNetSettings(),
+		txAssets:        &txAssets{feeAsset: proto.NewOptionalAssetWaves()},
+		rideV5Activated: false,
+	}
+	fee, err := minFeeInFeeAsset(tx, params)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(FeeUnit), fee)
+
+	params.txAssets = &txAssets{feeAsset: tx.FeeAsset, smartAssets: []crypto.Digest{tx.AmountAsset.ID}}
+	_, err = minFeeInFeeAsset(tx, params)
+	assert.Error(t, err, "minFeeInFeeAsset() did not fail with non-sponsored asset")
+
+	to.stor.addBlock(t, blockID0)
+	assetCost := uint64(4)
+	err = to.sponsoredAssets.sponsorAsset(tx.FeeAsset.ID, assetCost, blockID0)
+	require.NoError(t, err, "sponsorAsset() failed")
+	to.stor.flush(t)
+
+	fee, err = minFeeInFeeAsset(tx, params)
+	require.NoError(t, err)
+	assert.Equal(t, (FeeUnit+scriptExtraFee)/FeeUnit*assetCost, fee) // fee for the smart asset is included
+}
+
 func TestNFTMinFee(t *testing.T) {
 	storage := createStorageObjects(t, true)
 	params := &feeValidationParams{
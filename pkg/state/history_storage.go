@@ -276,7 +276,7 @@ func newHistoryRecordFromBytes(data []byte) (*historyRecord, error) {
 		}
 	} else {
 		dataSize := uint32(len(data))
-		for i := uint32(1); i <= dataSize-4; {
+		for i := uint32(1); i+4 <= dataSize; { // no underflow on truncated data
 			recordSize := binary.BigEndian.Uint32(data[i : i+4])
 			i += 4
 			if dataSize < i+recordSize {
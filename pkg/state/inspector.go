@@ -0,0 +1,351 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+)
+
+// maxHistoryProblems limits the number of problems collected by the history verification.
+const maxHistoryProblems = 100
+
+// keyPrefixNames are the names of the key prefixes, prefixes of the history records of blockchain entities
+// are named after the entities.
+var keyPrefixNames = map[byte]string{
+	wavesBalanceKeyPrefix:            "wavesBalance",
+	assetBalanceKeyPrefix:            "assetBalance",
+	lastBlockNumKeyPrefix:            "lastBlockNum",
+	blockIdToNumKeyPrefix:            "blockIDToNum",
+	blockNumToIdKeyPrefix:            "blockNumToID",
+	validBlockNumKeyPrefix:           "validBlockNum",
+	blockOffsetKeyPrefix:             "blockOffset",
+	txInfoKeyPrefix:                  "txInfo",
+	rollbackMinHeightKeyPrefix:       "rollbackMinHeight",
+	dbHeightKeyPrefix:                "dbHeight",
+	scoreKeyPrefix:                   "score",
+	assetConstKeyPrefix:              "assetConst",
+	assetHistKeyPrefix:               "asset",
+	leaseKeyPrefix:                   "lease",
+	aliasKeyPrefix:                   "alias",
+	addressToAliasesPrefix:           "addressToAliases",
+	disabledAliasKeyPrefix:           "disabledAlias",
+	activatedFeaturesKeyPrefix:       "activatedFeature",
+	approvedFeaturesKeyPrefix:        "approvedFeature",
+	votesFeaturesKeyPrefix:           "featureVote",
+	ordersVolumeKeyPrefix:            "ordersVolume",
+	blocksInfoKeyPrefix:              "feeDistr",
+	lastAccountsStorAddrNumKeyPrefix: "lastAccountsStorAddrNum",
+	accountStorAddrToNumKeyPrefix:    "accountStorAddrToNum",
+	accountsDataStorKeyPrefix:        "dataEntry",
+	sponsorshipKeyPrefix:             "sponsorship",
+	accountScriptKeyPrefix:           "accountScript",
+	assetScriptKeyPrefix:             "assetScript",
+	scriptBasicInfoKeyPrefix:         "scriptBasicInfo",
+	accountScriptComplexityKeyPrefix: "accountScriptComplexity",
+	assetScriptComplexityKeyPrefix:   "assetScriptComplexity",
+	rewardVotesKeyPrefix:             "rewardVotes",
+	rewardChangesKeyPrefix:           "rewardChanges",
+	batchedStorKeyPrefix:             "batchedStor",
+	lastBatchKeyPrefix:               "lastBatch",
+	invokeResultKeyPrefix:            "invokeResult",
+	stateInfoKeyPrefix:               "stateInfo",
+	txsByAddressesFileSizeKeyPrefix:  "txsByAddressesFileSize",
+	rwProtobufInfoKeyPrefix:          "rwProtobufInfo",
+	legacyStateHashKeyPrefix:         "legacyStateHash",
+	snapshotStateHashKeyPrefix:       "snapshotStateHash",
+	hitSourceKeyPrefix:               "hitSource",
+	snapshotsKeyPrefix:               "snapshots",
+	patchKeyPrefix:                   "patches",
+	challengedAddressKeyPrefix:       "challengedAddress",
+	prunedInfoKeyPrefix:              "prunedInfo",
+	prunedTxKeyPrefix:                "prunedTx",
+}
+
+func keyPrefixName(prefix byte) string {
+	if name, ok := keyPrefixNames[prefix]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", prefix)
+}
+
+// historyEntities returns the blockchain entities stored as history records by their key prefixes.
+func historyEntities() (map[byte]blockchainEntity, error) {
+	res := make(map[byte]blockchainEntity, len(properties))
+	for entity := range properties {
+		prefix, err := prefixByEntity(entity)
+		if err != nil {
+			return nil, errors.Wrapf(err, "entity %d", entity)
+		}
+		res[prefix[0]] = entity
+	}
+	return res, nil
+}
+
+// KeyPrefixStats is the number and the total size of the keys with the same prefix.
+type KeyPrefixStats struct {
+	Prefix byte
+	Name   string
+	// History is set if the keys store history records of a blockchain entity.
+	History    bool
+	Keys       uint64
+	KeysSize   uint64
+	ValuesSize uint64
+}
+
+// HistoryProblem is the invalid history record found by the verification.
+type HistoryProblem struct {
+	Entity  string
+	Key     []byte
+	Problem string
+}
+
+// HistoryReport is the result of the verification of all history records of the state.
+type HistoryReport struct {
+	Records uint64
+	Entries uint64
+	// StaleEntries is the number of entries of the rolled back blocks, they are removed on the next access.
+	StaleEntries  uint64
+	ProblemsCount uint64
+	// Problems are the first found problems, their number is limited.
+	Problems []HistoryProblem
+}
+
+func (r *HistoryReport) addProblem(entity string, key []byte, format string, args ...any) {
+	r.ProblemsCount++
+	if len(r.Problems) < maxHistoryProblems {
+		r.Problems = append(r.Problems, HistoryProblem{
+			Entity:  entity,
+			Key:     bytes.Clone(key),
+			Problem: fmt.Sprintf(format, args...),
+		})
+	}
+}
+
+// Inspector provides diagnostics of the state database of a stopped node: statistics of keys by their prefixes,
+// verification of history records, compaction and efficiency of the bloom filter.
+type Inspector struct {
+	db *keyvalue.KeyVal
+}
+
+// NewInspector opens the database of the state in dataDir. The storage backend and the compression algorithm
+// are taken from the DB meta file, other parameters of the database are taken from params.
+func NewInspector(dataDir string, params keyvalue.KeyValParams) (*Inspector, error) {
+	info, err := readDBMeta(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	dbDir := filepath.Join(dataDir, keyvalueDir)
+	if _, sErr := os.Stat(dbDir); sErr != nil {
+		return nil, errors.Wrap(sErr, "failed to find state database")
+	}
+	params.Backend = info.DBBackend
+	params.CompressionAlgo = info.DBCompressionAlgo
+	if params.BloomFilterStore != nil {
+		params.BloomFilterStore.WithPath(filepath.Join(dataDir, blocksStorDir, "bloom"))
+	}
+	db, err := keyvalue.NewKeyVal(dbDir, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open state database")
+	}
+	return &Inspector{db: db}, nil
+}
+
+// KeyStats returns statistics of all keys of the database grouped by the key prefix.
+func (i *Inspector) KeyStats(ctx context.Context) ([]KeyPrefixStats, error) {
+	entities, err := historyEntities()
+	if err != nil {
+		return nil, err
+	}
+	var stats [256]KeyPrefixStats
+	iter, err := i.db.NewKeyIterator(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+	for n := 0; iter.Next(); n++ {
+		if n%10000 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		key := iter.Key()
+		if len(key) == 0 {
+			continue
+		}
+		s := &stats[key[0]]
+		s.Keys++
+		s.KeysSize += uint64(len(key))
+		s.ValuesSize += uint64(len(iter.Value()))
+	}
+	if itErr := iter.Error(); itErr != nil {
+		return nil, errors.Wrap(itErr, "failed to iterate over state database")
+	}
+	var res []KeyPrefixStats
+	for p := range stats {
+		if stats[p].Keys == 0 {
+			continue
+		}
+		prefix := byte(p)
+		s := stats[p]
+		s.Prefix = prefix
+		s.Name = keyPrefixName(prefix)
+		_, s.History = entities[prefix]
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+// VerifyHistory checks that every history record decodes, contains entries of its blockchain entity
+// in the order of blocks and references only block nums issued by the state.
+func (i *Inspector) VerifyHistory(ctx context.Context) (HistoryReport, error) {
+	entities, err := historyEntities()
+	if err != nil {
+		return HistoryReport{}, err
+	}
+	lastBlockNum, err := i.lastBlockNum()
+	if err != nil {
+		return HistoryReport{}, err
+	}
+	valid, err := i.validBlockNums(lastBlockNum)
+	if err != nil {
+		return HistoryReport{}, err
+	}
+	var report HistoryReport
+	for prefix, entity := range entities { // the order of verification doesn't matter
+		if vErr := i.verifyEntity(ctx, prefix, entity, lastBlockNum, valid, &report); vErr != nil {
+			return HistoryReport{}, vErr
+		}
+	}
+	return report, nil
+}
+
+func (i *Inspector) verifyEntity(
+	ctx context.Context,
+	prefix byte,
+	entity blockchainEntity,
+	lastBlockNum uint32,
+	valid []uint64,
+	report *HistoryReport,
+) error {
+	name := keyPrefixName(prefix)
+	iter, err := i.db.NewKeyIterator([]byte{prefix})
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+	for n := 0; iter.Next(); n++ {
+		if n%10000 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		report.Records++
+		key, value := iter.Key(), iter.Value()
+		record, rErr := newHistoryRecordFromBytes(value)
+		if rErr != nil {
+			report.addProblem(name, key, "failed to decode history record: %v", rErr)
+			continue
+		}
+		if record.entityType != entity {
+			report.addProblem(name, key, "history record of entity %d under the key of entity %d",
+				record.entityType, entity)
+			continue
+		}
+		if encoded, mErr := record.marshalBinary(); mErr != nil || !bytes.Equal(encoded, value) {
+			report.addProblem(name, key, "history record has trailing data or invalid entries sizes")
+			continue
+		}
+		if len(record.entries) == 0 {
+			report.addProblem(name, key, "empty history record")
+			continue
+		}
+		var prev uint32
+		for j, e := range record.entries {
+			report.Entries++
+			switch {
+			case e.blockNum >= lastBlockNum:
+				report.addProblem(name, key, "entry %d references block num %d which is not issued yet", j, e.blockNum)
+			case j > 0 && e.blockNum <= prev:
+				report.addProblem(name, key, "entry %d with block num %d follows block num %d", j, e.blockNum, prev)
+			case valid[e.blockNum/64]&(1<<(e.blockNum%64)) == 0:
+				report.StaleEntries++
+			}
+			prev = e.blockNum
+		}
+	}
+	if itErr := iter.Error(); itErr != nil {
+		return errors.Wrapf(itErr, "failed to iterate over history records of %s", name)
+	}
+	return nil
+}
+
+// lastBlockNum returns the block num which is issued to the next block, all issued block nums are less than it.
+func (i *Inspector) lastBlockNum() (uint32, error) {
+	data, err := i.db.Get(lastBlockNumKeyBytes)
+	if err != nil {
+		if errors.Is(err, keyvalue.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "failed to get last block num")
+	}
+	if len(data) != 4 {
+		return 0, errors.Wrap(errInvalidDataSize, "failed to get last block num")
+	}
+	return binary.LittleEndian.Uint32(data), nil
+}
+
+// validBlockNums returns the bitset of block nums of the blocks which are not rolled back.
+func (i *Inspector) validBlockNums(lastBlockNum uint32) ([]uint64, error) {
+	valid := make([]uint64, lastBlockNum/64+1)
+	iter, err := i.db.NewKeyIterator([]byte{validBlockNumKeyPrefix})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) != 1+4 {
+			return nil, errors.Wrap(errInvalidDataSize, "invalid key of valid block num")
+		}
+		if num := binary.BigEndian.Uint32(key[1:]); num < lastBlockNum {
+			valid[num/64] |= 1 << (num % 64)
+		}
+	}
+	if itErr := iter.Error(); itErr != nil {
+		return nil, errors.Wrap(itErr, "failed to iterate over valid block nums")
+	}
+	return valid, nil
+}
+
+// Compact compacts the keys of the named prefix, as reported by KeyStats, or the whole database
+// if the name is empty.
+func (i *Inspector) Compact(name string) error {
+	if name == "" {
+		return i.db.Compact(nil)
+	}
+	for prefix, n := range keyPrefixNames {
+		if n == name {
+			return i.db.Compact([]byte{prefix})
+		}
+	}
+	return errors.Errorf("unknown key prefix name '%s'", name)
+}
+
+// BloomFilterStats returns the parameters and the filling of the bloom filter.
+func (i *Inspector) BloomFilterStats() keyvalue.BloomFilterStats {
+	return i.db.BloomFilterStats()
+}
+
+// ProbeBloomFilter looks up the given number of random absent keys and returns the number of false positives
+// of the bloom filter.
+func (i *Inspector) ProbeBloomFilter(count int) (uint64, error) {
+	return i.db.ProbeBloomFilter(count)
+}
+
+// Close closes the database and stores the bloom filter.
+func (i *Inspector) Close() error {
+	return i.db.Close()
+}
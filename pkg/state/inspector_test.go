@@ -0,0 +1,130 @@
+package state
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/importer"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+// keyPrefixConstants returns the key prefix constants declared in keys.go by their values.
+func keyPrefixConstants(t *testing.T) map[byte]string {
+	f, err := parser.ParseFile(token.NewFileSet(), "keys.go", nil, 0)
+	require.NoError(t, err)
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST || len(gd.Specs) == 0 {
+			continue
+		}
+		first, ok := gd.Specs[0].(*ast.ValueSpec)
+		if !ok || len(first.Values) != 1 {
+			continue
+		}
+		if id, isIdent := first.Values[0].(*ast.Ident); !isIdent || id.Name != "iota" {
+			continue
+		}
+		res := make(map[byte]string, len(gd.Specs))
+		for i, spec := range gd.Specs[1:] { // the first one is the placeholder for zero value
+			vs, isValue := spec.(*ast.ValueSpec)
+			require.True(t, isValue)
+			require.Len(t, vs.Names, 1)
+			require.Empty(t, vs.Values, "prefix %s must be assigned by iota", vs.Names[0].Name)
+			res[byte(i+1)] = vs.Names[0].Name
+		}
+		return res
+	}
+	require.FailNow(t, "key prefixes are not found in keys.go")
+	return nil
+}
+
+func TestKeyPrefixNames(t *testing.T) {
+	constants := keyPrefixConstants(t)
+	require.Equal(t, "wavesBalanceKeyPrefix", constants[wavesBalanceKeyPrefix])
+	require.Equal(t, "prunedTxKeyPrefix", constants[prunedTxKeyPrefix])
+	for p, constant := range constants {
+		assert.Contains(t, keyPrefixNames, p, "prefix %s has no name", constant)
+	}
+	assert.Len(t, keyPrefixNames, len(constants))
+	names := make(map[string]struct{}, len(keyPrefixNames))
+	for _, name := range keyPrefixNames {
+		_, dup := names[name]
+		assert.False(t, dup, "duplicate prefix name %s", name)
+		names[name] = struct{}{}
+	}
+	entities, err := historyEntities()
+	require.NoError(t, err)
+	assert.Len(t, entities, len(properties))
+}
+
+func TestInspector(t *testing.T) {
+	const height = 500
+	bs := settings.MustMainNetSettings()
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	importParams := importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath}
+
+	dataDir := t.TempDir()
+	params := DefaultTestingStateParams()
+	manager, err := newStateManager(t.Context(), dataDir, true, params, bs, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, manager, height-1, 1))
+	require.NoError(t, manager.RollbackToHeight(height-10))
+	require.NoError(t, manager.Close())
+
+	inspector, err := NewInspector(dataDir, params.DbParams)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, inspector.Close()) }()
+
+	stats, err := inspector.KeyStats(t.Context())
+	require.NoError(t, err)
+	byName := make(map[string]KeyPrefixStats, len(stats))
+	for i, s := range stats {
+		if i > 0 {
+			assert.Less(t, stats[i-1].Prefix, s.Prefix)
+		}
+		byName[s.Name] = s
+	}
+	require.Contains(t, byName, "wavesBalance")
+	assert.True(t, byName["wavesBalance"].History)
+	assert.NotZero(t, byName["wavesBalance"].ValuesSize)
+	require.Contains(t, byName, "blockNumToID")
+	assert.False(t, byName["blockNumToID"].History)
+	assert.Equal(t, uint64(height-10), byName["blockNumToID"].Keys)
+
+	report, err := inspector.VerifyHistory(t.Context())
+	require.NoError(t, err)
+	assert.NotZero(t, report.Records)
+	assert.NotZero(t, report.Entries)
+	assert.Zero(t, report.ProblemsCount)
+	assert.Empty(t, report.Problems)
+
+	iter, err := inspector.db.NewKeyIterator([]byte{wavesBalanceKeyPrefix})
+	require.NoError(t, err)
+	require.True(t, iter.Next())
+	key, value := bytes.Clone(iter.Key()), bytes.Clone(iter.Value())
+	iter.Release()
+	require.NoError(t, inspector.db.Put(key, value[:len(value)-1]))
+	report, err = inspector.VerifyHistory(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), report.ProblemsCount)
+	require.Len(t, report.Problems, 1)
+	assert.Equal(t, "wavesBalance", report.Problems[0].Entity)
+	assert.Equal(t, key, report.Problems[0].Key)
+
+	require.NoError(t, inspector.Compact("wavesBalance"))
+	require.NoError(t, inspector.Compact(""))
+	assert.Error(t, inspector.Compact("unknown"))
+	after, err := inspector.KeyStats(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, after, len(stats))
+
+	bloom := inspector.BloomFilterStats()
+	assert.Equal(t, params.DbParams.BloomFilterParams.DisableBloomFilter, !bloom.Enabled)
+}
@@ -135,6 +135,11 @@ const (
 	patchKeyPrefix
 
 	challengedAddressKeyPrefix
+
+	// Range of pruned transactions in the blockchain file.
+	prunedInfoKeyPrefix
+	// IDs of transactions --> bodies of transactions retained after pruning.
+	prunedTxKeyPrefix
 )
 
 var (
@@ -316,6 +321,17 @@ func (k *txInfoKey) bytes() []byte {
 	return buf
 }
 
+type prunedTxKey struct {
+	txID []byte
+}
+
+func (k *prunedTxKey) bytes() []byte {
+	buf := make([]byte, 1+crypto.DigestSize)
+	buf[0] = prunedTxKeyPrefix
+	copy(buf[1:], k.txID)
+	return buf
+}
+
 type scoreKey struct {
 	height uint64
 }
@@ -259,6 +259,27 @@ func (l *leases) leasingInfo(id crypto.Digest) (*leasing, error) {
 	return record, nil
 }
 
+// leasingInfoAtHeight returns leasing info as it was at the given height.
+// The height must be within the rollback window, older history entries are cut.
+func (l *leases) leasingInfoAtHeight(id crypto.Digest, height proto.Height) (*leasing, error) {
+	key := leaseKey{leaseID: id}
+	recordBytes, err := l.hs.entryDataAtHeight(key.bytes(), height)
+	if err != nil {
+		return nil, err
+	}
+	if recordBytes == nil {
+		return nil, errors.Wrapf(keyvalue.ErrNotFound, "lease %q not found at height %d", id.String(), height)
+	}
+	record := new(leasing)
+	if err = record.unmarshalBinary(recordBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal record")
+	}
+	if record.OriginTransactionID == nil {
+		record.OriginTransactionID = &id
+	}
+	return record, nil
+}
+
 func (l *leases) isActive(id crypto.Digest) (bool, error) {
 	info, err := l.leasingInfo(id)
 	if err != nil {
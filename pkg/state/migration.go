@@ -0,0 +1,103 @@
+package state
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const migrationDirSuffix = ".migration"
+
+// renameDir is replaced in tests to simulate failures of the database switch.
+var renameDir = os.Rename
+
+func readDBMeta(dataDir string) (stateInfo, error) {
+	f, err := os.Open(filepath.Join(filepath.Clean(dataDir), dbMetaFileName))
+	if err != nil {
+		return stateInfo{}, errors.Wrap(err, "failed to open DB meta file")
+	}
+	defer func() { _ = f.Close() }()
+	data, err := io.ReadAll(io.LimitReader(f, proto.MiB))
+	if err != nil {
+		return stateInfo{}, errors.Wrap(err, "failed to read DB meta file")
+	}
+	var info stateInfo
+	if umErr := info.unmarshalBinary(data); umErr != nil {
+		return stateInfo{}, errors.Wrap(umErr, "failed to unmarshal DB meta file")
+	}
+	return info, nil
+}
+
+// MigrateDatabase copies the key-value database of the closed state in dataDir to the database of the given
+// backend and switches the state to the new database. Blockchain files are left intact.
+// The old database is removed after the switch unless keepOld is set, in that case it's renamed by adding
+// the name of its backend as a suffix. If the migration is interrupted before the switch, the state
+// remains on the old database and the migration can be started again.
+func MigrateDatabase(ctx context.Context, dataDir string, backend keyvalue.Backend, keepOld bool) error {
+	info, err := readDBMeta(dataDir)
+	if err != nil {
+		return err
+	}
+	if info.DBBackend == backend {
+		return errors.Errorf("state already uses DB backend '%s'", backend)
+	}
+	dbDir := filepath.Join(dataDir, keyvalueDir)
+	if _, sErr := os.Stat(dbDir); sErr != nil {
+		return errors.Wrap(sErr, "failed to find state database")
+	}
+	old := dbDir + "." + strings.ToLower(info.DBBackend.String())
+	if _, sErr := os.Stat(old); !errors.Is(sErr, fs.ErrNotExist) {
+		return errors.Errorf("path for old database '%s' already exists", old)
+	}
+	tmp := dbDir + migrationDirSuffix
+	if rmErr := os.RemoveAll(tmp); rmErr != nil { // leftovers of the interrupted migration
+		return errors.Wrap(rmErr, "failed to remove previous migration attempt")
+	}
+	slog.Info("Copying state database", "from", info.DBBackend, "to", backend)
+	start := time.Now()
+	src := keyvalue.KeyValParams{Backend: info.DBBackend, CompressionAlgo: info.DBCompressionAlgo}
+	if cErr := keyvalue.Copy(ctx, dbDir, src, tmp, backend); cErr != nil {
+		if rmErr := os.RemoveAll(tmp); rmErr != nil {
+			slog.Warn("Failed to remove partially copied database", "path", tmp, logging.Error(rmErr))
+		}
+		return errors.Wrap(cErr, "failed to copy state database")
+	}
+	slog.Info("State database copied", "duration", time.Since(start))
+
+	if rnErr := renameDir(dbDir, old); rnErr != nil {
+		return errors.Wrap(rnErr, "failed to move old database")
+	}
+	if rnErr := renameDir(tmp, dbDir); rnErr != nil {
+		if rbErr := renameDir(old, dbDir); rbErr != nil { // the state has to stay on the old database
+			return errors.Wrapf(rnErr, "failed to move new database, the old one is kept at '%s' (%v)", old, rbErr)
+		}
+		return errors.Wrap(rnErr, "failed to move new database, the old one is restored")
+	}
+	info.DBBackend = backend
+	data, err := info.marshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal DB meta")
+	}
+	if wErr := writeFileSync(filepath.Join(dataDir, dbMetaFileName), data); wErr != nil {
+		return errors.Wrapf(wErr, "failed to update DB meta, the old database is kept at '%s'", old)
+	}
+	if keepOld {
+		slog.Info("Old state database is kept", "path", old)
+		return nil
+	}
+	if rmErr := os.RemoveAll(old); rmErr != nil {
+		return errors.Wrap(rmErr, "failed to remove old database")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/importer"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+func TestMigrateDatabase(t *testing.T) {
+	const height = 500
+	bs := settings.MustMainNetSettings()
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	importParams := importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath}
+
+	dataDir := t.TempDir()
+	params := DefaultTestingStateParams()
+	manager, err := newStateManager(t.Context(), dataDir, true, params, bs, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, manager, height-1, 1))
+	blockID, err := manager.HeightToBlockID(height)
+	require.NoError(t, err)
+	require.NoError(t, manager.Close())
+
+	require.NoError(t, MigrateDatabase(t.Context(), dataDir, keyvalue.BackendPebble, false))
+	assert.NoDirExists(t, filepath.Join(dataDir, keyvalueDir+".leveldb"))
+	assert.NoDirExists(t, filepath.Join(dataDir, keyvalueDir+migrationDirSuffix))
+	assert.Error(t, MigrateDatabase(t.Context(), dataDir, keyvalue.BackendPebble, false))
+
+	// Migrated state can't be opened with the old backend.
+	_, err = newStateManager(t.Context(), dataDir, true, params, bs, false, nil)
+	assert.ErrorIs(t, err, ErrIncompatibleStateParams)
+
+	params.DbParams.Backend = keyvalue.BackendPebble
+	manager, err = newStateManager(t.Context(), dataDir, true, params, bs, false, nil)
+	require.NoError(t, err)
+	migratedID, err := manager.HeightToBlockID(height)
+	require.NoError(t, err)
+	assert.Equal(t, blockID, migratedID)
+	require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, manager, height+99, height))
+	current, err := manager.Height()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(height+100), current)
+	require.NoError(t, manager.Close())
+
+	// Migration back keeps the old database on request.
+	require.NoError(t, MigrateDatabase(t.Context(), dataDir, keyvalue.BackendLevelDB, true))
+	assert.DirExists(t, filepath.Join(dataDir, keyvalueDir+".pebble"))
+	params.DbParams.Backend = keyvalue.BackendLevelDB
+	manager, err = newStateManager(t.Context(), dataDir, true, params, bs, false, nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, manager.Close()) }()
+	current, err = manager.Height()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(height+100), current)
+}
+
+func TestMigrateDatabaseRestoresOldOnFailedSwitch(t *testing.T) {
+	bs := settings.MustMainNetSettings()
+	dataDir := t.TempDir()
+	params := DefaultTestingStateParams()
+	manager, err := newStateManager(t.Context(), dataDir, true, params, bs, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, manager.Close())
+
+	dbDir := filepath.Join(dataDir, keyvalueDir)
+	defer func(rename func(string, string) error) { renameDir = rename }(renameDir)
+	renameDir = func(from, to string) error {
+		if from == dbDir+migrationDirSuffix {
+			return errors.New("rename failure")
+		}
+		return os.Rename(from, to)
+	}
+	require.Error(t, MigrateDatabase(t.Context(), dataDir, keyvalue.BackendPebble, false))
+	assert.DirExists(t, dbDir)
+	assert.NoDirExists(t, filepath.Join(dataDir, keyvalueDir+".leveldb"))
+
+	// The state stays on the old database.
+	manager, err = newStateManager(t.Context(), dataDir, true, params, bs, false, nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, manager.Close()) }()
+	current, err := manager.Height()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), current)
+}
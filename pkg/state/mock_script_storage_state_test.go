@@ -1183,6 +1183,72 @@ func (_c *MockScriptStorageState_scriptBasicInfoByAddressID_Call) RunAndReturn(r
 	return _c
 }
 
+// scriptBasicInfoByAddressIDAtHeight provides a mock function for the type MockScriptStorageState
+func (_mock *MockScriptStorageState) scriptBasicInfoByAddressIDAtHeight(addressID proto.AddressID, height proto.Height) (scriptBasicInfoRecord, error) {
+	ret := _mock.Called(addressID, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for scriptBasicInfoByAddressIDAtHeight")
+	}
+
+	var r0 scriptBasicInfoRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.AddressID, proto.Height) (scriptBasicInfoRecord, error)); ok {
+		return returnFunc(addressID, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.AddressID, proto.Height) scriptBasicInfoRecord); ok {
+		r0 = returnFunc(addressID, height)
+	} else {
+		r0 = ret.Get(0).(scriptBasicInfoRecord)
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.AddressID, proto.Height) error); ok {
+		r1 = returnFunc(addressID, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'scriptBasicInfoByAddressIDAtHeight'
+type MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call struct {
+	*mock.Call
+}
+
+// scriptBasicInfoByAddressIDAtHeight is a helper method to define mock.On call
+//   - addressID proto.AddressID
+//   - height proto.Height
+func (_e *MockScriptStorageState_Expecter) scriptBasicInfoByAddressIDAtHeight(addressID interface{}, height interface{}) *MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call {
+	return &MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call{Call: _e.mock.On("scriptBasicInfoByAddressIDAtHeight", addressID, height)}
+}
+
+func (_c *MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call) Run(run func(addressID proto.AddressID, height proto.Height)) *MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.AddressID
+		if args[0] != nil {
+			arg0 = args[0].(proto.AddressID)
+		}
+		var arg1 proto.Height
+		if args[1] != nil {
+			arg1 = args[1].(proto.Height)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call) Return(scriptBasicInfoRecordMoqParam scriptBasicInfoRecord, err error) *MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call {
+	_c.Call.Return(scriptBasicInfoRecordMoqParam, err)
+	return _c
+}
+
+func (_c *MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call) RunAndReturn(run func(addressID proto.AddressID, height proto.Height) (scriptBasicInfoRecord, error)) *MockScriptStorageState_scriptBasicInfoByAddressIDAtHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // scriptByAddr provides a mock function for the type MockScriptStorageState
 func (_mock *MockScriptStorageState) scriptByAddr(addr proto.WavesAddress) (*ast.Tree, error) {
 	ret := _mock.Called(addr)
@@ -1369,6 +1435,74 @@ func (_c *MockScriptStorageState_scriptBytesByAddr_Call) RunAndReturn(run func(a
 	return _c
 }
 
+// scriptBytesByAddrAtHeight provides a mock function for the type MockScriptStorageState
+func (_mock *MockScriptStorageState) scriptBytesByAddrAtHeight(addr proto.WavesAddress, height proto.Height) (proto.Script, error) {
+	ret := _mock.Called(addr, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for scriptBytesByAddrAtHeight")
+	}
+
+	var r0 proto.Script
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.WavesAddress, proto.Height) (proto.Script, error)); ok {
+		return returnFunc(addr, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.WavesAddress, proto.Height) proto.Script); ok {
+		r0 = returnFunc(addr, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(proto.Script)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.WavesAddress, proto.Height) error); ok {
+		r1 = returnFunc(addr, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScriptStorageState_scriptBytesByAddrAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'scriptBytesByAddrAtHeight'
+type MockScriptStorageState_scriptBytesByAddrAtHeight_Call struct {
+	*mock.Call
+}
+
+// scriptBytesByAddrAtHeight is a helper method to define mock.On call
+//   - addr proto.WavesAddress
+//   - height proto.Height
+func (_e *MockScriptStorageState_Expecter) scriptBytesByAddrAtHeight(addr interface{}, height interface{}) *MockScriptStorageState_scriptBytesByAddrAtHeight_Call {
+	return &MockScriptStorageState_scriptBytesByAddrAtHeight_Call{Call: _e.mock.On("scriptBytesByAddrAtHeight", addr, height)}
+}
+
+func (_c *MockScriptStorageState_scriptBytesByAddrAtHeight_Call) Run(run func(addr proto.WavesAddress, height proto.Height)) *MockScriptStorageState_scriptBytesByAddrAtHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.WavesAddress
+		if args[0] != nil {
+			arg0 = args[0].(proto.WavesAddress)
+		}
+		var arg1 proto.Height
+		if args[1] != nil {
+			arg1 = args[1].(proto.Height)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScriptStorageState_scriptBytesByAddrAtHeight_Call) Return(script proto.Script, err error) *MockScriptStorageState_scriptBytesByAddrAtHeight_Call {
+	_c.Call.Return(script, err)
+	return _c
+}
+
+func (_c *MockScriptStorageState_scriptBytesByAddrAtHeight_Call) RunAndReturn(run func(addr proto.WavesAddress, height proto.Height) (proto.Script, error)) *MockScriptStorageState_scriptBytesByAddrAtHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // scriptBytesByAsset provides a mock function for the type MockScriptStorageState
 func (_mock *MockScriptStorageState) scriptBytesByAsset(assetID proto.AssetID) (proto.Script, error) {
 	ret := _mock.Called(assetID)
@@ -1431,6 +1565,74 @@ func (_c *MockScriptStorageState_scriptBytesByAsset_Call) RunAndReturn(run func(
 	return _c
 }
 
+// scriptBytesByAssetAtHeight provides a mock function for the type MockScriptStorageState
+func (_mock *MockScriptStorageState) scriptBytesByAssetAtHeight(assetID proto.AssetID, height proto.Height) (proto.Script, error) {
+	ret := _mock.Called(assetID, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for scriptBytesByAssetAtHeight")
+	}
+
+	var r0 proto.Script
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.AssetID, proto.Height) (proto.Script, error)); ok {
+		return returnFunc(assetID, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.AssetID, proto.Height) proto.Script); ok {
+		r0 = returnFunc(assetID, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(proto.Script)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.AssetID, proto.Height) error); ok {
+		r1 = returnFunc(assetID, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScriptStorageState_scriptBytesByAssetAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'scriptBytesByAssetAtHeight'
+type MockScriptStorageState_scriptBytesByAssetAtHeight_Call struct {
+	*mock.Call
+}
+
+// scriptBytesByAssetAtHeight is a helper method to define mock.On call
+//   - assetID proto.AssetID
+//   - height proto.Height
+func (_e *MockScriptStorageState_Expecter) scriptBytesByAssetAtHeight(assetID interface{}, height interface{}) *MockScriptStorageState_scriptBytesByAssetAtHeight_Call {
+	return &MockScriptStorageState_scriptBytesByAssetAtHeight_Call{Call: _e.mock.On("scriptBytesByAssetAtHeight", assetID, height)}
+}
+
+func (_c *MockScriptStorageState_scriptBytesByAssetAtHeight_Call) Run(run func(assetID proto.AssetID, height proto.Height)) *MockScriptStorageState_scriptBytesByAssetAtHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.AssetID
+		if args[0] != nil {
+			arg0 = args[0].(proto.AssetID)
+		}
+		var arg1 proto.Height
+		if args[1] != nil {
+			arg1 = args[1].(proto.Height)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScriptStorageState_scriptBytesByAssetAtHeight_Call) Return(script proto.Script, err error) *MockScriptStorageState_scriptBytesByAssetAtHeight_Call {
+	_c.Call.Return(script, err)
+	return _c
+}
+
+func (_c *MockScriptStorageState_scriptBytesByAssetAtHeight_Call) RunAndReturn(run func(assetID proto.AssetID, height proto.Height) (proto.Script, error)) *MockScriptStorageState_scriptBytesByAssetAtHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // setAccountScript provides a mock function for the type MockScriptStorageState
 func (_mock *MockScriptStorageState) setAccountScript(addr proto.WavesAddress, script proto.Script, pk crypto.PublicKey, blockID proto.BlockID) error {
 	ret := _mock.Called(addr, script, pk, blockID)
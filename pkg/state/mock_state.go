@@ -10,6 +10,7 @@ import (
 	mock "github.com/stretchr/testify/mock"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 )
@@ -768,6 +769,140 @@ func (_c *MockState_AssetBalance_Call) RunAndReturn(run func(account proto.Recip
 	return _c
 }
 
+// AssetBalanceAtHeight provides a mock function for the type MockState
+func (_mock *MockState) AssetBalanceAtHeight(account proto.Recipient, assetID proto.AssetID, height proto.Height) (uint64, error) {
+	ret := _mock.Called(account, assetID, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssetBalanceAtHeight")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, proto.AssetID, proto.Height) (uint64, error)); ok {
+		return returnFunc(account, assetID, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, proto.AssetID, proto.Height) uint64); ok {
+		r0 = returnFunc(account, assetID, height)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.Recipient, proto.AssetID, proto.Height) error); ok {
+		r1 = returnFunc(account, assetID, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_AssetBalanceAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssetBalanceAtHeight'
+type MockState_AssetBalanceAtHeight_Call struct {
+	*mock.Call
+}
+
+// AssetBalanceAtHeight is a helper method to define mock.On call
+//   - account proto.Recipient
+//   - assetID proto.AssetID
+//   - height proto.Height
+func (_e *MockState_Expecter) AssetBalanceAtHeight(account interface{}, assetID interface{}, height interface{}) *MockState_AssetBalanceAtHeight_Call {
+	return &MockState_AssetBalanceAtHeight_Call{Call: _e.mock.On("AssetBalanceAtHeight", account, assetID, height)}
+}
+
+func (_c *MockState_AssetBalanceAtHeight_Call) Run(run func(account proto.Recipient, assetID proto.AssetID, height proto.Height)) *MockState_AssetBalanceAtHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.Recipient
+		if args[0] != nil {
+			arg0 = args[0].(proto.Recipient)
+		}
+		var arg1 proto.AssetID
+		if args[1] != nil {
+			arg1 = args[1].(proto.AssetID)
+		}
+		var arg2 proto.Height
+		if args[2] != nil {
+			arg2 = args[2].(proto.Height)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_AssetBalanceAtHeight_Call) Return(v uint64, err error) *MockState_AssetBalanceAtHeight_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockState_AssetBalanceAtHeight_Call) RunAndReturn(run func(account proto.Recipient, assetID proto.AssetID, height proto.Height) (uint64, error)) *MockState_AssetBalanceAtHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssetBalances provides a mock function for the type MockState
+func (_mock *MockState) AssetBalances(account proto.Recipient) ([]proto.AssetBalance, error) {
+	ret := _mock.Called(account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssetBalances")
+	}
+
+	var r0 []proto.AssetBalance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient) ([]proto.AssetBalance, error)); ok {
+		return returnFunc(account)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient) []proto.AssetBalance); ok {
+		r0 = returnFunc(account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]proto.AssetBalance)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.Recipient) error); ok {
+		r1 = returnFunc(account)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_AssetBalances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssetBalances'
+type MockState_AssetBalances_Call struct {
+	*mock.Call
+}
+
+// AssetBalances is a helper method to define mock.On call
+//   - account proto.Recipient
+func (_e *MockState_Expecter) AssetBalances(account interface{}) *MockState_AssetBalances_Call {
+	return &MockState_AssetBalances_Call{Call: _e.mock.On("AssetBalances", account)}
+}
+
+func (_c *MockState_AssetBalances_Call) Run(run func(account proto.Recipient)) *MockState_AssetBalances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.Recipient
+		if args[0] != nil {
+			arg0 = args[0].(proto.Recipient)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_AssetBalances_Call) Return(assetBalances []proto.AssetBalance, err error) *MockState_AssetBalances_Call {
+	_c.Call.Return(assetBalances, err)
+	return _c
+}
+
+func (_c *MockState_AssetBalances_Call) RunAndReturn(run func(account proto.Recipient) ([]proto.AssetBalance, error)) *MockState_AssetBalances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AssetInfo provides a mock function for the type MockState
 func (_mock *MockState) AssetInfo(assetID proto.AssetID) (*proto.AssetInfo, error) {
 	ret := _mock.Called(assetID)
@@ -1210,6 +1345,52 @@ func (_c *MockState_BlockVRF_Call) RunAndReturn(run func(blockHeader *proto.Bloc
 	return _c
 }
 
+// BlockchainChanged provides a mock function for the type MockState
+func (_mock *MockState) BlockchainChanged() <-chan struct{} {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockchainChanged")
+	}
+
+	var r0 <-chan struct{}
+	if returnFunc, ok := ret.Get(0).(func() <-chan struct{}); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+	return r0
+}
+
+// MockState_BlockchainChanged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockchainChanged'
+type MockState_BlockchainChanged_Call struct {
+	*mock.Call
+}
+
+// BlockchainChanged is a helper method to define mock.On call
+func (_e *MockState_Expecter) BlockchainChanged() *MockState_BlockchainChanged_Call {
+	return &MockState_BlockchainChanged_Call{Call: _e.mock.On("BlockchainChanged")}
+}
+
+func (_c *MockState_BlockchainChanged_Call) Run(run func()) *MockState_BlockchainChanged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockState_BlockchainChanged_Call) Return(valCh <-chan struct{}) *MockState_BlockchainChanged_Call {
+	_c.Call.Return(valCh)
+	return _c
+}
+
+func (_c *MockState_BlockchainChanged_Call) RunAndReturn(run func() <-chan struct{}) *MockState_BlockchainChanged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BlockchainSettings provides a mock function for the type MockState
 func (_mock *MockState) BlockchainSettings() (*settings.BlockchainSettings, error) {
 	ret := _mock.Called()
@@ -1426,6 +1607,86 @@ func (_c *MockState_CurrentScore_Call) RunAndReturn(run func() (*big.Int, error)
 	return _c
 }
 
+// DryRunTx provides a mock function for the type MockState
+func (_mock *MockState) DryRunTx(tx proto.Transaction, currentTimestamp uint64, verifySignature bool) ([]proto.AtomicSnapshot, uint64, error) {
+	ret := _mock.Called(tx, currentTimestamp, verifySignature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DryRunTx")
+	}
+
+	var r0 []proto.AtomicSnapshot
+	var r1 uint64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(proto.Transaction, uint64, bool) ([]proto.AtomicSnapshot, uint64, error)); ok {
+		return returnFunc(tx, currentTimestamp, verifySignature)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.Transaction, uint64, bool) []proto.AtomicSnapshot); ok {
+		r0 = returnFunc(tx, currentTimestamp, verifySignature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]proto.AtomicSnapshot)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.Transaction, uint64, bool) uint64); ok {
+		r1 = returnFunc(tx, currentTimestamp, verifySignature)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(proto.Transaction, uint64, bool) error); ok {
+		r2 = returnFunc(tx, currentTimestamp, verifySignature)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockState_DryRunTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DryRunTx'
+type MockState_DryRunTx_Call struct {
+	*mock.Call
+}
+
+// DryRunTx is a helper method to define mock.On call
+//   - tx proto.Transaction
+//   - currentTimestamp uint64
+//   - verifySignature bool
+func (_e *MockState_Expecter) DryRunTx(tx interface{}, currentTimestamp interface{}, verifySignature interface{}) *MockState_DryRunTx_Call {
+	return &MockState_DryRunTx_Call{Call: _e.mock.On("DryRunTx", tx, currentTimestamp, verifySignature)}
+}
+
+func (_c *MockState_DryRunTx_Call) Run(run func(tx proto.Transaction, currentTimestamp uint64, verifySignature bool)) *MockState_DryRunTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.Transaction
+		if args[0] != nil {
+			arg0 = args[0].(proto.Transaction)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_DryRunTx_Call) Return(snapshots []proto.AtomicSnapshot, complexity uint64, err error) *MockState_DryRunTx_Call {
+	_c.Call.Return(snapshots, complexity, err)
+	return _c
+}
+
+func (_c *MockState_DryRunTx_Call) RunAndReturn(run func(tx proto.Transaction, currentTimestamp uint64, verifySignature bool) ([]proto.AtomicSnapshot, uint64, error)) *MockState_DryRunTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EnrichedFullAssetInfo provides a mock function for the type MockState
 func (_mock *MockState) EnrichedFullAssetInfo(assetID proto.AssetID) (*proto.EnrichedFullAssetInfo, error) {
 	ret := _mock.Called(assetID)
@@ -1488,6 +1749,74 @@ func (_c *MockState_EnrichedFullAssetInfo_Call) RunAndReturn(run func(assetID pr
 	return _c
 }
 
+// EnrichedFullAssetInfoAtHeight provides a mock function for the type MockState
+func (_mock *MockState) EnrichedFullAssetInfoAtHeight(assetID proto.AssetID, height proto.Height) (*proto.EnrichedFullAssetInfo, error) {
+	ret := _mock.Called(assetID, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrichedFullAssetInfoAtHeight")
+	}
+
+	var r0 *proto.EnrichedFullAssetInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.AssetID, proto.Height) (*proto.EnrichedFullAssetInfo, error)); ok {
+		return returnFunc(assetID, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.AssetID, proto.Height) *proto.EnrichedFullAssetInfo); ok {
+		r0 = returnFunc(assetID, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.EnrichedFullAssetInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.AssetID, proto.Height) error); ok {
+		r1 = returnFunc(assetID, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_EnrichedFullAssetInfoAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrichedFullAssetInfoAtHeight'
+type MockState_EnrichedFullAssetInfoAtHeight_Call struct {
+	*mock.Call
+}
+
+// EnrichedFullAssetInfoAtHeight is a helper method to define mock.On call
+//   - assetID proto.AssetID
+//   - height proto.Height
+func (_e *MockState_Expecter) EnrichedFullAssetInfoAtHeight(assetID interface{}, height interface{}) *MockState_EnrichedFullAssetInfoAtHeight_Call {
+	return &MockState_EnrichedFullAssetInfoAtHeight_Call{Call: _e.mock.On("EnrichedFullAssetInfoAtHeight", assetID, height)}
+}
+
+func (_c *MockState_EnrichedFullAssetInfoAtHeight_Call) Run(run func(assetID proto.AssetID, height proto.Height)) *MockState_EnrichedFullAssetInfoAtHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.AssetID
+		if args[0] != nil {
+			arg0 = args[0].(proto.AssetID)
+		}
+		var arg1 proto.Height
+		if args[1] != nil {
+			arg1 = args[1].(proto.Height)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_EnrichedFullAssetInfoAtHeight_Call) Return(enrichedFullAssetInfo *proto.EnrichedFullAssetInfo, err error) *MockState_EnrichedFullAssetInfoAtHeight_Call {
+	_c.Call.Return(enrichedFullAssetInfo, err)
+	return _c
+}
+
+func (_c *MockState_EnrichedFullAssetInfoAtHeight_Call) RunAndReturn(run func(assetID proto.AssetID, height proto.Height) (*proto.EnrichedFullAssetInfo, error)) *MockState_EnrichedFullAssetInfoAtHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EstimatorVersion provides a mock function for the type MockState
 func (_mock *MockState) EstimatorVersion() (int, error) {
 	ret := _mock.Called()
@@ -1541,6 +1870,142 @@ func (_c *MockState_EstimatorVersion_Call) RunAndReturn(run func() (int, error))
 	return _c
 }
 
+// EvaluateExpression provides a mock function for the type MockState
+func (_mock *MockState) EvaluateExpression(addr proto.WavesAddress, expr *ast.Tree) (ride.Result, error) {
+	ret := _mock.Called(addr, expr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateExpression")
+	}
+
+	var r0 ride.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.WavesAddress, *ast.Tree) (ride.Result, error)); ok {
+		return returnFunc(addr, expr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.WavesAddress, *ast.Tree) ride.Result); ok {
+		r0 = returnFunc(addr, expr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ride.Result)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.WavesAddress, *ast.Tree) error); ok {
+		r1 = returnFunc(addr, expr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_EvaluateExpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvaluateExpression'
+type MockState_EvaluateExpression_Call struct {
+	*mock.Call
+}
+
+// EvaluateExpression is a helper method to define mock.On call
+//   - addr proto.WavesAddress
+//   - expr *ast.Tree
+func (_e *MockState_Expecter) EvaluateExpression(addr interface{}, expr interface{}) *MockState_EvaluateExpression_Call {
+	return &MockState_EvaluateExpression_Call{Call: _e.mock.On("EvaluateExpression", addr, expr)}
+}
+
+func (_c *MockState_EvaluateExpression_Call) Run(run func(addr proto.WavesAddress, expr *ast.Tree)) *MockState_EvaluateExpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.WavesAddress
+		if args[0] != nil {
+			arg0 = args[0].(proto.WavesAddress)
+		}
+		var arg1 *ast.Tree
+		if args[1] != nil {
+			arg1 = args[1].(*ast.Tree)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_EvaluateExpression_Call) Return(result ride.Result, err error) *MockState_EvaluateExpression_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockState_EvaluateExpression_Call) RunAndReturn(run func(addr proto.WavesAddress, expr *ast.Tree) (ride.Result, error)) *MockState_EvaluateExpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EvaluateFunctionCall provides a mock function for the type MockState
+func (_mock *MockState) EvaluateFunctionCall(addr proto.WavesAddress, fc proto.FunctionCall) (ride.Result, error) {
+	ret := _mock.Called(addr, fc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateFunctionCall")
+	}
+
+	var r0 ride.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.WavesAddress, proto.FunctionCall) (ride.Result, error)); ok {
+		return returnFunc(addr, fc)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.WavesAddress, proto.FunctionCall) ride.Result); ok {
+		r0 = returnFunc(addr, fc)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ride.Result)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.WavesAddress, proto.FunctionCall) error); ok {
+		r1 = returnFunc(addr, fc)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_EvaluateFunctionCall_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvaluateFunctionCall'
+type MockState_EvaluateFunctionCall_Call struct {
+	*mock.Call
+}
+
+// EvaluateFunctionCall is a helper method to define mock.On call
+//   - addr proto.WavesAddress
+//   - fc proto.FunctionCall
+func (_e *MockState_Expecter) EvaluateFunctionCall(addr interface{}, fc interface{}) *MockState_EvaluateFunctionCall_Call {
+	return &MockState_EvaluateFunctionCall_Call{Call: _e.mock.On("EvaluateFunctionCall", addr, fc)}
+}
+
+func (_c *MockState_EvaluateFunctionCall_Call) Run(run func(addr proto.WavesAddress, fc proto.FunctionCall)) *MockState_EvaluateFunctionCall_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.WavesAddress
+		if args[0] != nil {
+			arg0 = args[0].(proto.WavesAddress)
+		}
+		var arg1 proto.FunctionCall
+		if args[1] != nil {
+			arg1 = args[1].(proto.FunctionCall)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_EvaluateFunctionCall_Call) Return(result ride.Result, err error) *MockState_EvaluateFunctionCall_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockState_EvaluateFunctionCall_Call) RunAndReturn(run func(addr proto.WavesAddress, fc proto.FunctionCall) (ride.Result, error)) *MockState_EvaluateFunctionCall_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FullAssetInfo provides a mock function for the type MockState
 func (_mock *MockState) FullAssetInfo(assetID proto.AssetID) (*proto.FullAssetInfo, error) {
 	ret := _mock.Called(assetID)
@@ -2524,6 +2989,78 @@ func (_c *MockState_IsAssetExist_Call) RunAndReturn(run func(assetID proto.Asset
 	return _c
 }
 
+// LeaseBalanceAtHeight provides a mock function for the type MockState
+func (_mock *MockState) LeaseBalanceAtHeight(account proto.Recipient, height proto.Height) (int64, int64, error) {
+	ret := _mock.Called(account, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeaseBalanceAtHeight")
+	}
+
+	var r0 int64
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, proto.Height) (int64, int64, error)); ok {
+		return returnFunc(account, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, proto.Height) int64); ok {
+		r0 = returnFunc(account, height)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.Recipient, proto.Height) int64); ok {
+		r1 = returnFunc(account, height)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(proto.Recipient, proto.Height) error); ok {
+		r2 = returnFunc(account, height)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockState_LeaseBalanceAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaseBalanceAtHeight'
+type MockState_LeaseBalanceAtHeight_Call struct {
+	*mock.Call
+}
+
+// LeaseBalanceAtHeight is a helper method to define mock.On call
+//   - account proto.Recipient
+//   - height proto.Height
+func (_e *MockState_Expecter) LeaseBalanceAtHeight(account interface{}, height interface{}) *MockState_LeaseBalanceAtHeight_Call {
+	return &MockState_LeaseBalanceAtHeight_Call{Call: _e.mock.On("LeaseBalanceAtHeight", account, height)}
+}
+
+func (_c *MockState_LeaseBalanceAtHeight_Call) Run(run func(account proto.Recipient, height proto.Height)) *MockState_LeaseBalanceAtHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.Recipient
+		if args[0] != nil {
+			arg0 = args[0].(proto.Recipient)
+		}
+		var arg1 proto.Height
+		if args[1] != nil {
+			arg1 = args[1].(proto.Height)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_LeaseBalanceAtHeight_Call) Return(v int64, v1 int64, err error) *MockState_LeaseBalanceAtHeight_Call {
+	_c.Call.Return(v, v1, err)
+	return _c
+}
+
+func (_c *MockState_LeaseBalanceAtHeight_Call) RunAndReturn(run func(account proto.Recipient, height proto.Height) (int64, int64, error)) *MockState_LeaseBalanceAtHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LegacyStateHashAtHeight provides a mock function for the type MockState
 func (_mock *MockState) LegacyStateHashAtHeight(height proto.Height) (*proto.StateHash, error) {
 	ret := _mock.Called(height)
@@ -2750,6 +3287,72 @@ func (_c *MockState_MapUnsafe_Call) RunAndReturn(run func(fn func(state NonThrea
 	return _c
 }
 
+// MinFee provides a mock function for the type MockState
+func (_mock *MockState) MinFee(tx proto.Transaction) (proto.OptionalAsset, uint64, error) {
+	ret := _mock.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MinFee")
+	}
+
+	var r0 proto.OptionalAsset
+	var r1 uint64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(proto.Transaction) (proto.OptionalAsset, uint64, error)); ok {
+		return returnFunc(tx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.Transaction) proto.OptionalAsset); ok {
+		r0 = returnFunc(tx)
+	} else {
+		r0 = ret.Get(0).(proto.OptionalAsset)
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.Transaction) uint64); ok {
+		r1 = returnFunc(tx)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(proto.Transaction) error); ok {
+		r2 = returnFunc(tx)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockState_MinFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MinFee'
+type MockState_MinFee_Call struct {
+	*mock.Call
+}
+
+// MinFee is a helper method to define mock.On call
+//   - tx proto.Transaction
+func (_e *MockState_Expecter) MinFee(tx interface{}) *MockState_MinFee_Call {
+	return &MockState_MinFee_Call{Call: _e.mock.On("MinFee", tx)}
+}
+
+func (_c *MockState_MinFee_Call) Run(run func(tx proto.Transaction)) *MockState_MinFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.Transaction
+		if args[0] != nil {
+			arg0 = args[0].(proto.Transaction)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_MinFee_Call) Return(optionalAsset proto.OptionalAsset, v uint64, err error) *MockState_MinFee_Call {
+	_c.Call.Return(optionalAsset, v, err)
+	return _c
+}
+
+func (_c *MockState_MinFee_Call) RunAndReturn(run func(tx proto.Transaction) (proto.OptionalAsset, uint64, error)) *MockState_MinFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NFTList provides a mock function for the type MockState
 func (_mock *MockState) NFTList(account proto.Recipient, limit uint64, afterAssetID *proto.AssetID) ([]*proto.FullAssetInfo, error) {
 	ret := _mock.Called(account, limit, afterAssetID)
@@ -3284,6 +3887,50 @@ func (_c *MockState_ProvidesStateHashes_Call) RunAndReturn(run func() (bool, err
 	return _c
 }
 
+// PrunedHeight provides a mock function for the type MockState
+func (_mock *MockState) PrunedHeight() proto.Height {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrunedHeight")
+	}
+
+	var r0 proto.Height
+	if returnFunc, ok := ret.Get(0).(func() proto.Height); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(proto.Height)
+	}
+	return r0
+}
+
+// MockState_PrunedHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrunedHeight'
+type MockState_PrunedHeight_Call struct {
+	*mock.Call
+}
+
+// PrunedHeight is a helper method to define mock.On call
+func (_e *MockState_Expecter) PrunedHeight() *MockState_PrunedHeight_Call {
+	return &MockState_PrunedHeight_Call{Call: _e.mock.On("PrunedHeight")}
+}
+
+func (_c *MockState_PrunedHeight_Call) Run(run func()) *MockState_PrunedHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockState_PrunedHeight_Call) Return(v proto.Height) *MockState_PrunedHeight_Call {
+	_c.Call.Return(v)
+	return _c
+}
+
+func (_c *MockState_PrunedHeight_Call) RunAndReturn(run func() proto.Height) *MockState_PrunedHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResetValidationList provides a mock function for the type MockState
 func (_mock *MockState) ResetValidationList() {
 	_mock.Called()
@@ -3543,19 +4190,88 @@ func (_mock *MockState) RetrieveEntry(account proto.Recipient, key string) (prot
 	return r0, r1
 }
 
-// MockState_RetrieveEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveEntry'
-type MockState_RetrieveEntry_Call struct {
+// MockState_RetrieveEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveEntry'
+type MockState_RetrieveEntry_Call struct {
+	*mock.Call
+}
+
+// RetrieveEntry is a helper method to define mock.On call
+//   - account proto.Recipient
+//   - key string
+func (_e *MockState_Expecter) RetrieveEntry(account interface{}, key interface{}) *MockState_RetrieveEntry_Call {
+	return &MockState_RetrieveEntry_Call{Call: _e.mock.On("RetrieveEntry", account, key)}
+}
+
+func (_c *MockState_RetrieveEntry_Call) Run(run func(account proto.Recipient, key string)) *MockState_RetrieveEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.Recipient
+		if args[0] != nil {
+			arg0 = args[0].(proto.Recipient)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_RetrieveEntry_Call) Return(dataEntry proto.DataEntry, err error) *MockState_RetrieveEntry_Call {
+	_c.Call.Return(dataEntry, err)
+	return _c
+}
+
+func (_c *MockState_RetrieveEntry_Call) RunAndReturn(run func(account proto.Recipient, key string) (proto.DataEntry, error)) *MockState_RetrieveEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveEntryAtHeight provides a mock function for the type MockState
+func (_mock *MockState) RetrieveEntryAtHeight(account proto.Recipient, key string, height proto.Height) (proto.DataEntry, error) {
+	ret := _mock.Called(account, key, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveEntryAtHeight")
+	}
+
+	var r0 proto.DataEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, string, proto.Height) (proto.DataEntry, error)); ok {
+		return returnFunc(account, key, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, string, proto.Height) proto.DataEntry); ok {
+		r0 = returnFunc(account, key, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(proto.DataEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.Recipient, string, proto.Height) error); ok {
+		r1 = returnFunc(account, key, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_RetrieveEntryAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveEntryAtHeight'
+type MockState_RetrieveEntryAtHeight_Call struct {
 	*mock.Call
 }
 
-// RetrieveEntry is a helper method to define mock.On call
+// RetrieveEntryAtHeight is a helper method to define mock.On call
 //   - account proto.Recipient
 //   - key string
-func (_e *MockState_Expecter) RetrieveEntry(account interface{}, key interface{}) *MockState_RetrieveEntry_Call {
-	return &MockState_RetrieveEntry_Call{Call: _e.mock.On("RetrieveEntry", account, key)}
+//   - height proto.Height
+func (_e *MockState_Expecter) RetrieveEntryAtHeight(account interface{}, key interface{}, height interface{}) *MockState_RetrieveEntryAtHeight_Call {
+	return &MockState_RetrieveEntryAtHeight_Call{Call: _e.mock.On("RetrieveEntryAtHeight", account, key, height)}
 }
 
-func (_c *MockState_RetrieveEntry_Call) Run(run func(account proto.Recipient, key string)) *MockState_RetrieveEntry_Call {
+func (_c *MockState_RetrieveEntryAtHeight_Call) Run(run func(account proto.Recipient, key string, height proto.Height)) *MockState_RetrieveEntryAtHeight_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 proto.Recipient
 		if args[0] != nil {
@@ -3565,20 +4281,25 @@ func (_c *MockState_RetrieveEntry_Call) Run(run func(account proto.Recipient, ke
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 proto.Height
+		if args[2] != nil {
+			arg2 = args[2].(proto.Height)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockState_RetrieveEntry_Call) Return(dataEntry proto.DataEntry, err error) *MockState_RetrieveEntry_Call {
+func (_c *MockState_RetrieveEntryAtHeight_Call) Return(dataEntry proto.DataEntry, err error) *MockState_RetrieveEntryAtHeight_Call {
 	_c.Call.Return(dataEntry, err)
 	return _c
 }
 
-func (_c *MockState_RetrieveEntry_Call) RunAndReturn(run func(account proto.Recipient, key string) (proto.DataEntry, error)) *MockState_RetrieveEntry_Call {
+func (_c *MockState_RetrieveEntryAtHeight_Call) RunAndReturn(run func(account proto.Recipient, key string, height proto.Height) (proto.DataEntry, error)) *MockState_RetrieveEntryAtHeight_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3839,6 +4560,59 @@ func (_c *MockState_RewardVotes_Call) RunAndReturn(run func(height proto.Height)
 	return _c
 }
 
+// RollbackMinHeight provides a mock function for the type MockState
+func (_mock *MockState) RollbackMinHeight() (proto.Height, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackMinHeight")
+	}
+
+	var r0 proto.Height
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (proto.Height, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() proto.Height); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(proto.Height)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_RollbackMinHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackMinHeight'
+type MockState_RollbackMinHeight_Call struct {
+	*mock.Call
+}
+
+// RollbackMinHeight is a helper method to define mock.On call
+func (_e *MockState_Expecter) RollbackMinHeight() *MockState_RollbackMinHeight_Call {
+	return &MockState_RollbackMinHeight_Call{Call: _e.mock.On("RollbackMinHeight")}
+}
+
+func (_c *MockState_RollbackMinHeight_Call) Run(run func()) *MockState_RollbackMinHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockState_RollbackMinHeight_Call) Return(v proto.Height, err error) *MockState_RollbackMinHeight_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockState_RollbackMinHeight_Call) RunAndReturn(run func() (proto.Height, error)) *MockState_RollbackMinHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RollbackTo provides a mock function for the type MockState
 func (_mock *MockState) RollbackTo(removalEdge proto.BlockID) error {
 	ret := _mock.Called(removalEdge)
@@ -4065,6 +4839,74 @@ func (_c *MockState_ScriptBasicInfoByAccount_Call) RunAndReturn(run func(account
 	return _c
 }
 
+// ScriptBytesByAccountAtHeight provides a mock function for the type MockState
+func (_mock *MockState) ScriptBytesByAccountAtHeight(account proto.Recipient, height proto.Height) (proto.Script, error) {
+	ret := _mock.Called(account, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScriptBytesByAccountAtHeight")
+	}
+
+	var r0 proto.Script
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, proto.Height) (proto.Script, error)); ok {
+		return returnFunc(account, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, proto.Height) proto.Script); ok {
+		r0 = returnFunc(account, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(proto.Script)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.Recipient, proto.Height) error); ok {
+		r1 = returnFunc(account, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_ScriptBytesByAccountAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScriptBytesByAccountAtHeight'
+type MockState_ScriptBytesByAccountAtHeight_Call struct {
+	*mock.Call
+}
+
+// ScriptBytesByAccountAtHeight is a helper method to define mock.On call
+//   - account proto.Recipient
+//   - height proto.Height
+func (_e *MockState_Expecter) ScriptBytesByAccountAtHeight(account interface{}, height interface{}) *MockState_ScriptBytesByAccountAtHeight_Call {
+	return &MockState_ScriptBytesByAccountAtHeight_Call{Call: _e.mock.On("ScriptBytesByAccountAtHeight", account, height)}
+}
+
+func (_c *MockState_ScriptBytesByAccountAtHeight_Call) Run(run func(account proto.Recipient, height proto.Height)) *MockState_ScriptBytesByAccountAtHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.Recipient
+		if args[0] != nil {
+			arg0 = args[0].(proto.Recipient)
+		}
+		var arg1 proto.Height
+		if args[1] != nil {
+			arg1 = args[1].(proto.Height)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_ScriptBytesByAccountAtHeight_Call) Return(script proto.Script, err error) *MockState_ScriptBytesByAccountAtHeight_Call {
+	_c.Call.Return(script, err)
+	return _c
+}
+
+func (_c *MockState_ScriptBytesByAccountAtHeight_Call) RunAndReturn(run func(account proto.Recipient, height proto.Height) (proto.Script, error)) *MockState_ScriptBytesByAccountAtHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ScriptInfoByAccount provides a mock function for the type MockState
 func (_mock *MockState) ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error) {
 	ret := _mock.Called(account)
@@ -4189,6 +5031,74 @@ func (_c *MockState_ScriptInfoByAsset_Call) RunAndReturn(run func(assetID proto.
 	return _c
 }
 
+// ScriptInfoByAssetAtHeight provides a mock function for the type MockState
+func (_mock *MockState) ScriptInfoByAssetAtHeight(assetID proto.AssetID, height proto.Height) (*proto.ScriptInfo, error) {
+	ret := _mock.Called(assetID, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScriptInfoByAssetAtHeight")
+	}
+
+	var r0 *proto.ScriptInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.AssetID, proto.Height) (*proto.ScriptInfo, error)); ok {
+		return returnFunc(assetID, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.AssetID, proto.Height) *proto.ScriptInfo); ok {
+		r0 = returnFunc(assetID, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.ScriptInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.AssetID, proto.Height) error); ok {
+		r1 = returnFunc(assetID, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_ScriptInfoByAssetAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScriptInfoByAssetAtHeight'
+type MockState_ScriptInfoByAssetAtHeight_Call struct {
+	*mock.Call
+}
+
+// ScriptInfoByAssetAtHeight is a helper method to define mock.On call
+//   - assetID proto.AssetID
+//   - height proto.Height
+func (_e *MockState_Expecter) ScriptInfoByAssetAtHeight(assetID interface{}, height interface{}) *MockState_ScriptInfoByAssetAtHeight_Call {
+	return &MockState_ScriptInfoByAssetAtHeight_Call{Call: _e.mock.On("ScriptInfoByAssetAtHeight", assetID, height)}
+}
+
+func (_c *MockState_ScriptInfoByAssetAtHeight_Call) Run(run func(assetID proto.AssetID, height proto.Height)) *MockState_ScriptInfoByAssetAtHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.AssetID
+		if args[0] != nil {
+			arg0 = args[0].(proto.AssetID)
+		}
+		var arg1 proto.Height
+		if args[1] != nil {
+			arg1 = args[1].(proto.Height)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_ScriptInfoByAssetAtHeight_Call) Return(scriptInfo *proto.ScriptInfo, err error) *MockState_ScriptInfoByAssetAtHeight_Call {
+	_c.Call.Return(scriptInfo, err)
+	return _c
+}
+
+func (_c *MockState_ScriptInfoByAssetAtHeight_Call) RunAndReturn(run func(assetID proto.AssetID, height proto.Height) (*proto.ScriptInfo, error)) *MockState_ScriptInfoByAssetAtHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ShouldPersistAddressTransactions provides a mock function for the type MockState
 func (_mock *MockState) ShouldPersistAddressTransactions() (bool, error) {
 	ret := _mock.Called()
@@ -4514,6 +5424,68 @@ func (_c *MockState_TotalWavesAmount_Call) RunAndReturn(run func(height proto.He
 	return _c
 }
 
+// TraceInvoke provides a mock function for the type MockState
+func (_mock *MockState) TraceInvoke(txID crypto.Digest) (*InvokeTrace, error) {
+	ret := _mock.Called(txID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TraceInvoke")
+	}
+
+	var r0 *InvokeTrace
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(crypto.Digest) (*InvokeTrace, error)); ok {
+		return returnFunc(txID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(crypto.Digest) *InvokeTrace); ok {
+		r0 = returnFunc(txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*InvokeTrace)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(crypto.Digest) error); ok {
+		r1 = returnFunc(txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_TraceInvoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TraceInvoke'
+type MockState_TraceInvoke_Call struct {
+	*mock.Call
+}
+
+// TraceInvoke is a helper method to define mock.On call
+//   - txID crypto.Digest
+func (_e *MockState_Expecter) TraceInvoke(txID interface{}) *MockState_TraceInvoke_Call {
+	return &MockState_TraceInvoke_Call{Call: _e.mock.On("TraceInvoke", txID)}
+}
+
+func (_c *MockState_TraceInvoke_Call) Run(run func(txID crypto.Digest)) *MockState_TraceInvoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 crypto.Digest
+		if args[0] != nil {
+			arg0 = args[0].(crypto.Digest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_TraceInvoke_Call) Return(_a0 *InvokeTrace, _a1 error) *MockState_TraceInvoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockState_TraceInvoke_Call) RunAndReturn(run func(txID crypto.Digest) (*InvokeTrace, error)) *MockState_TraceInvoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TransactionByID provides a mock function for the type MockState
 func (_mock *MockState) TransactionByID(id []byte) (proto.Transaction, error) {
 	ret := _mock.Called(id)
@@ -5079,3 +6051,69 @@ func (_c *MockState_WavesBalance_Call) RunAndReturn(run func(account proto.Recip
 	_c.Call.Return(run)
 	return _c
 }
+
+// WavesBalanceAtHeight provides a mock function for the type MockState
+func (_mock *MockState) WavesBalanceAtHeight(account proto.Recipient, height proto.Height) (uint64, error) {
+	ret := _mock.Called(account, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WavesBalanceAtHeight")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, proto.Height) (uint64, error)); ok {
+		return returnFunc(account, height)
+	}
+	if returnFunc, ok := ret.Get(0).(func(proto.Recipient, proto.Height) uint64); ok {
+		r0 = returnFunc(account, height)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(proto.Recipient, proto.Height) error); ok {
+		r1 = returnFunc(account, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockState_WavesBalanceAtHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WavesBalanceAtHeight'
+type MockState_WavesBalanceAtHeight_Call struct {
+	*mock.Call
+}
+
+// WavesBalanceAtHeight is a helper method to define mock.On call
+//   - account proto.Recipient
+//   - height proto.Height
+func (_e *MockState_Expecter) WavesBalanceAtHeight(account interface{}, height interface{}) *MockState_WavesBalanceAtHeight_Call {
+	return &MockState_WavesBalanceAtHeight_Call{Call: _e.mock.On("WavesBalanceAtHeight", account, height)}
+}
+
+func (_c *MockState_WavesBalanceAtHeight_Call) Run(run func(account proto.Recipient, height proto.Height)) *MockState_WavesBalanceAtHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 proto.Recipient
+		if args[0] != nil {
+			arg0 = args[0].(proto.Recipient)
+		}
+		var arg1 proto.Height
+		if args[1] != nil {
+			arg1 = args[1].(proto.Height)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockState_WavesBalanceAtHeight_Call) Return(v uint64, err error) *MockState_WavesBalanceAtHeight_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockState_WavesBalanceAtHeight_Call) RunAndReturn(run func(account proto.Recipient, height proto.Height) (uint64, error)) *MockState_WavesBalanceAtHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,246 @@
+package state
+
+import (
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+const (
+	genesisHeight = 1
+	// pruneStep is the number of blocks pruned at once, the progress is stored after each step.
+	pruneStep      = 1000
+	prunedInfoSize = 8 * 3
+)
+
+var prunedInfoKeyBytes = []byte{prunedInfoKeyPrefix}
+
+// PruningParams configures removal of transactions and snapshots of old blocks from the state.
+// Headers of all blocks, information about all transactions and the current state remain intact.
+type PruningParams struct {
+	// KeepBlocks is the number of the latest blocks which transactions and snapshots are kept,
+	// pruning is disabled if it is zero.
+	KeepBlocks uint64
+}
+
+func (p PruningParams) enabled() bool {
+	return p.KeepBlocks != 0
+}
+
+func validatePruningParams(params StateParams) error {
+	if !params.Pruning.enabled() {
+		return nil
+	}
+	if params.Pruning.KeepBlocks < rollbackMaxBlocks {
+		return errors.Errorf("pruning has to keep at least %d blocks, but %d is set",
+			rollbackMaxBlocks, params.Pruning.KeepBlocks)
+	}
+	if params.StoreExtendedApiData {
+		return errors.New("pruning is not supported with extended API data")
+	}
+	if params.Checkpoints.enabled() {
+		return errors.New("pruning is not supported with state checkpoints")
+	}
+	if !holePunchingSupported {
+		return errors.New("pruning is not supported on this platform")
+	}
+	return nil
+}
+
+func prunedError(format string, args ...any) error {
+	return stateerr.NewStateError(stateerr.PrunedError, errors.Errorf(format, args...))
+}
+
+// prunedInfo is the range of the blockchain file with removed transactions.
+// Transactions of blocks from the second one up to the block at height are pruned.
+type prunedInfo struct {
+	height        uint64
+	txStartOffset uint64
+	txEndOffset   uint64
+}
+
+func (info *prunedInfo) marshalBinary() []byte {
+	res := make([]byte, prunedInfoSize)
+	binary.BigEndian.PutUint64(res[:8], info.height)
+	binary.BigEndian.PutUint64(res[8:16], info.txStartOffset)
+	binary.BigEndian.PutUint64(res[16:24], info.txEndOffset)
+	return res
+}
+
+func (info *prunedInfo) unmarshalBinary(data []byte) error {
+	if len(data) != prunedInfoSize {
+		return errInvalidDataSize
+	}
+	info.height = binary.BigEndian.Uint64(data[:8])
+	info.txStartOffset = binary.BigEndian.Uint64(data[8:16])
+	info.txEndOffset = binary.BigEndian.Uint64(data[16:24])
+	return nil
+}
+
+func (info *prunedInfo) containsHeight(height uint64) bool {
+	return height > genesisHeight && height <= info.height
+}
+
+func (info *prunedInfo) containsTxOffset(offset uint64) bool {
+	return offset >= info.txStartOffset && offset < info.txEndOffset
+}
+
+func loadPrunedInfo(db keyvalue.KeyValue) (prunedInfo, error) {
+	data, err := db.Get(prunedInfoKeyBytes)
+	if err != nil {
+		if errors.Is(err, keyvalue.ErrNotFound) { // nothing is pruned yet
+			return prunedInfo{}, nil
+		}
+		return prunedInfo{}, err
+	}
+	var info prunedInfo
+	if uErr := info.unmarshalBinary(data); uErr != nil {
+		return prunedInfo{}, uErr
+	}
+	return info, nil
+}
+
+// retainTransaction reports whether the body of the transaction has to be kept after pruning.
+// Transfers remain available to scripts through the transferTransactionById function.
+func retainTransaction(tx proto.Transaction) bool {
+	switch tx.GetTypeInfo().Type {
+	case proto.TransferTransaction:
+		return true
+	case proto.EthereumMetamaskTransaction:
+		ethTx, ok := tx.(*proto.EthereumTransaction)
+		if !ok {
+			return true
+		}
+		kind, err := proto.GuessEthereumTransactionKindType(ethTx.Data())
+		return err != nil || kind != proto.EthereumInvokeKindType
+	default:
+		return false
+	}
+}
+
+// pruner removes transactions and snapshots of old blocks in background.
+// Pruned range is stored in DB before the disk space of the blockchain file is released, so the range
+// always covers the released space. Blocks older than rollbackMaxBlocks can't be rolled back,
+// so the pruned part of the blockchain file is never truncated.
+type pruner struct {
+	params    PruningParams
+	db        keyvalue.KeyValue
+	writeLock *sync.Mutex
+	rw        *blockReadWriter
+
+	released uint64 // the end of the released range of the blockchain file
+	running  atomic.Bool
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func newPruner(params PruningParams, db keyvalue.KeyValue, sdb *stateDB, rw *blockReadWriter) *pruner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &pruner{
+		params:    params,
+		db:        db,
+		writeLock: sdb.retrieveWriteLock(),
+		rw:        rw,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// due reports whether the pruning has to be started after the blocks from prevHeight to height are applied.
+func (p *pruner) due(prevHeight, height proto.Height) bool {
+	return p.params.enabled() && height > p.params.KeepBlocks && height/pruneStep > prevHeight/pruneStep
+}
+
+// start begins the pruning of blocks which are older than the number of blocks to keep.
+func (p *pruner) start(height proto.Height) {
+	if !p.running.CompareAndSwap(false, true) {
+		return // previous pruning is still running, it will be continued next time
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer p.running.Store(false)
+		target := height - p.params.KeepBlocks
+		start := time.Now()
+		if err := p.prune(target); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			slog.Error("Failed to prune state", "height", target, logging.Error(err))
+			return
+		}
+		slog.Debug("State pruned", "height", target, "duration", time.Since(start))
+	}()
+}
+
+func (p *pruner) prune(target proto.Height) error {
+	if err := p.releaseSpace(p.rw.prunedRange()); err != nil { // the space may be left unreleased on shutdown
+		return err
+	}
+	for {
+		info := p.rw.prunedRange()
+		from := max(info.height, genesisHeight)
+		if from >= target {
+			return nil
+		}
+		if err := p.ctx.Err(); err != nil {
+			return err
+		}
+		to := min(from+pruneStep, target)
+		batch, err := p.db.NewBatch()
+		if err != nil {
+			return err
+		}
+		newInfo, err := p.rw.pruneTransactions(to, batch, retainTransaction)
+		if err != nil {
+			return errors.Wrapf(err, "failed to prune transactions up to height %d", to)
+		}
+		for h := from + 1; h <= to; h++ {
+			key := snapshotsKey{height: h}
+			batch.Delete(key.bytes())
+		}
+		if fErr := p.flush(batch); fErr != nil {
+			return errors.Wrap(fErr, "failed to store pruned range")
+		}
+		p.rw.setPruned(newInfo)
+		if rErr := p.releaseSpace(newInfo); rErr != nil {
+			return rErr
+		}
+	}
+}
+
+func (p *pruner) flush(batch keyvalue.Batch) error {
+	p.writeLock.Lock()
+	defer p.writeLock.Unlock()
+	return p.db.Flush(batch)
+}
+
+// releaseSpace deallocates the pruned range of the blockchain file keeping the offsets of other transactions.
+func (p *pruner) releaseSpace(info prunedInfo) error {
+	start := max(p.released, info.txStartOffset)
+	if start >= info.txEndOffset {
+		return nil
+	}
+	if err := punchHole(p.rw.blockchain, start, info.txEndOffset-start); err != nil {
+		return errors.Wrap(err, "failed to release disk space of pruned transactions")
+	}
+	p.released = info.txEndOffset
+	return nil
+}
+
+// close stops the pruning in progress and waits for it to finish.
+func (p *pruner) close() {
+	p.cancel()
+	p.wg.Wait()
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package state
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const holePunchingSupported = false
+
+func punchHole(_ *os.File, _, _ uint64) error {
+	return errors.New("hole punching is not supported")
+}
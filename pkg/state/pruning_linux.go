@@ -0,0 +1,33 @@
+package state
+
+import (
+	"os"
+
+	"github.com/ccoveille/go-safecast/v2"
+	"golang.org/x/sys/unix"
+)
+
+const holePunchingSupported = true
+
+// punchHole deallocates the range of the file, the size of the file and offsets of the data after it are kept.
+func punchHole(f *os.File, offset, length uint64) error {
+	off, err := safecast.Convert[int64](offset)
+	if err != nil {
+		return err
+	}
+	l, err := safecast.Convert[int64](length)
+	if err != nil {
+		return err
+	}
+	conn, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var fErr error
+	if cErr := conn.Control(func(fd uintptr) {
+		fErr = unix.Fallocate(int(fd), unix.FALLOC_FL_PUNCH_HOLE|unix.FALLOC_FL_KEEP_SIZE, off, l)
+	}); cErr != nil {
+		return cErr
+	}
+	return fErr
+}
@@ -0,0 +1,166 @@
+package state
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/importer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
+func allocatedSize(t *testing.T, path string) (int64, int64) {
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	require.True(t, ok)
+	const statBlockSize = 512
+	return fi.Size(), st.Blocks * statBlockSize
+}
+
+func TestPruning(t *testing.T) {
+	const (
+		height       = 3500
+		prunedHeight = height - rollbackMaxBlocks
+	)
+	bs := settings.MustMainNetSettings()
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	importParams := importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath}
+
+	params := DefaultTestingStateParams()
+	params.Pruning = PruningParams{KeepBlocks: rollbackMaxBlocks}
+	dataDir := t.TempDir()
+	manager, err := newStateManager(t.Context(), dataDir, true, params, bs, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, manager, height-1, 1))
+	manager.pruner.wg.Wait()
+	manager.pruner.start(height)
+	manager.pruner.wg.Wait()
+	assert.Equal(t, uint64(prunedHeight), manager.rw.prunedRange().height)
+
+	// Early blocks of MainNet contain only payments, which bodies are removed by pruning.
+	// Blocks in the file start from the second one.
+	blocks, err := readRealBlocks(blocksPath, prunedHeight-genesisHeight)
+	require.NoError(t, err)
+	var payment []byte
+	for _, b := range blocks {
+		for _, tx := range b.Transactions {
+			payment, err = tx.GetID(bs.AddressSchemeCharacter)
+			require.NoError(t, err)
+		}
+	}
+	require.NotNil(t, payment)
+
+	_, err = manager.BlockByHeight(genesisHeight)
+	assert.NoError(t, err)
+	_, err = manager.BlockByHeight(prunedHeight)
+	assert.True(t, stateerr.IsPruned(err))
+	_, err = manager.HeaderByHeight(prunedHeight)
+	assert.NoError(t, err)
+	_, err = manager.BlockByHeight(prunedHeight + 1)
+	assert.NoError(t, err)
+	_, err = manager.SnapshotsAtHeight(prunedHeight)
+	assert.True(t, stateerr.IsPruned(err))
+
+	_, err = manager.TransactionByID(payment)
+	assert.True(t, stateerr.IsPruned(err))
+	_, err = manager.NewestTransactionByID(payment)
+	assert.ErrorIs(t, err, types.ErrTransactionPruned)
+	txHeight, err := manager.TransactionHeightByID(payment)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, txHeight, uint64(prunedHeight))
+	assert.Error(t, manager.appender.checkDuplicateTxIdsImpl(payment, map[string]struct{}{}))
+
+	size, allocated := allocatedSize(t, filepath.Join(dataDir, blocksStorDir, "blockchain"))
+	assert.Less(t, allocated, size)
+	require.NoError(t, manager.Close())
+
+	// Pruned state can't be opened without pruning.
+	_, err = newStateManager(t.Context(), dataDir, true, DefaultTestingStateParams(), bs, false, nil)
+	assert.ErrorIs(t, err, ErrIncompatibleStateParams)
+
+	// Pruned range is restored on reopening and the blocks are applied as usual.
+	manager, err = newStateManager(t.Context(), dataDir, true, params, bs, false, nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, manager.Close()) }()
+	_, err = manager.BlockByHeight(prunedHeight)
+	assert.True(t, stateerr.IsPruned(err))
+	require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, manager, height+499, height))
+	current, err := manager.Height()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(height+500), current)
+}
+
+func TestPruneTransactionsRetained(t *testing.T) {
+	const height = 1000
+	bs := settings.MustMainNetSettings()
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	importParams := importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath}
+	manager := newTestStateManager(t, true, DefaultTestingStateParams(), bs)
+	require.NoError(t, importer.ApplyFromFile(t.Context(), importParams, manager, height, 1))
+
+	blocks, err := readRealBlocks(blocksPath, height-genesisHeight) // blocks in the file start from the second one
+	require.NoError(t, err)
+	var ids [][]byte
+	for _, b := range blocks {
+		for _, tx := range b.Transactions {
+			id, idErr := tx.GetID(bs.AddressSchemeCharacter)
+			require.NoError(t, idErr)
+			ids = append(ids, id)
+		}
+	}
+	require.Greater(t, len(ids), 1)
+	retained, pruned := ids[0], ids[len(ids)-1]
+
+	batch, err := manager.stateDB.db.NewBatch()
+	require.NoError(t, err)
+	info, err := manager.rw.pruneTransactions(height, batch, func(tx proto.Transaction) bool {
+		id, idErr := tx.GetID(bs.AddressSchemeCharacter)
+		require.NoError(t, idErr)
+		return bytes.Equal(id, retained)
+	})
+	require.NoError(t, err)
+	require.NoError(t, manager.stateDB.db.Flush(batch))
+	manager.rw.setPruned(info)
+
+	loaded, err := loadPrunedInfo(manager.stateDB.db)
+	require.NoError(t, err)
+	assert.Equal(t, info, loaded)
+	tx, err := manager.TransactionByID(retained)
+	require.NoError(t, err)
+	id, err := tx.GetID(bs.AddressSchemeCharacter)
+	require.NoError(t, err)
+	assert.Equal(t, retained, id)
+	_, err = manager.TransactionByID(pruned)
+	assert.True(t, stateerr.IsPruned(err))
+}
+
+func TestRetainTransaction(t *testing.T) {
+	assert.True(t, retainTransaction(&proto.TransferWithSig{Type: proto.TransferTransaction}))
+	assert.True(t, retainTransaction(&proto.TransferWithProofs{Type: proto.TransferTransaction}))
+	assert.True(t, retainTransaction(&proto.EthereumTransaction{})) // transfer of Waves without data
+	assert.False(t, retainTransaction(&proto.Payment{Type: proto.PaymentTransaction}))
+	assert.False(t, retainTransaction(&proto.InvokeScriptWithProofs{Type: proto.InvokeScriptTransaction}))
+}
+
+func TestPruningParamsValidation(t *testing.T) {
+	params := DefaultTestingStateParams()
+	params.Pruning = PruningParams{KeepBlocks: 100}
+	_, err := newStateManager(t.Context(), t.TempDir(), true, params, settings.MustMainNetSettings(), false, nil)
+	assert.ErrorContains(t, err, "pruning has to keep at least 2000 blocks")
+
+	params.Pruning = PruningParams{KeepBlocks: rollbackMaxBlocks}
+	params.StoreExtendedApiData = true
+	_, err = newStateManager(t.Context(), t.TempDir(), true, params, settings.MustMainNetSettings(), false, nil)
+	assert.ErrorContains(t, err, "pruning is not supported with extended API data")
+}
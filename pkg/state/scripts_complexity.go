@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/ride"
 )
@@ -83,6 +84,27 @@ func (sc *scriptsComplexity) scriptComplexityByAsset(asset proto.AssetID) (*ride
 	return &r.Estimation, nil
 }
 
+// scriptComplexityByAssetAtHeight returns the complexity of the asset script as it was at the given height.
+// The height must be within the rollback window.
+func (sc *scriptsComplexity) scriptComplexityByAssetAtHeight(
+	asset proto.AssetID,
+	height proto.Height,
+) (*ride.TreeEstimation, error) {
+	key := assetScriptComplexityKey{asset}
+	recordBytes, err := sc.hs.entryDataAtHeight(key.bytes(), height)
+	if err != nil {
+		return nil, err
+	}
+	if recordBytes == nil {
+		return nil, errors.Wrapf(keyvalue.ErrNotFound, "no complexity of asset script at height %d", height)
+	}
+	r := new(estimationRecord)
+	if err = r.unmarshalBinary(recordBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal asset script complexities record")
+	}
+	return &r.Estimation, nil
+}
+
 func (sc *scriptsComplexity) scriptComplexityByAddress(addr proto.Address) (*ride.TreeEstimation, error) {
 	key := accountScriptComplexityKey{addr.ID()}
 	recordBytes, err := sc.hs.topEntryData(key.bytes())
@@ -239,6 +239,18 @@ func (ss *scriptsStorage) scriptBytesByKey(key []byte) (proto.Script, error) {
 	return script, nil
 }
 
+// scriptBytesByKeyAtHeight returns the script as it was at the given height.
+// The height must be within the rollback window. Empty script is returned if there was no script.
+func (ss *scriptsStorage) scriptBytesByKeyAtHeight(key []byte, height proto.Height) (proto.Script, error) {
+	script, err := ss.hs.entryDataAtHeight(key, height)
+	if isNotFoundInHistoryOrDBErr(err) {
+		return proto.Script{}, nil
+	} else if err != nil {
+		return proto.Script{}, err
+	}
+	return script, nil
+}
+
 func (ss *scriptsStorage) newestScriptBytesByKey(key []byte) (proto.Script, error) {
 	script, err := ss.hs.newestTopEntryData(key)
 	if err != nil {
@@ -396,6 +408,11 @@ func (ss *scriptsStorage) scriptBytesByAsset(assetID proto.AssetID) (proto.Scrip
 	return ss.scriptBytesByKey(key.bytes())
 }
 
+func (ss *scriptsStorage) scriptBytesByAssetAtHeight(assetID proto.AssetID, height proto.Height) (proto.Script, error) {
+	key := assetScriptKey{assetID}
+	return ss.scriptBytesByKeyAtHeight(key.bytes(), height)
+}
+
 func (ss *scriptsStorage) newestScriptBytesByAsset(assetID proto.AssetID) (proto.Script, error) {
 	key := assetScriptKey{assetID}
 	return ss.newestScriptBytesByKey(key.bytes())
@@ -611,6 +628,30 @@ func (ss *scriptsStorage) scriptBasicInfoByAddressID(addressID proto.AddressID)
 	return info, nil
 }
 
+// scriptBasicInfoByAddressIDAtHeight returns basic info of the account script as it was at the given height.
+// The height must be within the rollback window, older history entries are cut.
+func (ss *scriptsStorage) scriptBasicInfoByAddressIDAtHeight(
+	addressID proto.AddressID,
+	height proto.Height,
+) (scriptBasicInfoRecord, error) {
+	key := scriptBasicInfoKey{scriptKey: &accountScriptKey{addressID}}
+	recordBytes, err := ss.hs.entryDataAtHeight(key.bytes(), height)
+	if err != nil {
+		return scriptBasicInfoRecord{}, err
+	}
+	if recordBytes == nil {
+		return scriptBasicInfoRecord{}, errEmptyScript
+	}
+	var info scriptBasicInfoRecord
+	if err := info.unmarshalBinary(recordBytes); err != nil {
+		return scriptBasicInfoRecord{}, err
+	}
+	if !info.scriptExists() {
+		return scriptBasicInfoRecord{}, errEmptyScript
+	}
+	return info, nil
+}
+
 // scriptByAddr returns script of corresponding proto.WavesAddress.
 // Note that only real proto.WavesAddress account can have a scripts.
 func (ss *scriptsStorage) scriptByAddr(addr proto.WavesAddress) (*ast.Tree, error) {
@@ -625,6 +666,14 @@ func (ss *scriptsStorage) scriptBytesByAddr(addr proto.WavesAddress) (proto.Scri
 	return ss.scriptBytesByKey(key.bytes())
 }
 
+func (ss *scriptsStorage) scriptBytesByAddrAtHeight(
+	addr proto.WavesAddress,
+	height proto.Height,
+) (proto.Script, error) {
+	key := accountScriptKey{addr: addr.ID()}
+	return ss.scriptBytesByKeyAtHeight(key.bytes(), height)
+}
+
 func (ss *scriptsStorage) clearCache() error {
 	var err error
 	ss.cache, err = newLru(maxCacheSize, maxCacheBytes)
@@ -17,6 +17,7 @@ type scriptStorageState interface {
 	newestScriptByAsset(assetID proto.AssetID) (*ast.Tree, error)
 	scriptByAsset(assetID proto.AssetID) (*ast.Tree, error)
 	scriptBytesByAsset(assetID proto.AssetID) (proto.Script, error)
+	scriptBytesByAssetAtHeight(assetID proto.AssetID, height proto.Height) (proto.Script, error)
 	newestScriptBytesByAsset(assetID proto.AssetID) (proto.Script, error)
 	newestScriptBytesByAddr(addr proto.WavesAddress) (proto.Script, error)
 	setAccountScript(addr proto.WavesAddress, script proto.Script, pk crypto.PublicKey, blockID proto.BlockID) error
@@ -29,8 +30,10 @@ type scriptStorageState interface {
 	newestScriptByAddr(addr proto.WavesAddress) (*ast.Tree, error)
 	newestScriptBasicInfoByAddressID(addressID proto.AddressID) (scriptBasicInfoRecord, error)
 	scriptBasicInfoByAddressID(addressID proto.AddressID) (scriptBasicInfoRecord, error)
+	scriptBasicInfoByAddressIDAtHeight(addressID proto.AddressID, height proto.Height) (scriptBasicInfoRecord, error)
 	scriptByAddr(addr proto.WavesAddress) (*ast.Tree, error)
 	scriptBytesByAddr(addr proto.WavesAddress) (proto.Script, error)
+	scriptBytesByAddrAtHeight(addr proto.WavesAddress, height proto.Height) (proto.Script, error)
 	clearCache() error
 	prepareHashes() error
 	reset()
@@ -122,6 +122,42 @@ func TestSetAccountScript(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestScriptBytesAtHeight(t *testing.T) {
+	to := createScriptsStorageTestObjects(t)
+
+	to.stor.addBlock(t, blockID0)
+	to.stor.addBlock(t, blockID1)
+	addr := testGlobal.senderInfo.addr
+	fullAssetID := testGlobal.asset0.asset.ID
+	shortAssetID := proto.AssetIDFromDigest(fullAssetID)
+	err := to.scriptsStorage.setAccountScript(addr, testGlobal.scriptBytes, testGlobal.senderInfo.pk, blockID1)
+	assert.NoError(t, err, "setAccountScript() failed")
+	err = to.scriptsStorage.setAssetScript(fullAssetID, testGlobal.scriptBytes, blockID1)
+	assert.NoError(t, err, "setAssetScript() failed")
+	to.stor.flush(t)
+	to.stor.addBlock(t, blockID2)
+	err = to.scriptsStorage.setAccountScript(addr, proto.Script{}, testGlobal.senderInfo.pk, blockID2)
+	assert.NoError(t, err, "setAccountScript() failed")
+	to.stor.flush(t)
+
+	height := to.stor.rw.recentHeight()
+	script, err := to.scriptsStorage.scriptBytesByAddrAtHeight(addr, height-2)
+	assert.NoError(t, err)
+	assert.Empty(t, script)
+	script, err = to.scriptsStorage.scriptBytesByAddrAtHeight(addr, height-1)
+	assert.NoError(t, err)
+	assert.Equal(t, proto.Script(testGlobal.scriptBytes), script)
+	script, err = to.scriptsStorage.scriptBytesByAddrAtHeight(addr, height)
+	assert.NoError(t, err)
+	assert.Empty(t, script)
+	script, err = to.scriptsStorage.scriptBytesByAssetAtHeight(shortAssetID, height-2)
+	assert.NoError(t, err)
+	assert.Empty(t, script)
+	script, err = to.scriptsStorage.scriptBytesByAssetAtHeight(shortAssetID, height)
+	assert.NoError(t, err)
+	assert.Equal(t, proto.Script(testGlobal.scriptBytes), script)
+}
+
 func TestSetAssetScript(t *testing.T) {
 	to := createScriptsStorageTestObjects(t)
 
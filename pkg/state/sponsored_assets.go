@@ -189,6 +189,23 @@ func (s *sponsoredAssets) assetCost(assetID proto.AssetID) (uint64, error) {
 	return record.assetCost, nil
 }
 
+// assetCostAtHeight returns the asset cost as it was at the given height.
+// The height must be within the rollback window. Zero is returned for assets that weren't sponsored.
+func (s *sponsoredAssets) assetCostAtHeight(assetID proto.AssetID, height proto.Height) (uint64, error) {
+	key := sponsorshipKey{assetID: assetID}
+	recordBytes, err := s.hs.entryDataAtHeight(key.bytes(), height)
+	if isNotFoundInHistoryOrDBErr(err) || (err == nil && recordBytes == nil) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	var record sponsorshipRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal sponsorship record")
+	}
+	return record.assetCost, nil
+}
+
 func (s *sponsoredAssets) sponsoredAssetToWaves(assetID proto.AssetID, assetAmount uint64) (uint64, error) {
 	cost, err := s.newestAssetCost(assetID)
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/logging"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
@@ -262,14 +263,24 @@ func checkCompatibilityV2(info stateInfo, params StateParams) error {
 			fmt.Stringer(info.DBCompressionAlgo), fmt.Stringer(params.DbParams.CompressionAlgo),
 		)
 	}
+	if info.Pruned && !params.Pruning.enabled() {
+		return errors.Wrap(ErrIncompatibleStateParams, "pruned state can't be used without pruning")
+	}
 	return nil
 }
 
 func checkAndUpdateCompatibilityV2(info stateInfo, params StateParams, amend bool) (stateInfo, error) {
+	if info.DBBackend != params.DbParams.Backend {
+		return info, errors.Wrapf(ErrIncompatibleStateParams,
+			"DB backend incompatibility: state has value '%s', want '%s'",
+			info.DBBackend, params.DbParams.Backend,
+		)
+	}
 	if err := checkCompatibilityV2(info, params); err != nil {
 		return info, err
 	}
 	info.Amend = handleAmendFlagV2(info.Amend, amend)
+	info.Pruned = info.Pruned || params.Pruning.enabled() // once pruned, the state stays pruned
 	return info, nil
 }
 
@@ -432,11 +443,36 @@ func (n *newBlocks) reset() {
 	n.curPos = 0
 }
 
+// changeNotifier broadcasts changes by closing the channel and replacing it with a new one.
+type changeNotifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+func newChangeNotifier() *changeNotifier {
+	return &changeNotifier{ch: make(chan struct{})}
+}
+
+func (n *changeNotifier) changed() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.ch
+}
+
+func (n *changeNotifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	close(n.ch)
+	n.ch = make(chan struct{})
+}
+
 type stateManager struct {
 	mu *sync.RWMutex
 
 	// Last added block.
 	lastBlock atomic.Value
+	// Notifies about changes of the last block.
+	changes *changeNotifier
 
 	genesis *proto.Block
 	stateDB *stateDB
@@ -458,6 +494,9 @@ type stateManager struct {
 	newBlocks *newBlocks
 
 	enableLightNode bool
+
+	checkpoints *checkpointer
+	pruner      *pruner
 }
 
 func initDatabase(
@@ -532,6 +571,8 @@ func checkAndUpdateDBMeta(dataDir string, amend bool, params StateParams) (_ sta
 		HasExtendedAPIData: params.StoreExtendedApiData,
 		HasStateHashes:     params.BuildStateHashes,
 		DBCompressionAlgo:  params.DbParams.CompressionAlgo,
+		Pruned:             params.Pruning.enabled(),
+		DBBackend:          params.DbParams.Backend,
 	}
 	if fileStat.Size() != 0 { // file exists and has data
 		data, rErr := io.ReadAll(io.LimitReader(f, proto.MiB))
@@ -605,6 +646,12 @@ func newStateManager(
 	if err := validateSettings(settings); err != nil {
 		return nil, err
 	}
+	if err := validateCheckpointParams(params); err != nil {
+		return nil, wrapErr(stateerr.InvalidInputError, err)
+	}
+	if err := validatePruningParams(params); err != nil {
+		return nil, wrapErr(stateerr.InvalidInputError, err)
+	}
 	if _, err := os.Stat(dataDir); errors.Is(err, fs.ErrNotExist) {
 		if dirErr := os.Mkdir(dataDir, 0750); dirErr != nil {
 			wErr := errors.Wrap(dirErr, "failed to create state directory")
@@ -671,8 +718,14 @@ func newStateManager(
 			}
 		}
 	}()
+	cp, err := newCheckpointer(params.Checkpoints, dataDir, settings.AddressSchemeCharacter,
+		params.DbParams.CompressionAlgo, db)
+	if err != nil {
+		return nil, wrapErr(stateerr.Other, errors.Wrap(err, "failed to create state checkpointer"))
+	}
 	state := &stateManager{
 		mu:                        &sync.RWMutex{},
+		changes:                   newChangeNotifier(),
 		stateDB:                   sdb,
 		stor:                      stor,
 		rw:                        rw,
@@ -681,6 +734,8 @@ func newStateManager(
 		verificationGoroutinesNum: params.VerificationGoroutinesNum,
 		newBlocks:                 newNewBlocks(rw, settings),
 		enableLightNode:           enableLightNode,
+		checkpoints:               cp,
+		pruner:                    newPruner(params.Pruning, db, sdb, rw),
 	}
 	// Set fields which depend on state.
 	// Consensus validator is needed to check block headers.
@@ -859,6 +914,7 @@ func (s *stateManager) loadLastBlock() error {
 		return errors.Errorf("failed to get block by height: %v", err)
 	}
 	s.lastBlock.Store(lastBlock)
+	s.changes.notify()
 	return nil
 }
 
@@ -866,6 +922,10 @@ func (s *stateManager) TopBlock() *proto.Block {
 	return s.lastBlock.Load().(*proto.Block)
 }
 
+func (s *stateManager) BlockchainChanged() <-chan struct{} {
+	return s.changes.changed()
+}
+
 func blockVRFCommon(
 	settings *settings.BlockchainSettings,
 	blockHeader *proto.BlockHeader,
@@ -1290,6 +1350,36 @@ func (s *stateManager) WavesBalance(account proto.Recipient) (uint64, error) {
 	return profile.balance, nil
 }
 
+func (s *stateManager) WavesBalanceAtHeight(account proto.Recipient, height proto.Height) (uint64, error) {
+	if err := s.checkRollbackHeight(height); err != nil {
+		return 0, wrapErr(stateerr.InvalidInputError, err)
+	}
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	profile, err := s.stor.balances.wavesBalanceAtHeight(addr.ID(), height)
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	return profile.balance, nil
+}
+
+func (s *stateManager) LeaseBalanceAtHeight(account proto.Recipient, height proto.Height) (int64, int64, error) {
+	if err := s.checkRollbackHeight(height); err != nil {
+		return 0, 0, wrapErr(stateerr.InvalidInputError, err)
+	}
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return 0, 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	profile, err := s.stor.balances.wavesBalanceAtHeight(addr.ID(), height)
+	if err != nil {
+		return 0, 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	return profile.leaseIn, profile.leaseOut, nil
+}
+
 func (s *stateManager) AssetBalance(account proto.Recipient, assetID proto.AssetID) (uint64, error) {
 	addr, err := s.recipientToAddress(account)
 	if err != nil {
@@ -1302,6 +1392,37 @@ func (s *stateManager) AssetBalance(account proto.Recipient, assetID proto.Asset
 	return balance, nil
 }
 
+func (s *stateManager) AssetBalanceAtHeight(
+	account proto.Recipient,
+	assetID proto.AssetID,
+	height proto.Height,
+) (uint64, error) {
+	if err := s.checkRollbackHeight(height); err != nil {
+		return 0, wrapErr(stateerr.InvalidInputError, err)
+	}
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	balance, err := s.stor.balances.assetBalanceAtHeight(addr.ID(), assetID, height)
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	return balance, nil
+}
+
+func (s *stateManager) AssetBalances(account proto.Recipient) ([]proto.AssetBalance, error) {
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	balances, err := s.stor.balances.assetBalances(addr.ID())
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return balances, nil
+}
+
 func (s *stateManager) WavesAddressesNumber() (uint64, error) {
 	res, err := s.stor.balances.wavesAddressesNumber()
 	if err != nil {
@@ -2028,9 +2149,29 @@ func (s *stateManager) addBlocks() (_ *proto.Block, retErr error) { //nolint:non
 	slog.Info("New height", "height", height+blocksNumber,
 		"BlockID", lastAppliedBlock.BlockID().String(), "GenSig", base58.Encode(lastAppliedBlock.GenSignature),
 		"ts", lastAppliedBlock.Timestamp)
+	s.maybeCreateCheckpoint(height, height+blocksNumber, lastAppliedBlock.BlockID())
+	if s.pruner.due(height, height+blocksNumber) {
+		s.pruner.start(height + blocksNumber)
+	}
 	return lastAppliedBlock, nil
 }
 
+// maybeCreateCheckpoint starts the creation of the state checkpoint if the checkpoint interval is passed.
+// The failure to create the checkpoint doesn't affect the blocks application, so it's only logged.
+func (s *stateManager) maybeCreateCheckpoint(prevHeight, height proto.Height, blockID proto.BlockID) {
+	if !s.checkpoints.due(prevHeight, height) {
+		return
+	}
+	sh, err := s.stor.stateHashes.legacyStateHash(height)
+	if err != nil {
+		slog.Error("Failed to get state hash for checkpoint", "height", height, logging.Error(err))
+		return
+	}
+	if cErr := s.checkpoints.start(height, blockID, sh.SumHash); cErr != nil {
+		slog.Error("Failed to start state checkpoint", "height", height, logging.Error(cErr))
+	}
+}
+
 func (s *stateManager) processBlockInPack(
 	block *proto.Block,
 	optionalSnapshot *proto.BlockSnapshot,
@@ -2183,6 +2324,12 @@ func (s *stateManager) RollbackToHeight(height uint64) error {
 }
 
 func (s *stateManager) rollbackToImpl(removalEdge proto.BlockID) error {
+	height, err := s.rw.heightByBlockID(removalEdge)
+	if err != nil {
+		return wrapErr(stateerr.RetrievalError, err)
+	}
+	// The checkpoint being created is discarded if the copied parts of block storage files are truncated.
+	s.checkpoints.rolledBack(height)
 	// The database part of rollback.
 	if err := s.stateDB.rollback(removalEdge); err != nil {
 		return wrapErr(stateerr.RollbackError, err)
@@ -2313,7 +2460,33 @@ func (s *stateManager) ValidateNextTx(
 	v proto.BlockVersion,
 	acceptFailed bool,
 ) ([]proto.AtomicSnapshot, error) {
-	return s.appender.validateNextTx(tx, currentTimestamp, parentTimestamp, v, acceptFailed)
+	return s.appender.validateNextTx(tx, currentTimestamp, parentTimestamp, v, acceptFailed, false)
+}
+
+// DryRunTx validates the transaction on top of the current state as UTX does and discards all the changes.
+func (s *stateManager) DryRunTx(
+	tx proto.Transaction,
+	currentTimestamp uint64,
+	verifySignature bool,
+) ([]proto.AtomicSnapshot, uint64, error) {
+	defer s.ResetValidationList()
+	lastBlock := s.TopBlock()
+	snapshot, err := s.appender.validateNextTx(tx, currentTimestamp, lastBlock.Timestamp, lastBlock.Version,
+		false, !verifySignature,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Validation list is empty before the call, so the total complexity is the complexity of the transaction.
+	return snapshot, s.appender.sc.getTotalComplexity(), nil
+}
+
+func (s *stateManager) MinFee(tx proto.Transaction) (proto.OptionalAsset, uint64, error) {
+	asset, fee, err := s.appender.minFee(tx, s.TopBlock().Version)
+	if err != nil {
+		return proto.OptionalAsset{}, 0, wrapErr(stateerr.InvalidInputError, err)
+	}
+	return asset, fee, nil
 }
 
 func (s *stateManager) CreateNextSnapshotHash(block *proto.Block) (crypto.Digest, error) {
@@ -2547,6 +2720,28 @@ func (s *stateManager) RetrieveEntry(account proto.Recipient, key string) (proto
 	return entry, nil
 }
 
+func (s *stateManager) RetrieveEntryAtHeight(
+	account proto.Recipient,
+	key string,
+	height proto.Height,
+) (proto.DataEntry, error) {
+	if err := s.checkRollbackHeight(height); err != nil {
+		return nil, wrapErr(stateerr.InvalidInputError, err)
+	}
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	entry, err := s.stor.accountsDataStor.retrieveEntryAtHeight(addr, key, height)
+	if err != nil {
+		if isNotFoundInHistoryOrDBErr(err) {
+			return nil, wrapErr(stateerr.NotFoundError, err)
+		}
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return entry, nil
+}
+
 func (s *stateManager) RetrieveNewestIntegerEntry(account proto.Recipient, key string) (*proto.IntegerDataEntry, error) {
 	addr, err := s.NewestRecipientToAddress(account)
 	if err != nil {
@@ -2647,12 +2842,15 @@ func (s *stateManager) RetrieveBinaryEntry(account proto.Recipient, key string)
 // WARNING! Function returns error if a transaction exists but failed or elided.
 func (s *stateManager) NewestTransactionByID(id []byte) (proto.Transaction, error) {
 	tx, status, err := s.rw.readNewestTransaction(id)
-	if err != nil {
+	if err != nil && !stateerr.IsPruned(err) {
 		return nil, wrapErr(stateerr.RetrievalError, err)
 	}
-	if status.IsNotSucceeded() {
+	if status.IsNotSucceeded() { // the status is known even if the transaction is pruned
 		return nil, wrapErr(stateerr.RetrievalError, errors.Errorf("transaction is not succeeded, status=%d", status))
 	}
+	if err != nil {
+		return nil, wrapErr(stateerr.PrunedError, fmt.Errorf("%w: %w", types.ErrTransactionPruned, err))
+	}
 	return tx, nil
 }
 
@@ -2950,6 +3148,80 @@ func (s *stateManager) EnrichedFullAssetInfo(assetID proto.AssetID) (*proto.Enri
 	return res, nil
 }
 
+// EnrichedFullAssetInfoAtHeight returns the asset information as it was at the given height.
+// The height must be within the rollback window. If the asset wasn't issued by the height,
+// error of type `errs.UnknownAsset` is returned.
+func (s *stateManager) EnrichedFullAssetInfoAtHeight(
+	assetID proto.AssetID,
+	height proto.Height,
+) (*proto.EnrichedFullAssetInfo, error) {
+	if err := s.checkRollbackHeight(height); err != nil {
+		return nil, wrapErr(stateerr.InvalidInputError, err)
+	}
+	info, err := s.stor.assets.assetInfoAtHeight(assetID, height)
+	if err != nil {
+		if errors.Is(err, errs.UnknownAsset{}) {
+			return nil, err
+		}
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	if !info.quantity.IsUint64() {
+		return nil, wrapErr(stateerr.Other, errors.New("asset quantity overflows uint64"))
+	}
+	issuer, err := proto.NewAddressFromPublicKey(s.settings.AddressSchemeCharacter, info.Issuer)
+	if err != nil {
+		return nil, wrapErr(stateerr.Other, err)
+	}
+	assetCost, err := s.stor.sponsoredAssets.assetCostAtHeight(assetID, height)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	scriptBytes, err := s.stor.scriptsStorage.scriptBytesByAssetAtHeight(assetID, height)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	scripted := !scriptBytes.IsEmpty()
+	txID := proto.ReconstructDigest(assetID, info.Tail)
+	tx, _ := s.TransactionByID(txID.Bytes()) // Explicitly ignore error here, in case of error tx is nil as expected
+	res := &proto.EnrichedFullAssetInfo{
+		FullAssetInfo: proto.FullAssetInfo{
+			AssetInfo: proto.AssetInfo{
+				AssetConstInfo: proto.AssetConstInfo{
+					ID:          txID,
+					IssueHeight: info.IssueHeight,
+					Issuer:      issuer,
+					Decimals:    info.Decimals,
+				},
+				Quantity:        info.quantity.Uint64(),
+				IssuerPublicKey: info.Issuer,
+				Reissuable:      info.reissuable,
+				Scripted:        scripted,
+				Sponsored:       assetCost != 0,
+			},
+			Name:             info.name,
+			Description:      info.description,
+			IssueTransaction: tx,
+			SponsorshipCost:  assetCost,
+		},
+		SequenceInBlock: info.IssueSequenceInBlock,
+	}
+	if assetCost != 0 {
+		sponsorBalance, bErr := s.WavesBalanceAtHeight(proto.NewRecipientFromAddress(issuer), height)
+		if bErr != nil {
+			return nil, bErr
+		}
+		res.SponsorBalance = sponsorBalance
+	}
+	if scripted {
+		scriptInfo, sErr := s.ScriptInfoByAssetAtHeight(assetID, height)
+		if sErr != nil {
+			return nil, sErr
+		}
+		res.ScriptInfo = *scriptInfo
+	}
+	return res, nil
+}
+
 func (s *stateManager) NFTList(account proto.Recipient, limit uint64, afterAssetID *proto.AssetID) ([]*proto.FullAssetInfo, error) {
 	addr, err := s.recipientToAddress(account)
 	if err != nil {
@@ -2974,6 +3246,266 @@ func (s *stateManager) NFTList(account proto.Recipient, limit uint64, afterAsset
 	return infos, nil
 }
 
+func (s *stateManager) EvaluateExpression(addr proto.WavesAddress, expr *ast.Tree) (ride.Result, error) {
+	env, err := s.readOnlyEnvironment(addr, expr.LibVersion)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	r, err := ride.EvaluateExpression(env, expr)
+	if err != nil {
+		return nil, wrapErr(stateerr.InvalidInputError, err)
+	}
+	return r, nil
+}
+
+func (s *stateManager) EvaluateFunctionCall(addr proto.WavesAddress, fc proto.FunctionCall) (ride.Result, error) {
+	tree, err := s.stor.scriptsStorage.newestScriptByAddr(addr)
+	if err != nil {
+		return nil, wrapErr(stateerr.NotFoundError, errors.Wrapf(err, "failed to get script of %s", addr.String()))
+	}
+	if !tree.IsDApp() {
+		return nil, wrapErr(stateerr.InvalidInputError, errors.Errorf("address %s is not a dApp", addr.String()))
+	}
+	env, err := s.readOnlyEnvironment(addr, tree.LibVersion)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	r, err := ride.CallFunction(env, tree, fc)
+	if err != nil {
+		return nil, wrapErr(stateerr.InvalidInputError, err)
+	}
+	return r, nil
+}
+
+// InvokeTrace is the result of re-execution of the invoke script transaction.
+type InvokeTrace struct {
+	DApp   proto.WavesAddress
+	Call   proto.FunctionCall
+	Result ride.Result // nil if the evaluation has failed
+	Err    error       // the error of evaluation
+	Trace  *ride.Trace
+}
+
+// TraceInvoke re-executes the confirmed invoke script transaction or Ethereum invoke transaction and collects
+// the log of evaluation, including the evaluation of nested invocations. The transaction is evaluated against
+// the state at the previous height with the changes of the preceding transactions of its block applied,
+// so the state at the previous height must be within the rollback window. Changes made by the script are
+// never applied to the state.
+func (s *stateManager) TraceInvoke(txID crypto.Digest) (*InvokeTrace, error) {
+	tx, err := s.TransactionByID(txID.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	txHeight, err := s.TransactionHeightByID(txID.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if rhErr := s.checkRollbackHeight(txHeight - 1); rhErr != nil {
+		return nil, wrapErr(stateerr.InvalidInputError, errors.Wrapf(rhErr,
+			"state before transaction %s at height %d is out of the rollback window", txID.String(), txHeight,
+		))
+	}
+	p, err := s.traceInvokeParameters(tx)
+	if err != nil {
+		return nil, err
+	}
+	st, err := newTxEvaluationState(s, txID.Bytes(), txHeight)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := st.NewestScriptByAccount(proto.NewRecipientFromAddress(p.dApp))
+	if err != nil {
+		return nil, wrapErr(stateerr.NotFoundError, errors.Wrapf(err, "failed to get script of %s", p.dApp.String()))
+	}
+	activated := make(map[settings.Feature]bool, len(rideEnvironmentFeatures))
+	for _, f := range rideEnvironmentFeatures {
+		activated[f] = s.stor.features.isActivatedAtHeight(int16(f), txHeight)
+	}
+	env, err := s.rideEnvironment(st, txHeight, activated, p.dApp, tree.LibVersion)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	env.SetTimestamp(tx.GetTimestamp())
+	if sErr := env.SetTransaction(tx); sErr != nil {
+		return nil, wrapErr(stateerr.Other, sErr)
+	}
+	if sErr := p.setInvoke(env, tree.LibVersion); sErr != nil {
+		return nil, wrapErr(stateerr.Other, sErr)
+	}
+	if tree.LibVersion >= ast.LibV5 {
+		const checkSenderBalance = false // the balance was checked when the transaction was applied
+		env, err = ride.NewEnvironmentWithWrappedState(env, st, p.payments, p.sender, proto.IsProtobufTx(tx),
+			tree.LibVersion, checkSenderBalance,
+		)
+		if err != nil {
+			return nil, wrapErr(stateerr.Other, errors.Wrap(err, "failed to create RIDE environment with wrapped state"))
+		}
+	}
+	t := &InvokeTrace{DApp: p.dApp, Call: p.call, Trace: ride.NewTrace()}
+	t.Result, t.Err = ride.CallFunction(env, tree, p.call, ride.WithTrace(t.Trace))
+	return t, nil
+}
+
+// traceInvokeParameters holds the parameters of the traced invocation taken from the transaction.
+type traceInvokeParameters struct {
+	dApp      proto.WavesAddress
+	sender    proto.WavesAddress
+	call      proto.FunctionCall
+	payments  proto.ScriptPayments
+	setInvoke func(env *ride.EvaluationEnvironment, v ast.LibraryVersion) error
+}
+
+func (s *stateManager) traceInvokeParameters(tx proto.Transaction) (traceInvokeParameters, error) {
+	scheme := s.settings.AddressSchemeCharacter
+	switch t := tx.(type) {
+	case *proto.InvokeScriptWithProofs:
+		dApp, err := s.recipientToAddress(t.ScriptRecipient)
+		if err != nil {
+			return traceInvokeParameters{}, wrapErr(stateerr.RetrievalError, err)
+		}
+		sender, err := proto.NewAddressFromPublicKey(scheme, t.SenderPK)
+		if err != nil {
+			return traceInvokeParameters{}, wrapErr(stateerr.Other, err)
+		}
+		return traceInvokeParameters{
+			dApp:     dApp,
+			sender:   sender,
+			call:     t.FunctionCall,
+			payments: t.Payments,
+			setInvoke: func(env *ride.EvaluationEnvironment, v ast.LibraryVersion) error {
+				return env.SetInvoke(t, v)
+			},
+		}, nil
+	case *proto.EthereumTransaction:
+		if _, ok := t.TxKind.(*proto.EthereumInvokeScriptTxKind); !ok {
+			break
+		}
+		dApp, err := t.WavesAddressTo(scheme)
+		if err != nil {
+			return traceInvokeParameters{}, wrapErr(stateerr.Other, err)
+		}
+		sender, err := t.WavesAddressFrom(scheme)
+		if err != nil {
+			return traceInvokeParameters{}, wrapErr(stateerr.Other, err)
+		}
+		data := t.TxKind.DecodedData()
+		args, err := proto.ConvertDecodedEthereumArgumentsToProtoArguments(data.Inputs)
+		if err != nil {
+			return traceInvokeParameters{}, wrapErr(stateerr.Other,
+				errors.Wrap(err, "failed to convert ethereum arguments"),
+			)
+		}
+		payments := make(proto.ScriptPayments, 0, len(data.Payments))
+		for _, p := range data.Payments {
+			payments = append(payments, proto.ScriptPayment{
+				Amount: uint64(p.Amount),
+				Asset:  proto.NewOptionalAsset(p.PresentAssetID, p.AssetID),
+			})
+		}
+		return traceInvokeParameters{
+			dApp:     dApp,
+			sender:   sender,
+			call:     proto.NewFunctionCall(data.Name, args),
+			payments: payments,
+			setInvoke: func(env *ride.EvaluationEnvironment, v ast.LibraryVersion) error {
+				return env.SetEthereumInvoke(t, v, payments)
+			},
+		}, nil
+	}
+	txID, err := tx.GetID(scheme)
+	if err != nil {
+		return traceInvokeParameters{}, wrapErr(stateerr.Other, err)
+	}
+	return traceInvokeParameters{}, wrapErr(stateerr.InvalidInputError,
+		errors.Errorf("transaction %s is not an invoke script transaction", base58.Encode(txID)),
+	)
+}
+
+// readOnlyEnvironment creates Ride environment for evaluation in the context of the given address.
+// The address itself is the caller, all changes made by the script are kept in the wrapped state diff
+// and are never applied to the state.
+func (s *stateManager) readOnlyEnvironment(
+	addr proto.WavesAddress,
+	v ast.LibraryVersion,
+) (*ride.EvaluationEnvironment, error) {
+	env, err := s.newestEnvironment(addr, v)
+	if err != nil {
+		return nil, err
+	}
+	env.SetReadOnlyInvocation(addr, v)
+	if v >= ast.LibV5 {
+		const checkSenderBalance = false // there are no payments
+		return ride.NewEnvironmentWithWrappedState(env, s, nil, addr, true, v, checkSenderBalance)
+	}
+	return env, nil
+}
+
+// rideEnvironmentFeatures are the features that change the behavior of Ride environment.
+var rideEnvironmentFeatures = []settings.Feature{
+	settings.BlockV5, settings.RideV5, settings.RideV6, settings.ConsensusImprovements,
+	settings.BlockRewardDistribution, settings.LightNode,
+}
+
+// newestEnvironment creates Ride environment for evaluation of the script of the given address
+// on top of the newest block.
+func (s *stateManager) newestEnvironment(
+	addr proto.WavesAddress,
+	v ast.LibraryVersion,
+) (*ride.EvaluationEnvironment, error) {
+	activated := make(map[settings.Feature]bool, len(rideEnvironmentFeatures))
+	for _, f := range rideEnvironmentFeatures {
+		ok, err := s.stor.features.newestIsActivated(int16(f))
+		if err != nil {
+			return nil, err
+		}
+		activated[f] = ok
+	}
+	return s.rideEnvironment(s, s.rw.recentHeight(), activated, addr, v)
+}
+
+// rideEnvironment creates Ride environment for evaluation of the script of the given address
+// on top of the given state with the block at the given height as the last block.
+func (s *stateManager) rideEnvironment(
+	st types.SmartState,
+	height proto.Height,
+	activated map[settings.Feature]bool,
+	addr proto.WavesAddress,
+	v ast.LibraryVersion,
+) (*ride.EvaluationEnvironment, error) {
+	env, err := ride.NewEnvironment(
+		s.settings.AddressSchemeCharacter,
+		st,
+		s.settings.InternalInvokePaymentsValidationAfterHeight,
+		s.settings.PaymentsFixAfterHeight,
+		activated[settings.BlockV5],
+		activated[settings.RideV6],
+		activated[settings.ConsensusImprovements],
+		activated[settings.BlockRewardDistribution],
+		activated[settings.LightNode],
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create RIDE environment")
+	}
+	blockInfo, err := st.NewestBlockInfoByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	env.SetThisFromAddress(addr)
+	env.ChooseSizeCheck(v)
+	if err := env.SetLastBlockFromBlockInfo(blockInfo); err != nil {
+		return nil, err
+	}
+	env.SetTimestamp(blockInfo.Timestamp)
+	env.ChooseTakeString(activated[settings.RideV5])
+	env.ChooseMaxDataEntriesSize(activated[settings.RideV5])
+	limit, err := ride.MaxChainInvokeComplexityByVersion(v)
+	if err != nil { // No callables before V3, so the limit of verifier is used
+		limit = ride.MaxVerifierComplexity(activated[settings.RideV5])
+	}
+	env.SetLimit(limit)
+	return env, nil
+}
+
 func (s *stateManager) ScriptBasicInfoByAccount(account proto.Recipient) (*proto.ScriptBasicInfo, error) {
 	addr, err := s.recipientToAddress(account)
 	if err != nil {
@@ -3023,6 +3555,26 @@ func (s *stateManager) ScriptInfoByAccount(account proto.Recipient) (*proto.Scri
 	}, nil
 }
 
+// ScriptBytesByAccountAtHeight returns the account script as it was at the given height.
+// The height must be within the rollback window. Empty script is returned if the account had no script.
+func (s *stateManager) ScriptBytesByAccountAtHeight(
+	account proto.Recipient,
+	height proto.Height,
+) (proto.Script, error) {
+	if err := s.checkRollbackHeight(height); err != nil {
+		return nil, wrapErr(stateerr.InvalidInputError, err)
+	}
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	script, err := s.stor.scriptsStorage.scriptBytesByAddrAtHeight(addr, height)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return script, nil
+}
+
 func (s *stateManager) ScriptInfoByAsset(assetID proto.AssetID) (*proto.ScriptInfo, error) {
 	scriptBytes, err := s.stor.scriptsStorage.scriptBytesByAsset(assetID)
 	if err != nil {
@@ -3043,6 +3595,39 @@ func (s *stateManager) ScriptInfoByAsset(assetID proto.AssetID) (*proto.ScriptIn
 	}, nil
 }
 
+// ScriptInfoByAssetAtHeight returns the asset script information as it was at the given height.
+// The height must be within the rollback window. Not found error is returned if the asset had no script.
+func (s *stateManager) ScriptInfoByAssetAtHeight(
+	assetID proto.AssetID,
+	height proto.Height,
+) (*proto.ScriptInfo, error) {
+	if err := s.checkRollbackHeight(height); err != nil {
+		return nil, wrapErr(stateerr.InvalidInputError, err)
+	}
+	scriptBytes, err := s.stor.scriptsStorage.scriptBytesByAssetAtHeight(assetID, height)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	if scriptBytes.IsEmpty() {
+		return nil, wrapErr(stateerr.NotFoundError,
+			errors.Wrapf(keyvalue.ErrNotFound, "asset %s has no script at height %d", assetID.String(), height),
+		)
+	}
+	est, err := s.stor.scriptsComplexity.scriptComplexityByAssetAtHeight(assetID, height)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	version, err := proto.VersionFromScriptBytes(scriptBytes)
+	if err != nil {
+		return nil, wrapErr(stateerr.Other, err)
+	}
+	return &proto.ScriptInfo{
+		Version:    version,
+		Bytes:      scriptBytes,
+		Complexity: uint64(est.Estimation),
+	}, nil
+}
+
 func (s *stateManager) NewestScriptInfoByAsset(assetID proto.AssetID) (*proto.ScriptInfo, error) {
 	scriptBytes, err := s.stor.scriptsStorage.newestScriptBytesByAsset(assetID)
 	if err != nil {
@@ -3138,6 +3723,18 @@ func (s *stateManager) SnapshotStateHashAtHeight(height proto.Height) (crypto.Di
 	return sh, nil
 }
 
+func (s *stateManager) PrunedHeight() proto.Height {
+	return s.rw.prunedRange().height
+}
+
+func (s *stateManager) RollbackMinHeight() (proto.Height, error) {
+	height, err := s.stateDB.getRollbackMinHeight()
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	return height, nil
+}
+
 func (s *stateManager) IsNotFound(err error) bool {
 	return stateerr.IsNotFound(err)
 }
@@ -3252,10 +3849,15 @@ func rewardBoostFeatureInfo(
 }
 
 func (s *stateManager) SnapshotsAtHeight(height proto.Height) (proto.BlockSnapshot, error) {
+	if pruned := s.rw.prunedRange(); pruned.containsHeight(height) {
+		return proto.BlockSnapshot{}, prunedError("snapshots of block at height %d are removed by pruning", height)
+	}
 	return s.stor.snapshots.getSnapshots(height)
 }
 
 func (s *stateManager) Close() error {
+	s.checkpoints.close()
+	s.pruner.close()
 	if err := s.atx.close(); err != nil {
 		return wrapErr(stateerr.ClosureError, err)
 	}
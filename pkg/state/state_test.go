@@ -24,6 +24,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	ridec "github.com/wavesplatform/gowaves/pkg/ride/compiler"
 	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
 	"github.com/wavesplatform/gowaves/pkg/types"
 )
 
@@ -178,6 +179,80 @@ func TestValidationWithoutBlocks(t *testing.T) {
 	assert.Equal(t, uint64(0), senderBalance)
 }
 
+func TestDryRunTx(t *testing.T) {
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	bs := settings.MustMainNetSettings()
+	manager := newTestStateManager(t, true, DefaultTestingStateParams(), bs)
+	height := proto.Height(75)
+	err = importer.ApplyFromFile(
+		t.Context(),
+		importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath, LightNodeMode: false},
+		manager,
+		height, 1)
+	require.NoError(t, err, "ApplyFromFile() failed")
+
+	tx := createPayment(t)
+	err = manager.stateDB.addBlock(blockID0)
+	require.NoError(t, err)
+	waves := newWavesValueFromProfile(balanceProfile{tx.Amount + tx.Fee, 0, 0})
+	err = manager.stor.balances.setWavesBalance(testGlobal.senderInfo.addr.ID(), waves, blockID0)
+	require.NoError(t, err)
+	require.NoError(t, manager.flush())
+	recipient := proto.NewRecipientFromAddress(testGlobal.recipientInfo.addr)
+
+	snapshot, complexity, err := manager.DryRunTx(tx, defaultTimestamp, true)
+	require.NoError(t, err)
+	assert.Zero(t, complexity)
+	assert.Contains(t, snapshot, &proto.WavesBalanceSnapshot{Address: testGlobal.recipientInfo.addr, Balance: tx.Amount})
+	// Changes are discarded, so the same transaction is valid again.
+	_, _, err = manager.DryRunTx(tx, defaultTimestamp, true)
+	require.NoError(t, err)
+	balance, err := manager.NewestWavesBalance(recipient)
+	require.NoError(t, err)
+	assert.Zero(t, balance)
+
+	// Transaction with invalid signature is valid only if signature verification is disabled.
+	invalidSig := proto.NewUnsignedPayment(testGlobal.senderInfo.pk, testGlobal.recipientInfo.addr,
+		tx.Amount, tx.Fee, tx.Timestamp,
+	)
+	require.NoError(t, invalidSig.Sign(proto.TestNetScheme, testGlobal.recipientInfo.sk))
+	_, _, err = manager.DryRunTx(invalidSig, defaultTimestamp, true)
+	assert.Error(t, err)
+	_, _, err = manager.DryRunTx(invalidSig, defaultTimestamp, false)
+	assert.NoError(t, err)
+
+	// This tx tries to send more Waves than exist at all.
+	invalidTx := createPayment(t)
+	invalidTx.Amount = 19999999500000000
+	_, _, err = manager.DryRunTx(invalidTx, defaultTimestamp, true)
+	assert.Error(t, err)
+}
+
+func TestMinFee(t *testing.T) {
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	bs := settings.MustMainNetSettings()
+	manager := newTestStateManager(t, true, DefaultTestingStateParams(), bs)
+	err = importer.ApplyFromFile(
+		t.Context(),
+		importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath, LightNodeMode: false},
+		manager,
+		75, 1)
+	require.NoError(t, err, "ApplyFromFile() failed")
+
+	tx := createPayment(t)
+	tx.Fee = 0
+	asset, fee, err := manager.MinFee(tx)
+	require.NoError(t, err)
+	assert.Equal(t, proto.NewOptionalAssetWaves(), asset)
+	assert.Equal(t, uint64(FeeUnit), fee)
+
+	// Transactions with proofs are not allowed before SmartAccounts activation.
+	_, _, err = manager.MinFee(createTransferWithProofs(t))
+	assert.True(t, stateerr.IsInvalidInput(err))
+}
+
 func TestStateRollback(t *testing.T) {
 	dir, err := getLocalDir()
 	if err != nil {
@@ -390,6 +465,109 @@ func TestTransactionByID(t *testing.T) {
 	assert.Equal(t, correctTx, tx)
 }
 
+func TestTraceInvokeRejectsTransactions(t *testing.T) {
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	bs := settings.MustMainNetSettings()
+	manager := newTestStateManager(t, true, DefaultTestingStateParams(), bs)
+	err = importer.ApplyFromFile(
+		t.Context(),
+		importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath, LightNodeMode: false},
+		manager, 75, 1)
+	require.NoError(t, err, "ApplyFromFile() failed")
+
+	// Put the transactions into the storage of blocks as if they were in the block after the last one.
+	data := createDataWithProofs(t, 1)
+	fc := proto.NewFunctionCall("call", proto.Arguments{})
+	invoke := createInvokeScriptWithProofs(t, []proto.ScriptPayment{}, fc, proto.NewOptionalAssetWaves(), invokeFee)
+	blockID := proto.NewBlockIDFromDigest(crypto.Digest{1})
+	require.NoError(t, manager.rw.startBlock(blockID))
+	_, err = manager.rw.writeTransaction(data, proto.TransactionSucceeded)
+	require.NoError(t, err)
+	_, err = manager.rw.writeTransaction(invoke, proto.TransactionSucceeded)
+	require.NoError(t, err)
+	require.NoError(t, manager.rw.finishBlock(blockID))
+	require.NoError(t, manager.rw.flush())
+	require.NoError(t, manager.rw.db.Flush(manager.rw.dbBatch))
+
+	_, err = manager.TraceInvoke(*data.ID)
+	require.Error(t, err)
+	assert.True(t, stateerr.IsInvalidInput(err))
+	assert.ErrorContains(t, err, "is not an invoke script transaction")
+
+	// Move the rollback window above the previous block of the transactions.
+	require.NoError(t, manager.stateDB.setRollbackMinHeight(77))
+	require.NoError(t, manager.stateDB.flushBatch())
+	_, err = manager.TraceInvoke(*invoke.ID)
+	require.Error(t, err)
+	assert.True(t, stateerr.IsInvalidInput(err))
+	assert.ErrorContains(t, err, "at height 77 is out of the rollback window")
+}
+
+func TestTxEvaluationState(t *testing.T) {
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	bs := settings.MustMainNetSettings()
+	scheme := bs.AddressSchemeCharacter
+	manager := newTestStateManager(t, true, DefaultTestingStateParams(), bs)
+	err = importer.ApplyFromFile(
+		t.Context(),
+		importer.ImportParams{Schema: scheme, BlockchainPath: blocksPath, LightNodeMode: false},
+		manager, 75, 1)
+	require.NoError(t, err, "ApplyFromFile() failed")
+
+	const height = 43 // the block contains two payment transactions
+	block, err := manager.BlockByHeight(height)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions, 2)
+	firstID, err := block.Transactions[0].GetID(scheme)
+	require.NoError(t, err)
+	secondID, err := block.Transactions[1].GetID(scheme)
+	require.NoError(t, err)
+	snapshots, err := manager.SnapshotsAtHeight(height)
+	require.NoError(t, err)
+
+	first, err := newTxEvaluationState(manager, firstID, height)
+	require.NoError(t, err)
+	second, err := newTxEvaluationState(manager, secondID, height)
+	require.NoError(t, err)
+
+	h, err := second.AddingBlockHeight()
+	require.NoError(t, err)
+	assert.Equal(t, proto.Height(height), h)
+
+	// The first transaction sees the balances at the previous height,
+	// the second one sees the balances changed by the first transaction.
+	var checked int
+	for _, snapshot := range snapshots.TxSnapshots[0] {
+		wb, ok := snapshot.(*proto.WavesBalanceSnapshot)
+		if !ok {
+			continue
+		}
+		rcp := proto.NewRecipientFromAddress(wb.Address)
+		prev, pErr := manager.WavesBalanceAtHeight(rcp, height-1)
+		require.NoError(t, pErr)
+		balance, bErr := first.NewestWavesBalance(rcp)
+		require.NoError(t, bErr)
+		assert.Equal(t, prev, balance)
+		balance, bErr = second.NewestWavesBalance(rcp)
+		require.NoError(t, bErr)
+		assert.Equal(t, wb.Balance, balance)
+		checked++
+	}
+	assert.NotZero(t, checked)
+
+	_, err = first.NewestTransactionByID(firstID)
+	assert.True(t, stateerr.IsNotFound(err))
+	_, err = second.NewestTransactionByID(firstID)
+	assert.NoError(t, err)
+	_, err = second.NewestTransactionHeightByID(secondID)
+	assert.True(t, stateerr.IsNotFound(err))
+
+	_, err = newTxEvaluationState(manager, crypto.Digest{1}.Bytes(), height)
+	assert.True(t, stateerr.IsNotFound(err))
+}
+
 func TestStateManager_TopBlock(t *testing.T) {
 	blocksPath, err := blocksPath()
 	bs := settings.MustMainNetSettings()
@@ -45,6 +45,9 @@ const (
 
 	// Other is used for miscellaneous technical errors that should not normally occur.
 	Other
+
+	// PrunedError is returned when a requested entity was removed from the state by pruning.
+	PrunedError
 )
 
 type StateError struct {
@@ -102,3 +105,11 @@ func IsInvalidInput(err error) bool {
 		return false
 	}
 }
+
+func IsPruned(err error) bool {
+	var stateErr StateError
+	if errors.As(err, &stateErr) {
+		return stateErr.Type() == PrunedError
+	}
+	return false
+}
@@ -82,3 +82,25 @@ func TestIsInvalidInput(t *testing.T) {
 		assert.Equal(t, test.result, stateerr.IsInvalidInput(test.err))
 	}
 }
+
+func TestIsPruned(t *testing.T) {
+	tests := []struct {
+		err    error
+		result bool
+	}{
+		{nil, false},
+		{fmt.Errorf("some err"), false},
+		{keyvalue.ErrNotFound, false},
+		{stateerr.NewStateError(stateerr.RetrievalError, nil), false},
+		{fmt.Errorf("wrapped: %w", stateerr.NewStateError(stateerr.NotFoundError, nil)), false},
+
+		{stateerr.NewStateError(stateerr.PrunedError, nil), true},
+		{fmt.Errorf("wrapped: %w", stateerr.NewStateError(stateerr.PrunedError, nil)), true},
+		{errors.Wrap(stateerr.NewStateError(stateerr.PrunedError, nil), "errors wrapped"), true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.result, stateerr.IsPruned(test.err))
+	}
+	// Pruned data must not be treated as absent.
+	assert.False(t, stateerr.IsNotFound(stateerr.NewStateError(stateerr.PrunedError, nil)))
+}
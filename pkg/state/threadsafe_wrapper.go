@@ -7,6 +7,7 @@ import (
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 )
@@ -44,6 +45,10 @@ func (a *ThreadSafeReadWrapper) TopBlock() *proto.Block {
 	return a.s.TopBlock()
 }
 
+func (a *ThreadSafeReadWrapper) BlockchainChanged() <-chan struct{} {
+	return a.s.BlockchainChanged()
+}
+
 func (a *ThreadSafeReadWrapper) Block(blockID proto.BlockID) (*proto.Block, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -110,12 +115,43 @@ func (a *ThreadSafeReadWrapper) WavesBalance(account proto.Recipient) (uint64, e
 	return a.s.WavesBalance(account)
 }
 
+func (a *ThreadSafeReadWrapper) WavesBalanceAtHeight(account proto.Recipient, height proto.Height) (uint64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.WavesBalanceAtHeight(account, height)
+}
+
+func (a *ThreadSafeReadWrapper) LeaseBalanceAtHeight(
+	account proto.Recipient,
+	height proto.Height,
+) (int64, int64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.LeaseBalanceAtHeight(account, height)
+}
+
 func (a *ThreadSafeReadWrapper) AssetBalance(account proto.Recipient, asset proto.AssetID) (uint64, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	return a.s.AssetBalance(account, asset)
 }
 
+func (a *ThreadSafeReadWrapper) AssetBalanceAtHeight(
+	account proto.Recipient,
+	asset proto.AssetID,
+	height proto.Height,
+) (uint64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.AssetBalanceAtHeight(account, asset, height)
+}
+
+func (a *ThreadSafeReadWrapper) AssetBalances(account proto.Recipient) ([]proto.AssetBalance, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.AssetBalances(account)
+}
+
 func (a *ThreadSafeReadWrapper) WavesAddressesNumber() (uint64, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -224,6 +260,16 @@ func (a *ThreadSafeReadWrapper) RetrieveEntry(account proto.Recipient, key strin
 	return a.s.RetrieveEntry(account, key)
 }
 
+func (a *ThreadSafeReadWrapper) RetrieveEntryAtHeight(
+	account proto.Recipient,
+	key string,
+	height proto.Height,
+) (proto.DataEntry, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.RetrieveEntryAtHeight(account, key, height)
+}
+
 func (a *ThreadSafeReadWrapper) RetrieveIntegerEntry(account proto.Recipient, key string) (*proto.IntegerDataEntry, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -304,12 +350,27 @@ func (a *ThreadSafeReadWrapper) EnrichedFullAssetInfo(assetID proto.AssetID) (*p
 	return a.s.EnrichedFullAssetInfo(assetID)
 }
 
+func (a *ThreadSafeReadWrapper) EnrichedFullAssetInfoAtHeight(
+	assetID proto.AssetID,
+	height proto.Height,
+) (*proto.EnrichedFullAssetInfo, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.EnrichedFullAssetInfoAtHeight(assetID, height)
+}
+
 func (a *ThreadSafeReadWrapper) NFTList(account proto.Recipient, limit uint64, afterAssetID *proto.AssetID) ([]*proto.FullAssetInfo, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	return a.s.NFTList(account, limit, afterAssetID)
 }
 
+func (a *ThreadSafeReadWrapper) MinFee(tx proto.Transaction) (proto.OptionalAsset, uint64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.MinFee(tx)
+}
+
 func (a *ThreadSafeReadWrapper) ScriptBasicInfoByAccount(account proto.Recipient) (*proto.ScriptBasicInfo, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -328,6 +389,15 @@ func (a *ThreadSafeReadWrapper) ScriptInfoByAsset(assetID proto.AssetID) (*proto
 	return a.s.ScriptInfoByAsset(assetID)
 }
 
+func (a *ThreadSafeReadWrapper) ScriptInfoByAssetAtHeight(
+	assetID proto.AssetID,
+	height proto.Height,
+) (*proto.ScriptInfo, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.ScriptInfoByAssetAtHeight(assetID, height)
+}
+
 func (a *ThreadSafeReadWrapper) NewestScriptByAccount(recipient proto.Recipient) (*ast.Tree, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -340,6 +410,33 @@ func (a *ThreadSafeReadWrapper) NewestScriptBytesByAccount(recipient proto.Recip
 	return a.s.NewestScriptBytesByAccount(recipient)
 }
 
+func (a *ThreadSafeReadWrapper) ScriptBytesByAccountAtHeight(
+	account proto.Recipient,
+	height proto.Height,
+) (proto.Script, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.ScriptBytesByAccountAtHeight(account, height)
+}
+
+func (a *ThreadSafeReadWrapper) EvaluateExpression(addr proto.WavesAddress, expr *ast.Tree) (ride.Result, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.EvaluateExpression(addr, expr)
+}
+
+func (a *ThreadSafeReadWrapper) EvaluateFunctionCall(addr proto.WavesAddress, fc proto.FunctionCall) (ride.Result, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.EvaluateFunctionCall(addr, fc)
+}
+
+func (a *ThreadSafeReadWrapper) TraceInvoke(txID crypto.Digest) (*InvokeTrace, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.TraceInvoke(txID)
+}
+
 func (a *ThreadSafeReadWrapper) IsActiveLeasing(leaseID crypto.Digest) (bool, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -352,6 +449,16 @@ func (a *ThreadSafeReadWrapper) InvokeResultByID(invokeID crypto.Digest) (*proto
 	return a.s.InvokeResultByID(invokeID)
 }
 
+func (a *ThreadSafeReadWrapper) PrunedHeight() proto.Height {
+	return a.s.PrunedHeight()
+}
+
+func (a *ThreadSafeReadWrapper) RollbackMinHeight() (proto.Height, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.RollbackMinHeight()
+}
+
 func (a *ThreadSafeReadWrapper) ProvidesStateHashes() (bool, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -518,6 +625,16 @@ func (a *ThreadSafeWriteWrapper) TxValidation(f func(validation TxValidation) er
 	return f(a.s)
 }
 
+func (a *ThreadSafeWriteWrapper) DryRunTx(
+	tx proto.Transaction,
+	currentTimestamp uint64,
+	verifySignature bool,
+) ([]proto.AtomicSnapshot, uint64, error) {
+	a.lockUnsafe() // the same as TxValidation, can be called from multiple goroutines
+	defer a.unlockUnsafe()
+	return a.s.DryRunTx(tx, currentTimestamp, verifySignature)
+}
+
 func (a *ThreadSafeWriteWrapper) StartProvidingExtendedApi() error {
 	a.lock()
 	defer a.unlock()
@@ -33,6 +33,9 @@ type checkerInfo struct {
 	rideV5Activated         bool
 	rideV6Activated         bool
 	blockRewardDistribution bool
+	// estimatingFee disables the fee validation, the assets used to calculate the fee are stored in feeAssets.
+	estimatingFee bool
+	feeAssets     *txAssets
 }
 
 func (i *checkerInfo) estimatorVersion() int {
@@ -146,25 +149,9 @@ func (tc *transactionChecker) checkScriptComplexity(libVersion ast.LibraryVersio
 		| DApp Callable V5                       | 10000                         | 10000                        |
 		| DApp Callable V6, V7, V8               | 52000                         | 52000                        |
 	*/
-	var maxCallableComplexity, maxVerifierComplexity int
-	switch version := libVersion; version {
-	case ast.LibV1, ast.LibV2:
-		maxCallableComplexity = MaxCallableScriptComplexityV12
-		maxVerifierComplexity = MaxVerifierScriptComplexityReduced
-	case ast.LibV3, ast.LibV4:
-		maxCallableComplexity = MaxCallableScriptComplexityV34
-		maxVerifierComplexity = MaxVerifierScriptComplexity
-	case ast.LibV5:
-		maxCallableComplexity = MaxCallableScriptComplexityV5
-		maxVerifierComplexity = MaxVerifierScriptComplexity
-	case ast.LibV6, ast.LibV7, ast.LibV8:
-		maxCallableComplexity = MaxCallableScriptComplexityV6
-		maxVerifierComplexity = MaxVerifierScriptComplexity
-	default:
-		return errors.Errorf("unknown script LibVersion=%d", version)
-	}
-	if reducedVerifierComplexity {
-		maxVerifierComplexity = MaxVerifierScriptComplexityReduced
+	maxCallableComplexity, maxVerifierComplexity, err := ride.MaxScriptComplexity(libVersion, reducedVerifierComplexity)
+	if err != nil {
+		return err
 	}
 	if !isDapp { // Expression (simple) script, has only verifier.
 		if complexity := estimation.Verifier; complexity > maxVerifierComplexity {
@@ -251,6 +238,10 @@ func (tc *transactionChecker) checkFee(
 	assets *txAssets,
 	info *checkerInfo,
 ) error {
+	if info.estimatingFee {
+		info.feeAssets = assets
+		return nil
+	}
 	sponsorshipActivated, err := tc.stor.sponsoredAssets.isSponsorshipActivated()
 	if err != nil {
 		return err
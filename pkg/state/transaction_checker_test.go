@@ -138,6 +138,29 @@ func TestCheckTransferWithSig(t *testing.T) {
 	assert.Error(t, err, "checkTransferWithSig did not fail with invalid timestamp")
 }
 
+func TestCheckFeeEstimation(t *testing.T) {
+	info := defaultCheckerInfo()
+	to := createCheckerTestObjects(t, info)
+
+	tx := createTransferWithSig(t)
+	tx.Fee = 0
+	to.stor.createAsset(t, tx.FeeAsset.ID)
+	to.stor.createSmartAsset(t, tx.AmountAsset.ID)
+	to.stor.activateSponsorship(t)
+	err := to.stor.entities.sponsoredAssets.sponsorAsset(tx.FeeAsset.ID, 10, info.blockID)
+	require.NoError(t, err, "sponsorAsset() failed")
+
+	_, err = to.tc.checkTransferWithSig(tx, info)
+	assert.Error(t, err, "checkTransferWithSig did not fail with zero fee")
+
+	info.estimatingFee = true
+	_, err = to.tc.checkTransferWithSig(tx, info)
+	require.NoError(t, err, "checkTransferWithSig failed while estimating fee")
+	require.NotNil(t, info.feeAssets)
+	assert.Equal(t, tx.FeeAsset, info.feeAssets.feeAsset)
+	assert.Equal(t, []crypto.Digest{tx.AmountAsset.ID}, info.feeAssets.smartAssets)
+}
+
 func TestCheckTransferWithProofs(t *testing.T) {
 	info := defaultCheckerInfo()
 	to := createCheckerTestObjects(t, info)
@@ -1010,8 +1033,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		// libVersion 1, 2
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV12 - 1,
-				Verifier:   MaxVerifierScriptComplexityReduced - 1,
+				Estimation: ride.MaxCallableScriptComplexityV12 - 1,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced - 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV1, ast.LibV2},
 			isDapp:                    true,
@@ -1020,8 +1043,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV12,
-				Verifier:   MaxVerifierScriptComplexityReduced,
+				Estimation: ride.MaxCallableScriptComplexityV12,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV1, ast.LibV2},
 			isDapp:                    true,
@@ -1030,8 +1053,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV12 + 1,
-				Verifier:   MaxVerifierScriptComplexityReduced,
+				Estimation: ride.MaxCallableScriptComplexityV12 + 1,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV1, ast.LibV2},
 			isDapp:                    true,
@@ -1040,8 +1063,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV12,
-				Verifier:   MaxVerifierScriptComplexityReduced + 1,
+				Estimation: ride.MaxCallableScriptComplexityV12,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced + 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV1, ast.LibV2},
 			isDapp:                    true,
@@ -1051,8 +1074,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		// libVersion 3, 4
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV34 - 1,
-				Verifier:   MaxVerifierScriptComplexity - 1,
+				Estimation: ride.MaxCallableScriptComplexityV34 - 1,
+				Verifier:   ride.MaxVerifierScriptComplexity - 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV3, ast.LibV4},
 			isDapp:                    true,
@@ -1061,8 +1084,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV12,
-				Verifier:   MaxVerifierScriptComplexity,
+				Estimation: ride.MaxCallableScriptComplexityV12,
+				Verifier:   ride.MaxVerifierScriptComplexity,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV3, ast.LibV4},
 			isDapp:                    true,
@@ -1071,8 +1094,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV34 + 1,
-				Verifier:   MaxVerifierScriptComplexity,
+				Estimation: ride.MaxCallableScriptComplexityV34 + 1,
+				Verifier:   ride.MaxVerifierScriptComplexity,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV3, ast.LibV4},
 			isDapp:                    true,
@@ -1081,8 +1104,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV34,
-				Verifier:   MaxVerifierScriptComplexity + 1,
+				Estimation: ride.MaxCallableScriptComplexityV34,
+				Verifier:   ride.MaxVerifierScriptComplexity + 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV3, ast.LibV4},
 			isDapp:                    true,
@@ -1092,8 +1115,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		// libVersion 5
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV5 - 1,
-				Verifier:   MaxVerifierScriptComplexity - 1,
+				Estimation: ride.MaxCallableScriptComplexityV5 - 1,
+				Verifier:   ride.MaxVerifierScriptComplexity - 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV5},
 			isDapp:                    true,
@@ -1102,8 +1125,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV5,
-				Verifier:   MaxVerifierScriptComplexity,
+				Estimation: ride.MaxCallableScriptComplexityV5,
+				Verifier:   ride.MaxVerifierScriptComplexity,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV5},
 			isDapp:                    true,
@@ -1112,8 +1135,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV5 + 1,
-				Verifier:   MaxVerifierScriptComplexity,
+				Estimation: ride.MaxCallableScriptComplexityV5 + 1,
+				Verifier:   ride.MaxVerifierScriptComplexity,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV5},
 			isDapp:                    true,
@@ -1122,8 +1145,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV5,
-				Verifier:   MaxVerifierScriptComplexity + 1,
+				Estimation: ride.MaxCallableScriptComplexityV5,
+				Verifier:   ride.MaxVerifierScriptComplexity + 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV5},
 			isDapp:                    true,
@@ -1133,8 +1156,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		// libVersion 6
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV6 - 1,
-				Verifier:   MaxVerifierScriptComplexity - 1,
+				Estimation: ride.MaxCallableScriptComplexityV6 - 1,
+				Verifier:   ride.MaxVerifierScriptComplexity - 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV6},
 			isDapp:                    true,
@@ -1143,8 +1166,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV6,
-				Verifier:   MaxVerifierScriptComplexity,
+				Estimation: ride.MaxCallableScriptComplexityV6,
+				Verifier:   ride.MaxVerifierScriptComplexity,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV6},
 			isDapp:                    true,
@@ -1153,8 +1176,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV6 + 1,
-				Verifier:   MaxVerifierScriptComplexity,
+				Estimation: ride.MaxCallableScriptComplexityV6 + 1,
+				Verifier:   ride.MaxVerifierScriptComplexity,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV6},
 			isDapp:                    true,
@@ -1163,8 +1186,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV6,
-				Verifier:   MaxVerifierScriptComplexity + 1,
+				Estimation: ride.MaxCallableScriptComplexityV6,
+				Verifier:   ride.MaxVerifierScriptComplexity + 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV6},
 			isDapp:                    true,
@@ -1174,8 +1197,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		// libVersion 3, 4, 5, 6 - reduced script complexity
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV34,
-				Verifier:   MaxVerifierScriptComplexityReduced - 1,
+				Estimation: ride.MaxCallableScriptComplexityV34,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced - 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV3, ast.LibV4, ast.LibV5, ast.LibV6},
 			isDapp:                    true,
@@ -1184,8 +1207,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV34,
-				Verifier:   MaxVerifierScriptComplexityReduced,
+				Estimation: ride.MaxCallableScriptComplexityV34,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV3, ast.LibV4, ast.LibV5, ast.LibV6},
 			isDapp:                    true,
@@ -1194,8 +1217,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV34,
-				Verifier:   MaxVerifierScriptComplexityReduced + 1,
+				Estimation: ride.MaxCallableScriptComplexityV34,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced + 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV3, ast.LibV4, ast.LibV5, ast.LibV6},
 			isDapp:                    true,
@@ -1205,8 +1228,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		// not DApp
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV6,
-				Verifier:   MaxVerifierScriptComplexityReduced - 1,
+				Estimation: ride.MaxCallableScriptComplexityV6,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced - 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV1, ast.LibV2},
 			isDapp:                    false,
@@ -1215,8 +1238,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV6,
-				Verifier:   MaxVerifierScriptComplexityReduced,
+				Estimation: ride.MaxCallableScriptComplexityV6,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV1, ast.LibV2},
 			isDapp:                    false,
@@ -1225,8 +1248,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		},
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV6,
-				Verifier:   MaxVerifierScriptComplexityReduced + 1,
+				Estimation: ride.MaxCallableScriptComplexityV6,
+				Verifier:   ride.MaxVerifierScriptComplexityReduced + 1,
 			},
 			libVersions:               []ast.LibraryVersion{ast.LibV1, ast.LibV2},
 			isDapp:                    false,
@@ -1236,8 +1259,8 @@ func TestCheckSetScriptWithProofsCheckScriptComplexity(t *testing.T) {
 		// unknown lib version
 		{
 			estimationStub: ride.TreeEstimation{
-				Estimation: MaxCallableScriptComplexityV34,
-				Verifier:   MaxVerifierScriptComplexity,
+				Estimation: ride.MaxCallableScriptComplexityV34,
+				Verifier:   ride.MaxVerifierScriptComplexity,
 			},
 			libVersions:               []ast.LibraryVersion{128},
 			isDapp:                    true,
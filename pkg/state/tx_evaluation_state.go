@@ -0,0 +1,717 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
+// txEvaluationState is a read-only view of the state as it was right before the evaluation of a transaction
+// of an applied block. It reads the state at the height of the previous block and overlays it with
+// the snapshots of the transactions that precede the transaction in its block.
+// The view is limited to the rollback window, because older history entries are cut.
+// The block reward of the block generator is not included in the view until the generator's balance is
+// changed by one of the preceding transactions.
+type txEvaluationState struct {
+	s      *stateManager
+	height proto.Height // Height of the block of the transaction.
+	base   proto.Height // Height of the state to read from, the height of the previous block.
+	txs    map[string]struct{}
+
+	wavesBalances  map[proto.AddressID]balanceProfile
+	assetBalances  map[assetBalanceKey]uint64
+	entries        map[entryId]proto.DataEntry
+	accountScripts map[proto.AddressID]proto.AccountScriptSnapshot
+	assetScripts   map[proto.AssetID]proto.Script
+	sponsorships   map[proto.AssetID]uint64
+	aliases        map[string]proto.WavesAddress
+	assets         map[proto.AssetID]*assetInfo
+	leases         map[crypto.Digest]*leasing
+}
+
+var (
+	_ types.EnrichedSmartState = (*txEvaluationState)(nil)
+	_ proto.SnapshotApplier    = (*txEvaluationState)(nil) // the preceding transactions are applied in memory
+)
+
+// newTxEvaluationState creates the view of the state right before the evaluation of the transaction
+// with the given ID from the block at the given height.
+func newTxEvaluationState(s *stateManager, txID []byte, height proto.Height) (*txEvaluationState, error) {
+	block, err := s.BlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := s.SnapshotsAtHeight(height)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	if len(snapshots.TxSnapshots) != len(block.Transactions) {
+		return nil, wrapErr(stateerr.Other, errors.Errorf(
+			"number of snapshots %d differs from number of transactions %d in block at height %d",
+			len(snapshots.TxSnapshots), len(block.Transactions), height,
+		))
+	}
+	st := &txEvaluationState{
+		s:              s,
+		height:         height,
+		base:           height - 1,
+		txs:            make(map[string]struct{}),
+		wavesBalances:  make(map[proto.AddressID]balanceProfile),
+		assetBalances:  make(map[assetBalanceKey]uint64),
+		entries:        make(map[entryId]proto.DataEntry),
+		accountScripts: make(map[proto.AddressID]proto.AccountScriptSnapshot),
+		assetScripts:   make(map[proto.AssetID]proto.Script),
+		sponsorships:   make(map[proto.AssetID]uint64),
+		aliases:        make(map[string]proto.WavesAddress),
+		assets:         make(map[proto.AssetID]*assetInfo),
+		leases:         make(map[crypto.Digest]*leasing),
+	}
+	for i, tx := range block.Transactions {
+		id, idErr := tx.GetID(s.settings.AddressSchemeCharacter)
+		if idErr != nil {
+			return nil, wrapErr(stateerr.Other, idErr)
+		}
+		if bytes.Equal(id, txID) {
+			return st, nil
+		}
+		for _, snapshot := range snapshots.TxSnapshots[i] {
+			if applyErr := snapshot.Apply(st); applyErr != nil {
+				return nil, wrapErr(stateerr.Other, errors.Wrapf(applyErr,
+					"failed to apply snapshot of transaction %s", base58.Encode(id),
+				))
+			}
+		}
+		st.txs[string(id)] = struct{}{}
+	}
+	return nil, wrapErr(stateerr.NotFoundError, errors.Errorf("transaction %s not found in block at height %d",
+		base58.Encode(txID), height,
+	))
+}
+
+func (st *txEvaluationState) ApplyWavesBalance(snapshot proto.WavesBalanceSnapshot) error {
+	id := snapshot.Address.ID()
+	profile, err := st.wavesBalance(id)
+	if err != nil {
+		return err
+	}
+	profile.balance = snapshot.Balance
+	st.wavesBalances[id] = profile
+	return nil
+}
+
+func (st *txEvaluationState) ApplyLeaseBalance(snapshot proto.LeaseBalanceSnapshot) error {
+	id := snapshot.Address.ID()
+	profile, err := st.wavesBalance(id)
+	if err != nil {
+		return err
+	}
+	profile.leaseIn = int64(snapshot.LeaseIn)
+	profile.leaseOut = int64(snapshot.LeaseOut)
+	st.wavesBalances[id] = profile
+	return nil
+}
+
+func (st *txEvaluationState) ApplyAssetBalance(snapshot proto.AssetBalanceSnapshot) error {
+	key := assetBalanceKey{address: snapshot.Address.ID(), asset: proto.AssetIDFromDigest(snapshot.AssetID)}
+	st.assetBalances[key] = snapshot.Balance
+	return nil
+}
+
+func (st *txEvaluationState) ApplyAlias(snapshot proto.AliasSnapshot) error {
+	st.aliases[snapshot.Alias] = snapshot.Address
+	return nil
+}
+
+func (st *txEvaluationState) ApplyNewAsset(snapshot proto.NewAssetSnapshot) error {
+	st.assets[proto.AssetIDFromDigest(snapshot.AssetID)] = &assetInfo{
+		assetConstInfo: assetConstInfo{
+			Tail:        proto.DigestTail(snapshot.AssetID),
+			Issuer:      snapshot.IssuerPublicKey,
+			Decimals:    snapshot.Decimals,
+			IssueHeight: st.height,
+			IsNFT:       snapshot.IsNFT,
+		},
+	}
+	return nil
+}
+
+func (st *txEvaluationState) ApplyAssetDescription(snapshot proto.AssetDescriptionSnapshot) error {
+	assetID := proto.AssetIDFromDigest(snapshot.AssetID)
+	info, err := st.assetInfo(assetID)
+	if err != nil {
+		return err
+	}
+	updated := *info
+	updated.name = snapshot.AssetName
+	updated.description = snapshot.AssetDescription
+	updated.lastNameDescChangeHeight = st.height
+	st.assets[assetID] = &updated
+	return nil
+}
+
+func (st *txEvaluationState) ApplyAssetVolume(snapshot proto.AssetVolumeSnapshot) error {
+	assetID := proto.AssetIDFromDigest(snapshot.AssetID)
+	info, err := st.assetInfo(assetID)
+	if err != nil {
+		return err
+	}
+	updated := *info
+	updated.quantity = snapshot.TotalQuantity
+	updated.reissuable = snapshot.IsReissuable
+	st.assets[assetID] = &updated
+	return nil
+}
+
+func (st *txEvaluationState) ApplyAssetScript(snapshot proto.AssetScriptSnapshot) error {
+	st.assetScripts[proto.AssetIDFromDigest(snapshot.AssetID)] = snapshot.Script
+	return nil
+}
+
+func (st *txEvaluationState) ApplySponsorship(snapshot proto.SponsorshipSnapshot) error {
+	st.sponsorships[proto.AssetIDFromDigest(snapshot.AssetID)] = snapshot.MinSponsoredFee
+	return nil
+}
+
+func (st *txEvaluationState) ApplyAccountScript(snapshot proto.AccountScriptSnapshot) error {
+	addr, err := proto.NewAddressFromPublicKey(st.s.settings.AddressSchemeCharacter, snapshot.SenderPublicKey)
+	if err != nil {
+		return err
+	}
+	st.accountScripts[addr.ID()] = snapshot
+	return nil
+}
+
+func (st *txEvaluationState) ApplyFilledVolumeAndFee(proto.FilledVolumeFeeSnapshot) error {
+	return nil // Filled volumes of orders are not accessible from Ride.
+}
+
+func (st *txEvaluationState) ApplyDataEntries(snapshot proto.DataEntriesSnapshot) error {
+	id := snapshot.Address.ID()
+	for _, entry := range snapshot.DataEntries {
+		st.entries[entryId{addrID: id, key: entry.GetKey()}] = entry
+	}
+	return nil
+}
+
+func (st *txEvaluationState) ApplyNewLease(snapshot proto.NewLeaseSnapshot) error {
+	st.leases[snapshot.LeaseID] = &leasing{
+		SenderPK:      snapshot.SenderPK,
+		RecipientAddr: snapshot.RecipientAddr,
+		Amount:        snapshot.Amount,
+		Status:        LeaseActive,
+	}
+	return nil
+}
+
+func (st *txEvaluationState) ApplyCancelledLease(snapshot proto.CancelledLeaseSnapshot) error {
+	l, err := st.leasingInfo(snapshot.LeaseID)
+	if err != nil {
+		return err
+	}
+	cancelled := *l
+	cancelled.Status = LeaseCancelled
+	st.leases[snapshot.LeaseID] = &cancelled
+	return nil
+}
+
+func (st *txEvaluationState) ApplyTransactionsStatus(proto.TransactionStatusSnapshot) error {
+	return nil // Statuses of the preceding transactions are taken from the state.
+}
+
+func (st *txEvaluationState) wavesBalance(id proto.AddressID) (balanceProfile, error) {
+	if profile, ok := st.wavesBalances[id]; ok {
+		return profile, nil
+	}
+	return st.s.stor.balances.wavesBalanceAtHeight(id, st.base)
+}
+
+func (st *txEvaluationState) assetBalance(id proto.AddressID, assetID proto.AssetID) (uint64, error) {
+	if balance, ok := st.assetBalances[assetBalanceKey{address: id, asset: assetID}]; ok {
+		return balance, nil
+	}
+	return st.s.stor.balances.assetBalanceAtHeight(id, assetID, st.base)
+}
+
+func (st *txEvaluationState) assetInfo(assetID proto.AssetID) (*assetInfo, error) {
+	if info, ok := st.assets[assetID]; ok {
+		return info, nil
+	}
+	return st.s.stor.assets.assetInfoAtHeight(assetID, st.base)
+}
+
+func (st *txEvaluationState) assetCost(assetID proto.AssetID) (uint64, error) {
+	if cost, ok := st.sponsorships[assetID]; ok {
+		return cost, nil
+	}
+	return st.s.stor.sponsoredAssets.assetCostAtHeight(assetID, st.base)
+}
+
+func (st *txEvaluationState) assetScript(assetID proto.AssetID) (proto.Script, error) {
+	if script, ok := st.assetScripts[assetID]; ok {
+		return script, nil
+	}
+	return st.s.stor.scriptsStorage.scriptBytesByAssetAtHeight(assetID, st.base)
+}
+
+func (st *txEvaluationState) accountScript(addr proto.WavesAddress) (proto.Script, error) {
+	if snapshot, ok := st.accountScripts[addr.ID()]; ok {
+		return snapshot.Script, nil
+	}
+	return st.s.stor.scriptsStorage.scriptBytesByAddrAtHeight(addr, st.base)
+}
+
+func (st *txEvaluationState) leasingInfo(id crypto.Digest) (*leasing, error) {
+	if l, ok := st.leases[id]; ok {
+		return l, nil
+	}
+	return st.s.stor.leases.leasingInfoAtHeight(id, st.base)
+}
+
+func (st *txEvaluationState) addrByAlias(alias string) (proto.WavesAddress, error) {
+	if addr, ok := st.aliases[alias]; ok {
+		return addr, nil
+	}
+	return st.s.stor.aliases.addrByAliasAtHeight(alias, st.base)
+}
+
+func (st *txEvaluationState) entry(addr proto.WavesAddress, key string) (proto.DataEntry, error) {
+	if entry, ok := st.entries[entryId{addrID: addr.ID(), key: key}]; ok {
+		if entry.GetValueType() == proto.DataDelete {
+			return nil, errors.Wrapf(keyvalue.ErrNotFound, "entry '%s' was removed", key)
+		}
+		return entry, nil
+	}
+	return st.s.stor.accountsDataStor.retrieveEntryAtHeight(addr, key, st.base)
+}
+
+func (st *txEvaluationState) allEntries(addr proto.WavesAddress) ([]proto.DataEntry, error) {
+	stored, err := st.s.stor.accountsDataStor.retrieveEntriesAtHeight(addr, st.base)
+	if err != nil {
+		return nil, err
+	}
+	id := addr.ID()
+	entries := make([]proto.DataEntry, 0, len(stored))
+	for _, e := range stored {
+		if _, ok := st.entries[entryId{addrID: id, key: e.GetKey()}]; !ok {
+			entries = append(entries, e)
+		}
+	}
+	for eid, e := range st.entries {
+		if eid.addrID == id && e.GetValueType() != proto.DataDelete {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// checkTransaction returns an error if the transaction wasn't applied before the evaluated transaction.
+func (st *txEvaluationState) checkTransaction(id []byte) error {
+	txHeight, err := st.s.NewestTransactionHeightByID(id)
+	if err != nil {
+		return err
+	}
+	if txHeight < st.height {
+		return nil
+	}
+	if _, ok := st.txs[string(id)]; ok && txHeight == st.height {
+		return nil
+	}
+	return wrapErr(stateerr.NotFoundError, errors.Errorf("transaction %s not found at height %d",
+		base58.Encode(id), st.height,
+	))
+}
+
+func (st *txEvaluationState) NewestScriptPKByAddr(addr proto.WavesAddress) (crypto.PublicKey, error) {
+	if snapshot, ok := st.accountScripts[addr.ID()]; ok {
+		if snapshot.Script.IsEmpty() {
+			return crypto.PublicKey{}, errors.Wrap(errEmptyScript, "failed to get script public key")
+		}
+		return snapshot.SenderPublicKey, nil
+	}
+	info, err := st.s.stor.scriptsStorage.scriptBasicInfoByAddressIDAtHeight(addr.ID(), st.base)
+	if err != nil {
+		return crypto.PublicKey{}, errors.Wrap(err, "failed to get script public key")
+	}
+	return info.PK, nil
+}
+
+func (st *txEvaluationState) AddingBlockHeight() (uint64, error) {
+	return st.height, nil
+}
+
+func (st *txEvaluationState) NewestTransactionByID(id []byte) (proto.Transaction, error) {
+	if err := st.checkTransaction(id); err != nil {
+		return nil, err
+	}
+	return st.s.NewestTransactionByID(id)
+}
+
+func (st *txEvaluationState) NewestTransactionHeightByID(id []byte) (uint64, error) {
+	if err := st.checkTransaction(id); err != nil {
+		return 0, err
+	}
+	return st.s.NewestTransactionHeightByID(id)
+}
+
+func (st *txEvaluationState) NewestScriptByAccount(account proto.Recipient) (*ast.Tree, error) {
+	script, err := st.NewestScriptBytesByAccount(account)
+	if err != nil {
+		return nil, err
+	}
+	if script.IsEmpty() {
+		return nil, errors.Wrapf(proto.ErrNotFound, "failed to get script by account '%s'", account.String())
+	}
+	tree, err := scriptBytesToTree(script)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get script by account '%s'", account.String())
+	}
+	return tree, nil
+}
+
+func (st *txEvaluationState) NewestScriptBytesByAccount(account proto.Recipient) (proto.Script, error) {
+	addr, err := st.NewestRecipientToAddress(account)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get script bytes by account '%s'", account.String())
+	}
+	script, err := st.accountScript(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get script bytes by account '%s'", account.String())
+	}
+	return script, nil
+}
+
+func (st *txEvaluationState) NewestRecipientToAddress(recipient proto.Recipient) (proto.WavesAddress, error) {
+	if addr := recipient.Address(); addr != nil {
+		return *addr, nil
+	}
+	return st.addrByAlias(recipient.Alias().Alias)
+}
+
+func (st *txEvaluationState) NewestAddrByAlias(alias proto.Alias) (proto.WavesAddress, error) {
+	addr, err := st.addrByAlias(alias.Alias)
+	if err != nil {
+		return proto.WavesAddress{}, wrapErr(stateerr.RetrievalError, err)
+	}
+	return addr, nil
+}
+
+func (st *txEvaluationState) NewestLeasingInfo(id crypto.Digest) (*proto.LeaseInfo, error) {
+	l, err := st.leasingInfo(id)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := proto.NewAddressFromPublicKey(st.s.settings.AddressSchemeCharacter, l.SenderPK)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.LeaseInfo{
+		Sender:      sender,
+		Recipient:   l.RecipientAddr,
+		IsActive:    l.isActive(),
+		LeaseAmount: l.Amount,
+	}, nil
+}
+
+func (st *txEvaluationState) IsStateUntouched(account proto.Recipient) (bool, error) {
+	addr, err := st.NewestRecipientToAddress(account)
+	if err != nil {
+		return false, wrapErr(stateerr.RetrievalError, err)
+	}
+	entries, err := st.allEntries(addr)
+	if err != nil {
+		return false, wrapErr(stateerr.RetrievalError, err)
+	}
+	return len(entries) == 0, nil
+}
+
+func (st *txEvaluationState) NewestAssetBalance(account proto.Recipient, asset crypto.Digest) (uint64, error) {
+	addr, err := st.NewestRecipientToAddress(account)
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	return st.NewestAssetBalanceByAddressID(addr.ID(), asset)
+}
+
+func (st *txEvaluationState) NewestAssetBalanceByAddressID(id proto.AddressID, asset crypto.Digest) (uint64, error) {
+	balance, err := st.assetBalance(id, proto.AssetIDFromDigest(asset))
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	return balance, nil
+}
+
+func (st *txEvaluationState) NewestWavesBalance(account proto.Recipient) (uint64, error) {
+	addr, err := st.NewestRecipientToAddress(account)
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	profile, err := st.wavesBalance(addr.ID())
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	return profile.balance, nil
+}
+
+func (st *txEvaluationState) NewestFullWavesBalance(account proto.Recipient) (*proto.FullWavesBalance, error) {
+	addr, err := st.NewestRecipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	bp, err := st.WavesBalanceProfile(addr.ID())
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return bp.ToFullWavesBalance()
+}
+
+// WavesBalanceProfile returns Waves balance profile of the account. The generating balance is calculated
+// for the height of the block of the evaluated transaction, the same way as during the block application.
+func (st *txEvaluationState) WavesBalanceProfile(id proto.AddressID) (*types.WavesBalanceProfile, error) {
+	profile, err := st.wavesBalance(id)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	challenged, err := st.s.stor.balances.isChallengedAddress(id, st.height)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	var generating uint64
+	if !challenged {
+		startHeight, _ := st.s.settings.RangeForGeneratingBalanceByHeight(st.height)
+		gb, gbErr := st.s.stor.balances.minEffectiveBalanceInRange(id, startHeight, st.base)
+		eb, ebErr := profile.effectiveBalanceUnchecked()
+		if gbErr == nil && ebErr == nil {
+			generating = min(gb, eb)
+		}
+	}
+	return &types.WavesBalanceProfile{
+		Balance:    profile.balance,
+		LeaseIn:    profile.leaseIn,
+		LeaseOut:   profile.leaseOut,
+		Generating: generating,
+		Challenged: challenged,
+	}, nil
+}
+
+func (st *txEvaluationState) retrieveEntry(account proto.Recipient, key string) (proto.DataEntry, error) {
+	addr, err := st.NewestRecipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	entry, err := st.entry(addr, key)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return entry, nil
+}
+
+func (st *txEvaluationState) RetrieveNewestIntegerEntry(
+	account proto.Recipient,
+	key string,
+) (*proto.IntegerDataEntry, error) {
+	entry, err := st.retrieveEntry(account, key)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := entry.(*proto.IntegerDataEntry)
+	if !ok {
+		return nil, wrapErr(stateerr.RetrievalError, errors.New("failed to convert to integer entry"))
+	}
+	return e, nil
+}
+
+func (st *txEvaluationState) RetrieveNewestBooleanEntry(
+	account proto.Recipient,
+	key string,
+) (*proto.BooleanDataEntry, error) {
+	entry, err := st.retrieveEntry(account, key)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := entry.(*proto.BooleanDataEntry)
+	if !ok {
+		return nil, wrapErr(stateerr.RetrievalError, errors.New("failed to convert to boolean entry"))
+	}
+	return e, nil
+}
+
+func (st *txEvaluationState) RetrieveNewestStringEntry(
+	account proto.Recipient,
+	key string,
+) (*proto.StringDataEntry, error) {
+	entry, err := st.retrieveEntry(account, key)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := entry.(*proto.StringDataEntry)
+	if !ok {
+		return nil, wrapErr(stateerr.RetrievalError, errors.New("failed to convert to string entry"))
+	}
+	return e, nil
+}
+
+func (st *txEvaluationState) RetrieveNewestBinaryEntry(
+	account proto.Recipient,
+	key string,
+) (*proto.BinaryDataEntry, error) {
+	entry, err := st.retrieveEntry(account, key)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := entry.(*proto.BinaryDataEntry)
+	if !ok {
+		return nil, wrapErr(stateerr.RetrievalError, errors.New("failed to convert to binary entry"))
+	}
+	return e, nil
+}
+
+func (st *txEvaluationState) RetrieveEntries(account proto.Recipient) ([]proto.DataEntry, error) {
+	addr, err := st.NewestRecipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	entries, err := st.allEntries(addr)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return entries, nil
+}
+
+func (st *txEvaluationState) NewestAssetIsSponsored(asset crypto.Digest) (bool, error) {
+	cost, err := st.assetCost(proto.AssetIDFromDigest(asset))
+	if err != nil {
+		return false, wrapErr(stateerr.RetrievalError, err)
+	}
+	return cost > 0, nil
+}
+
+func (st *txEvaluationState) NewestAssetConstInfo(assetID proto.AssetID) (*proto.AssetConstInfo, error) {
+	info, err := st.assetInfo(assetID)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	issuer, err := proto.NewAddressFromPublicKey(st.s.settings.AddressSchemeCharacter, info.Issuer)
+	if err != nil {
+		return nil, wrapErr(stateerr.Other, err)
+	}
+	return &proto.AssetConstInfo{
+		ID:          proto.ReconstructDigest(assetID, info.Tail),
+		IssueHeight: info.IssueHeight,
+		Issuer:      issuer,
+		Decimals:    info.Decimals,
+	}, nil
+}
+
+func (st *txEvaluationState) NewestAssetInfo(asset crypto.Digest) (*proto.AssetInfo, error) {
+	assetID := proto.AssetIDFromDigest(asset)
+	info, err := st.assetInfo(assetID)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	if !info.quantity.IsUint64() {
+		return nil, wrapErr(stateerr.Other, errors.New("asset quantity overflows uint64"))
+	}
+	issuer, err := proto.NewAddressFromPublicKey(st.s.settings.AddressSchemeCharacter, info.Issuer)
+	if err != nil {
+		return nil, wrapErr(stateerr.Other, err)
+	}
+	cost, err := st.assetCost(assetID)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	script, err := st.assetScript(assetID)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return &proto.AssetInfo{
+		AssetConstInfo: proto.AssetConstInfo{
+			ID:          proto.ReconstructDigest(assetID, info.Tail),
+			IssueHeight: info.IssueHeight,
+			Issuer:      issuer,
+			Decimals:    info.Decimals,
+		},
+		Quantity:        info.quantity.Uint64(),
+		IssuerPublicKey: info.Issuer,
+
+		Reissuable: info.reissuable,
+		Scripted:   !script.IsEmpty(),
+		Sponsored:  cost > 0,
+	}, nil
+}
+
+// NewestFullAssetInfo is used to request full asset info from RIDE, the issue transaction and
+// the complexity of the asset script are not used by Ride, so they are not set.
+func (st *txEvaluationState) NewestFullAssetInfo(asset crypto.Digest) (*proto.FullAssetInfo, error) {
+	ai, err := st.NewestAssetInfo(asset)
+	if err != nil {
+		return nil, err
+	}
+	assetID := proto.AssetIDFromDigest(asset)
+	info, err := st.assetInfo(assetID)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	res := &proto.FullAssetInfo{
+		AssetInfo:   *ai,
+		Name:        info.name,
+		Description: info.description,
+	}
+	if ai.Sponsored {
+		cost, cErr := st.assetCost(assetID)
+		if cErr != nil {
+			return nil, wrapErr(stateerr.RetrievalError, cErr)
+		}
+		sponsorBalance, bErr := st.NewestWavesBalance(proto.NewRecipientFromAddress(ai.Issuer))
+		if bErr != nil {
+			return nil, bErr
+		}
+		res.SponsorshipCost = cost
+		res.SponsorBalance = sponsorBalance
+	}
+	if ai.Scripted {
+		script, sErr := st.assetScript(assetID)
+		if sErr != nil {
+			return nil, wrapErr(stateerr.RetrievalError, sErr)
+		}
+		version, vErr := proto.VersionFromScriptBytes(script)
+		if vErr != nil {
+			return nil, wrapErr(stateerr.Other, vErr)
+		}
+		res.ScriptInfo = proto.ScriptInfo{Version: version, Bytes: script}
+	}
+	return res, nil
+}
+
+func (st *txEvaluationState) NewestScriptByAsset(asset crypto.Digest) (*ast.Tree, error) {
+	script, err := st.assetScript(proto.AssetIDFromDigest(asset))
+	if err != nil {
+		return nil, err
+	}
+	if script.IsEmpty() {
+		return nil, proto.ErrNotFound
+	}
+	return scriptBytesToTree(script)
+}
+
+func (st *txEvaluationState) NewestBlockInfoByHeight(height proto.Height) (*proto.BlockInfo, error) {
+	if height > st.height {
+		return nil, wrapErr(stateerr.NotFoundError, errors.Errorf("block at height %d not found", height))
+	}
+	return st.s.NewestBlockInfoByHeight(height)
+}
+
+func (st *txEvaluationState) EstimatorVersion() (int, error) {
+	return st.s.EstimatorVersion()
+}
+
+func (st *txEvaluationState) IsNotFound(err error) bool {
+	return stateerr.IsNotFound(err)
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -109,12 +110,17 @@ func (bp *WavesBalanceProfile) ToFullWavesBalance() (*proto.FullWavesBalance, er
 	}, nil
 }
 
+// ErrTransactionPruned is returned by SmartState.NewestTransactionByID if the body of the transaction
+// was removed from the state by pruning.
+var ErrTransactionPruned = errors.New("transaction is pruned")
+
 // SmartState is a part of state used by smart contracts.
 
 type SmartState interface {
 	NewestScriptPKByAddr(addr proto.WavesAddress) (crypto.PublicKey, error)
 	AddingBlockHeight() (uint64, error)
 	// NewestTransactionByID returns a transaction, BUT returns error if a transaction exists but failed or elided.
+	// ErrTransactionPruned is returned if the body of the transaction was removed by pruning.
 	NewestTransactionByID([]byte) (proto.Transaction, error)
 	// NewestTransactionHeightByID returns a transaction height, BUT returns error if a transaction
 	//  exists but failed or elided.
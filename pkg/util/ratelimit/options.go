@@ -0,0 +1,75 @@
+// Package ratelimit provides the options of per-client request rate limiting shared by the REST and gRPC APIs.
+package ratelimit
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultCacheSize = 64 * 1024 // 64 KB
+	DefaultRPS       = 1
+	DefaultBurst     = 1
+)
+
+const (
+	cacheSizeKey = "cache"
+	rpsKey       = "rps"
+	burstKey     = "burst"
+)
+
+type Options struct {
+	MemoryCacheSize      int
+	MaxRequestsPerSecond int
+	MaxBurst             int
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		MemoryCacheSize:      DefaultCacheSize,
+		MaxRequestsPerSecond: DefaultRPS,
+		MaxBurst:             DefaultBurst,
+	}
+}
+
+func NewOptionsFromString(s string) (*Options, error) {
+	opt := DefaultOptions()
+	query, err := url.ParseQuery(strings.TrimSpace(s))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid rate limiter options")
+	}
+	cacheSize, err := extractFirstIntValue(query, cacheSizeKey, DefaultCacheSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid rate limiter options")
+	}
+	opt.MemoryCacheSize = cacheSize
+	rps, err := extractFirstIntValue(query, rpsKey, DefaultRPS)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid rate limiter options")
+	}
+	opt.MaxRequestsPerSecond = rps
+	burst, err := extractFirstIntValue(query, burstKey, DefaultBurst)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid rate limiter options")
+	}
+	opt.MaxBurst = burst
+	return opt, nil
+}
+
+func extractFirstIntValue(query url.Values, key string, dft int) (int, error) {
+	values, ok := query[key]
+	if !ok {
+		return dft, nil
+	}
+	if len(values) < 1 {
+		return 0, errors.Errorf("no value for key '%s'", key)
+	}
+	v, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid value for key '%s'", key)
+	}
+	return int(v), nil
+}
@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOptionsFromString(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		fail bool
+		opts *Options
+		err  string
+	}{
+		{"", false, &Options{DefaultCacheSize, DefaultRPS, DefaultBurst}, ""},
+		{"cache=12345&rps=67890&burst=13579", false, &Options{12345, 67890, 13579}, ""},
+		{"  cache=12345 &rps=67890 &burst=13579   ", false, &Options{12345, 67890, 13579}, ""},
+		{"rps=67890&burst=13579", false, &Options{DefaultCacheSize, 67890, 13579}, ""},
+		{"rps=67890", false, &Options{DefaultCacheSize, 67890, DefaultBurst}, ""},
+		{"rps=-1", false, &Options{DefaultCacheSize, -1, DefaultBurst}, ""},
+		{"cache=xxx&rps=67890&burst=13579", true, nil, "invalid rate limiter options: invalid value for key 'cache': strconv.ParseInt: parsing \"xxx\": invalid syntax"},
+		{"cache=&rps=67890&burst=13579", true, nil, "invalid rate limiter options: invalid value for key 'cache': strconv.ParseInt: parsing \"\": invalid syntax"},
+		{"cache=&rps=67890&burst=13579", true, nil, "invalid rate limiter options: invalid value for key 'cache': strconv.ParseInt: parsing \"\": invalid syntax"},
+		{"shmesh=&RPS=67890", false, &Options{DefaultCacheSize, DefaultBurst, DefaultBurst}, ""},
+	} {
+		opts, err := NewOptionsFromString(test.s)
+		if test.fail {
+			require.Error(t, err)
+			assert.EqualError(t, err, test.err)
+		} else {
+			require.NoError(t, err)
+			assert.NotNil(t, opts)
+			assert.Equal(t, test.opts, opts)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Package tlsreloader provides the server TLS configuration with certificates that are reloaded from files
+// without restart of the server.
+package tlsreloader
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+)
+
+// DefaultCheckInterval is the default interval of checking the certificate files for changes.
+const DefaultCheckInterval = 10 * time.Second
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Reloader keeps the certificate, the key and optionally the CA certificates to verify clients loaded from files.
+// The files are reloaded on SIGHUP or when they are changed, if the reload fails the previous certificates are
+// kept in use.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	stamps    []fileStamp
+}
+
+// New creates the Reloader and loads the certificates. The clientCAFile is optional, if it's not empty
+// the clients are required to present a certificate signed by one of the CAs from the file (mutual TLS).
+func New(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both certificate and key files are required")
+	}
+	r := &Reloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		files = append(files, r.clientCAFile)
+	}
+	return files
+}
+
+func (r *Reloader) readStamps() ([]fileStamp, error) {
+	files := r.files()
+	stamps := make([]fileStamp, len(files))
+	for i, f := range files {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to stat file %q", f)
+		}
+		stamps[i] = fileStamp{modTime: fi.ModTime(), size: fi.Size()}
+	}
+	return stamps, nil
+}
+
+// Reload loads the certificates from files unconditionally.
+func (r *Reloader) Reload() error {
+	stamps, err := r.readStamps()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load TLS certificate")
+	}
+	var pool *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, rErr := os.ReadFile(r.clientCAFile)
+		if rErr != nil {
+			return errors.Wrap(rErr, "failed to read client CA file")
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.Errorf("no certificates found in client CA file %q", r.clientCAFile)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = pool
+	r.stamps = stamps
+	return nil
+}
+
+// changed reports whether any of the files was modified since the last reload.
+func (r *Reloader) changed() (bool, error) {
+	stamps, err := r.readStamps()
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := range stamps {
+		if !stamps[i].modTime.Equal(r.stamps[i].modTime) || stamps[i].size != r.stamps[i].size {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Run reloads the certificates on SIGHUP and on change of files, which are checked with the given interval,
+// until the context is done.
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reload("SIGHUP received")
+		case <-ticker.C:
+			ok, err := r.changed()
+			if err != nil {
+				slog.Warn("Failed to check TLS certificate files", slog.String("cert", r.certFile), logging.Error(err))
+				continue
+			}
+			if ok {
+				r.reload("files changed")
+			}
+		}
+	}
+}
+
+func (r *Reloader) reload(reason string) {
+	if err := r.Reload(); err != nil {
+		slog.Error("Failed to reload TLS certificates, previous certificates are kept",
+			slog.String("cert", r.certFile), slog.String("reason", reason), logging.Error(err))
+		return
+	}
+	slog.Info("TLS certificates reloaded", slog.String("cert", r.certFile), slog.String("reason", reason))
+}
+
+// Config returns the server TLS configuration that uses the last loaded certificates for every new connection.
+// The nextProtos are the application protocols supported by the server, used for ALPN negotiation.
+func (r *Reloader) Config(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.connectionConfig(nextProtos), nil
+		},
+	}
+}
+
+func (r *Reloader) connectionConfig(nextProtos []string) *tls.Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   nextProtos,
+		Certificates: []tls.Certificate{*r.cert},
+	}
+	if r.clientCAs != nil {
+		cfg.ClientCAs = r.clientCAs
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg
+}
@@ -0,0 +1,183 @@
+package tlsreloader
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, name string, serial int64, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	} else {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (c *testCert) write(t *testing.T, certFile, keyFile string) {
+	require.NoError(t, os.WriteFile(certFile, c.certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyFile, c.keyPEM, 0600))
+}
+
+func (c *testCert) tlsCertificate(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	require.NoError(t, err)
+	return cert
+}
+
+// handshake connects to the server with the given config and returns the server certificate.
+func handshake(t *testing.T, serverCfg, clientCfg *tls.Config) (*x509.Certificate, error) {
+	sc, cc := net.Pipe()
+	defer sc.Close()
+	defer cc.Close()
+	srv := tls.Server(sc, serverCfg)
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Handshake()
+	}()
+	cl := tls.Client(cc, clientCfg)
+	err := cl.Handshake()
+	if err == nil {
+		// Client finishes the handshake before the server checks the client certificate,
+		// so the server's alert has to be read to let it finish.
+		go func() {
+			_, _ = io.Copy(io.Discard, cl)
+		}()
+		err = <-done
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cl.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	ca := newTestCert(t, "ca", 1, nil)
+	first := newTestCert(t, "first", 2, ca)
+	first.write(t, certFile, keyFile)
+
+	r, err := New(certFile, keyFile, "")
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	clientCfg := &tls.Config{RootCAs: pool, ServerName: "localhost", MinVersion: tls.VersionTLS12}
+	serverCfg := r.Config("h2")
+
+	cert, err := handshake(t, serverCfg, clientCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "first", cert.Subject.CommonName)
+
+	changed, err := r.changed()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	second := newTestCert(t, "second", 3, ca)
+	second.write(t, certFile, keyFile)
+	changed, err = r.changed()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.NoError(t, r.Reload())
+	cert, err = handshake(t, serverCfg, clientCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "second", cert.Subject.CommonName)
+
+	// Broken files don't replace the loaded certificate.
+	require.NoError(t, os.WriteFile(keyFile, []byte("broken"), 0600))
+	assert.Error(t, r.Reload())
+	cert, err = handshake(t, serverCfg, clientCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "second", cert.Subject.CommonName)
+}
+
+func TestReloaderMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	caFile := filepath.Join(dir, "ca.pem")
+	ca := newTestCert(t, "ca", 1, nil)
+	newTestCert(t, "server", 2, ca).write(t, certFile, keyFile)
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM, 0600))
+
+	r, err := New(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	serverCfg := r.Config()
+
+	noCert := &tls.Config{RootCAs: pool, ServerName: "localhost", MinVersion: tls.VersionTLS12}
+	_, err = handshake(t, serverCfg, noCert)
+	assert.Error(t, err)
+
+	client := newTestCert(t, "client", 3, ca)
+	withCert := &tls.Config{
+		RootCAs:      pool,
+		ServerName:   "localhost",
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{client.tlsCertificate(t)},
+	}
+	_, err = handshake(t, serverCfg, withCert)
+	assert.NoError(t, err)
+
+	other := newTestCert(t, "other-ca", 4, nil)
+	foreign := newTestCert(t, "foreign", 5, other)
+	withForeignCert := &tls.Config{
+		RootCAs:      pool,
+		ServerName:   "localhost",
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{foreign.tlsCertificate(t)},
+	}
+	_, err = handshake(t, serverCfg, withForeignCert)
+	assert.Error(t, err)
+
+	_, err = New(certFile, "", "")
+	assert.Error(t, err)
+}